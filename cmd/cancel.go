@@ -15,18 +15,30 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pulumi/pulumi/pkg/util/result"
 
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
+// updateCanceler is implemented by the backends that can cancel a stack's running update: the Pulumi service, and
+// SQL databases, which release the stack's lock.
+type updateCanceler interface {
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
+}
+
+var _ updateCanceler = (httpstate.Backend)(nil)
+var _ updateCanceler = (sqlstate.Backend)(nil)
+
 func newCancelCmd() *cobra.Command {
 	var yes bool
 	var stack string
@@ -61,8 +73,8 @@ func newCancelCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			// Ensure that we are targeting the Pulumi cloud.
-			backend, ok := s.Backend().(httpstate.Backend)
+			// Ensure that we are targeting a backend that can cancel updates.
+			backend, ok := s.Backend().(updateCanceler)
 			if !ok {
 				return result.Error("the `cancel` command is not supported for local stacks")
 			}
@@ -58,6 +58,11 @@ func newConfigCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if showSecrets {
+				if err = checkShowSecrets(stack); err != nil {
+					return err
+				}
+			}
 
 			return listConfig(stack, showSecrets, jsonOut)
 		}),
@@ -464,6 +469,9 @@ func getConfig(stack backend.Stack, key config.Key, jsonOut bool) error {
 	if v, ok := cfg[key]; ok {
 		var d config.Decrypter
 		if v.Secure() {
+			if err := checkShowSecrets(stack); err != nil {
+				return err
+			}
 			var err error
 			if d, err = getStackDencrypter(stack); err != nil {
 				return errors.Wrap(err, "could not create a decrypter")
@@ -22,6 +22,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/secrets"
@@ -64,7 +65,7 @@ func getStackSecretsManager(s backend.Stack) (secrets.Manager, error) {
 		switch stack := s.(type) {
 		case httpstate.Stack:
 			return newServiceSecretsManager(stack)
-		case filestate.Stack:
+		case filestate.Stack, sqlstate.Stack:
 			return newPassphraseSecretsManager(s.Ref().Name(), stackConfigFile)
 		}
 
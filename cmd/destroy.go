@@ -39,6 +39,7 @@ func newDestroyCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var restartCrashedProviders bool
 	var refresh bool
 	var showConfig bool
 	var showReplacementSteps bool
@@ -123,6 +124,8 @@ func newDestroyCmd() *cobra.Command {
 				Refresh:        refresh,
 				DestroyTargets: targetUrns,
 				UseLegacyDiff:  useLegacyDiff(),
+
+				RestartCrashedProviders: restartCrashedProviders,
 			}
 
 			_, res := s.Destroy(commandContext(), backend.UpdateOperation{
@@ -171,6 +174,9 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().BoolVar(
+		&restartCrashedProviders, "restart-crashed-providers", false,
+		"Restart providers that crash during the destroy and retry the reads they were performing")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -43,7 +43,8 @@ func newHistoryCmd() *cobra.Command {
 		Short:      "[PREVIEW] Update history for a stack",
 		Long: `Update history for a stack
 
-This command lists data about previous updates for a stack.`,
+This command lists data about previous updates for a stack.  A stack's state may be rolled back
+to the checkpoint saved by one of these updates using ` + "`pulumi stack history restore`" + `.`,
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
@@ -60,6 +61,9 @@ This command lists data about previous updates for a stack.`,
 			}
 			var decrypter config.Decrypter
 			if showSecrets {
+				if err = checkShowSecrets(s); err != nil {
+					return err
+				}
 				crypter, err := getStackDencrypter(s)
 				if err != nil {
 					return errors.Wrap(err, "decrypting secrets")
@@ -82,12 +86,16 @@ This command lists data about previous updates for a stack.`,
 		"Show secret values when listing config instead of displaying blinded values")
 	cmd.PersistentFlags().BoolVarP(
 		&jsonOut, "json", "j", false, "Emit output as JSON")
+
+	cmd.AddCommand(newHistoryPruneCmd())
+	cmd.AddCommand(newHistoryRestoreCmd())
 	return cmd
 }
 
 // updateInfoJSON is the shape of the --json output for a configuration value.  While we can add fields to this
 // structure in the future, we should not change existing fields.
 type updateInfoJSON struct {
+	Version     int                        `json:"version,omitempty"`
 	Kind        string                     `json:"kind"`
 	StartTime   string                     `json:"startTime"`
 	Message     string                     `json:"message"`
@@ -108,6 +116,7 @@ func displayUpdatesJSON(updates []backend.UpdateInfo, decrypter config.Decrypter
 	updatesJSON := make([]updateInfoJSON, len(updates))
 	for idx, update := range updates {
 		info := updateInfoJSON{
+			Version:     update.Version,
 			Kind:        string(update.Kind),
 			StartTime:   time.Unix(update.StartTime, 0).UTC().Format(timeFormat),
 			Message:     update.Message,
@@ -155,6 +164,9 @@ func displayUpdatesConsole(updates []backend.UpdateInfo, opts display.Options) e
 
 	for _, update := range updates {
 
+		if update.Version != 0 {
+			fmt.Printf("Version: %v\n", update.Version)
+		}
 		fmt.Printf("UpdateKind: %v\n", update.Kind)
 		if update.Result == "succeeded" {
 			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%sStatus: %v%s\n", colors.Green, update.Result, colors.Reset)))
@@ -0,0 +1,112 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newHistoryPruneCmd() *cobra.Command {
+	var stackName string
+	var keep int
+	var olderThan string
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Remove the history and backups of a stack's older updates",
+		Long: "Remove the history and backups of a stack's older updates.\n" +
+			"\n" +
+			"This command removes the update records, checkpoint copies, and backups kept for the\n" +
+			"updates of a stack managed by a local or cloud storage backend, other than the newest\n" +
+			"--keep updates and those more recent than --older-than.  The newest update is always kept.\n" +
+			"Removed updates can no longer be restored with `pulumi stack history restore`.\n" +
+			"\n" +
+			"The same policy may be applied after every update by setting the PULUMI_RETAIN_HISTORY_COUNT\n" +
+			"and PULUMI_RETAIN_HISTORY_AGE environment variables, which also supply the defaults for this\n" +
+			"command's flags.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			policy, err := filestate.RetentionPolicyFromEnv()
+			if err != nil {
+				return result.FromError(err)
+			}
+			if cmd.Flags().Changed("keep") {
+				if keep < 0 {
+					return result.Errorf("--keep must not be negative")
+				}
+				policy.Count = keep
+			}
+			if olderThan != "" {
+				if policy.MaxAge, err = time.ParseDuration(olderThan); err != nil || policy.MaxAge < 0 {
+					return result.Errorf("invalid --older-than '%s'; expected a duration such as '720h'", olderThan)
+				}
+			}
+			if policy.IsZero() {
+				return result.Errorf("no retention policy given; pass --keep or --older-than")
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(stackName, false /*offerNew */, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			b, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return result.Errorf("the history of stack '%s' is managed by its backend, and cannot be pruned",
+					s.Ref())
+			}
+
+			prompt := fmt.Sprintf("This will permanently remove the older history and backups of the '%s' stack!",
+				s.Ref())
+			if !yes && !confirmPrompt(prompt, s.Ref().String(), opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			pruned, err := b.PruneHistory(commandContext(), s.Ref(), policy)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "pruning history"))
+			}
+			fmt.Printf("Removed %d updates from the history of stack '%s'.\n", pruned, s.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().IntVar(
+		&keep, "keep", 0,
+		"The number of most recent updates to keep")
+	cmd.PersistentFlags().StringVar(
+		&olderThan, "older-than", "",
+		"Remove updates older than this duration (e.g., '720h')")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with pruning anyway")
+
+	return cmd
+}
@@ -0,0 +1,224 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newHistoryRestoreCmd() *cobra.Command {
+	var stackName string
+	var reencrypt bool
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "restore <version>",
+		Short: "Roll back a stack's state to the checkpoint saved by a previous update",
+		Long: "Roll back a stack's state to the checkpoint saved by a previous update.\n" +
+			"\n" +
+			"This command replaces the stack's current state with the state it had after the update\n" +
+			"with the given version, as listed by `pulumi stack history`.  The resources that this adds,\n" +
+			"removes, or changes are shown, and must be confirmed before the state is replaced.  No\n" +
+			"cloud resources are modified; run `pulumi refresh` or `pulumi up` afterwards to reconcile\n" +
+			"the restored state with your cloud resources.\n" +
+			"\n" +
+			"If the checkpoint was encrypted by a different secrets manager than the stack's current\n" +
+			"state, it is only restored if --reencrypt is passed, in which case its secrets are\n" +
+			"re-encrypted with the stack's current secrets manager.",
+		Args: cmdutil.ExactArgs(1),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			version, err := strconv.Atoi(args[0])
+			if err != nil || version < 1 {
+				return result.Errorf("invalid version '%s'; expected a positive integer", args[0])
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(stackName, false /*offerNew */, opts, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			current, err := s.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			target, err := loadStackVersion(s, version)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			// The checkpoint's secrets were decrypted using the secrets manager that encrypted them.  Unless asked to,
+			// we refuse to write them back using a different one than the stack's current state uses.
+			sm := target.SecretsManager
+			if !secretsManagersMatch(current, target) {
+				if !reencrypt {
+					return result.Errorf("the checkpoint for version %d was encrypted by a different secrets "+
+						"manager than the stack's current state; rerun with --reencrypt to re-encrypt its secrets "+
+						"with the stack's current secrets manager", version)
+				}
+				if sm, err = getStackSecretsManager(s); err != nil {
+					return result.FromError(err)
+				}
+			}
+
+			diffs := diffSnapshots(current, target)
+			if len(diffs) == 0 {
+				fmt.Printf("The state of stack '%s' already matches version %d.\n", s.Ref(), version)
+			} else {
+				fmt.Printf("Restoring version %d of stack '%s' will make the following changes to its state:\n",
+					version, s.Ref())
+				printResourceDiffs(diffs, opts)
+			}
+			fmt.Println()
+
+			prompt := fmt.Sprintf("This will replace the state of the '%s' stack with version %d!", s.Ref(), version)
+			if !yes && !confirmPrompt(prompt, s.Ref().String(), opts) {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			// Explicitly clear-out any pending operations, as `pulumi stack import` does.
+			for _, op := range target.PendingOperations {
+				msg := fmt.Sprintf(
+					"removing pending operation '%s' on '%s' from snapshot", op.Type, op.Resource.URN)
+				cmdutil.Diag().Warningf(diag.Message(op.Resource.URN, msg))
+			}
+			target.PendingOperations = nil
+
+			sdep, err := stack.SerializeDeployment(target, sm)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "constructing deployment for upload"))
+			}
+			bytes, err := json.Marshal(sdep)
+			if err != nil {
+				return result.FromError(err)
+			}
+			dep := apitype.UntypedDeployment{
+				Version:    apitype.DeploymentSchemaVersionCurrent,
+				Deployment: bytes,
+			}
+			if err = s.ImportDeployment(commandContext(), &dep); err != nil {
+				return result.FromError(errors.Wrap(err, "could not import deployment"))
+			}
+			fmt.Printf("Restored version %d of stack '%s'.\n", version, s.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVar(
+		&reencrypt, "reencrypt", false,
+		"Re-encrypt the checkpoint's secrets if it was encrypted by a different secrets manager")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with the restore anyway")
+
+	return cmd
+}
+
+// secretsManagersMatch returns true if the secrets in the target snapshot may be written back using the secrets manager
+// of the current one.  A target without a secrets manager has no secrets, so it may always be written.
+func secretsManagersMatch(current, target *deploy.Snapshot) bool {
+	if target.SecretsManager == nil {
+		return true
+	}
+	if current == nil {
+		return false
+	}
+	return secrets.AreCompatible(current.SecretsManager, target.SecretsManager)
+}
+
+// resourceDiff describes how restoring a snapshot would change a single resource.
+type resourceDiff struct {
+	op  deploy.StepOp // the kind of change: OpCreate, OpDelete, or OpUpdate.
+	urn resource.URN  // the resource that changes.
+}
+
+// diffSnapshots returns the resources that are added, removed, or changed by replacing the current snapshot with the
+// target snapshot, sorted by URN.
+func diffSnapshots(current, target *deploy.Snapshot) []resourceDiff {
+	olds, news := snapshotResources(current), snapshotResources(target)
+
+	var diffs []resourceDiff
+	for urn, newRes := range news {
+		if oldRes, has := olds[urn]; !has {
+			diffs = append(diffs, resourceDiff{op: deploy.OpCreate, urn: urn})
+		} else if !resourceStatesEqual(oldRes, newRes) {
+			diffs = append(diffs, resourceDiff{op: deploy.OpUpdate, urn: urn})
+		}
+	}
+	for urn := range olds {
+		if _, has := news[urn]; !has {
+			diffs = append(diffs, resourceDiff{op: deploy.OpDelete, urn: urn})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].urn < diffs[j].urn })
+	return diffs
+}
+
+// snapshotResources indexes the live resources in the given snapshot, if any, by URN.
+func snapshotResources(snap *deploy.Snapshot) map[resource.URN]*resource.State {
+	resources := make(map[resource.URN]*resource.State)
+	if snap != nil {
+		for _, res := range snap.Resources {
+			if !res.Delete {
+				resources[res.URN] = res
+			}
+		}
+	}
+	return resources
+}
+
+// resourceStatesEqual returns true if the two states record the same resource with the same properties.
+func resourceStatesEqual(a, b *resource.State) bool {
+	return a.Type == b.Type && a.ID == b.ID && a.Provider == b.Provider && a.Parent == b.Parent &&
+		a.Protect == b.Protect && a.Inputs.DeepEquals(b.Inputs) && a.Outputs.DeepEquals(b.Outputs)
+}
+
+func printResourceDiffs(diffs []resourceDiff, opts display.Options) {
+	for _, d := range diffs {
+		var color, sign string
+		switch d.op {
+		case deploy.OpCreate:
+			color, sign = colors.SpecCreate, "+"
+		case deploy.OpDelete:
+			color, sign = colors.SpecDelete, "-"
+		default:
+			color, sign = colors.SpecUpdate, "~"
+		}
+		fmt.Print(opts.Color.Colorize(fmt.Sprintf("    %s%s %s%s\n", color, sign, d.urn, colors.Reset)))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+type otherSecretsManager struct{}
+
+func (otherSecretsManager) Type() string                         { return "other" }
+func (otherSecretsManager) State() interface{}                   { return nil }
+func (otherSecretsManager) Encrypter() (config.Encrypter, error) { return config.NopEncrypter, nil }
+func (otherSecretsManager) Decrypter() (config.Decrypter, error) { return config.NopDecrypter, nil }
+
+func newRestoreTestState(name string, inputs resource.PropertyMap) *resource.State {
+	typ := tokens.Type("test:index:Resource")
+	urn := resource.NewURN("dev", "proj", "", typ, tokens.QName(name))
+	return resource.NewState(typ, urn, true, false, "id", inputs, resource.PropertyMap{}, "", false, false,
+		nil, nil, "", nil, false, nil, nil, nil)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	a := newRestoreTestState("a", resource.PropertyMap{})
+	b := newRestoreTestState("b", resource.PropertyMap{"x": resource.NewStringProperty("1")})
+	b2 := newRestoreTestState("b", resource.PropertyMap{"x": resource.NewStringProperty("2")})
+	c := newRestoreTestState("c", resource.PropertyMap{})
+	d := newRestoreTestState("d", resource.PropertyMap{})
+
+	current := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{a, b, c}, nil)
+	target := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{b2, c, d}, nil)
+
+	assert.Equal(t, []resourceDiff{
+		{op: deploy.OpDelete, urn: a.URN},
+		{op: deploy.OpUpdate, urn: b.URN},
+		{op: deploy.OpCreate, urn: d.URN},
+	}, diffSnapshots(current, target))
+	assert.Empty(t, diffSnapshots(current, current))
+
+	// A stack without any state has nothing to remove.
+	assert.Equal(t, []resourceDiff{{op: deploy.OpCreate, urn: a.URN}},
+		diffSnapshots(nil, deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{a}, nil)))
+}
+
+func TestSecretsManagersMatch(t *testing.T) {
+	snapWith := func(sm secrets.Manager) *deploy.Snapshot {
+		return deploy.NewSnapshot(deploy.Manifest{}, sm, nil, nil)
+	}
+
+	assert.True(t, secretsManagersMatch(snapWith(b64.NewBase64SecretsManager()), snapWith(nil)))
+	assert.True(t, secretsManagersMatch(nil, snapWith(nil)))
+	assert.True(t, secretsManagersMatch(
+		snapWith(b64.NewBase64SecretsManager()), snapWith(b64.NewBase64SecretsManager())))
+	assert.False(t, secretsManagersMatch(snapWith(otherSecretsManager{}), snapWith(b64.NewBase64SecretsManager())))
+	assert.False(t, secretsManagersMatch(snapWith(nil), snapWith(b64.NewBase64SecretsManager())))
+	assert.False(t, secretsManagersMatch(nil, snapWith(b64.NewBase64SecretsManager())))
+}
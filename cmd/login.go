@@ -27,6 +27,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -34,6 +35,8 @@ import (
 func newLoginCmd() *cobra.Command {
 	var cloudURL string
 	var localMode bool
+	var readOnly bool
+	var showSecrets bool
 
 	cmd := &cobra.Command{
 		Use:   "login [<url>]",
@@ -80,7 +83,26 @@ func newLoginCmd() *cobra.Command {
 			"\n" +
 			"Azure Blob:\n" +
 			"\n" +
-			"    $ pulumi login azblob://my-pulumi-state-bucket\n",
+			"    $ pulumi login azblob://my-pulumi-state-bucket\n" +
+			"\n" +
+			"[PREVIEW] State may also be kept in a SQL database, which records each stack's update history and\n" +
+			"engine events, and locks stacks while they are being updated. For instance,\n" +
+			"\n" +
+			"SQLite:\n" +
+			"\n" +
+			"    $ pulumi login sqlite://~/.pulumi/state.db\n" +
+			"\n" +
+			"PostgreSQL:\n" +
+			"\n" +
+			"    $ pulumi login postgres://user@db.acmecorp.com/pulumi\n" +
+			"\n" +
+			"Passwords are never stored; set the `PGPASSWORD` environment variable or list the password in a\n" +
+			"`PGPASSFILE` instead.\n" +
+			"\n" +
+			"To inspect stacks without any risk of changing them, pass --read-only.  Operations that would modify\n" +
+			"a stack, such as `pulumi up`, `pulumi destroy`, and `pulumi stack import`, are then refused, and secret\n" +
+			"values are hidden unless --show-secrets is also passed.  Setting the `PULUMI_READ_ONLY` environment\n" +
+			"variable has the same effect for a single command.\n",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOptions := display.Options{
@@ -118,9 +140,25 @@ func newLoginCmd() *cobra.Command {
 				}
 			}
 
+			if showSecrets && !readOnly {
+				return errors.New("--show-secrets may only be specified with --read-only")
+			}
+
+			// Record the backend's access mode before logging in, so that a backend that is to be read-only is
+			// never modified while logging in, and one that is no longer read-only may be.
+			accountURL := cloudURL
+			if !sqlstate.IsSQLStateBackendURL(cloudURL) && !filestate.IsFileStateBackendURL(cloudURL) {
+				accountURL = httpstate.ValueOrDefaultURL(cloudURL)
+			}
+			if err := storeReadOnlyMode(accountURL, readOnly, showSecrets); err != nil {
+				return errors.Wrap(err, "storing the access mode")
+			}
+
 			var be backend.Backend
 			var err error
-			if filestate.IsFileStateBackendURL(cloudURL) {
+			if sqlstate.IsSQLStateBackendURL(cloudURL) {
+				be, err = sqlstate.Login(cmdutil.Diag(), cloudURL)
+			} else if filestate.IsFileStateBackendURL(cloudURL) {
 				be, err = filestate.Login(cmdutil.Diag(), cloudURL)
 			} else {
 				be, err = httpstate.Login(commandContext(), cmdutil.Diag(), cloudURL, displayOptions)
@@ -128,12 +166,18 @@ func newLoginCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrapf(err, "problem logging in")
 			}
+			if err = storeReadOnlyMode(be.URL(), readOnly, showSecrets); err != nil {
+				return errors.Wrap(err, "storing the access mode")
+			}
 
 			if currentUser, err := be.CurrentUser(); err == nil {
 				fmt.Printf("Logged into %s as %s (%s)\n", be.Name(), currentUser, be.URL())
 			} else {
 				fmt.Printf("Logged into %s (%s)\n", be.Name(), be.URL())
 			}
+			if readOnly {
+				fmt.Println("Operations that modify stacks will be refused")
+			}
 
 			return nil
 		}),
@@ -141,6 +185,24 @@ func newLoginCmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringVarP(&cloudURL, "cloud-url", "c", "", "A cloud URL to log into")
 	cmd.PersistentFlags().BoolVarP(&localMode, "local", "l", false, "Use Pulumi in local-only mode")
+	cmd.PersistentFlags().BoolVar(&readOnly, "read-only", false,
+		"Refuse any operation that would modify a stack, so that stacks may be safely inspected")
+	cmd.PersistentFlags().BoolVar(&showSecrets, "show-secrets", false,
+		"Allow secret values to be shown while in read-only mode")
 
 	return cmd
 }
+
+// storeReadOnlyMode records whether the stored account for the backend with the given URL is read-only.  Nothing is
+// stored for a backend that has no account and is not read-only.
+func storeReadOnlyMode(url string, readOnly, showSecrets bool) error {
+	account, err := workspace.GetAccount(url)
+	if err != nil {
+		return err
+	}
+	if account.ReadOnly == readOnly && account.ShowSecrets == showSecrets {
+		return nil
+	}
+	account.ReadOnly, account.ShowSecrets = readOnly, showSecrets
+	return workspace.StoreAccount(url, account, false)
+}
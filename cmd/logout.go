@@ -21,6 +21,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -67,7 +68,9 @@ func newLogoutCmd() *cobra.Command {
 
 			var be backend.Backend
 			var err error
-			if filestate.IsFileStateBackendURL(cloudURL) {
+			if sqlstate.IsSQLStateBackendURL(cloudURL) {
+				be, err = sqlstate.New(cmdutil.Diag(), cloudURL)
+			} else if filestate.IsFileStateBackendURL(cloudURL) {
 				be, err = filestate.New(cmdutil.Diag(), cloudURL)
 			} else {
 				be, err = httpstate.New(cmdutil.Diag(), cloudURL)
@@ -0,0 +1,148 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPluginBundleCmd() *cobra.Command {
+	var platformNames []string
+	var cmd = &cobra.Command{
+		Use:   "bundle <file>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Collect the plugins a project needs into a bundle",
+		Long: "Collect the plugins a project needs into a bundle.\n" +
+			"\n" +
+			"This command downloads every plugin that the current project needs and writes them all\n" +
+			"into a single archive.  The archive may be carried to machines that can't reach a plugin\n" +
+			"server or mirror, and its plugins installed there with `pulumi plugin install --from-bundle\n" +
+			"<file>`.  Plugins are bundled for the current platform, unless other platforms, such as\n" +
+			"linux-arm64, are given with --platform.\n" +
+			"\n" +
+			"Plugins are downloaded from the mirrors listed in PULUMI_PLUGIN_MIRRORS, and then from\n" +
+			"their servers.  Plugins recorded in the project's Pulumi.lock file must match their\n" +
+			"recorded checksums.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			platforms := []workspace.Platform{workspace.CurrentPlatform()}
+			if len(platformNames) > 0 {
+				platforms = nil
+				for _, name := range platformNames {
+					platform, err := workspace.ParsePlatform(name)
+					if err != nil {
+						return err
+					}
+					platforms = append(platforms, platform)
+				}
+			}
+
+			plugins, err := getProjectPlugins()
+			if err != nil {
+				return err
+			}
+			var lock *workspace.LockFile
+			if projPath, err := workspace.DetectProjectPath(); err == nil && projPath != "" {
+				if lock, err = workspace.LoadLockFile(workspace.LockFilePath(projPath)); err != nil {
+					return err
+				}
+			}
+
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			bundle := workspace.NewPluginBundleWriter(f)
+			count := 0
+			err = func() error {
+				for _, plug := range plugins {
+					// Language plugins aren't installed from tarballs, so there is nothing to bundle.
+					if plug.Kind == workspace.LanguagePlugin {
+						continue
+					}
+					if plug.Version == nil {
+						return errors.Errorf("cannot bundle %s plugin %s, as its version is not known", plug.Kind, plug)
+					}
+					for _, platform := range platforms {
+						plug.Platform = platform
+						if lock != nil {
+							plug = lock.Apply(plug)
+						}
+						if err := addToBundle(bundle, plug); err != nil {
+							return err
+						}
+					}
+					count++
+				}
+				return bundle.Close()
+			}()
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				contract.IgnoreError(os.Remove(path))
+				return err
+			}
+
+			fmt.Printf("Bundled %d plugins into %s\n", count, path)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&platformNames,
+		"platform", nil, "A platform, such as linux-arm64, to bundle plugins for; may be repeated")
+
+	return cmd
+}
+
+// addToBundle downloads the given plugin's tarball and adds it to a plugin bundle.
+func addToBundle(bundle *workspace.PluginBundleWriter, plug workspace.PluginInfo) error {
+	label := fmt.Sprintf("[%s plugin %s for %s]", plug.Kind, plug, plug.GetPlatform())
+	cmdutil.Diag().Infoerrf(diag.Message("", "%s bundling"), label)
+
+	var err error
+	if plug.Checksum == "" {
+		if plug.Checksum, err = plug.DownloadChecksum(); err != nil {
+			return errors.Wrapf(err, "%s downloading checksum", label)
+		}
+	}
+	tarball, size, err := plug.Download()
+	if err != nil {
+		return errors.Wrapf(err, "%s downloading", label)
+	}
+	tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", cmdutil.GetGlobalColorization())
+	b, err := ioutil.ReadAll(tarball)
+	contract.IgnoreClose(tarball)
+	if err != nil {
+		return errors.Wrapf(err, "%s downloading", label)
+	}
+
+	if checksum := workspace.TarballChecksum(b); plug.Checksum != "" && checksum != plug.Checksum {
+		return errors.Errorf("%s the downloaded tarball has checksum %s, but %s was expected",
+			label, checksum, plug.Checksum)
+	}
+	return bundle.Add(plug, b)
+}
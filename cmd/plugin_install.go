@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -35,6 +36,7 @@ func newPluginInstallCmd() *cobra.Command {
 	var cloudURL string
 	var exact bool
 	var file string
+	var fromBundle string
 	var reinstall bool
 	var verbose bool
 
@@ -50,7 +52,19 @@ func newPluginInstallCmd() *cobra.Command {
 			"project.  VERSION cannot be a range: it must be a specific number.\n" +
 			"\n" +
 			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"downloading more plugins than is strictly necessary.\n" +
+			"\n" +
+			"Each plugin's tarball is checked against the SHA-256 checksum published alongside it by\n" +
+			"its server, if there is one.  When run within a project, the exact version and server of\n" +
+			"each plugin installed, and the checksums of its tarball and executable, are recorded in the\n" +
+			"project's Pulumi.lock file.  Plugins recorded there are only installed from tarballs with\n" +
+			"the recorded checksums, and Pulumi refuses to use plugin executables that don't match.\n" +
+			"\n" +
+			"Plugins are downloaded from the mirrors listed, separated by commas, in PULUMI_PLUGIN_MIRRORS\n" +
+			"before their servers.  A mirror may be an HTTP(S) URL, a local directory, or a blob storage\n" +
+			"URL such as s3://bucket/plugins.  Where no server or mirror can be reached, plugins may be\n" +
+			"installed from a bundle written by `pulumi plugin bundle` by passing --from-bundle.  If no\n" +
+			"plugin is specified, every plugin in the bundle for the current platform is installed.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOpts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -60,6 +74,10 @@ func newPluginInstallCmd() *cobra.Command {
 				return errors.New("only one of server and cloud-url may be specified")
 			}
 
+			if file != "" && fromBundle != "" {
+				return errors.New("only one of --file (-f) and --from-bundle may be specified")
+			}
+
 			if cloudURL != "" {
 				cmdutil.Diag().Warningf(diag.Message("", "cloud-url is deprecated, please pass '--server "+
 					"%s/releases/plugins' instead."), cloudURL)
@@ -68,9 +86,18 @@ func newPluginInstallCmd() *cobra.Command {
 			}
 
 			// Note we don't presently set this as the default value for `--server` so we can play games like the above
-			// where we want to ensure at most one of `--server` or `--cloud-url` is set.
-			if serverURL == "" {
-				serverURL = "https://api.pulumi.com/releases/plugins"
+			// where we want to ensure at most one of `--server` or `--cloud-url` is set.  We also want to know whether
+			// a server was given, so that otherwise the server recorded in the project's lock file may be used.
+			const defaultServerURL = "https://api.pulumi.com/releases/plugins"
+
+			var bundle *workspace.PluginBundle
+			if fromBundle != "" {
+				b, err := workspace.OpenPluginBundle(fromBundle)
+				if err != nil {
+					return err
+				}
+				defer contract.IgnoreClose(b)
+				bundle = b
 			}
 
 			// Parse the kind, name, and version, if specified.
@@ -98,8 +125,15 @@ func newPluginInstallCmd() *cobra.Command {
 					return errors.New("--file (-f) is only valid if a specific package is being installed")
 				}
 
-				// If a specific plugin wasn't given, compute the set of plugins the current project needs.
-				plugins, err := getProjectPlugins()
+				// If a specific plugin wasn't given, install everything in the bundle, if there is one, or else compute
+				// the set of plugins the current project needs.
+				var plugins []workspace.PluginInfo
+				var err error
+				if bundle != nil {
+					plugins, err = bundle.Plugins(workspace.CurrentPlatform())
+				} else {
+					plugins, err = getProjectPlugins()
+				}
 				if err != nil {
 					return err
 				}
@@ -112,31 +146,89 @@ func newPluginInstallCmd() *cobra.Command {
 				}
 			}
 
+			// If we're within a project, its lock file records the plugins that it uses.
+			var lock *workspace.LockFile
+			var lockPath string
+			lockChanged := false
+			if projPath, err := workspace.DetectProjectPath(); err == nil && projPath != "" {
+				lockPath = workspace.LockFilePath(projPath)
+				if lock, err = workspace.LoadLockFile(lockPath); err != nil {
+					return err
+				}
+			}
+			recordPlugin := func(install workspace.PluginInfo, label string) error {
+				if lock == nil {
+					return nil
+				}
+				checksum, err := workspace.GetPluginChecksum(install)
+				if err != nil {
+					return err
+				}
+				executable, err := workspace.GetPluginExecutableChecksum(install)
+				if err != nil {
+					return err
+				}
+				if checksum == "" && install.Checksum == "" {
+					cmdutil.Diag().Warningf(diag.Message("", "%s the checksum of the installed plugin's tarball is not "+
+						"known, so it was not recorded in %s; pass --reinstall to record it"), label, workspace.LockFileName)
+				}
+				// The default server is implied, so it isn't recorded.
+				if install.ServerURL == defaultServerURL {
+					install.ServerURL = ""
+				}
+				if lock.Record(install, checksum, executable) {
+					lockChanged = true
+				}
+				return nil
+			}
+
 			// Now for each kind, name, version pair, download it from the release website, and install it.
 			for _, install := range installs {
+				// Plugins recorded in the lock file are installed from the recorded server, unless another server
+				// was given, and must match the recorded checksum.
+				if lock != nil {
+					install = lock.Apply(install)
+				}
+				if install.ServerURL == "" {
+					install.ServerURL = defaultServerURL
+				}
+				if install.Checksum == "" && bundle != nil {
+					install.Checksum = bundle.Checksum(install)
+				}
+
 				label := fmt.Sprintf("[%s plugin %s]", install.Kind, install)
 				cmdutil.Diag().Infoerrf(
 					diag.Message("", "%s installing"), label)
 
 				// If the plugin already exists, don't download it unless --reinstall was passed.  Note that
-				// by default we accept plugins with >= constraints, unless --exact was passed which requires ==.
+				// by default we accept plugins with >= constraints, unless --exact was passed which requires ==.  The
+				// lock file records exact versions, so within a project, an exact match is also required.
 				if !reinstall {
-					if exact {
-						if workspace.HasPlugin(install) {
-							if verbose {
-								cmdutil.Diag().Infoerrf(
-									diag.Message("", "%s skipping install (existing == match)"), label)
-							}
-							continue
-						}
+					var has bool
+					match := "=="
+					if exact || lock != nil {
+						has = workspace.HasPlugin(install)
 					} else {
-						if has, _ := workspace.HasPluginGTE(install); has {
-							if verbose {
-								cmdutil.Diag().Infoerrf(
-									diag.Message("", "%s skipping install (existing >= match)"), label)
-							}
-							continue
+						has, _ = workspace.HasPluginGTE(install)
+						match = ">="
+					}
+					if has && install.ExecutableChecksum != "" {
+						err := workspace.VerifyPluginChecksum(install)
+						has = err == nil
+						if !has && verbose {
+							cmdutil.Diag().Infoerrf(
+								diag.Message("", "%s reinstalling (%v)"), label, err)
+						}
+					}
+					if has {
+						if verbose {
+							cmdutil.Diag().Infoerrf(
+								diag.Message("", "%s skipping install (existing %s match)"), label, match)
 						}
+						if err := recordPlugin(install, label); err != nil {
+							return err
+						}
+						continue
 					}
 				}
 
@@ -144,14 +236,29 @@ func newPluginInstallCmd() *cobra.Command {
 				var source string
 				var tarball io.ReadCloser
 				var err error
-				if file == "" {
+				if bundle != nil {
+					source = fromBundle
+					if verbose {
+						cmdutil.Diag().Infoerrf(
+							diag.Message("", "%s opening tarball from bundle %s"), label, fromBundle)
+					}
+					if tarball, _, err = bundle.Open(install); err != nil {
+						return errors.Wrapf(err, "%s opening tarball", label)
+					}
+				} else if file == "" {
+					sources := strings.Join(install.Sources(), ", ")
 					if verbose {
 						cmdutil.Diag().Infoerrf(
-							diag.Message("", "%s downloading from %s"), label, install.ServerURL)
+							diag.Message("", "%s downloading from %s"), label, sources)
+					}
+					if install.Checksum == "" {
+						if install.Checksum, err = install.DownloadChecksum(); err != nil {
+							return errors.Wrapf(err, "%s downloading checksum from %s", label, sources)
+						}
 					}
 					var size int64
 					if tarball, size, err = install.Download(); err != nil {
-						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
+						return errors.Wrapf(err, "%s downloading from %s", label, sources)
 					}
 					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
 				} else {
@@ -171,8 +278,16 @@ func newPluginInstallCmd() *cobra.Command {
 				if err = install.Install(tarball); err != nil {
 					return errors.Wrapf(err, "installing %s from %s", label, source)
 				}
+				if err = recordPlugin(install, label); err != nil {
+					return err
+				}
 			}
 
+			if lockChanged {
+				if err := lock.Save(lockPath); err != nil {
+					return errors.Wrapf(err, "saving %s", lockPath)
+				}
+			}
 			return nil
 		}),
 	}
@@ -185,6 +300,8 @@ func newPluginInstallCmd() *cobra.Command {
 		"exact", false, "Force installation of an exact version match (usually >= is accepted)")
 	cmd.PersistentFlags().StringVarP(&file,
 		"file", "f", "", "Install a plugin from a tarball file, instead of downloading it")
+	cmd.PersistentFlags().StringVar(&fromBundle,
+		"from-bundle", "", "Install plugins from a bundle written by 'pulumi plugin bundle', instead of downloading them")
 	cmd.PersistentFlags().BoolVar(&reinstall,
 		"reinstall", false, "Reinstall a plugin even if it already exists")
 	cmd.PersistentFlags().BoolVar(&verbose,
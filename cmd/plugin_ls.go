@@ -17,6 +17,7 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
@@ -80,12 +81,13 @@ func newPluginLsCmd() *cobra.Command {
 // pluginInfoJSON is the shape of the --json output for a configuration value.  While we can add fields to this
 // structure in the future, we should not change existing fields.
 type pluginInfoJSON struct {
-	Name         string  `json:"name"`
-	Kind         string  `json:"kind"`
-	Version      string  `json:"version"`
-	Size         int     `json:"size"`
-	InstallTime  *string `json:"installTime,omitempty"`
-	LastUsedTime *string `json:"lastUsedTime,omitempty"`
+	Name         string   `json:"name"`
+	Kind         string   `json:"kind"`
+	Version      string   `json:"version"`
+	Platforms    []string `json:"platforms,omitempty"`
+	Size         int      `json:"size"`
+	InstallTime  *string  `json:"installTime,omitempty"`
+	LastUsedTime *string  `json:"lastUsedTime,omitempty"`
 }
 
 func formatPluginsJSON(plugins []workspace.PluginInfo) error {
@@ -101,6 +103,9 @@ func formatPluginsJSON(plugins []workspace.PluginInfo) error {
 			Version: plugin.Version.String(),
 			Size:    int(plugin.Size),
 		}
+		for _, platform := range plugin.Platforms {
+			jsonPluginInfo[idx].Platforms = append(jsonPluginInfo[idx].Platforms, platform.String())
+		}
 
 		if !plugin.InstallTime.IsZero() {
 			jsonPluginInfo[idx].InstallTime = makeStringRef(plugin.InstallTime.UTC().Format(timeFormat))
@@ -144,14 +149,15 @@ func formatPluginConsole(plugins []workspace.PluginInfo) error {
 		}
 
 		rows = append(rows, cmdutil.TableRow{
-			Columns: []string{plugin.Name, string(plugin.Kind), version, bytes, installTime, lastUsedTime},
+			Columns: []string{
+				plugin.Name, string(plugin.Kind), version, formatPluginArch(plugin), bytes, installTime, lastUsedTime},
 		})
 
 		totalSize += uint64(plugin.Size)
 	}
 
 	cmdutil.PrintTable(cmdutil.Table{
-		Headers: []string{"NAME", "KIND", "VERSION", "SIZE", "INSTALLED", "LAST USED"},
+		Headers: []string{"NAME", "KIND", "VERSION", "ARCH", "SIZE", "INSTALLED", "LAST USED"},
 		Rows:    rows,
 	})
 
@@ -161,5 +167,23 @@ func formatPluginConsole(plugins []workspace.PluginInfo) error {
 	return nil
 }
 
+// formatPluginArch lists the architectures for which a plugin is installed.  Platforms with a different OS than the
+// current one are listed in full.
+func formatPluginArch(plugin workspace.PluginInfo) string {
+	if len(plugin.Platforms) == 0 {
+		return naString
+	}
+
+	var archs []string
+	for _, platform := range plugin.Platforms {
+		if platform.OS == workspace.CurrentPlatform().OS {
+			archs = append(archs, platform.Arch)
+		} else {
+			archs = append(archs, platform.String())
+		}
+	}
+	return strings.Join(archs, ", ")
+}
+
 const humanNeverTime = "never"
 const naString = "n/a"
@@ -34,6 +34,7 @@ func newPreviewCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var policyPackPaths []string
+	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
 	var jsonDisplay bool
@@ -67,12 +68,17 @@ func newPreviewCmd() *cobra.Command {
 				displayType = display.DisplayDiff
 			}
 
+			localPolicyPacks, err := makeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
-					LocalPolicyPackPaths: policyPackPaths,
-					Parallel:             parallel,
-					Debug:                debug,
-					UseLegacyDiff:        useLegacyDiff(),
+					LocalPolicyPacks: localPolicyPacks,
+					Parallel:         parallel,
+					Debug:            debug,
+					UseLegacyDiff:    useLegacyDiff(),
 				},
 				Display: display.Options{
 					Color:                cmdutil.GetGlobalColorization(),
@@ -165,6 +171,9 @@ func newPreviewCmd() *cobra.Command {
 		cmd.PersistentFlags().StringSliceVar(
 			&policyPackPaths, "policy-pack", []string{},
 			"Run one or more analyzers as part of this update")
+		cmd.PersistentFlags().StringSliceVar(
+			&policyPackConfigPaths, "policy-pack-config", []string{},
+			"Path to JSON file containing the config for the policy pack of the corresponding \"--policy-pack\" flag")
 	}
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
@@ -38,6 +38,7 @@ func newRefreshCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var restartCrashedProviders bool
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -122,6 +123,8 @@ func newRefreshCmd() *cobra.Command {
 				Debug:          debug,
 				UseLegacyDiff:  useLegacyDiff(),
 				RefreshTargets: targetUrns,
+
+				RestartCrashedProviders: restartCrashedProviders,
 			}
 
 			changes, res := s.Refresh(commandContext(), backend.UpdateOperation{
@@ -175,6 +178,9 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().BoolVar(
+		&restartCrashedProviders, "restart-crashed-providers", false,
+		"Restart providers that crash during the refresh and retry the reads they were performing")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
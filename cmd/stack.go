@@ -51,6 +51,11 @@ func newStackCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if showSecrets {
+				if err = checkShowSecrets(s); err != nil {
+					return err
+				}
+			}
 			snap, err := s.Snapshot(commandContext())
 			if err != nil {
 				return err
@@ -161,11 +166,14 @@ func newStackCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSecrets, "show-secrets", false, "Display stack outputs which are marked as secret in plaintext")
 
+	cmd.AddCommand(newStackDiffCmd())
 	cmd.AddCommand(newStackExportCmd())
 	cmd.AddCommand(newStackGraphCmd())
+	cmd.AddCommand(newHistoryCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
 	cmd.AddCommand(newStackLsCmd())
+	cmd.AddCommand(newStackMigrateCmd())
 	cmd.AddCommand(newStackOutputCmd())
 	cmd.AddCommand(newStackRmCmd())
 	cmd.AddCommand(newStackSelectCmd())
@@ -0,0 +1,104 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newStackDiffCmd() *cobra.Command {
+	var stackName string
+	var jsonOut bool
+	var showSames bool
+	var cmd = &cobra.Command{
+		Use:   "diff <versionA> <versionB>",
+		Short: "Show the differences between the checkpoints saved by two updates",
+		Long: "Show the differences between the checkpoints saved by two updates.\n" +
+			"\n" +
+			"This command compares the state that the stack had after the update with version A, as listed\n" +
+			"by `pulumi stack history`, with the state that it had after the update with version B.  The\n" +
+			"resources that were created, deleted, replaced or updated in between are shown along with the\n" +
+			"properties that changed, including the stack's outputs.  Secret values are never shown.",
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			var versions [2]int
+			for i, arg := range args {
+				version, err := strconv.Atoi(arg)
+				if err != nil || version < 1 {
+					return result.Errorf("invalid version '%s'; expected a positive integer", arg)
+				}
+				versions[i] = version
+			}
+
+			opts := display.Options{
+				Color:             cmdutil.GetGlobalColorization(),
+				ShowSameResources: showSames,
+				SummaryDiff:       !showSames,
+			}
+			s, err := requireStack(stackName, false /*offerNew */, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			var snaps [2]*deploy.Snapshot
+			for i, version := range versions {
+				if snaps[i], err = loadStackVersion(s, version); err != nil {
+					return result.FromError(err)
+				}
+			}
+
+			diffs := display.DiffSnapshots(snaps[0], snaps[1])
+			if jsonOut {
+				return result.WrapIfNonNil(display.ShowSnapshotDiffJSON(os.Stdout, diffs, opts))
+			}
+			display.ShowSnapshotDiff(os.Stdout, diffs, opts)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit the differences as JSON")
+	cmd.PersistentFlags().BoolVar(
+		&showSames, "show-sames", false, "Show resources and properties that did not change")
+
+	return cmd
+}
+
+// loadStackVersion loads the checkpoint that the given stack saved after the update with the given version.
+func loadStackVersion(s backend.Stack, version int) (*deploy.Snapshot, error) {
+	deployment, err := s.Backend().ExportDeploymentVersion(commandContext(), s, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the checkpoint for version %d", version)
+	}
+	snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not deserialize the checkpoint for version %d", version)
+	}
+	return snap, nil
+}
@@ -0,0 +1,374 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/secrets/service"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackMigrateCmd() *cobra.Command {
+	var stackName string
+	var all bool
+	var to string
+	var secretsProvider string
+	var cmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy stacks to another backend",
+		Long: "Copy stacks to another backend.\n" +
+			"\n" +
+			"This command copies a stack, or with --all every stack of the current project, from the backend\n" +
+			"that is currently logged in to the backend with the URL given by --to.  The stack's deployment,\n" +
+			"update history, and tags are copied, as far as the target backend supports them.  The stacks in\n" +
+			"the current backend are left as they are.\n" +
+			"\n" +
+			"If you have not used the target backend before, log in to it with `pulumi login`, and then log\n" +
+			"back in to the current backend to run this command.\n" +
+			"\n" +
+			"Secrets encrypted by the pulumi.com secrets provider can only be decrypted for the stack that\n" +
+			"encrypted them, so a stack that uses it is given the target backend's default secrets provider:\n" +
+			"`passphrase` for the local and cloud storage backends.  A different secrets provider may be chosen\n" +
+			"with --secrets-provider.  The secrets in the stack's deployments are then re-encrypted with the\n" +
+			"new provider.  The stack's configuration file is left as it is, so that the current backend can\n" +
+			"still use it; instead, its configuration is re-encrypted into a copy named after it with the\n" +
+			"suffix `.migrated`, which should replace it once the migrated stack is in use.\n" +
+			"\n" +
+			"If a stack cannot be copied, the partial copy is removed from the target backend.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			if to == "" {
+				return result.Errorf("missing the URL of the target backend; pass it with --to")
+			}
+			if all && stackName != "" {
+				return result.Errorf("only one of --stack or --all may be specified, not both")
+			}
+			if secretsProvider != "" {
+				if err := validateSecretsProvider(secretsProvider); err != nil {
+					return result.FromError(err)
+				}
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			source, err := currentBackend(opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+			target, err := migrationTarget(to)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if target.URL() == source.URL() {
+				return result.Errorf("the stacks are already managed by %s", target.URL())
+			}
+
+			var stacks []backend.Stack
+			if all {
+				proj, _, err := readProject()
+				if err != nil {
+					return result.FromError(err)
+				}
+				projName := string(proj.Name)
+				summaries, err := source.ListStacks(commandContext(), backend.ListStacksFilter{Project: &projName})
+				if err != nil {
+					return result.FromError(err)
+				}
+				for _, summary := range summaries {
+					s, err := source.GetStack(commandContext(), summary.Name())
+					if err != nil {
+						return result.FromError(err)
+					}
+					stacks = append(stacks, s)
+				}
+			} else {
+				s, err := requireStack(stackName, false /*offerNew */, opts, false /*setCurrent*/)
+				if err != nil {
+					return result.FromError(err)
+				}
+				stacks = append(stacks, s)
+			}
+
+			for _, s := range stacks {
+				configPath, err := migrateStack(commandContext(), s, target, secretsProvider)
+				if err != nil {
+					return result.FromError(errors.Wrapf(err, "migrating stack '%s'", s.Ref()))
+				}
+				fmt.Printf("Migrated stack '%s' to %s.\n", s.Ref(), target.URL())
+				if configPath != "" {
+					fmt.Printf("Its configuration for %s was written to %s; replace its configuration file with "+
+						"this once you have logged in to %s.\n", target.URL(), configPath, target.URL())
+				}
+			}
+			if len(stacks) > 0 {
+				fmt.Printf("Run `pulumi login %s` to manage the migrated stacks.\n", target.URL())
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to migrate. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVar(
+		&all, "all", false,
+		"Migrate every stack of the current project")
+	cmd.PersistentFlags().StringVar(
+		&to, "to", "",
+		"The URL of the backend to copy the stacks to")
+	cmd.PersistentFlags().StringVar(
+		&secretsProvider, "secrets-provider", "",
+		"The type of the provider that should be used to encrypt and decrypt the migrated stacks' secrets "+
+			"(possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault). Defaults to "+
+			"each stack's current provider, if it can be used with the target backend")
+
+	return cmd
+}
+
+// migrationTarget returns the backend with the given URL, without changing the backend that is currently logged in.
+func migrationTarget(url string) (backend.Backend, error) {
+	if sqlstate.IsSQLStateBackendURL(url) {
+		return sqlstate.New(cmdutil.Diag(), url)
+	}
+	if filestate.IsFileStateBackendURL(url) {
+		return filestate.New(cmdutil.Diag(), url)
+	}
+
+	url = httpstate.ValueOrDefaultURL(url)
+	account, err := workspace.GetAccount(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting stored credentials")
+	}
+	if account.AccessToken == "" {
+		return nil, errors.Errorf("not logged in to %s; run `pulumi login %s`, and then log back in to the "+
+			"current backend", url, url)
+	}
+	return httpstate.New(cmdutil.Diag(), url)
+}
+
+// migrateStack copies the given stack to a new stack of the same name in the target backend.  If the stack's secrets
+// are to be encrypted by a different secrets provider, its configuration is also re-encrypted, into a copy of its
+// configuration file whose path is returned; the original is left for the source backend to use.  This is always
+// the case for a stack that uses the service's secrets provider, whose secrets can only be decrypted by the service
+// on behalf of that stack; it is given the target backend's default secrets provider.  If the stack cannot be
+// copied, the new stack is removed from the target backend.
+func migrateStack(ctx context.Context, s backend.Stack, target backend.Backend,
+	secretsProvider string) (string, error) {
+
+	targetRef, err := target.ParseStackReference(string(s.Ref().Name()))
+	if err != nil {
+		return "", err
+	}
+	existing, err := target.GetStack(ctx, targetRef)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return "", errors.Errorf("a stack named '%s' already exists in %s", targetRef, target.URL())
+	}
+
+	sm, err := getStackSecretsManager(s)
+	if err != nil {
+		return "", err
+	}
+	if secretsProvider == "" && sm.Type() == service.Type {
+		secretsProvider = "default"
+	}
+
+	// A new secrets provider is configured in a temporary copy of the stack's configuration file, which is renamed
+	// alongside the original only once the stack has been copied.
+	var newConfigPath string
+	var plaintext map[config.Key]string
+	var ps *workspace.ProjectStack
+	if secretsProvider != "" {
+		ps, err = loadProjectStack(s)
+		if err != nil {
+			return "", err
+		}
+		dec, err := sm.Decrypter()
+		if err != nil {
+			return "", err
+		}
+		if plaintext, err = ps.Config.Decrypt(dec); err != nil {
+			return "", errors.Wrap(err, "decrypting configuration")
+		}
+		if newConfigPath, err = newMigrationConfigFile(s, ps); err != nil {
+			return "", err
+		}
+		defer func() {
+			// Nothing is left behind if the migration fails.
+			contract.IgnoreError(os.Remove(newConfigPath))
+		}()
+	}
+
+	targetStack, err := target.CreateStack(ctx, targetRef, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "creating stack")
+	}
+	migrated := false
+	defer func() {
+		if !migrated {
+			if _, removeErr := target.RemoveStack(ctx, targetStack, true /*force*/); removeErr != nil {
+				cmdutil.Diag().Warningf(diag.Message("", "could not remove the partial copy of stack '%s' from %s: %v"),
+					targetRef, target.URL(), removeErr)
+			}
+		}
+	}()
+
+	targetSM := sm
+	if secretsProvider != "" {
+		if targetSM, err = newMigrationSecretsManager(targetStack, newConfigPath, secretsProvider); err != nil {
+			return "", err
+		}
+		if err = reencryptConfig(newConfigPath, ps.Config, plaintext, targetSM); err != nil {
+			return "", err
+		}
+	}
+
+	err = backend.MigrateStack(ctx, cmdutil.Diag(), s, targetStack, backend.MigrateOptions{
+		SecretsProvider: migrationSecretsProvider{sm: sm},
+		SecretsManager:  targetSM,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "copying stack to %s", target.URL())
+	}
+
+	var migratedConfigPath string
+	if newConfigPath != "" {
+		configPath, err := getProjectStackPath(s)
+		if err != nil {
+			return "", err
+		}
+		migratedConfigPath = configPath + ".migrated"
+		if err = os.Rename(newConfigPath, migratedConfigPath); err != nil {
+			return "", errors.Wrapf(err, "writing configuration file %s", migratedConfigPath)
+		}
+	}
+	migrated = true
+	return migratedConfigPath, nil
+}
+
+// newMigrationConfigFile writes a copy of the given stack's configuration, without any secrets provider, to a new file
+// alongside the stack's configuration file, and returns the path of the copy.
+func newMigrationConfigFile(s backend.Stack, ps *workspace.ProjectStack) (string, error) {
+	configPath, err := getProjectStackPath(s)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(configPath), "Pulumi.*.yaml")
+	if err != nil {
+		return "", errors.Wrap(err, "creating configuration file")
+	}
+	contract.IgnoreClose(f)
+
+	unconfigured := *ps
+	unconfigured.SecretsProvider, unconfigured.EncryptionSalt, unconfigured.EncryptedKey = "", "", ""
+	if err = unconfigured.Save(f.Name()); err != nil {
+		contract.IgnoreError(os.Remove(f.Name()))
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// newMigrationSecretsManager configures the given secrets provider for the given stack in the given configuration
+// file, as `pulumi stack init` does, and returns its secrets manager.
+func newMigrationSecretsManager(s backend.Stack, configPath string, secretsProvider string) (secrets.Manager, error) {
+	var sm secrets.Manager
+	var err error
+	switch {
+	case secretsProvider == passphrase.Type:
+		sm, err = newPassphraseSecretsManager(s.Ref().Name(), configPath)
+	case secretsProvider != "default":
+		sm, err = newCloudSecretsManager(s.Ref().Name(), configPath, secretsProvider)
+	default:
+		switch s := s.(type) {
+		case httpstate.Stack:
+			sm, err = newServiceSecretsManager(s)
+		case filestate.Stack, sqlstate.Stack:
+			sm, err = newPassphraseSecretsManager(s.Ref().Name(), configPath)
+		default:
+			return nil, errors.Errorf("unknown stack type %s", reflect.TypeOf(s))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stack.NewCachingSecretsManager(sm), nil
+}
+
+// reencryptConfig re-encrypts the secure values of the given configuration, whose plaintext is given, with the given
+// secrets manager, and saves them in the given configuration file.
+func reencryptConfig(configPath string, cfg config.Map, plaintext map[config.Key]string, sm secrets.Manager) error {
+	ps, err := workspace.LoadProjectStack(configPath)
+	if err != nil {
+		return err
+	}
+	enc, err := sm.Encrypter()
+	if err != nil {
+		return err
+	}
+	for key, value := range cfg {
+		if !value.Secure() {
+			continue
+		}
+		ciphertext, err := enc.EncryptValue(plaintext[key])
+		if err != nil {
+			return errors.Wrapf(err, "encrypting configuration value '%s'", key)
+		}
+		ps.Config[key] = config.NewSecureValue(ciphertext)
+	}
+	return ps.Save(configPath)
+}
+
+// migrationSecretsProvider decrypts deployments with the migrated stack's secrets manager if it encrypted them, which
+// avoids asking for the same passphrase again, and otherwise with the default secrets provider.
+type migrationSecretsProvider struct {
+	sm secrets.Manager
+}
+
+func (p migrationSecretsProvider) OfType(ty string, state json.RawMessage) (secrets.Manager, error) {
+	if p.sm.Type() == ty {
+		var current, other interface{}
+		if byts, err := json.Marshal(p.sm.State()); err == nil && json.Unmarshal(byts, &current) == nil &&
+			json.Unmarshal(state, &other) == nil && reflect.DeepEqual(current, other) {
+			return p.sm, nil
+		}
+	}
+	return stack.DefaultSecretsProvider.OfType(ty, state)
+}
@@ -50,6 +50,11 @@ func newStackOutputCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if showSecrets {
+				if err = checkShowSecrets(s); err != nil {
+					return err
+				}
+			}
 			snap, err := s.Snapshot(commandContext())
 			if err != nil {
 				return err
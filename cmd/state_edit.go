@@ -0,0 +1,246 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newStateEditCommand() *cobra.Command {
+	var format string
+	var stackName string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edits a stack's state in a text editor",
+		Long: `Edits a stack's state in a text editor
+
+This command opens the stack's state in the editor named by the VISUAL or EDITOR environment variable, as either
+JSON or YAML. Once the editor exits, the edited state is checked for integrity and, if it is valid, saved as the
+stack's new state. If the edited state is invalid, nothing is saved and the edits are left in place so that they can
+be fixed and imported with 'pulumi stack import'.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			if format != "json" && format != "yaml" {
+				return result.Errorf("unsupported format %q; expected json or yaml", format)
+			}
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			return editState(stackName, format, showPrompt)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringVar(&format, "format", "json", "The format in which to edit the state: json or yaml")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+// editState opens the state of the given stack in an editor and saves the result, as long as it is valid.
+func editState(stackName, format string, showPrompt bool) result.Result {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+	s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+	if err != nil {
+		return result.FromError(err)
+	}
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return result.FromError(err)
+	}
+	if snap == nil {
+		return result.Errorf("stack '%s' has no state to edit", s.Ref())
+	}
+
+	sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager)
+	if err != nil {
+		return result.FromError(errors.Wrap(err, "serializing deployment"))
+	}
+	original, err := marshalDeployment(sdep, format)
+	if err != nil {
+		return result.FromError(err)
+	}
+
+	file, err := ioutil.TempFile("", "pulumi-state-*."+format)
+	if err != nil {
+		return result.FromError(err)
+	}
+	path := file.Name()
+	_, err = file.Write(original)
+	contract.IgnoreClose(file)
+	if err != nil {
+		return result.FromError(err)
+	}
+
+	// Keep the edited file around unless its contents were saved or discarded deliberately.
+	keep := true
+	defer func() {
+		if !keep {
+			contract.IgnoreError(os.Remove(path))
+		}
+	}()
+
+	if err = runEditor(path); err != nil {
+		keep = false
+		return result.FromError(err)
+	}
+	edited, err := ioutil.ReadFile(path)
+	if err != nil {
+		return result.FromError(err)
+	}
+	if bytes.Equal(original, edited) {
+		keep = false
+		fmt.Println("No changes were made")
+		return nil
+	}
+
+	var dep apitype.DeploymentV3
+	if err = unmarshalDeployment(edited, format, &dep); err != nil {
+		return result.Errorf("the edited state could not be read: %v\nYour edits were left in %s", err, path)
+	}
+	newSnap, err := stack.DeserializeDeploymentV3(dep, stack.DefaultSecretsProvider)
+	if err != nil {
+		return result.Errorf("the edited state could not be read: %v\nYour edits were left in %s", err, path)
+	}
+	if err = newSnap.VerifyIntegrity(); err != nil {
+		return result.Errorf("the edited state is invalid: %v\nYour edits were left in %s", err, path)
+	}
+
+	if showPrompt && !confirmStateEdit(opts) {
+		keep = false
+		return result.Bail()
+	}
+	if err = saveSnapshot(s, newSnap); err != nil {
+		return result.Errorf("%v\nYour edits were left in %s", err, path)
+	}
+	keep = false
+	fmt.Println("State edited successfully")
+	return nil
+}
+
+// runEditor opens the given file in the user's editor and waits for it to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// The editor may be given with arguments, as in "code --wait".
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "running editor %q", editor)
+	}
+	return nil
+}
+
+// marshalDeployment renders a deployment in the given format, which is either "json" or "yaml".
+func marshalDeployment(dep *apitype.DeploymentV3, format string) ([]byte, error) {
+	if format == "json" {
+		return json.MarshalIndent(dep, "", "    ")
+	}
+
+	// Deployments are only meant to be serialized as JSON, so render them as YAML by way of their JSON form.
+	b, err := json.Marshal(dep)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err = json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(v)
+}
+
+// unmarshalDeployment reads a deployment rendered by marshalDeployment.
+func unmarshalDeployment(b []byte, format string, dep *apitype.DeploymentV3) error {
+	if format == "yaml" {
+		var v interface{}
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		converted, err := yamlToJSONValue(v)
+		if err != nil {
+			return err
+		}
+		if b, err = json.Marshal(converted); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, dep)
+}
+
+// yamlToJSONValue converts a value decoded from YAML into one that can be encoded as JSON, which requires that all
+// map keys be strings.
+func yamlToJSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, errors.Errorf("unexpected non-string key %v", key)
+			}
+			converted, err := yamlToJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, value := range v {
+			converted, err := yamlToJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = converted
+		}
+		return a, nil
+	default:
+		return v, nil
+	}
+}
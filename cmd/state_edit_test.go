@@ -0,0 +1,50 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+func TestDeploymentRoundTrip(t *testing.T) {
+	dep := &apitype.DeploymentV3{
+		Manifest: apitype.ManifestV1{Time: time.Now().UTC().Truncate(time.Second), Magic: "magic", Version: "1.0.0"},
+		Resources: []apitype.ResourceV3{{
+			URN:    resource.URN("urn:pulumi:dev::demo::a:b:c::a"),
+			Custom: true,
+			ID:     "id",
+			Type:   "a:b:c",
+			Inputs: map[string]interface{}{
+				"count":  float64(3),
+				"nested": map[string]interface{}{"list": []interface{}{"x", true}},
+			},
+			Dependencies: []resource.URN{"urn:pulumi:dev::demo::a:b:c::b"},
+		}},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		b, err := marshalDeployment(dep, format)
+		assert.NoError(t, err)
+		var roundTripped apitype.DeploymentV3
+		assert.NoError(t, unmarshalDeployment(b, format, &roundTripped))
+		assert.Equal(t, *dep, roundTripped, format)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+func newStateMoveCommand() *cobra.Command {
+	var stack string
+	var dest string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "move <resource URN>...",
+		Short: "Moves resources from one stack's state to another's",
+		Long: `Moves resources from one stack's state to another's
+
+This command moves one or more resources from a stack's state into the state of the stack named by --dest, rewriting
+their URNs to belong to the destination stack. The providers used by the moved resources are copied along with them,
+and are removed from the source stack once no remaining resource uses them. Resources that are parented to the source
+stack are parented to the destination stack instead.
+
+The moved resources must be self-contained: every resource that they depend on or descend from must be moved with
+them, and no resource that stays behind may depend on or descend from them. Both stacks' states are checked for
+integrity before they are saved.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+Example:
+pulumi state move --dest prod 'urn:pulumi:dev::demo::aws:s3/bucket:Bucket::logs'
+`,
+		Args: cmdutil.MinimumNArgs(1),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			if dest == "" {
+				return result.Error("missing required flag --dest")
+			}
+
+			var urns []resource.URN
+			for _, arg := range args {
+				urns = append(urns, resource.URN(arg))
+			}
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			if res := moveResources(stack, dest, urns, showPrompt); res != nil {
+				return res
+			}
+			fmt.Printf("Moved %d resource(s) successfully\n", len(urns))
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to move resources from. Defaults to the current stack")
+	cmd.Flags().StringVar(&dest, "dest", "", "The name of the stack to move resources to")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+// moveResources moves the resources with the given URNs from one stack to another.
+func moveResources(sourceName, destName string, urns []resource.URN, showPrompt bool) result.Result {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+	source, err := requireStack(sourceName, false, opts, false /*setCurrent*/)
+	if err != nil {
+		return result.FromError(err)
+	}
+	dest, err := requireStack(destName, false, opts, false /*setCurrent*/)
+	if err != nil {
+		return result.FromError(err)
+	}
+	if source.Ref().String() == dest.Ref().String() {
+		return result.Error("the source and destination stacks must differ")
+	}
+
+	sourceSnap, err := source.Snapshot(commandContext())
+	if err != nil {
+		return result.FromError(err)
+	}
+	if sourceSnap == nil || len(sourceSnap.Resources) == 0 {
+		return result.Errorf("stack '%s' has no resources", source.Ref())
+	}
+	destSnap, err := dest.Snapshot(commandContext())
+	if err != nil {
+		return result.FromError(err)
+	}
+	if destSnap == nil {
+		destSnap = deploy.NewSnapshot(deploy.Manifest{
+			Time:    time.Now(),
+			Version: version.Version,
+			Plugins: sourceSnap.Manifest.Plugins,
+		}, nil, nil, nil)
+	}
+	if destSnap.SecretsManager == nil {
+		// The destination has never stored a secret, so use the secrets manager that its configuration names in case
+		// the moved resources hold any.
+		if destSnap.SecretsManager, err = getStackSecretsManager(dest); err != nil {
+			return result.FromError(err)
+		}
+	}
+
+	// Resources that share a URN, such as those pending deletion, are moved together.
+	var resources []*resource.State
+	for _, urn := range urns {
+		located := edit.LocateResource(sourceSnap, urn)
+		if len(located) == 0 {
+			return result.Errorf("No such resource %q exists in the current state", urn)
+		}
+		resources = append(resources, located...)
+	}
+
+	// Resources in the destination belong to its project, which is the source's project unless the destination
+	// already holds resources from another.
+	destProject := sourceSnap.Resources[0].URN.Project()
+	if len(destSnap.Resources) != 0 {
+		destProject = destSnap.Resources[0].URN.Project()
+	}
+
+	if showPrompt && !confirmStateEdit(opts) {
+		return result.Bail()
+	}
+
+	if err = edit.MoveResources(sourceSnap, destSnap, resources, dest.Ref().Name(), destProject); err != nil {
+		return result.FromError(err)
+	}
+
+	// Save the destination first, so that a failure to save the source leaves the moved resources in both stacks
+	// rather than in neither.
+	if err = saveSnapshot(dest, destSnap); err != nil {
+		return result.FromError(errors.Wrapf(err, "saving stack '%s'", dest.Ref()))
+	}
+	return result.WrapIfNonNil(errors.Wrapf(saveSnapshot(source, sourceSnap), "saving stack '%s'", source.Ref()))
+}
@@ -0,0 +1,189 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+func newStateRepairCommand() *cobra.Command {
+	var dryRun bool
+	var stackName string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "repair",
+		Short: "Repairs a stack's state so that it passes integrity checks",
+		Long: `Repairs a stack's state so that it passes integrity checks
+
+A stack's state can be left invalid by a crash or by editing it by hand, after which every command that uses it
+fails its integrity checks. This command checks the state and proposes a set of repairs:
+
+  - Resources are reordered so that each follows its provider, its parent and its dependencies.
+  - References to missing parents and dependencies are re-pointed to a resource that carries the missing URN as an
+    alias. Otherwise, missing parents are replaced by the stack, and missing dependencies are dropped.
+  - Duplicate copies of a resource are removed. Where the copies refer to different physical resources, you are
+    asked to choose the one to keep; the others are removed from the state, but not deleted.
+  - Pending operations, which prevent further updates, are removed.
+  - References to missing default providers are re-linked to another default provider for the same package.
+
+The proposed repairs are shown before they are made. Use --dry-run to only show them.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
+			showPrompt := !yes
+
+			return repairState(stackName, dryRun, showPrompt)
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show the repairs that would be made")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+// repairState repairs the state of the given stack, showing the repairs before making them.
+func repairState(stackName string, dryRun, showPrompt bool) result.Result {
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+	if err != nil {
+		return result.FromError(err)
+	}
+	snap, err := loadUncheckedSnapshot(s)
+	if err != nil {
+		return result.FromError(err)
+	}
+	if len(snap.Resources) == 0 && len(snap.PendingOperations) == 0 {
+		fmt.Println("The stack has no state to repair")
+		return nil
+	}
+
+	// Where several copies of a resource refer to different physical resources, the user must choose the one to keep.
+	var choose edit.ChooseFunc
+	if !dryRun && cmdutil.Interactive() {
+		choose = func(urn resource.URN, candidates []*resource.State) (*resource.State, error) {
+			return chooseDuplicateResource(urn, candidates, opts)
+		}
+	}
+
+	repairs, repairErr := edit.RepairSnapshot(snap, choose)
+	if len(repairs) == 0 && repairErr == nil {
+		fmt.Println("The stack's state is valid; no repairs are needed")
+		return nil
+	}
+
+	if len(repairs) != 0 {
+		fmt.Println(opts.Color.Colorize(
+			fmt.Sprintf("%sThe following repairs %s made to the state of stack '%s':%s",
+				colors.SpecHeadline, repairVerb(dryRun || repairErr != nil), s.Ref(), colors.Reset)))
+		for _, r := range repairs {
+			fmt.Printf("    - %s\n", r)
+		}
+		fmt.Println()
+	}
+	if repairErr != nil {
+		return result.Errorf("%v\nThe remaining problems must be fixed interactively or by hand, for example with "+
+			"'pulumi state edit'", repairErr)
+	}
+	if dryRun {
+		return nil
+	}
+
+	if showPrompt && !confirmStateEdit(opts) {
+		return result.Bail()
+	}
+
+	// The repaired snapshot passes its integrity checks, so it is saved as usual.
+	if err = saveSnapshot(s, snap); err != nil {
+		return result.FromError(err)
+	}
+	fmt.Println("State repaired successfully")
+	return nil
+}
+
+// loadUncheckedSnapshot loads the snapshot of the given stack without checking its integrity, since the state that is
+// to be repaired is expected to fail those checks.
+func loadUncheckedSnapshot(s backend.Stack) (*deploy.Snapshot, error) {
+	var dep *apitype.UntypedDeployment
+	var err error
+	if exporter, ok := s.Backend().(backend.UncheckedDeploymentExporter); ok {
+		dep, err = exporter.ExportDeploymentUnchecked(commandContext(), s)
+	} else {
+		dep, err = s.ExportDeployment(commandContext())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stack.DeserializeUntypedDeployment(dep, stack.DefaultSecretsProvider)
+}
+
+// chooseDuplicateResource prompts the user to choose which of several copies of a resource with different IDs is kept.
+func chooseDuplicateResource(urn resource.URN, candidates []*resource.State,
+	opts display.Options) (*resource.State, error) {
+
+	surveycore.DisableColor = true
+	surveycore.QuestionIcon = ""
+	surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+	prompt := fmt.Sprintf("Resource %s has copies with different IDs. Select the one to keep; the others will be "+
+		"removed from the state without being deleted:", urn)
+	prompt = opts.Color.Colorize(colors.SpecPrompt + prompt + colors.Reset)
+
+	var options []string
+	optionMap := make(map[string]*resource.State)
+	for _, res := range candidates {
+		message := fmt.Sprintf("%q", res.ID)
+		options = append(options, message)
+		optionMap[message] = res
+	}
+
+	var option string
+	if err := survey.AskOne(&survey.Select{
+		Message:  prompt,
+		Options:  options,
+		PageSize: len(options),
+	}, &option, nil); err != nil {
+		return nil, errors.Errorf("no copy of resource %s selected", urn)
+	}
+	return optionMap[option], nil
+}
+
+func repairVerb(dryRun bool) string {
+	if dryRun {
+		return "would be"
+	}
+	return "will be"
+}
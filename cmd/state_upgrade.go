@@ -0,0 +1,65 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStateUpgradeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrades the layout of the current backend's state",
+		Long: `Upgrades the layout of the current backend's state
+
+The local and cloud storage backends keep each project's stacks separately, under .pulumi/stacks/<project>. State
+written by older versions of Pulumi keeps every stack directly under .pulumi/stacks, and must be upgraded once before
+it can be used. This command moves each stack, along with its history and backups, into the directory of its project,
+which is found in the URNs of the stack's resources. Stacks that have never had any resources are moved into the
+current project; run this command from its directory.
+
+Older versions of Pulumi will not see the stacks once they have been moved.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			url, err := workspace.GetCurrentCloudURL()
+			if err != nil {
+				return errors.Wrapf(err, "could not get cloud url")
+			}
+			if !filestate.IsFileStateBackendURL(url) {
+				return errors.Errorf("the state in %s does not need to be upgraded", url)
+			}
+
+			var project tokens.PackageName
+			if proj, _, err := readProject(); err == nil {
+				project = proj.Name
+			}
+			if err = filestate.UpgradeLayout(cmdutil.Diag(), url, project); err != nil {
+				return err
+			}
+			fmt.Printf("The state in %s uses the current layout.\n", url)
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -54,9 +54,11 @@ func newUpCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var policyPackPaths []string
+	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var restartCrashedProviders bool
 	var refresh bool
 	var showConfig bool
 	var showReplacementSteps bool
@@ -100,18 +102,25 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(errors.Wrap(err, "getting stack configuration"))
 		}
 
+		localPolicyPacks, err := makeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths)
+		if err != nil {
+			return result.FromError(err)
+		}
+
 		targetUrns := []resource.URN{}
 		for _, t := range *targets {
 			targetUrns = append(targetUrns, resource.URN(t))
 		}
 
 		opts.Engine = engine.UpdateOptions{
-			LocalPolicyPackPaths: policyPackPaths,
-			Parallel:             parallel,
-			Debug:                debug,
-			Refresh:              refresh,
-			UseLegacyDiff:        useLegacyDiff(),
-			UpdateTargets:        targetUrns,
+			LocalPolicyPacks: localPolicyPacks,
+			Parallel:         parallel,
+			Debug:            debug,
+			Refresh:          refresh,
+			UseLegacyDiff:    useLegacyDiff(),
+			UpdateTargets:    targetUrns,
+
+			RestartCrashedProviders: restartCrashedProviders,
 		}
 
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
@@ -264,11 +273,18 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(errors.Wrap(err, "getting stack configuration"))
 		}
 
+		localPolicyPacks, err := makeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths)
+		if err != nil {
+			return result.FromError(err)
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			LocalPolicyPackPaths: policyPackPaths,
-			Parallel:             parallel,
-			Debug:                debug,
-			Refresh:              refresh,
+			LocalPolicyPacks: localPolicyPacks,
+			Parallel:         parallel,
+			Debug:            debug,
+			Refresh:          refresh,
+
+			RestartCrashedProviders: restartCrashedProviders,
 		}
 
 		// TODO for the URL case:
@@ -385,6 +401,9 @@ func newUpCmd() *cobra.Command {
 		cmd.PersistentFlags().StringSliceVar(
 			&policyPackPaths, "policy-pack", []string{},
 			"Run one or more policy packs as part of this update")
+		cmd.PersistentFlags().StringSliceVar(
+			&policyPackConfigPaths, "policy-pack-config", []string{},
+			"Path to JSON file containing the config for the policy pack of the corresponding \"--policy-pack\" flag")
 	}
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
@@ -392,6 +411,9 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().BoolVar(
+		&restartCrashedProviders, "restart-crashed-providers", false,
+		"Restart providers that crash during the update and retry the reads they were performing")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -38,6 +38,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
 	"github.com/pulumi/pulumi/pkg/backend/state"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
@@ -73,6 +74,9 @@ func currentBackend(opts display.Options) (backend.Backend, error) {
 		return nil, errors.Wrapf(err, "could not get cloud url")
 	}
 
+	if sqlstate.IsSQLStateBackendURL(url) {
+		return sqlstate.New(cmdutil.Diag(), url)
+	}
 	if filestate.IsFileStateBackendURL(url) {
 		return filestate.New(cmdutil.Diag(), url)
 	}
@@ -82,6 +86,16 @@ func currentBackend(opts display.Options) (backend.Backend, error) {
 // This is used to control the contents of the tracing header.
 var tracingHeader = os.Getenv("PULUMI_TRACING_HEADER")
 
+// checkShowSecrets returns an error if secret values may not be shown for the given stack, because its backend is in
+// read-only mode and showing them has not been allowed.
+func checkShowSecrets(s backend.Stack) error {
+	mode, err := backend.GetReadOnlyMode(s.Backend().URL())
+	if err != nil {
+		return err
+	}
+	return mode.CheckShowSecrets()
+}
+
 func commandContext() context.Context {
 	ctx := context.Background()
 	if cmdutil.IsTracingEnabled() {
@@ -108,8 +122,10 @@ func createStack(
 	// secrets provider or one of the cloud-backed secrets providers.  We do not need to do this
 	// for the Pulumi service backend secrets provider.
 	isDefaultSecretsProvider := secretsProvider == "" || secretsProvider == "default"
-	if _, ok := b.(filestate.Backend); ok && isDefaultSecretsProvider {
-		// The default when using the filestate backend is the passphrase secrets provider
+	_, isFileState := b.(filestate.Backend)
+	_, isSQLState := b.(sqlstate.Backend)
+	if (isFileState || isSQLState) && isDefaultSecretsProvider {
+		// The default when using the filestate or sqlstate backends is the passphrase secrets provider
 		secretsProvider = passphrase.Type
 	}
 	if secretsProvider == passphrase.Type {
@@ -670,3 +686,12 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 		SkipPreview: skipPreview,
 	}, nil
 }
+
+// makeLocalPolicyPacks pairs the paths given by each --policy-pack flag with those given by each --policy-pack-config
+// flag, if any.
+func makeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths []string) ([]engine.LocalPolicyPack, error) {
+	if len(policyPackConfigPaths) > 0 && len(policyPackConfigPaths) != len(policyPackPaths) {
+		return nil, errors.New("the number of --policy-pack-config flags must match the number of --policy-pack flags")
+	}
+	return engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths), nil
+}
@@ -54,7 +54,7 @@ type PolicyEvent struct {
 	PolicyPackName    string `json:"policyPackName"`
 	PolicyPackVersion string `json:"policyPackVersion"`
 
-	// EnforcementLevel is one of "warning" or "mandatory".
+	// EnforcementLevel is one of "warning", "mandatory", or "remediate".
 	EnforcementLevel string `json:"enforcementLevel"`
 }
 
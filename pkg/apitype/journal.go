@@ -0,0 +1,73 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+import "time"
+
+// JournalEntryKind is the kind of a record in a checkpoint journal.
+type JournalEntryKind string
+
+const (
+	// JournalEntryCheckpoint begins a journal.  It identifies the checkpoint that the rest of the journal applies to,
+	// and assigns identifiers to the checkpoint's resources.
+	JournalEntryCheckpoint JournalEntryKind = "checkpoint"
+	// JournalEntryBegin records the start of a step, which usually marks an operation as pending.
+	JournalEntryBegin JournalEntryKind = "begin"
+	// JournalEntryEnd records the end of a step, which adds resources to or removes them from the deployment.
+	JournalEntryEnd JournalEntryKind = "end"
+	// JournalEntryOutputs records the registration of a resource's outputs.
+	JournalEntryOutputs JournalEntryKind = "outputs"
+)
+
+// JournalOperationV1 is an operation that a journal entry marks as pending.
+type JournalOperationV1 struct {
+	// Resource is the identifier of the resource state that the operation was initiated with.
+	Resource int64 `json:"resource" yaml:"resource"`
+	// Type is the type of the operation.
+	Type OperationType `json:"type" yaml:"type"`
+}
+
+// JournalEntryV1 is a record in a checkpoint journal.  A journal is an append-only sequence of the mutations made to a
+// deployment since it was last checkpointed; replaying the journal against the checkpoint produces the current
+// deployment.  Each resource state in a journal is referred to by an identifier, which is assigned either by the
+// journal's checkpoint entry or by the entry that first records the state.
+type JournalEntryV1 struct {
+	// Kind is the kind of this entry.
+	Kind JournalEntryKind `json:"kind" yaml:"kind"`
+	// Op is the step operation, for begin and end entries.
+	Op string `json:"op,omitempty" yaml:"op,omitempty"`
+
+	// CheckpointTime is the manifest time of the checkpoint that a checkpoint entry applies to.
+	CheckpointTime *time.Time `json:"checkpointTime,omitempty" yaml:"checkpointTime,omitempty"`
+	// Resources holds the identifiers of the checkpoint's resources, in order, for a checkpoint entry.
+	Resources []int64 `json:"resources,omitempty" yaml:"resources,omitempty"`
+	// NewResources is the number of leading checkpoint resources that were produced by the current deployment, as
+	// opposed to carried over from the one before it, for a checkpoint entry.
+	NewResources int `json:"newResources,omitempty" yaml:"newResources,omitempty"`
+	// Operations holds the identifiers of the resources of the checkpoint's pending operations, in order, for a
+	// checkpoint entry.
+	Operations []int64 `json:"operations,omitempty" yaml:"operations,omitempty"`
+
+	// States holds the current contents of each resource state that this entry introduces or changes.
+	States map[int64]ResourceV3 `json:"states,omitempty" yaml:"states,omitempty"`
+	// Pending holds the operations that this entry marks as pending.
+	Pending []JournalOperationV1 `json:"pending,omitempty" yaml:"pending,omitempty"`
+	// Completed holds the identifiers of resources whose pending operations this entry marks as complete.
+	Completed []int64 `json:"completed,omitempty" yaml:"completed,omitempty"`
+	// Done holds the identifiers of resources that this entry removes from the previous deployment's resources.
+	Done []int64 `json:"done,omitempty" yaml:"done,omitempty"`
+	// New holds the identifiers of resources that this entry adds to the current deployment's resources.
+	New []int64 `json:"new,omitempty" yaml:"new,omitempty"`
+}
@@ -14,6 +14,8 @@
 
 package apitype
 
+import "encoding/json"
+
 // CreatePolicyPackRequest defines the request body for creating a new Policy
 // Pack for an organization. The request contains the metadata related to the
 // Policy Pack.
@@ -54,6 +56,11 @@ type RequiredPolicy struct {
 
 	// Where the Policy Pack can be downloaded from.
 	PackLocation string `json:"packLocation,omitempty"`
+
+	// The configuration that is to be passed to the Policy Pack. This is a map of policy names to their
+	// configuration. Each configuration is either an enforcement level or an object containing an
+	// "enforcementLevel" and any configuration properties of the policy.
+	Config map[string]*json.RawMessage `json:"config,omitempty"`
 }
 
 // Policy defines the metadata for an individual Policy within a Policy Pack.
@@ -70,6 +77,18 @@ type Policy struct {
 	// Message is the message that will be displayed to end users when they violate
 	// this policy.
 	Message string `json:"message"`
+
+	// ConfigSchema is the JSON schema of the policy's configuration, if it is configurable.
+	ConfigSchema *PolicyConfigSchema `json:"configSchema,omitempty"`
+}
+
+// PolicyConfigSchema defines the JSON schema of a particular Policy's configuration.
+type PolicyConfigSchema struct {
+	// Properties maps each of the configuration properties to its JSON schema.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// Required is the list of configuration properties that must be supplied.
+	Required []string `json:"required,omitempty"`
 }
 
 // EnforcementLevel indicates how a policy should be enforced
@@ -82,8 +101,25 @@ const (
 
 	// Mandatory is an enforcement level that prevents a resource from being created.
 	Mandatory EnforcementLevel = "mandatory"
+
+	// Remediate is an enforcement level that fixes the resource's properties using the
+	// policy's remediation, rather than rejecting the resource.
+	Remediate EnforcementLevel = "remediate"
+
+	// Disabled is an enforcement level that prevents a policy from being run.
+	Disabled EnforcementLevel = "disabled"
 )
 
+// IsValid returns true if the enforcement level is one of the known enforcement levels.
+func (el EnforcementLevel) IsValid() bool {
+	switch el {
+	case Advisory, Mandatory, Remediate, Disabled:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetPolicyPackResponse is the response to get a specific Policy Pack's
 // metadata and policies.
 type GetPolicyPackResponse struct {
@@ -47,15 +47,16 @@ type UpdateProgramRequest struct {
 // Should generally mirror engine.UpdateOptions, but we clone it in this package to add
 // flexibility in case there is a breaking change in the engine-type.
 type UpdateOptions struct {
-	LocalPolicyPackPaths []string            `json:"localPolicyPackPaths"`
-	Color                colors.Colorization `json:"color"`
-	DryRun               bool                `json:"dryRun"`
-	Parallel             int                 `json:"parallel"`
-	ShowConfig           bool                `json:"showConfig"`
-	ShowReplacementSteps bool                `json:"showReplacementSteps"`
-	ShowSames            bool                `json:"showNames"`
-	Summary              bool                `json:"summary"`
-	Debug                bool                `json:"debug"`
+	LocalPolicyPackPaths   []string                      `json:"localPolicyPackPaths"`
+	LocalPolicyPackConfigs []map[string]*json.RawMessage `json:"localPolicyPackConfigs,omitempty"`
+	Color                  colors.Colorization           `json:"color"`
+	DryRun                 bool                          `json:"dryRun"`
+	Parallel               int                           `json:"parallel"`
+	ShowConfig             bool                          `json:"showConfig"`
+	ShowReplacementSteps   bool                          `json:"showReplacementSteps"`
+	ShowSames              bool                          `json:"showNames"`
+	Summary                bool                          `json:"summary"`
+	Debug                  bool                          `json:"debug"`
 }
 
 // UpdateMetadata describes optional metadata about an update.
@@ -0,0 +1,292 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/go/pulumi/config"
+)
+
+func newTestWorkspace(t *testing.T, program pulumi.RunFunc) (*Workspace, string) {
+	dir, err := ioutil.TempDir("", "auto-test")
+	assert.NoError(t, err)
+
+	d := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{})
+	b, err := filestate.New(d, "file://"+dir)
+	assert.NoError(t, err)
+
+	ws, err := NewInlineWorkspace(b, filepath.Join(dir, "project"), "autotest", program)
+	assert.NoError(t, err)
+	return ws, dir
+}
+
+func TestInlineStackLifecycle(t *testing.T) {
+	os.Setenv(PassphraseEnvVar, "password")
+	defer os.Unsetenv(PassphraseEnvVar)
+
+	program := func(ctx *pulumi.Context) error {
+		name, _ := ctx.GetConfig("autotest:name")
+		secret, _ := ctx.GetConfig("autotest:secret")
+		if _, err := ctx.RegisterResource("test:index:Component", name, false, nil); err != nil {
+			return err
+		}
+		ctx.Export("name", name)
+		ctx.Export("secret", secret)
+		return nil
+	}
+
+	ctx := context.Background()
+	ws, dir := newTestWorkspace(t, program)
+	defer os.RemoveAll(dir)
+
+	stk, err := ws.CreateStack(ctx, "dev")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "dev", stk.Name())
+
+	// Configuration round-trips, including secrets, and is namespaced to the project by default.
+	assert.NoError(t, stk.SetConfig("name", "widget", false))
+	assert.NoError(t, stk.SetConfig("autotest:secret", "hunter2", true))
+	v, has, err := stk.GetConfig("autotest:name")
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, "widget", v)
+	v, has, err = stk.GetConfig("secret")
+	assert.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, "hunter2", v)
+	_, has, err = stk.GetConfig("missing")
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// Preview reports the creates without performing them.
+	prev, err := stk.Preview(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, prev.ChangeSummary[deploy.OpCreate])
+	outs, err := stk.Outputs(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, outs)
+
+	// Up creates the resources and returns the stack's outputs, and events are delivered to the callback.
+	var events int
+	up, err := stk.Up(ctx, UpdateOptions{OnEvent: func(e engine.Event) { events++ }})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, up.ChangeSummary[deploy.OpCreate])
+	assert.Equal(t, OutputValue{Value: "widget"}, up.Outputs["name"])
+	assert.Equal(t, OutputValue{Value: "hunter2"}, up.Outputs["secret"])
+	assert.NotZero(t, events)
+
+	// A second update is a no-op.
+	up, err = stk.Up(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, up.ChangeSummary[deploy.OpSame])
+
+	ref, err := stk.Refresh(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Zero(t, ref.ChangeSummary[deploy.OpDelete])
+
+	// The stack cannot be removed while it has resources, unless forced.
+	assert.Error(t, ws.RemoveStack(ctx, "dev", false))
+
+	des, err := stk.Destroy(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, des.ChangeSummary[deploy.OpDelete])
+
+	assert.NoError(t, ws.RemoveStack(ctx, "dev", false))
+	_, err = ws.SelectStack(ctx, "dev")
+	assert.Error(t, err)
+}
+
+func TestInlineProgramFailure(t *testing.T) {
+	os.Setenv(PassphraseEnvVar, "password")
+	defer os.Unsetenv(PassphraseEnvVar)
+
+	ctx := context.Background()
+	ws, dir := newTestWorkspace(t, func(ctx *pulumi.Context) error {
+		return errors.New("oh no")
+	})
+	defer os.RemoveAll(dir)
+
+	stk, err := ws.UpsertStack(ctx, "dev")
+	assert.NoError(t, err)
+
+	_, err = stk.Up(ctx, UpdateOptions{})
+	assert.Error(t, err)
+
+	// Selecting an existing stack via upsert returns the same stack.
+	again, err := ws.UpsertStack(ctx, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, stk.Name(), again.Name())
+}
+
+func TestInlineBindSecretConfig(t *testing.T) {
+	os.Setenv(PassphraseEnvVar, "password")
+	defer os.Unsetenv(PassphraseEnvVar)
+
+	var settings struct {
+		Name     string `pulumi:"name"`
+		Password string `pulumi:"password" secret:"true"`
+	}
+	program := func(ctx *pulumi.Context) error {
+		return config.Bind(ctx, &settings)
+	}
+
+	ctx := context.Background()
+	ws, dir := newTestWorkspace(t, program)
+	defer os.RemoveAll(dir)
+
+	stk, err := ws.CreateStack(ctx, "dev")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// A secret field may not be bound to a value that is not stored as a secret.
+	assert.NoError(t, stk.SetConfig("name", "widget", false))
+	assert.NoError(t, stk.SetConfig("password", "hunter2", false))
+	_, err = stk.Preview(ctx, UpdateOptions{})
+	assert.Error(t, err)
+
+	// Once the value is stored as a secret, the program binds it.
+	assert.NoError(t, stk.SetConfig("password", "hunter2", true))
+	_, err = stk.Preview(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "widget", settings.Name)
+	assert.Equal(t, "hunter2", settings.Password)
+}
+
+// widgetProvider is a dynamic provider that manages widgets in memory.
+type widgetProvider struct {
+	widgets map[pulumi.ID]resource.PropertyMap
+	nextID  int
+}
+
+func (p *widgetProvider) Create(inputs resource.PropertyMap) (pulumi.ID, resource.PropertyMap, error) {
+	p.nextID++
+	id := pulumi.ID(fmt.Sprintf("widget-%d", p.nextID))
+	p.widgets[id] = inputs
+	return id, inputs, nil
+}
+
+func (p *widgetProvider) Read(id pulumi.ID, state resource.PropertyMap) (resource.PropertyMap, error) {
+	return p.widgets[id], nil
+}
+
+func (p *widgetProvider) Update(id pulumi.ID, olds, news resource.PropertyMap) (resource.PropertyMap, error) {
+	p.widgets[id] = news
+	return news, nil
+}
+
+func (p *widgetProvider) Delete(id pulumi.ID, state resource.PropertyMap) error {
+	delete(p.widgets, id)
+	return nil
+}
+
+func TestInlineDynamicProvider(t *testing.T) {
+	os.Setenv(PassphraseEnvVar, "password")
+	defer os.Unsetenv(PassphraseEnvVar)
+
+	widgets := &widgetProvider{widgets: make(map[pulumi.ID]resource.PropertyMap)}
+	program := func(ctx *pulumi.Context) error {
+		prov, err := ctx.NewDynamicProvider("widgets", widgets)
+		if err != nil {
+			return err
+		}
+
+		count, _ := ctx.GetConfig("autotest:count")
+		color, _ := ctx.GetConfig("autotest:color")
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			_, err := ctx.RegisterResource("pulumi-go:dynamic:Widget", fmt.Sprintf("w%d", i), true,
+				map[string]interface{}{"color": color}, pulumi.ResourceOpt{Provider: prov})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	ws, dir := newTestWorkspace(t, program)
+	defer os.RemoveAll(dir)
+
+	stk, err := ws.CreateStack(ctx, "dev")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NoError(t, stk.SetConfig("count", "2", false))
+	assert.NoError(t, stk.SetConfig("color", "red", false))
+
+	// The widgets are created by the program's own provider.
+	up, err := stk.Up(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, up.ChangeSummary[deploy.OpCreate])
+	assert.Len(t, widgets.widgets, 2)
+
+	// Rerunning the program is a no-op, even though the provider is served at a new address.
+	up, err = stk.Up(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, up.ChangeSummary[deploy.OpSame])
+
+	// Changes are applied by the provider, and widgets that are no longer registered are deleted by it.
+	assert.NoError(t, stk.SetConfig("count", "1", false))
+	assert.NoError(t, stk.SetConfig("color", "blue", false))
+	up, err = stk.Up(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, up.ChangeSummary[deploy.OpUpdate])
+	assert.Equal(t, 1, up.ChangeSummary[deploy.OpDelete])
+	if assert.Len(t, widgets.widgets, 1) {
+		for _, w := range widgets.widgets {
+			assert.Equal(t, "blue", w["color"].StringValue())
+		}
+	}
+
+	// Refreshing and destroying the stack do not run the program, so they cannot reach its provider.
+	_, err = stk.Refresh(ctx, UpdateOptions{})
+	assert.Error(t, err)
+	_, err = stk.Destroy(ctx, UpdateOptions{})
+	assert.Error(t, err)
+	assert.Len(t, widgets.widgets, 1)
+
+	// Instead, the widgets are deleted by an update whose program serves the provider but registers no widgets, after
+	// which the stack may be destroyed.
+	assert.NoError(t, stk.SetConfig("count", "0", false))
+	up, err = stk.Up(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, up.ChangeSummary[deploy.OpDelete])
+	assert.Empty(t, widgets.widgets)
+	des, err := stk.Destroy(ctx, UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, des.ChangeSummary[deploy.OpDelete])
+}
@@ -0,0 +1,127 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/cancel"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// inlineRuntime is a language runtime that runs a Go program in-process rather than launching a language host.
+type inlineRuntime struct {
+	program pulumi.RunFunc
+}
+
+var _ plugin.LanguageRuntime = (*inlineRuntime)(nil)
+
+func newInlineRuntime(program pulumi.RunFunc) plugin.LanguageRuntime {
+	return &inlineRuntime{program: program}
+}
+
+func (r *inlineRuntime) Close() error {
+	return nil
+}
+
+// GetRequiredPlugins returns no plugins: an inline program's provider plugins are loaded on demand as it registers
+// resources.
+func (r *inlineRuntime) GetRequiredPlugins(info plugin.ProgInfo) ([]workspace.PluginInfo, error) {
+	return nil, nil
+}
+
+// Run runs the program against the engine's resource monitor.  As with an out-of-process program, failures in the
+// program itself are reported as an error message rather than an error.
+func (r *inlineRuntime) Run(info plugin.RunInfo) (string, bool, error) {
+	cfg := make(map[string]string)
+	for k, v := range info.Config {
+		cfg[k.String()] = v
+	}
+	var secretKeys []string
+	for _, k := range info.ConfigSecrets {
+		secretKeys = append(secretKeys, k.String())
+	}
+
+	ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+		Project:          info.Project,
+		Stack:            info.Stack,
+		Config:           cfg,
+		ConfigSecretKeys: secretKeys,
+		Parallel:         info.Parallel,
+		DryRun:           info.DryRun,
+		MonitorAddr:      info.MonitorAddress,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	if err = pulumi.RunWithContext(ctx, r.program); err != nil {
+		return err.Error(), false, nil
+	}
+	return "", false, nil
+}
+
+func (r *inlineRuntime) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{Name: "go", Kind: workspace.LanguagePlugin}, nil
+}
+
+// contextScopeSource is a source of cancellation scopes that are cancelled when a Go context is done.
+type contextScopeSource struct {
+	ctx context.Context
+}
+
+type contextScope struct {
+	context *cancel.Context
+	done    chan bool
+	closed  chan bool
+}
+
+func (s contextScopeSource) NewScope(events chan<- engine.Event, isPreview bool) backend.CancellationScope {
+	cancelContext, cancelSource := cancel.NewContext(context.Background())
+
+	c := &contextScope{
+		context: cancelContext,
+		done:    make(chan bool),
+		closed:  make(chan bool),
+	}
+
+	// When the caller's context is done, request that the operation cancel gracefully rather than terminate, so that
+	// the engine has a chance to record the state of any in-flight resource operations.
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			cancelSource.Cancel()
+		case <-c.closed:
+		}
+		close(c.done)
+	}()
+
+	return c
+}
+
+func (s *contextScope) Context() *cancel.Context {
+	return s.context
+}
+
+func (s *contextScope) Close() {
+	close(s.closed)
+	<-s.done
+}
@@ -0,0 +1,123 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/secrets/service"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// PassphraseEnvVar is the environment variable from which the passphrase for passphrase-based secrets is read.  Unlike
+// the CLI, a workspace never prompts for a passphrase.
+const PassphraseEnvVar = "PULUMI_CONFIG_PASSPHRASE"
+
+// secretsManager returns the secrets manager for this stack, choosing it in the same way as the CLI: an explicitly
+// configured provider is used if present, and otherwise the backend's default.
+func (s *Stack) secretsManager() (secrets.Manager, error) {
+	path := s.configPath()
+	ps, err := workspace.LoadProjectStack(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := func() (secrets.Manager, error) {
+		if ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "" {
+			return newCloudSecretsManager(ps, path)
+		}
+
+		if ps.EncryptionSalt != "" {
+			return newPassphraseSecretsManager(ps, path)
+		}
+
+		switch stk := s.s.(type) {
+		case httpstate.Stack:
+			client := stk.Backend().(httpstate.Backend).Client()
+			return service.NewServiceSecretsManager(client, stk.StackIdentifier())
+		case filestate.Stack, sqlstate.Stack:
+			return newPassphraseSecretsManager(ps, path)
+		}
+
+		return nil, errors.Errorf("unknown stack type %s", reflect.TypeOf(s.s))
+	}()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting secrets manager")
+	}
+	return stack.NewCachingSecretsManager(sm), nil
+}
+
+// newPassphraseSecretsManager returns a passphrase-based secrets manager for a stack, using the passphrase found in
+// the environment.  If the stack does not yet have a salt, a fresh one is created and saved to its configuration.
+func newPassphraseSecretsManager(ps *workspace.ProjectStack, path string) (secrets.Manager, error) {
+	phrase, ok := os.LookupEnv(PassphraseEnvVar)
+	if !ok {
+		return nil, errors.Errorf("passphrase must be set with the %s environment variable", PassphraseEnvVar)
+	}
+
+	if ps.EncryptionSalt == "" {
+		salt := make([]byte, 8)
+		_, err := cryptorand.Read(salt)
+		contract.Assertf(err == nil, "could not read from system random")
+
+		// Encrypt a message and store it with the salt so we can test if the password is correct later.
+		crypter := config.NewSymmetricCrypterFromPassphrase(phrase, salt)
+		msg, err := crypter.EncryptValue("pulumi")
+		contract.AssertNoError(err)
+
+		ps.EncryptionSalt = fmt.Sprintf("v1:%s:%s", base64.StdEncoding.EncodeToString(salt), msg)
+		if err = ps.Save(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return passphrase.NewPassphaseSecretsManager(phrase, ps.EncryptionSalt)
+}
+
+// newCloudSecretsManager returns a secrets manager for a stack that uses a cloud key management service, creating and
+// saving a new data key if the stack does not yet have one.
+func newCloudSecretsManager(ps *workspace.ProjectStack, path string) (secrets.Manager, error) {
+	if ps.EncryptedKey == "" {
+		dataKey, err := cloud.GenerateNewDataKey(ps.SecretsProvider)
+		if err != nil {
+			return nil, err
+		}
+		ps.EncryptedKey = base64.StdEncoding.EncodeToString(dataKey)
+		if err = ps.Save(path); err != nil {
+			return nil, err
+		}
+	}
+
+	dataKey, err := base64.StdEncoding.DecodeString(ps.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	return cloud.NewCloudSecretsManager(ps.SecretsProvider, dataKey)
+}
@@ -0,0 +1,406 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auto
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// defaultParallel is the degree of parallelism used when none is specified, matching the CLI.
+const defaultParallel = math.MaxInt32
+
+// Stack is a stack within a workspace, upon which updates and other operations may be performed.
+type Stack struct {
+	ws *Workspace
+	s  backend.Stack
+}
+
+// Name returns the stack's name.
+func (s *Stack) Name() string { return string(s.s.Ref().Name()) }
+
+// Ref returns the backend's reference to this stack.
+func (s *Stack) Ref() backend.StackReference { return s.s.Ref() }
+
+// Workspace returns the workspace this stack belongs to.
+func (s *Stack) Workspace() *Workspace { return s.ws }
+
+// configPath returns the path to this stack's configuration file.
+func (s *Stack) configPath() string {
+	return s.ws.stackConfigPath(s.s.Ref().Name())
+}
+
+// parseConfigKey parses a configuration key.  As with the CLI, a key with no namespace is treated as belonging to the
+// workspace's project.
+func (s *Stack) parseConfigKey(key string) (config.Key, error) {
+	if !strings.Contains(key, tokens.TokenDelimiter) {
+		key = fmt.Sprintf("%s:%s", s.ws.proj.Name, key)
+	}
+	return config.ParseKey(key)
+}
+
+// GetConfig returns the value of the configuration key for this stack, decrypting it if it is a secret.  The boolean
+// result is false if the key is not set.
+func (s *Stack) GetConfig(key string) (string, bool, error) {
+	k, err := s.parseConfigKey(key)
+	if err != nil {
+		return "", false, err
+	}
+	ps, err := workspace.LoadProjectStack(s.configPath())
+	if err != nil {
+		return "", false, err
+	}
+	v, has := ps.Config[k]
+	if !has {
+		return "", false, nil
+	}
+
+	dec := config.NewBlindingDecrypter()
+	if v.Secure() {
+		sm, err := s.secretsManager()
+		if err != nil {
+			return "", false, err
+		}
+		if dec, err = sm.Decrypter(); err != nil {
+			return "", false, err
+		}
+	}
+	raw, err := v.Value(dec)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "reading configuration value '%s'", key)
+	}
+	return raw, true, nil
+}
+
+// SetConfig sets the value of the configuration key for this stack.  If secret is true, the value is encrypted using
+// the stack's secrets manager.
+func (s *Stack) SetConfig(key, value string, secret bool) error {
+	k, err := s.parseConfigKey(key)
+	if err != nil {
+		return err
+	}
+
+	v := config.NewValue(value)
+	if secret {
+		sm, err := s.secretsManager()
+		if err != nil {
+			return err
+		}
+		enc, err := sm.Encrypter()
+		if err != nil {
+			return err
+		}
+		ciphertext, err := enc.EncryptValue(value)
+		if err != nil {
+			return errors.Wrapf(err, "encrypting configuration value '%s'", key)
+		}
+		v = config.NewSecureValue(ciphertext)
+	}
+
+	// Note that the stack configuration is loaded after the secrets manager, which may have saved new state into it.
+	path := s.configPath()
+	ps, err := workspace.LoadProjectStack(path)
+	if err != nil {
+		return err
+	}
+	ps.Config[k] = v
+	return ps.Save(path)
+}
+
+// RemoveConfig removes the configuration key from this stack, if it is set.
+func (s *Stack) RemoveConfig(key string) error {
+	k, err := s.parseConfigKey(key)
+	if err != nil {
+		return err
+	}
+	path := s.configPath()
+	ps, err := workspace.LoadProjectStack(path)
+	if err != nil {
+		return err
+	}
+	delete(ps.Config, k)
+	return ps.Save(path)
+}
+
+// configuration returns the configuration to use for an operation on this stack.
+func (s *Stack) configuration(sm secrets.Manager) (backend.StackConfiguration, error) {
+	ps, err := workspace.LoadProjectStack(s.configPath())
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "loading stack configuration")
+	}
+
+	// As with the CLI, the decrypter is only needed if there are secrets in the configuration.
+	if !ps.Config.HasSecureValue() {
+		return backend.StackConfiguration{Config: ps.Config, Decrypter: config.NewPanicCrypter()}, nil
+	}
+	dec, err := sm.Decrypter()
+	if err != nil {
+		return backend.StackConfiguration{}, errors.Wrap(err, "getting configuration decrypter")
+	}
+	return backend.StackConfiguration{Config: ps.Config, Decrypter: dec}, nil
+}
+
+// UpdateOptions controls the behavior of a single operation on a stack.
+type UpdateOptions struct {
+	// Message is an optional message to record with the operation.
+	Message string
+	// Parallel is the degree of parallelism for resource operations (<=1 for serial).  If zero, operations are
+	// unbounded, as they are in the CLI.
+	Parallel int
+	// Refresh, when true, refreshes the stack's state before performing an update.
+	Refresh bool
+	// Targets optionally restricts the operation to the resources with the given URNs.
+	Targets []resource.URN
+	// OnEvent, if non-nil, is called for every event raised by the engine during the operation.
+	OnEvent func(e engine.Event)
+	// Progress, if non-nil, receives the textual progress display that the CLI would print.
+	Progress io.Writer
+}
+
+// PreviewResult is the result of previewing an update to a stack.
+type PreviewResult struct {
+	// ChangeSummary counts the resource operations that the update would perform, by kind.
+	ChangeSummary engine.ResourceChanges
+}
+
+// UpResult is the result of updating a stack.
+type UpResult struct {
+	// ChangeSummary counts the resource operations performed by the update, by kind.
+	ChangeSummary engine.ResourceChanges
+	// Outputs contains the stack's outputs after the update.
+	Outputs OutputMap
+}
+
+// RefreshResult is the result of refreshing a stack.
+type RefreshResult struct {
+	// ChangeSummary counts the resource operations performed by the refresh, by kind.
+	ChangeSummary engine.ResourceChanges
+}
+
+// DestroyResult is the result of destroying a stack.
+type DestroyResult struct {
+	// ChangeSummary counts the resource operations performed by the destroy, by kind.
+	ChangeSummary engine.ResourceChanges
+}
+
+// OutputValue is the value of a single stack output.
+type OutputValue struct {
+	// Value is the output's value, as plain Go data.
+	Value interface{}
+	// Secret is true if the value is a secret.
+	Secret bool
+}
+
+// OutputMap maps the names of a stack's outputs to their values.
+type OutputMap map[string]OutputValue
+
+// Preview computes the changes that an update of the stack would make, without making them.
+func (s *Stack) Preview(ctx context.Context, opts UpdateOptions) (PreviewResult, error) {
+	changes, err := s.run(ctx, apitype.PreviewUpdate, opts)
+	if err != nil {
+		return PreviewResult{}, err
+	}
+	return PreviewResult{ChangeSummary: changes}, nil
+}
+
+// Up updates the stack's resources to match the workspace's program and configuration.
+func (s *Stack) Up(ctx context.Context, opts UpdateOptions) (UpResult, error) {
+	changes, err := s.run(ctx, apitype.UpdateUpdate, opts)
+	if err != nil {
+		return UpResult{ChangeSummary: changes}, err
+	}
+	outs, err := s.Outputs(ctx)
+	if err != nil {
+		return UpResult{ChangeSummary: changes}, err
+	}
+	return UpResult{ChangeSummary: changes, Outputs: outs}, nil
+}
+
+// Refresh updates the stack's state to match the actual state of its resources.
+func (s *Stack) Refresh(ctx context.Context, opts UpdateOptions) (RefreshResult, error) {
+	changes, err := s.run(ctx, apitype.RefreshUpdate, opts)
+	if err != nil {
+		return RefreshResult{ChangeSummary: changes}, err
+	}
+	return RefreshResult{ChangeSummary: changes}, nil
+}
+
+// Destroy deletes all of the stack's resources.
+func (s *Stack) Destroy(ctx context.Context, opts UpdateOptions) (DestroyResult, error) {
+	changes, err := s.run(ctx, apitype.DestroyUpdate, opts)
+	if err != nil {
+		return DestroyResult{ChangeSummary: changes}, err
+	}
+	return DestroyResult{ChangeSummary: changes}, nil
+}
+
+// Outputs returns the stack's current outputs.
+func (s *Stack) Outputs(ctx context.Context) (OutputMap, error) {
+	// Some backends cache a stack's snapshot, so fetch the stack afresh to observe the results of recent operations.
+	latest, err := s.ws.backend.GetStack(ctx, s.s.Ref())
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting stack '%s'", s.Name())
+	} else if latest == nil {
+		return nil, errors.Errorf("no stack named '%s' found", s.Name())
+	}
+	s.s = latest
+
+	snap, err := s.s.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := stack.GetRootStackResource(snap)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting root stack resource")
+	}
+
+	outs := OutputMap{}
+	if res == nil {
+		return outs, nil
+	}
+	for k, v := range res.Outputs {
+		if v.IsSecret() {
+			outs[string(k)] = OutputValue{Value: v.SecretValue().Element.Mappable(), Secret: true}
+		} else {
+			outs[string(k)] = OutputValue{Value: v.Mappable()}
+		}
+	}
+	return outs, nil
+}
+
+// run performs an operation of the given kind on the stack.
+func (s *Stack) run(ctx context.Context, kind apitype.UpdateKind,
+	opts UpdateOptions) (engine.ResourceChanges, error) {
+
+	sm, err := s.secretsManager()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.configuration(sm)
+	if err != nil {
+		return nil, err
+	}
+
+	engineOpts := engine.UpdateOptions{
+		Parallel: opts.Parallel,
+		Refresh:  opts.Refresh,
+	}
+	if engineOpts.Parallel == 0 {
+		engineOpts.Parallel = defaultParallel
+	}
+	switch kind {
+	case apitype.PreviewUpdate, apitype.UpdateUpdate:
+		engineOpts.UpdateTargets = opts.Targets
+	case apitype.RefreshUpdate:
+		engineOpts.RefreshTargets = opts.Targets
+	case apitype.DestroyUpdate:
+		engineOpts.DestroyTargets = opts.Targets
+	}
+	if s.ws.program != nil {
+		engineOpts.LanguageRuntime = newInlineRuntime(s.ws.program)
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = ioutil.Discard
+	}
+
+	// Forward engine events to the caller's callback, if any.
+	var events chan engine.Event
+	eventsDone := make(chan bool)
+	if opts.OnEvent != nil {
+		events = make(chan engine.Event)
+		go func() {
+			for e := range events {
+				opts.OnEvent(e)
+			}
+			close(eventsDone)
+		}()
+	} else {
+		close(eventsDone)
+	}
+
+	op := backend.UpdateOperation{
+		Proj: s.ws.proj,
+		Root: s.ws.root,
+		M: &backend.UpdateMetadata{
+			Message:     opts.Message,
+			Environment: make(map[string]string),
+		},
+		Opts: backend.UpdateOptions{
+			Engine: engineOpts,
+			Display: display.Options{
+				Color:  colors.Never,
+				Type:   display.DisplayProgress,
+				Stdout: progress,
+				Stderr: progress,
+			},
+			AutoApprove: true,
+			SkipPreview: true,
+			Events:      events,
+		},
+		SecretsManager:     sm,
+		StackConfiguration: cfg,
+		Scopes:             contextScopeSource{ctx: ctx},
+	}
+
+	var changes engine.ResourceChanges
+	var res result.Result
+	switch kind {
+	case apitype.PreviewUpdate:
+		changes, res = s.s.Preview(ctx, op)
+	case apitype.UpdateUpdate:
+		changes, res = s.s.Update(ctx, op)
+	case apitype.RefreshUpdate:
+		changes, res = s.s.Refresh(ctx, op)
+	case apitype.DestroyUpdate:
+		changes, res = s.s.Destroy(ctx, op)
+	default:
+		return nil, errors.Errorf("unsupported operation %s", kind)
+	}
+
+	if events != nil {
+		close(events)
+	}
+	<-eventsDone
+
+	if res != nil {
+		if res.IsBail() {
+			// The engine has already reported the reason for the failure as a diagnostic event.
+			return changes, errors.Errorf("%s failed; see the engine's diagnostics for details", kind)
+		}
+		return changes, res.Error()
+	}
+	return changes, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auto exposes the stack operations of the Pulumi CLI -- selecting and creating stacks, managing their
+// configuration, previewing, updating, refreshing, and destroying them, and reading their outputs -- as a Go library.
+// This allows programs to drive deployments directly, rather than running `pulumi` as a subprocess and parsing its
+// output.  Programs may either be read from a project directory, or supplied in-process as a pulumi.RunFunc.
+package auto
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/backend/sqlstate"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// Workspace binds a Pulumi project to the backend that manages its stacks.  Stack configuration is stored in the
+// project's root directory, in the same Pulumi.<stack>.yaml files used by the CLI.
+type Workspace struct {
+	backend backend.Backend
+	proj    *workspace.Project
+	root    string         // the directory holding the project and its stack configuration.
+	ext     string         // the file extension to use for stack configuration files.
+	program pulumi.RunFunc // an optional in-process program to run in place of the project's program.
+}
+
+// NewWorkspace creates a workspace for the project found in the given directory, whose stacks are managed by the
+// given backend.  Operations on its stacks run the project's program using its language runtime, exactly as the
+// CLI would.
+func NewWorkspace(b backend.Backend, dir string) (*Workspace, error) {
+	path, err := workspace.DetectProjectPathFrom(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching for a project in '%s'", dir)
+	} else if path == "" {
+		return nil, errors.Errorf("no Pulumi project found in '%s'", dir)
+	}
+
+	proj, err := workspace.LoadProject(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading project '%s'", path)
+	}
+
+	return &Workspace{
+		backend: b,
+		proj:    proj,
+		root:    filepath.Dir(path),
+		ext:     filepath.Ext(path),
+	}, nil
+}
+
+// NewInlineWorkspace creates a workspace for a project whose program is the given Go function, run in-process.  The
+// directory is used to hold stack configuration and as the program's working directory; it is created if necessary.
+func NewInlineWorkspace(b backend.Backend, dir string, project tokens.PackageName,
+	program pulumi.RunFunc) (*Workspace, error) {
+
+	if program == nil {
+		return nil, errors.New("an inline workspace requires a program")
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(root, 0755); err != nil {
+		return nil, errors.Wrapf(err, "creating workspace directory '%s'", root)
+	}
+
+	proj := &workspace.Project{
+		Name:    project,
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+	}
+	if err = proj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Workspace{
+		backend: b,
+		proj:    proj,
+		root:    root,
+		ext:     ".yaml",
+		program: program,
+	}, nil
+}
+
+// Backend returns the backend that manages this workspace's stacks.
+func (w *Workspace) Backend() backend.Backend { return w.backend }
+
+// Project returns this workspace's project.
+func (w *Workspace) Project() *workspace.Project { return w.proj }
+
+// Root returns the directory holding this workspace's project and stack configuration.
+func (w *Workspace) Root() string { return w.root }
+
+// SelectStack returns the existing stack with the given name, or an error if there is no such stack.
+func (w *Workspace) SelectStack(ctx context.Context, name string) (*Stack, error) {
+	ref, err := w.parseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := w.backend.GetStack(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting stack '%s'", name)
+	} else if s == nil {
+		return nil, errors.Errorf("no stack named '%s' found", name)
+	}
+	return &Stack{ws: w, s: s}, nil
+}
+
+// CreateStack creates a new stack with the given name, or returns an error if the stack already exists.
+func (w *Workspace) CreateStack(ctx context.Context, name string) (*Stack, error) {
+	ref, err := w.parseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := w.backend.CreateStack(ctx, ref, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating stack '%s'", name)
+	}
+
+	// Initialize the stack's secrets manager now, so that its state is recorded before any configuration is set.
+	stk := &Stack{ws: w, s: s}
+	if _, err = stk.secretsManager(); err != nil {
+		return nil, err
+	}
+	return stk, nil
+}
+
+// UpsertStack returns the stack with the given name, creating it if it does not already exist.
+func (w *Workspace) UpsertStack(ctx context.Context, name string) (*Stack, error) {
+	ref, err := w.parseStackReference(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := w.backend.GetStack(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting stack '%s'", name)
+	} else if s != nil {
+		return &Stack{ws: w, s: s}, nil
+	}
+	return w.CreateStack(ctx, name)
+}
+
+// RemoveStack removes the stack with the given name.  Unless force is true, stacks that still contain resources
+// are not removed.
+func (w *Workspace) RemoveStack(ctx context.Context, name string, force bool) error {
+	stk, err := w.SelectStack(ctx, name)
+	if err != nil {
+		return err
+	}
+	if _, err = w.backend.RemoveStack(ctx, stk.s, force); err != nil {
+		return err
+	}
+
+	// Remove the stack's configuration file as well, as the CLI does.
+	if err = os.Remove(w.stackConfigPath(stk.s.Ref().Name())); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// parseStackReference parses the name of one of this workspace's stacks.  The local and SQL backends otherwise assume
+// that an unqualified name refers to a stack of the project in the current working directory, so the names of stacks
+// they manage are qualified with this workspace's project.
+func (w *Workspace) parseStackReference(name string) (backend.StackReference, error) {
+	_, isFileState := w.backend.(filestate.Backend)
+	_, isSQLState := w.backend.(sqlstate.Backend)
+	if (isFileState || isSQLState) && !strings.Contains(name, "/") {
+		name = fmt.Sprintf("%s/%s", w.proj.Name, name)
+	}
+	return w.backend.ParseStackReference(name)
+}
+
+// stackConfigPath returns the path of the configuration file for the stack with the given name.
+func (w *Workspace) stackConfigPath(name tokens.QName) string {
+	file := fmt.Sprintf("%s.%s%s", workspace.ProjectFile,
+		strings.Replace(string(name), tokens.QNameDelimiter, "-", -1), w.ext)
+	return filepath.Join(w.root, w.proj.Config, file)
+}
@@ -157,6 +157,9 @@ type Backend interface {
 
 	// ExportDeployment exports the deployment for the given stack as an opaque JSON message.
 	ExportDeployment(ctx context.Context, stack Stack) (*apitype.UntypedDeployment, error)
+	// ExportDeploymentVersion exports the deployment that the given stack had after the update with the given version,
+	// as listed by GetHistory, as an opaque JSON message.
+	ExportDeploymentVersion(ctx context.Context, stack Stack, version int) (*apitype.UntypedDeployment, error)
 	// ImportDeployment imports the given deployment into the indicated stack.
 	ImportDeployment(ctx context.Context, stack Stack, deployment *apitype.UntypedDeployment) error
 	// Logout logs you out of the backend and removes any stored credentials.
@@ -165,6 +168,13 @@ type Backend interface {
 	CurrentUser() (string, error)
 }
 
+// UncheckedDeploymentExporter is implemented by backends that check the integrity of a stack's state whenever it is
+// loaded, so that state that fails those checks can still be loaded in order to repair it.
+type UncheckedDeploymentExporter interface {
+	// ExportDeploymentUnchecked exports the deployment of the given stack without checking its integrity.
+	ExportDeploymentUnchecked(ctx context.Context, stack Stack) (*apitype.UntypedDeployment, error)
+}
+
 // UpdateOperation is a complete stack update operation (preview, update, refresh, or destroy).
 type UpdateOperation struct {
 	Proj               *workspace.Project
@@ -203,6 +213,10 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+
+	// Events, if non-nil, receives every engine event raised by the operation, including those of its preview.  The
+	// caller owns the channel; it is never closed by the backend.
+	Events chan<- engine.Event
 }
 
 // QueryOptions configures a query to operate against a backend and the engine.
@@ -66,11 +66,17 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 		case event := <-events:
 			spinner.Reset()
 
-			out := os.Stdout
+			var out io.Writer = os.Stdout
+			if opts.Stdout != nil {
+				out = opts.Stdout
+			}
 			if event.Type == engine.DiagEvent {
 				payload := event.Payload.(engine.DiagEventPayload)
 				if payload.Severity == diag.Error || payload.Severity == diag.Warning {
 					out = os.Stderr
+					if opts.Stderr != nil {
+						out = opts.Stderr
+					}
 				}
 			}
 
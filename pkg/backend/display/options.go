@@ -14,7 +14,11 @@
 
 package display
 
-import "github.com/pulumi/pulumi/pkg/diag/colors"
+import (
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+)
 
 // Type of output to display.
 type Type int
@@ -42,4 +46,6 @@ type Options struct {
 	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
 	Debug                bool                // true to enable debug output.
+	Stdout               io.Writer           // the writer to use for standard output.  Defaults to os.Stdout.
+	Stderr               io.Writer           // the writer to use for standard error.  Defaults to os.Stderr.
 }
@@ -283,6 +283,9 @@ func ShowProgressEvents(op string, action apitype.UpdateKind, stack tokens.QName
 	}()
 
 	_, stdout, _ := term.StdStreams()
+	if opts.Stdout != nil {
+		stdout = opts.Stdout
+	}
 	ShowProgressOutput(progressOutput, stdout, display.isTerminal)
 
 	ticker.Stop()
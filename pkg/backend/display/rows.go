@@ -235,6 +235,8 @@ func (data *resourceRowData) RecordPolicyViolationEvent(event engine.Event) {
 		payload.Severity = diag.Error
 	case apitype.Advisory:
 		payload.Severity = diag.Warning
+	case apitype.Remediate:
+		payload.Severity = diag.Info
 	default:
 		contract.Failf("Unknown enforcement level %q", pePayload.EnforcementLevel)
 	}
@@ -0,0 +1,202 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+)
+
+// ResourceDiff describes how a resource differs between two snapshots.
+type ResourceDiff struct {
+	Op    deploy.StepOp          // OpCreate, OpDelete, OpReplace, OpUpdate or OpSame.
+	URN   resource.URN           // the resource's URN.
+	Old   *resource.State        // the resource's state in the old snapshot, if any.
+	New   *resource.State        // the resource's state in the new snapshot, if any.
+	Diffs []resource.PropertyKey // the keys of the inputs and outputs that differ.
+}
+
+// DiffSnapshots compares the live resources of two snapshots, either of which may be nil. The differences are returned
+// in the order in which the resources appear in the new snapshot, followed by the resources that only appear in the
+// old snapshot. A resource whose ID differs between the snapshots is considered to have been replaced, and one whose
+// properties, provider, parent or protection differ is considered to have been updated.
+func DiffSnapshots(old, new *deploy.Snapshot) []ResourceDiff {
+	olds := make(map[resource.URN]*resource.State)
+	news := make(map[resource.URN]bool)
+	if old != nil {
+		for _, res := range old.Resources {
+			if !res.Delete {
+				olds[res.URN] = res
+			}
+		}
+	}
+
+	var diffs []ResourceDiff
+	if new != nil {
+		for _, res := range new.Resources {
+			if res.Delete {
+				continue
+			}
+			news[res.URN] = true
+
+			oldRes, has := olds[res.URN]
+			if !has {
+				diffs = append(diffs, ResourceDiff{Op: deploy.OpCreate, URN: res.URN, New: res})
+				continue
+			}
+
+			d := ResourceDiff{Op: deploy.OpSame, URN: res.URN, Old: oldRes, New: res}
+			keys := make(map[resource.PropertyKey]bool)
+			for _, pair := range [][2]resource.PropertyMap{{oldRes.Inputs, res.Inputs}, {oldRes.Outputs, res.Outputs}} {
+				if diff := pair[0].Diff(pair[1], engine.IsInternalPropertyKey); diff != nil {
+					for _, k := range diff.Keys() {
+						if !diff.Same(k) {
+							keys[k] = true
+						}
+					}
+				}
+			}
+			for k := range keys {
+				d.Diffs = append(d.Diffs, k)
+			}
+			sort.Slice(d.Diffs, func(i, j int) bool { return d.Diffs[i] < d.Diffs[j] })
+
+			switch {
+			case oldRes.ID != res.ID:
+				d.Op = deploy.OpReplace
+			case len(d.Diffs) != 0 || oldRes.Provider != res.Provider || oldRes.Parent != res.Parent ||
+				oldRes.Protect != res.Protect:
+				d.Op = deploy.OpUpdate
+			}
+			diffs = append(diffs, d)
+		}
+	}
+	if old != nil {
+		for _, res := range old.Resources {
+			if !res.Delete && !news[res.URN] {
+				diffs = append(diffs, ResourceDiff{Op: deploy.OpDelete, URN: res.URN, Old: res})
+			}
+		}
+	}
+	return diffs
+}
+
+// ShowSnapshotDiff renders the differences between two snapshots, as computed by DiffSnapshots, followed by a summary
+// of the changes. Unchanged resources are only shown if opts.ShowSameResources is set.
+func ShowSnapshotDiff(out io.Writer, diffs []ResourceDiff, opts Options) {
+	seen := make(map[resource.URN]engine.StepEventMetadata)
+	changes := make(engine.ResourceChanges)
+	for _, d := range diffs {
+		changes[d.Op]++
+
+		metadata := snapshotDiffMetadata(d, opts)
+		seen[d.URN] = metadata
+		if shouldShow(metadata, opts) {
+			renderDiff(out, metadata, false /*planning*/, opts.Debug, seen, opts)
+		}
+	}
+
+	b := &bytes.Buffer{}
+	fprintIgnoreError(b, opts.Color.Colorize(fmt.Sprintf("%sResources:%s\n", colors.SpecHeadline, colors.Reset)))
+	for _, op := range deploy.StepOps {
+		if c := changes[op]; c > 0 && op != deploy.OpSame {
+			fprintIgnoreError(b, opts.Color.Colorize(
+				fmt.Sprintf("    %s%d %s%s\n", op.Prefix(), c, op.PastTense(), colors.Reset)))
+		}
+	}
+	if c := changes[deploy.OpSame]; c > 0 {
+		fprintfIgnoreError(b, "    %d unchanged\n", c)
+	}
+	if len(changes) == 0 {
+		fprintIgnoreError(b, "    no resources\n")
+	}
+	fprintIgnoreError(out, "\n"+b.String())
+}
+
+// snapshotDiffDigest is a JSON-serializable overview of the differences between two snapshots.
+type snapshotDiffDigest struct {
+	// Steps contains the differences for each resource, in the same form as the steps of a preview.
+	Steps []*previewStep `json:"steps"`
+	// ChangeSummary contains a map of count per operation (create, update, etc).
+	ChangeSummary engine.ResourceChanges `json:"changeSummary"`
+}
+
+// ShowSnapshotDiffJSON renders the differences between two snapshots, as computed by DiffSnapshots, as a JSON document
+// whose steps have the same form as those emitted for previews. Secret values are blinded.
+func ShowSnapshotDiffJSON(out io.Writer, diffs []ResourceDiff, opts Options) error {
+	digest := snapshotDiffDigest{Steps: []*previewStep{}, ChangeSummary: make(engine.ResourceChanges)}
+	for _, d := range diffs {
+		digest.ChangeSummary[d.Op]++
+		if d.Op == deploy.OpSame && !opts.ShowSameResources {
+			continue
+		}
+
+		step := &previewStep{Op: d.Op, URN: d.URN, DiffReasons: d.Diffs}
+		if d.Old != nil {
+			res, err := stack.SerializeResource(stateForJSONOutput(d.Old, opts), config.NewPanicCrypter())
+			if err != nil {
+				return err
+			}
+			step.OldState, step.Provider = &res, d.Old.Provider
+		}
+		if d.New != nil {
+			res, err := stack.SerializeResource(stateForJSONOutput(d.New, opts), config.NewPanicCrypter())
+			if err != nil {
+				return err
+			}
+			step.NewState, step.Provider = &res, d.New.Provider
+		}
+		digest.Steps = append(digest.Steps, step)
+	}
+
+	b, err := json.MarshalIndent(&digest, "", "    ")
+	if err != nil {
+		return err
+	}
+	fprintIgnoreError(out, string(b)+"\n")
+	return nil
+}
+
+// snapshotDiffMetadata describes the difference in a resource between two snapshots as a step, so that it can be
+// rendered in the same way as the steps of an update.
+func snapshotDiffMetadata(d ResourceDiff, opts Options) engine.StepEventMetadata {
+	metadata := engine.StepEventMetadata{
+		Op:      d.Op,
+		URN:     d.URN,
+		Old:     engine.NewStepEventStateMetadata(d.Old, opts.Debug),
+		New:     engine.NewStepEventStateMetadata(d.New, opts.Debug),
+		Diffs:   d.Diffs,
+		Logical: true,
+	}
+	if d.New != nil {
+		metadata.Type, metadata.Res, metadata.Provider = d.New.Type, metadata.New, d.New.Provider
+	} else {
+		metadata.Type, metadata.Res, metadata.Provider = d.Old.Type, metadata.Old, d.Old.Provider
+	}
+	if d.Op == deploy.OpReplace {
+		metadata.Keys = []resource.PropertyKey{"id"}
+	}
+	return metadata
+}
@@ -0,0 +1,76 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func newDiffTestState(name string, id resource.ID, outputs resource.PropertyMap) *resource.State {
+	typ := tokens.Type("test:index:Resource")
+	urn := resource.NewURN("dev", "proj", "", typ, tokens.QName(name))
+	return resource.NewState(typ, urn, true, false, id, resource.PropertyMap{}, outputs, "", false, false, nil, nil,
+		"", nil, false, nil, nil, nil)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	same := newDiffTestState("same", "1", resource.PropertyMap{"a": resource.NewStringProperty("a")})
+	oldUpdated := newDiffTestState("updated", "2", resource.PropertyMap{
+		"a":      resource.NewStringProperty("a"),
+		"secret": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+	})
+	newUpdated := newDiffTestState("updated", "2", resource.PropertyMap{
+		"a":      resource.NewStringProperty("a"),
+		"secret": resource.MakeSecret(resource.NewStringProperty("hunter3")),
+	})
+	oldReplaced := newDiffTestState("replaced", "3", nil)
+	newReplaced := newDiffTestState("replaced", "4", nil)
+	deleted := newDiffTestState("deleted", "5", nil)
+	created := newDiffTestState("created", "6", nil)
+	pending := newDiffTestState("created", "7", nil)
+	pending.Delete = true
+
+	old := deploy.NewSnapshot(deploy.Manifest{Time: time.Now()}, nil,
+		[]*resource.State{same, oldUpdated, oldReplaced, deleted}, nil)
+	new := deploy.NewSnapshot(deploy.Manifest{Time: time.Now()}, nil,
+		[]*resource.State{created, same, newUpdated, newReplaced, pending}, nil)
+
+	diffs := DiffSnapshots(old, new)
+	assert.Equal(t, []ResourceDiff{
+		{Op: deploy.OpCreate, URN: created.URN, New: created},
+		{Op: deploy.OpSame, URN: same.URN, Old: same, New: same},
+		{Op: deploy.OpUpdate, URN: newUpdated.URN, Old: oldUpdated, New: newUpdated,
+			Diffs: []resource.PropertyKey{"secret"}},
+		{Op: deploy.OpReplace, URN: newReplaced.URN, Old: oldReplaced, New: newReplaced},
+		{Op: deploy.OpDelete, URN: deleted.URN, Old: deleted},
+	}, diffs)
+	assert.Len(t, DiffSnapshots(nil, old), 4)
+	assert.Empty(t, DiffSnapshots(nil, nil))
+
+	// The rendered diff never shows secret values, and only shows unchanged resources when asked to.
+	opts := Options{Color: colors.Never}
+	var out bytes.Buffer
+	ShowSnapshotDiff(&out, diffs, opts)
+	assert.NotContains(t, out.String(), "hunter")
+	assert.NotContains(t, out.String(), "::same")
+	assert.Contains(t, out.String(), "::updated")
+	assert.Contains(t, out.String(), "Resources:\n    + 1 created\n    ~ 1 updated\n    - 1 deleted\n"+
+		"    +-1 replaced\n    1 unchanged\n")
+
+	out.Reset()
+	assert.NoError(t, ShowSnapshotDiffJSON(&out, diffs, opts))
+	assert.NotContains(t, out.String(), "hunter")
+	var digest map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &digest))
+	assert.Len(t, digest["steps"], 4)
+	assert.Equal(t, map[string]interface{}{"create": 1.0, "delete": 1.0, "update": 1.0, "replace": 1.0, "same": 1.0},
+		digest["changeSummary"])
+}
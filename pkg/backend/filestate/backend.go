@@ -24,6 +24,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -58,6 +59,10 @@ import (
 type Backend interface {
 	backend.Backend
 	local() // at the moment, no local specific info, so just use a marker function.
+
+	// PruneHistory removes the history, checkpoint copies, and backups of the given stack's updates that the given
+	// policy does not retain, and returns the number of updates whose history was removed.
+	PruneHistory(ctx context.Context, stackRef backend.StackReference, policy RetentionPolicy) (int, error)
 }
 
 type localBackend struct {
@@ -70,14 +75,36 @@ type localBackend struct {
 	url         string
 
 	bucket Bucket
+
+	currentProject *workspace.Project
+
+	// readOnly records whether operations that modify stacks are refused.
+	readOnly backend.ReadOnlyMode
+
+	// legacy records whether the bucket keeps its stacks in the original flat layout, which can be read but must be
+	// upgraded before it is modified.
+	legacy bool
+
+	// stackPaths caches the path of each stack's checkpoint file, which depends on whether the checkpoint is
+	// compressed, so that the bucket need only be consulted once per stack.
+	stackPaths     map[localBackendReference]string
+	stackPathsLock sync.Mutex
 }
 
+// localBackendReference identifies a stack by its project and name.  Each project's stacks are stored separately, so
+// stacks in different projects may share a name.
 type localBackendReference struct {
-	name tokens.QName
+	project tokens.PackageName
+	name    tokens.QName
+	b       *localBackend
 }
 
 func (r localBackendReference) String() string {
-	return string(r.name)
+	// If the project is the current project, we can elide it.
+	if r.b != nil && r.b.currentProject != nil && r.project == r.b.currentProject.Name {
+		return string(r.name)
+	}
+	return fmt.Sprintf("%s/%s", r.project, r.name)
 }
 
 func (r localBackendReference) Name() tokens.QName {
@@ -96,6 +123,20 @@ func IsFileStateBackendURL(urlstr string) bool {
 const FilePathPrefix = "file://"
 
 func New(d diag.Sink, originalURL string) (Backend, error) {
+	b, err := newLocalBackend(d, originalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure that the bucket uses a layout we can read.  Upgrading it is left to `pulumi state upgrade`.
+	if err = b.checkLayout(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// newLocalBackend opens the bucket with the given URL, without checking its layout.
+func newLocalBackend(d diag.Sink, originalURL string) (*localBackend, error) {
 	if !IsFileStateBackendURL(originalURL) {
 		return nil, errors.Errorf("local URL %s has an illegal prefix; expected one of: %s",
 			originalURL, strings.Join(blob.DefaultURLMux().BucketSchemes(), ", "))
@@ -126,11 +167,24 @@ func New(d diag.Sink, originalURL string) (Backend, error) {
 		}
 	}
 
+	// When stacks are referred to by name alone, they belong to the current project, if there is one.
+	currentProject, err := workspace.DetectProject()
+	if err != nil {
+		currentProject = nil
+	}
+
+	readOnly, err := backend.GetReadOnlyMode(originalURL)
+	if err != nil {
+		return nil, err
+	}
+
 	return &localBackend{
-		d:           d,
-		originalURL: originalURL,
-		url:         u,
-		bucket:      &wrappedBucket{bucket: bucket},
+		d:              d,
+		originalURL:    originalURL,
+		url:            u,
+		bucket:         &wrappedBucket{bucket: bucket},
+		currentProject: currentProject,
+		readOnly:       readOnly,
 	}, nil
 }
 
@@ -180,10 +234,19 @@ func massageBlobPath(path string) (string, error) {
 }
 
 func Login(d diag.Sink, url string) (Backend, error) {
-	be, err := New(d, url)
+	be, err := newLocalBackend(d, url)
 	if err != nil {
 		return nil, err
 	}
+
+	// A bucket whose layout cannot be used, or must be upgraded before it is modified, can still be logged in to.
+	if err = be.checkLayout(); err != nil {
+		d.Warningf(diag.Message("" /*urn*/, "%v"), err)
+	} else if be.legacy {
+		d.Warningf(diag.Message("" /*urn*/, "the state in %s keeps its stacks in the original layout, which does "+
+			"not separate them by project; they can be read, but run `pulumi state upgrade` before modifying them"),
+			be.originalURL)
+	}
 	return be, workspace.StoreAccount(be.URL(), workspace.Account{}, true)
 }
 
@@ -217,13 +280,51 @@ func (b *localBackend) SupportsOrganizations() bool {
 	return false
 }
 
+// ParseStackReference parses a stack reference of the form <stack> or <project>/<stack>.  If the project is omitted,
+// the stack belongs to the current project.
 func (b *localBackend) ParseStackReference(stackRefName string) (backend.StackReference, error) {
-	return localBackendReference{name: tokens.QName(stackRefName)}, nil
+	var project, name string
+	split := strings.Split(stackRefName, "/")
+	switch len(split) {
+	case 1:
+		name = split[0]
+	case 2:
+		project, name = split[0], split[1]
+		if project == "" {
+			return nil, errors.Errorf("could not parse stack name '%s'", stackRefName)
+		}
+	default:
+		return nil, errors.Errorf("could not parse stack name '%s'", stackRefName)
+	}
+
+	if project == "" {
+		if b.currentProject == nil {
+			return nil, errors.Errorf("could not determine the project of stack '%s'; run this command in a "+
+				"project directory, or qualify the stack name as <project>/<stack>", stackRefName)
+		}
+		project = string(b.currentProject.Name)
+	} else if err := workspace.ValidateProjectName(project); err != nil {
+		return nil, errors.Wrapf(err, "invalid project name in stack name '%s'", stackRefName)
+	}
+
+	return localBackendReference{project: tokens.PackageName(project), name: tokens.QName(name), b: b}, nil
+}
+
+// getReference returns the local reference underlying the given stack reference.
+func (b *localBackend) getReference(stackRef backend.StackReference) (localBackendReference, error) {
+	ref, ok := stackRef.(localBackendReference)
+	if !ok {
+		return localBackendReference{}, errors.New("bad stack reference type")
+	}
+	return ref, nil
 }
 
 func (b *localBackend) DoesProjectExist(ctx context.Context, projectName string) (bool, error) {
-	// Local backends don't really have multiple projects, so just return false here.
-	return false, nil
+	stacks, err := b.getLocalStacks(&projectName)
+	if err != nil {
+		return false, err
+	}
+	return len(stacks) > 0, nil
 }
 
 func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackReference,
@@ -231,12 +332,20 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 
 	contract.Requiref(opts == nil, "opts", "local stacks do not support any options")
 
-	stackName := stackRef.Name()
+	if err := b.checkWritable("create a stack"); err != nil {
+		return nil, err
+	}
+
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	stackName := ref.name
 	if stackName == "" {
 		return nil, errors.New("invalid empty stack name")
 	}
 
-	if _, _, err := b.getStack(stackName); err == nil {
+	if _, _, err = b.getStack(ref); err == nil {
 		return nil, &backend.StackAlreadyExistsError{StackName: string(stackName)}
 	}
 
@@ -248,7 +357,7 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, errors.Wrap(err, "validating stack properties")
 	}
 
-	file, err := b.saveStack(stackName, nil, nil)
+	file, err := b.saveStack(ref, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -260,8 +369,11 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 }
 
 func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackReference) (backend.Stack, error) {
-	stackName := stackRef.Name()
-	snapshot, path, err := b.getStack(stackName)
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, path, err := b.getStack(ref)
 	switch {
 	case gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound:
 		return nil, nil
@@ -273,17 +385,17 @@ func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackRefer
 }
 
 func (b *localBackend) ListStacks(
-	ctx context.Context, _ backend.ListStacksFilter) ([]backend.StackSummary, error) {
-	stacks, err := b.getLocalStacks()
+	ctx context.Context, filter backend.ListStacksFilter) ([]backend.StackSummary, error) {
+	stacks, err := b.getLocalStacks(filter.Project)
 	if err != nil {
 		return nil, err
 	}
 
-	// Note that the provided stack filter is not honored, since fields like
+	// Note that only the project filter is honored, since fields like
 	// organizations and tags aren't persisted in the local backend.
 	var results []backend.StackSummary
-	for _, stackName := range stacks {
-		stack, err := b.GetStack(ctx, localBackendReference{name: stackName})
+	for _, ref := range stacks {
+		stack, err := b.GetStack(ctx, ref)
 		if err != nil {
 			return nil, err
 		}
@@ -296,8 +408,14 @@ func (b *localBackend) ListStacks(
 }
 
 func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
-	stackName := stack.Ref().Name()
-	snapshot, _, err := b.getStack(stackName)
+	if err := b.checkWritable("remove a stack"); err != nil {
+		return false, err
+	}
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return false, err
+	}
+	snapshot, _, err := b.getStack(ref)
 	if err != nil {
 		return false, err
 	}
@@ -307,43 +425,80 @@ func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, for
 		return true, errors.New("refusing to remove stack because it still contains resources")
 	}
 
-	return false, b.removeStack(stackName)
+	return false, b.removeStack(ref)
 }
 
+// RenameStack renames a stack.  The new name may be qualified as <project>/<stack> to move the stack to a different
+// project; otherwise, the stack remains in its current project.
 func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
-	stackName := stack.Ref().Name()
-	snap, _, err := b.getStack(stackName)
+	if err := b.checkWritable("rename a stack"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return err
+	}
+	snap, _, err := b.getStack(ref)
 	if err != nil {
 		return err
 	}
 
+	newRef := localBackendReference{project: ref.project, name: newName, b: b}
+	if strings.Contains(string(newName), "/") {
+		parsed, err := b.ParseStackReference(string(newName))
+		if err != nil {
+			return err
+		}
+		newRef = parsed.(localBackendReference)
+	}
+
 	// Ensure the destination stack does not already exist.
-	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newName))
+	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newRef))
 	if err != nil {
 		return err
 	}
 	if hasExisting {
-		return errors.Errorf("a stack named %s already exists", newName)
+		return errors.Errorf("a stack named %s already exists", newRef)
 	}
 
-	// If we have a snapshot, we need to rename the URNs inside it to use the new stack name.
+	// If we have a snapshot, we need to rename the URNs inside it to use the new stack and project names.
 	if snap != nil {
-		if err = edit.RenameStack(snap, newName, ""); err != nil {
+		var newProject tokens.PackageName
+		if newRef.project != ref.project {
+			newProject = newRef.project
+		}
+		if err = edit.RenameStack(snap, newRef.name, newProject); err != nil {
 			return err
 		}
 	}
 
 	// Now save the snapshot with a new name (we pass nil to re-use the existing secrets manager from the snapshot).
-	if _, err = b.saveStack(newName, snap, nil); err != nil {
+	if _, err = b.saveStack(newRef, snap, nil); err != nil {
 		return err
 	}
 
 	// To remove the old stack, just make a backup of the file and don't write out anything new.
-	file := b.stackPath(stackName)
+	file := b.stackPath(ref)
 	backupTarget(b.bucket, file)
+	b.setStackPath(ref, "")
+	if err = b.removeJournal(ref); err != nil {
+		return err
+	}
+
+	// Move the stack's tags, if it has any.
+	tags, err := b.getTags(ref)
+	if err != nil {
+		return err
+	}
+	if err = b.saveTags(newRef, tags); err != nil {
+		return err
+	}
+	if err = b.saveTags(ref, nil); err != nil {
+		return err
+	}
 
 	// And rename the histoy folder as well.
-	return b.renameHistory(stackName, newName)
+	return b.renameHistory(ref, newRef)
 }
 
 func (b *localBackend) GetLatestConfiguration(ctx context.Context,
@@ -380,16 +535,25 @@ func (b *localBackend) Preview(ctx context.Context, stack backend.Stack,
 
 func (b *localBackend) Update(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.checkWritable("update a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.UpdateUpdate, stack, op, b.apply)
 }
 
 func (b *localBackend) Refresh(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.checkWritable("refresh a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply)
 }
 
 func (b *localBackend) Destroy(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.checkWritable("destroy a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
 
@@ -405,7 +569,11 @@ func (b *localBackend) apply(
 	events chan<- engine.Event) (engine.ResourceChanges, result.Result) {
 
 	stackRef := stack.Ref()
-	stackName := stackRef.Name()
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	stackName := ref.name
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
 	if !op.Opts.Display.JSONDisplay {
@@ -415,7 +583,7 @@ func (b *localBackend) apply(
 	}
 
 	// Start the update.
-	update, err := b.newUpdate(stackName, op)
+	update, err := b.newUpdate(ref, op)
 	if err != nil {
 		return nil, result.FromError(err)
 	}
@@ -441,13 +609,16 @@ func (b *localBackend) apply(
 			if events != nil {
 				events <- e
 			}
+			if op.Opts.Events != nil {
+				op.Opts.Events <- e
+			}
 		}
 
 		close(eventsDone)
 	}()
 
 	// Create the management machinery.
-	persister := b.newSnapshotPersister(stackName, op.SecretsManager)
+	persister := b.newSnapshotPersister(ref, op.SecretsManager)
 	manager := backend.NewSnapshotManager(persister, update.GetTarget().Snapshot)
 	engineCtx := &engine.Context{
 		Cancel:          scope.Context(),
@@ -506,8 +677,11 @@ func (b *localBackend) apply(
 	var saveErr error
 	var backupErr error
 	if !opts.DryRun {
-		saveErr = b.addToHistory(stackName, info)
-		backupErr = b.backupStack(stackName)
+		saveErr = b.addToHistory(ref, info)
+		backupErr = b.backupStack(ref)
+		if saveErr == nil && backupErr == nil {
+			b.applyRetentionPolicy(ref)
+		}
 	}
 
 	if updateRes != nil {
@@ -531,10 +705,10 @@ func (b *localBackend) apply(
 		var link string
 		if strings.HasPrefix(b.url, FilePathPrefix) {
 			u, _ := url.Parse(b.url)
-			u.Path = filepath.ToSlash(path.Join(u.Path, b.stackPath(stackName)))
+			u.Path = filepath.ToSlash(path.Join(u.Path, b.stackPath(ref)))
 			link = u.String()
 		} else {
-			link, err = b.bucket.SignedURL(context.TODO(), b.stackPath(stackName), nil)
+			link, err = b.bucket.SignedURL(context.TODO(), b.stackPath(ref), nil)
 			if err != nil {
 				return changes, result.FromError(errors.Wrap(err, "Could not get signed url for stack location"))
 			}
@@ -558,8 +732,11 @@ func (b *localBackend) query(ctx context.Context, op backend.QueryOperation,
 }
 
 func (b *localBackend) GetHistory(ctx context.Context, stackRef backend.StackReference) ([]backend.UpdateInfo, error) {
-	stackName := stackRef.Name()
-	updates, err := b.getHistory(stackName)
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := b.getHistory(ref)
 	if err != nil {
 		return nil, err
 	}
@@ -569,8 +746,11 @@ func (b *localBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 func (b *localBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg backend.StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 
-	stackName := stack.Ref().Name()
-	target, err := b.getTarget(stackName, cfg.Config, cfg.Decrypter)
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	target, err := b.getTarget(ref, cfg.Config, cfg.Decrypter)
 	if err != nil {
 		return nil, err
 	}
@@ -600,8 +780,11 @@ func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]opera
 func (b *localBackend) ExportDeployment(ctx context.Context,
 	stk backend.Stack) (*apitype.UntypedDeployment, error) {
 
-	stackName := stk.Ref().Name()
-	snap, _, err := b.getStack(stackName)
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	snap, _, err := b.getStack(ref)
 	if err != nil {
 		return nil, err
 	}
@@ -626,11 +809,90 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 	}, nil
 }
 
+var _ backend.UncheckedDeploymentExporter = (*localBackend)(nil)
+
+// ExportDeploymentUnchecked exports the deployment of the given stack without checking its integrity.
+func (b *localBackend) ExportDeploymentUnchecked(ctx context.Context,
+	stk backend.Stack) (*apitype.UntypedDeployment, error) {
+
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	chk, err := b.getCheckpoint(ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load checkpoint")
+	}
+
+	deployment := chk.Latest
+	if deployment == nil {
+		deployment = &apitype.DeploymentV3{}
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+func (b *localBackend) ExportDeploymentVersion(ctx context.Context, stk backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	chk, err := b.getHistoricalCheckpoint(ref, version)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := chk.Latest
+	if deployment == nil {
+		deployment = &apitype.DeploymentV3{}
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+var _ backend.HistoryImporter = (*localBackend)(nil)
+
+// ImportHistory adds the given updates, oldest first, to the history of the given stack, which must have none.
+func (b *localBackend) ImportHistory(ctx context.Context, stk backend.Stack, updates []backend.UpdateInfo,
+	deployments []*apitype.DeploymentV3) error {
+
+	if err := b.checkWritable("import a stack's history"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return err
+	}
+	return b.importHistory(ref, updates, deployments)
+}
+
 func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment) error {
 
-	stackName := stk.Ref().Name()
-	_, _, err := b.getStack(stackName)
+	if err := b.checkWritable("import a deployment"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return err
+	}
+	_, _, err = b.getStack(ref)
 	if err != nil {
 		return err
 	}
@@ -640,8 +902,12 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 		return err
 	}
 
-	_, err = b.saveStack(stackName, snap, snap.SecretsManager)
-	return err
+	if _, err = b.saveStack(ref, snap, snap.SecretsManager); err != nil {
+		return err
+	}
+
+	// The imported deployment replaces any changes recorded in the stack's journal.
+	return b.removeJournal(ref)
 }
 
 func (b *localBackend) Logout() error {
@@ -656,39 +922,60 @@ func (b *localBackend) CurrentUser() (string, error) {
 	return user.Username, nil
 }
 
-func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
-	var stacks []tokens.QName
-
-	// Read the stack directory.
-	path := b.stackPath("")
-
-	files, err := listBucket(b.bucket, path)
-	if err != nil {
-		return nil, errors.Wrap(err, "error listing stacks")
+// getLocalStacks returns references to the stacks in the given project, or to the stacks in all projects if the
+// project is nil.
+func (b *localBackend) getLocalStacks(project *string) ([]localBackendReference, error) {
+	if b.legacy {
+		return b.getLegacyStacks(project)
 	}
 
-	for _, file := range files {
-		// Ignore directories.
-		if file.IsDir {
-			continue
+	// If no project was given, read the directory of projects.
+	var projects []tokens.PackageName
+	if project != nil {
+		projects = append(projects, tokens.PackageName(*project))
+	} else {
+		dirs, err := listBucket(b.bucket, b.stacksDirectory(""))
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing stacks")
 		}
-
-		// Skip files without valid extensions (e.g., *.bak files).
-		stackfn := objectName(file)
-		ext := filepath.Ext(stackfn)
-		if _, has := encoding.Marshalers[ext]; !has {
-			continue
+		for _, dir := range dirs {
+			if dir.IsDir {
+				projects = append(projects, tokens.PackageName(path.Base(strings.TrimSuffix(dir.Key, "/"))))
+			}
 		}
+	}
 
-		// Read in this stack's information.
-		name := tokens.QName(stackfn[:len(stackfn)-len(ext)])
-		_, _, err := b.getStack(name)
+	var stacks []localBackendReference
+	for _, proj := range projects {
+		// Read the project's stack directory.
+		files, err := listBucket(b.bucket, b.stacksDirectory(proj))
 		if err != nil {
-			logging.V(5).Infof("error reading stack: %v (%v) skipping", name, err)
-			continue // failure reading the stack information.
+			return nil, errors.Wrap(err, "error listing stacks")
 		}
 
-		stacks = append(stacks, name)
+		for _, file := range files {
+			// Ignore directories.
+			if file.IsDir {
+				continue
+			}
+
+			// Skip files without valid extensions (e.g., *.bak files).
+			stackfn := objectName(file)
+			m, ext := encoding.Detect(stackfn)
+			if m == nil || !strings.HasSuffix(stackfn, ext) {
+				continue
+			}
+
+			// Read in this stack's information.
+			ref := localBackendReference{project: proj, name: tokens.QName(stackfn[:len(stackfn)-len(ext)]), b: b}
+			_, _, err := b.getStack(ref)
+			if err != nil {
+				logging.V(5).Infof("error reading stack: %v (%v) skipping", ref, err)
+				continue // failure reading the stack information.
+			}
+
+			stacks = append(stacks, ref)
+		}
 	}
 
 	return stacks, nil
@@ -698,14 +985,26 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 func (b *localBackend) GetStackTags(ctx context.Context,
 	stack backend.Stack) (map[apitype.StackTagName]string, error) {
 
-	// The local backend does not currently persist tags.
-	return nil, errors.New("stack tags not supported in --local mode")
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	return b.getTags(ref)
 }
 
 // UpdateStackTags updates the stacks's tags, replacing all existing tags.
 func (b *localBackend) UpdateStackTags(ctx context.Context,
 	stack backend.Stack, tags map[apitype.StackTagName]string) error {
 
-	// The local backend does not currently persist tags.
-	return errors.New("stack tags not supported in --local mode")
+	if err := b.checkWritable("update a stack's tags"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return err
+	}
+	if err = validation.ValidateStackTags(tags); err != nil {
+		return err
+	}
+	return b.saveTags(ref, tags)
 }
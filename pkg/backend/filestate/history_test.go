@@ -0,0 +1,126 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// newTestSnapshot returns a snapshot holding the given number of resources of the given stack.
+func newTestSnapshot(ref localBackendReference, count int) *deploy.Snapshot {
+	var resources []*resource.State
+	for i := 0; i < count; i++ {
+		typ := tokens.Type("test:index:Resource")
+		urn := resource.NewURN(ref.name, ref.project, "", typ, tokens.QName(string(rune('a'+i))))
+		resources = append(resources, resource.NewState(typ, urn, true, false, "id", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, "", nil, false, nil, nil, nil))
+	}
+	return deploy.NewSnapshot(deploy.Manifest{}, nil, resources, nil)
+}
+
+func TestHistoryVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proj"}
+	ctx := context.Background()
+
+	stackRef, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref, err := b.getReference(stackRef)
+	assert.NoError(t, err)
+
+	// Record two updates, the first producing one resource and the second two.
+	for i := 1; i <= 2; i++ {
+		_, err = b.saveStack(ref, newTestSnapshot(ref, i), nil)
+		assert.NoError(t, err)
+		assert.NoError(t, b.addToHistory(ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	}
+
+	history, err := b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, 2, history[0].Version)
+		assert.Equal(t, 1, history[1].Version)
+	}
+
+	// Each version's deployment is the checkpoint saved after that update.
+	for version := 1; version <= 2; version++ {
+		deployment, err := b.ExportDeploymentVersion(ctx, s, version)
+		assert.NoError(t, err)
+		snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+		assert.NoError(t, err)
+		assert.Len(t, snap.Resources, version)
+	}
+
+	_, err = b.ExportDeploymentVersion(ctx, s, 3)
+	assert.EqualError(t, err, "stack 'dev' has no update with version 3")
+}
+
+func TestHistoryVersionsAfterUnversionedUpdates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	ref := localBackendReference{project: "proj", name: "dev", b: b}
+	_, err = b.saveStack(ref, newTestSnapshot(ref, 1), nil)
+	assert.NoError(t, err)
+
+	// Updates recorded before updates were given versions are numbered by their position, and later updates are
+	// numbered after them.  Record two updates, and then remove their versions.
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.addToHistory(ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	}
+	entries, err := b.listHistory(ref)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		entry.update.Version = 0
+		byts, err := json.Marshal(entry.update)
+		assert.NoError(t, err)
+		assert.NoError(t, b.bucket.WriteAll(context.Background(), entry.file, byts, nil))
+	}
+
+	latest, err := b.latestHistoryVersion(ref)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, latest)
+
+	assert.NoError(t, b.addToHistory(ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+	history, err := b.getHistory(ref)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 3) {
+		assert.Equal(t, 3, history[0].Version)
+		assert.Equal(t, 2, history[1].Version)
+		assert.Equal(t, 1, history[2].Version)
+	}
+}
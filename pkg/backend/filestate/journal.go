@@ -0,0 +1,135 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// DisableCheckpointJournalEnvVar may be set to true to rewrite a stack's whole checkpoint after every change made
+// during an update, rather than recording the changes in the stack's journal.
+const DisableCheckpointJournalEnvVar = "PULUMI_DISABLE_CHECKPOINT_JOURNAL"
+
+// journalDirectory returns the directory holding the journal of changes made to the given stack since its checkpoint
+// was last saved.  Each object in the directory holds the entries of one append, and is named with its sequence number.
+func (b *localBackend) journalDirectory(ref localBackendReference) string {
+	contract.Require(ref.name != "", "ref")
+	return filepath.Join(b.StateDir(), workspace.JournalDir, string(ref.project), fsutil.QnamePath(ref.name))
+}
+
+// journalFile returns the path of the object with the given sequence number in the given stack's journal.
+func (b *localBackend) journalFile(ref localBackendReference, seq int) string {
+	return filepath.Join(b.journalDirectory(ref), fmt.Sprintf("%010d.json", seq))
+}
+
+// appendToJournal writes the given entries to the given stack's journal, as the object with the given sequence number.
+func (b *localBackend) appendToJournal(ref localBackendReference, seq int, entries []apitype.JournalEntryV1) error {
+	byts, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "serializing journal entries")
+	}
+	file := b.journalFile(ref, seq)
+	if err = b.bucket.WriteAll(context.TODO(), file, byts, nil); err != nil {
+		return errors.Wrap(err, "An IO error occurred during the current operation")
+	}
+	logging.V(9).Infof("Appended %d entries to the journal of stack %s: %s", len(entries), ref, file)
+	return nil
+}
+
+// readJournal returns the entries in the given stack's journal, in the order they were written.
+func (b *localBackend) readJournal(ref localBackendReference) ([]apitype.JournalEntryV1, error) {
+	files, err := listBucketIfExists(b.bucket, b.journalDirectory(ref))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+
+	var entries []apitype.JournalEntryV1
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		byts, err := b.bucket.ReadAll(context.TODO(), file.Key)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading journal")
+		}
+		var batch []apitype.JournalEntryV1
+		if err = json.Unmarshal(byts, &batch); err != nil {
+			return nil, errors.Wrapf(err, "reading journal entries from %s", file.Key)
+		}
+		entries = append(entries, batch...)
+	}
+	return entries, nil
+}
+
+// replayJournal applies the given stack's journal, if it has one, to the given checkpoint.  This recovers the changes
+// made by an update that did not finish saving its checkpoint.
+func (b *localBackend) replayJournal(ref localBackendReference, chk *apitype.CheckpointV3) error {
+	if chk.Latest == nil {
+		return nil
+	}
+	entries, err := b.readJournal(ref)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	latest, err := backend.ReplayJournal(*chk.Latest, entries)
+	if err != nil {
+		return errors.Wrapf(err, "replaying the journal of stack '%s'", ref)
+	}
+	chk.Latest = &latest
+	return nil
+}
+
+// removeJournal removes the given stack's journal.
+func (b *localBackend) removeJournal(ref localBackendReference) error {
+	files, err := listBucketIfExists(b.bucket, b.journalDirectory(ref))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if err = deleteIfExists(b.bucket, file.Key); err != nil {
+			return errors.Wrap(err, "deleting journal")
+		}
+	}
+	return nil
+}
+
+// truncateJournal removes the objects of the given stack's journal with sequence numbers less than the given one.
+// Unlike removeJournal, it does not need to list the journal, so it only removes objects whose sequence numbers are
+// known to have been written.
+func (b *localBackend) truncateJournal(ref localBackendReference, seq int) error {
+	for i := 0; i < seq; i++ {
+		if err := deleteIfExists(b.bucket, b.journalFile(ref, i)); err != nil {
+			return errors.Wrap(err, "deleting journal")
+		}
+	}
+	return nil
+}
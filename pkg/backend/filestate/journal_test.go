@@ -0,0 +1,213 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+type testRegisterResourceEvent struct {
+	deploy.SourceEvent
+}
+
+func (testRegisterResourceEvent) Goal() *resource.Goal               { return nil }
+func (testRegisterResourceEvent) Done(result *deploy.RegisterResult) {}
+
+func newTestResource(ref localBackendReference, name string) *resource.State {
+	typ := tokens.Type("test:index:Component")
+	urn := resource.NewURN(ref.name, ref.project, "", typ, tokens.QName(name))
+	return resource.NewState(typ, urn, false, false, "", resource.PropertyMap{}, resource.PropertyMap{}, "", false,
+		false, nil, nil, "", nil, false, nil, nil, nil)
+}
+
+func stackURNs(t *testing.T, b *localBackend, ref localBackendReference) []resource.URN {
+	snap, _, err := b.getStack(ref)
+	assert.NoError(t, err)
+	var urns []resource.URN
+	for _, res := range snap.Resources {
+		urns = append(urns, res.URN)
+	}
+	return urns
+}
+
+func TestJournalRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proj"}
+	ctx := context.Background()
+
+	stackRef, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref, err := b.getReference(stackRef)
+	assert.NoError(t, err)
+
+	a, bRes := newTestResource(ref, "a"), newTestResource(ref, "b")
+	_, err = b.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{a, bRes}, nil), nil)
+	assert.NoError(t, err)
+	base, _, err := b.getStack(ref)
+	assert.NoError(t, err)
+
+	persister := b.newSnapshotPersister(ref, nil)
+	assert.IsType(t, &localJournalPersister{}, persister)
+	manager := backend.NewSnapshotManager(persister, base)
+	apply := func(step deploy.Step) {
+		mutation, err := manager.BeginMutation(step)
+		assert.NoError(t, err)
+		assert.NoError(t, mutation.End(step, true))
+	}
+
+	// Each change made by the update is recovered from the journal, as if the update were interrupted after it.
+	aPrime := newTestResource(ref, "a")
+	aPrime.Inputs["changed"] = resource.NewBoolProperty(true)
+	apply(deploy.NewUpdateStep(nil, testRegisterResourceEvent{}, base.Resources[0], aPrime, nil, nil, nil, nil))
+	assert.Equal(t, []resource.URN{a.URN, bRes.URN}, stackURNs(t, b, ref))
+
+	c := newTestResource(ref, "c")
+	apply(deploy.NewCreateStep(nil, testRegisterResourceEvent{}, c))
+	assert.Equal(t, []resource.URN{a.URN, c.URN, bRes.URN}, stackURNs(t, b, ref))
+
+	apply(deploy.NewDeleteStep(nil, base.Resources[1]))
+	assert.Equal(t, []resource.URN{a.URN, c.URN}, stackURNs(t, b, ref))
+
+	// The journal holds one object for the beginning and one for the end of each change.
+	journal, err := listBucket(b.bucket, b.journalDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, journal, 6)
+
+	// The recovered stack is what is exported.
+	exported, err := b.ExportDeployment(ctx, s)
+	assert.NoError(t, err)
+	assert.Contains(t, string(exported.Deployment), `"changed":true`)
+
+	// Closing the manager compacts the journal into the checkpoint.
+	assert.NoError(t, manager.Close())
+	assert.Equal(t, []resource.URN{a.URN, c.URN}, stackURNs(t, b, ref))
+	chk, err := b.getCheckpoint(ref)
+	assert.NoError(t, err)
+	assert.Len(t, chk.Latest.Resources, 2)
+	journal, err = listBucket(b.bucket, b.journalDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, journal, 1)
+
+	// Saving the stack outside of an update leaves its journal alone, which is stale now that the checkpoint has
+	// changed, but importing a deployment discards it.
+	_, err = b.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{a}, nil), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []resource.URN{a.URN}, stackURNs(t, b, ref))
+	journal, err = listBucketIfExists(b.bucket, b.journalDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, journal, 1)
+
+	assert.NoError(t, b.ImportDeployment(ctx, s, exported))
+	assert.Equal(t, []resource.URN{a.URN, c.URN}, stackURNs(t, b, ref))
+	journal, err = listBucketIfExists(b.bucket, b.journalDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, journal, 0)
+}
+
+func TestJournalTruncation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	ref := localBackendReference{project: "proj", name: "dev", b: b}
+	a := newTestResource(ref, "a")
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{a}, nil)
+
+	// An interrupted update leaves its journal behind.
+	for seq := 0; seq < 3; seq++ {
+		assert.NoError(t, b.appendToJournal(ref, seq, nil))
+	}
+
+	// The first checkpoint that a journal persister saves discards all of it.  Later checkpoints discard only the
+	// objects that the persister appended.
+	persister := &localJournalPersister{localSnapshotPersister: localSnapshotPersister{ref: ref, backend: b}}
+	assert.NoError(t, persister.Save(snap))
+	journal, err := listBucketIfExists(b.bucket, b.journalDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, journal, 0)
+
+	assert.NoError(t, persister.Append(nil))
+	assert.NoError(t, persister.Append(nil))
+	assert.NoError(t, b.appendToJournal(ref, 5, nil))
+	assert.NoError(t, persister.Save(snap))
+	journal, err = listBucketIfExists(b.bucket, b.journalDirectory(ref))
+	assert.NoError(t, err)
+	if assert.Len(t, journal, 1) {
+		assert.Equal(t, b.journalFile(ref, 5), journal[0].Key)
+	}
+}
+
+func TestDisableJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	ref := localBackendReference{project: "proj", name: "dev", b: b}
+
+	old := os.Getenv(DisableCheckpointJournalEnvVar)
+	defer os.Setenv(DisableCheckpointJournalEnvVar, old)
+	assert.NoError(t, os.Setenv(DisableCheckpointJournalEnvVar, "true"))
+	assert.IsType(t, &localSnapshotPersister{}, b.newSnapshotPersister(ref, nil))
+}
+
+func TestExportDeploymentUnchecked(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proj"}
+	ctx := context.Background()
+
+	stackRef, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref, err := b.getReference(stackRef)
+	assert.NoError(t, err)
+
+	// Save a snapshot with two live copies of the same resource, which fails the integrity checks.
+	DisableIntegrityChecking = true
+	a := newTestResource(ref, "a")
+	_, err = b.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{a, a}, nil), nil)
+	DisableIntegrityChecking = false
+	assert.NoError(t, err)
+
+	_, err = b.ExportDeployment(ctx, s)
+	assert.Error(t, err)
+
+	exported, err := b.ExportDeploymentUnchecked(ctx, s)
+	assert.NoError(t, err)
+	assert.Contains(t, string(exported.Deployment), string(a.URN))
+}
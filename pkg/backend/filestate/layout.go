@@ -0,0 +1,370 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// metaFile is the name of the file, within the bookkeeping directory, that records the layout of a bucket's state.
+const metaFile = "meta.json"
+
+// layoutVersion is the version of the bucket layout that this backend writes.  Version 0, which has no meta file,
+// stores every stack's checkpoint directly in the stacks directory.  Version 1 stores each stack's checkpoint, history,
+// and backups in a subdirectory named after its project.
+const layoutVersion = 1
+
+// bucketMeta is the content of the meta file.
+type bucketMeta struct {
+	Version int `json:"version"`
+}
+
+// metaPath returns the path of the bucket's meta file.
+func (b *localBackend) metaPath() string {
+	return filepath.Join(b.StateDir(), metaFile)
+}
+
+// checkLayout ensures that the bucket uses a layout this backend can read, without changing it.  A bucket that has no
+// meta file but has stacks in the original flat layout is marked as legacy: its stacks can be read and listed, but
+// must be upgraded by UpgradeLayout before they can be modified.
+func (b *localBackend) checkLayout() error {
+	ctx := context.TODO()
+	exists, err := b.bucket.Exists(ctx, b.metaPath())
+	if err != nil {
+		return errors.Wrap(err, "checking the state layout version")
+	}
+	if exists {
+		byts, err := b.bucket.ReadAll(ctx, b.metaPath())
+		if err != nil {
+			return errors.Wrap(err, "reading the state layout version")
+		}
+		var meta bucketMeta
+		if err = json.Unmarshal(byts, &meta); err != nil {
+			return errors.Wrapf(err, "reading the state layout version from %s", b.metaPath())
+		}
+		if meta.Version > layoutVersion {
+			return errors.Errorf("the state in %s uses layout version %d, but this version of Pulumi only supports "+
+				"up to version %d; please upgrade Pulumi", b.originalURL, meta.Version, layoutVersion)
+		}
+		return nil
+	}
+
+	legacy, err := b.legacyStackFiles()
+	if err != nil {
+		return errors.Wrap(err, "checking the state layout version")
+	}
+	b.legacy = len(legacy) > 0
+	return nil
+}
+
+// checkWritable returns an error if the given operation, which modifies the bucket, may not be performed, either
+// because the backend is read-only or because the bucket must first be upgraded.
+func (b *localBackend) checkWritable(operation string) error {
+	if err := b.readOnly.Check(operation); err != nil {
+		return err
+	}
+	if b.legacy {
+		return errors.Errorf("cannot %s: the state in %s keeps its stacks in the original layout, which does not "+
+			"separate them by project; run `pulumi state upgrade` to move them into their projects", operation,
+			b.originalURL)
+	}
+	return nil
+}
+
+// projectDirectory returns the name of the directory in which the given stack's files are kept, beneath the stacks,
+// history, backup, and tags directories.  This is empty for buckets in the original layout, which keep every stack's
+// files directly in those directories.
+func (b *localBackend) projectDirectory(ref localBackendReference) tokens.PackageName {
+	if b.legacy {
+		return ""
+	}
+	return ref.project
+}
+
+// getLegacyStacks returns references to the stacks in the given project, or to the stacks in all projects if the
+// project is nil, for a bucket in the original layout.  A stack's project is found in the URNs of its resources;
+// stacks that have never had any resources are taken to belong to the current project, if there is one.
+func (b *localBackend) getLegacyStacks(project *string) ([]localBackendReference, error) {
+	stackFiles, err := b.legacyStackFiles()
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing stacks")
+	}
+
+	var names []string
+	for name := range stackFiles {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var stacks []localBackendReference
+	for _, n := range names {
+		name := tokens.QName(n)
+		proj, err := b.legacyStackProject(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing stacks")
+		}
+		if proj == "" {
+			if b.currentProject == nil {
+				logging.V(5).Infof("could not determine the project of stack %s; skipping", name)
+				continue
+			}
+			proj = b.currentProject.Name
+		}
+		if project != nil && string(proj) != *project {
+			continue
+		}
+
+		ref := localBackendReference{project: proj, name: name, b: b}
+		if _, _, err := b.getStack(ref); err != nil {
+			logging.V(5).Infof("error reading stack: %v (%v) skipping", ref, err)
+			continue
+		}
+		stacks = append(stacks, ref)
+	}
+	return stacks, nil
+}
+
+// UpgradeLayout moves every stack in the bucket with the given URL from the original flat layout into the directory
+// of its project, and writes the meta file, so that the bucket is upgraded only once.  A stack's project is found in
+// the URNs of its resources; stacks that have never had any resources are moved into the given default project.  If
+// there is no default project, or if a stack already exists in its project, nothing is moved.
+func UpgradeLayout(d diag.Sink, url string, defaultProject tokens.PackageName) error {
+	b, err := newLocalBackend(d, url)
+	if err != nil {
+		return err
+	}
+	if err = b.readOnly.Check("upgrade the state layout"); err != nil {
+		return err
+	}
+	exists, err := b.bucket.Exists(context.TODO(), b.metaPath())
+	if err != nil {
+		return errors.Wrap(err, "checking the state layout version")
+	}
+	if exists {
+		// The bucket has already been upgraded, but may have been upgraded by a newer version.
+		return b.checkLayout()
+	}
+
+	if err = b.migrateLegacyStacks(defaultProject); err != nil {
+		return errors.Wrap(err, "moving stacks into their project directories")
+	}
+
+	byts, err := json.Marshal(bucketMeta{Version: layoutVersion})
+	if err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(context.TODO(), b.metaPath(), byts, nil)
+}
+
+// legacyStackFiles returns the files of every stack stored in the original flat layout, grouped by stack.  These
+// include the checkpoint itself, its ".bak" backup, and any retained copies, all of which are named after the stack's
+// checkpoint file.
+func (b *localBackend) legacyStackFiles() (map[tokens.QName][]string, error) {
+	files, err := listBucketIfExists(b.bucket, b.stacksDirectory(""))
+	if err != nil {
+		return nil, err
+	}
+
+	stackFiles := make(map[tokens.QName][]string)
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		filename := objectName(file)
+		i := strings.Index(filename, ".json")
+		if i <= 0 {
+			continue
+		}
+		name := tokens.QName(filename[:i])
+		stackFiles[name] = append(stackFiles[name], filename)
+	}
+	return stackFiles, nil
+}
+
+// migrateLegacyStacks moves the checkpoints, history, and backups of every stack stored in the original flat layout
+// into the directories of the stacks' projects.  A stack's project is found in the URNs of its resources, either in
+// its current checkpoint or in the checkpoints in its history; stacks whose project cannot be determined this way are
+// moved into the given default project.  Every stack's project is determined before any stack is moved.
+func (b *localBackend) migrateLegacyStacks(defaultProject tokens.PackageName) error {
+	stackFiles, err := b.legacyStackFiles()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range stackFiles {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	projects := make(map[tokens.QName]tokens.PackageName)
+	var unplaced []string
+	for _, n := range names {
+		name := tokens.QName(n)
+		project, err := b.legacyStackProject(name)
+		if err != nil {
+			return errors.Wrapf(err, "determining the project of stack '%s'", name)
+		}
+		if project == "" {
+			unplaced = append(unplaced, n)
+			project = defaultProject
+		}
+		if project != "" {
+			ref := localBackendReference{project: project, name: name, b: b}
+			exists, err := b.bucket.Exists(context.TODO(), b.stackPath(ref))
+			if err != nil {
+				return err
+			}
+			if exists {
+				return errors.Errorf("stack '%s' cannot be moved into project '%s', which already has a stack with "+
+					"that name", name, project)
+			}
+		}
+		projects[name] = project
+	}
+	if len(unplaced) > 0 {
+		if defaultProject == "" {
+			return errors.Errorf("could not determine the project of the following stacks, which have never had any "+
+				"resources: %s; run this command from the directory of the project they belong to",
+				strings.Join(unplaced, ", "))
+		}
+		b.d.Warningf(diag.Message("" /*urn*/, "could not determine the project of the following stacks, which have "+
+			"never had any resources, so they were moved into project '%s': %s. Use `pulumi stack rename` to move "+
+			"them into another project."),
+			defaultProject, strings.Join(unplaced, ", "))
+	}
+
+	for _, n := range names {
+		name := tokens.QName(n)
+		project := projects[name]
+		ref := localBackendReference{project: project, name: name, b: b}
+		logging.V(5).Infof("moving stack '%s' into project '%s'", name, project)
+
+		// Move the history and backups first, and the checkpoint last, so that if we are interrupted, the stack is
+		// still found in its original location and will be moved when the upgrade is run again.
+		legacyHistory := filepath.Join(b.StateDir(), workspace.HistoryDir, string(name))
+		if err = moveAllByPrefix(b.bucket, legacyHistory, b.historyDirectory(ref)); err != nil {
+			return err
+		}
+		legacyBackups := filepath.Join(b.StateDir(), workspace.BackupDir, string(name))
+		if err = moveAllByPrefix(b.bucket, legacyBackups, b.backupDirectory(ref)); err != nil {
+			return err
+		}
+		sort.Strings(stackFiles[name])
+		for i := len(stackFiles[name]) - 1; i >= 0; i-- {
+			filename := stackFiles[name][i]
+			src := filepath.Join(b.stacksDirectory(""), filename)
+			dst := filepath.Join(b.stacksDirectory(project), filename)
+			if err = renameObject(b.bucket, src, dst); err != nil {
+				return err
+			}
+		}
+		b.setStackPath(ref, "")
+	}
+	return nil
+}
+
+// legacyStackProject returns the project of a stack stored in the original flat layout, or "" if it cannot be found.
+func (b *localBackend) legacyStackProject(name tokens.QName) (tokens.PackageName, error) {
+	ctx := context.TODO()
+
+	// Look at the stack's current checkpoint first, and then at the checkpoints in its history, most recent first.
+	checkpoints := []string{filepath.Join(b.stacksDirectory(""), string(name)+".json")}
+	history, err := listBucketIfExists(b.bucket, filepath.Join(b.StateDir(), workspace.HistoryDir, string(name)))
+	if err != nil {
+		return "", err
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if strings.HasSuffix(history[i].Key, ".checkpoint.json") {
+			checkpoints = append(checkpoints, history[i].Key)
+		}
+	}
+
+	for _, file := range checkpoints {
+		if exists, err := b.bucket.Exists(ctx, file); err != nil {
+			return "", err
+		} else if !exists {
+			continue
+		}
+		byts, err := b.bucket.ReadAll(ctx, file)
+		if err != nil {
+			return "", err
+		}
+		chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(byts)
+		if err != nil {
+			logging.V(5).Infof("error reading checkpoint %s: %v; skipping", file, err)
+			continue
+		}
+		if project := checkpointProject(chk); project != "" {
+			return project, nil
+		}
+	}
+	return "", nil
+}
+
+// checkpointProject returns the project named by the URNs of the resources in the given checkpoint, if any.
+func checkpointProject(chk *apitype.CheckpointV3) tokens.PackageName {
+	if chk.Latest == nil {
+		return ""
+	}
+	for _, res := range chk.Latest.Resources {
+		if res.URN.IsValid() {
+			return res.URN.Project()
+		}
+	}
+	return ""
+}
+
+// moveAllByPrefix moves all objects in the given source directory to the given destination directory.
+func moveAllByPrefix(bucket Bucket, src, dst string) error {
+	files, err := listBucketIfExists(bucket, src)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if err = renameObject(bucket, file.Key, path.Join(filepath.ToSlash(dst), objectName(file))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listBucketIfExists is like listBucket, but returns an empty list if the directory does not exist.
+func listBucketIfExists(bucket Bucket, dir string) ([]*blob.ListObject, error) {
+	files, err := listBucket(bucket, dir)
+	if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+		return nil, nil
+	}
+	return files, err
+}
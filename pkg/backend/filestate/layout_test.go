@@ -0,0 +1,230 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newTestBackend(t *testing.T, dir string) *localBackend {
+	b, err := New(diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never}), "file://"+dir)
+	assert.NoError(t, err)
+	return b.(*localBackend)
+}
+
+// writeLegacyCheckpoint writes a checkpoint in the original flat layout for a stack whose resources belong to the
+// given project, or which has no resources if the project is empty.
+func writeLegacyCheckpoint(t *testing.T, dir string, name tokens.QName, project tokens.PackageName) {
+	var resources []*resource.State
+	if project != "" {
+		typ := tokens.Type("pulumi:pulumi:Stack")
+		urn := resource.NewURN(name, project, "", typ, tokens.QName(string(project)+"-"+string(name)))
+		resources = append(resources, resource.NewState(typ, urn, false, false, "", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, "", nil, false, nil, nil, nil))
+	}
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, resources, nil)
+	chk, err := stack.SerializeCheckpoint(name, snap, nil)
+	assert.NoError(t, err)
+	byts, err := json.Marshal(chk)
+	assert.NoError(t, err)
+
+	stacks := filepath.Join(dir, workspace.BookkeepingDir, workspace.StackDir)
+	assert.NoError(t, os.MkdirAll(stacks, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(stacks, string(name)+".json"), byts, 0600))
+}
+
+func TestProjectScopedStacks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proja"}
+	ctx := context.Background()
+
+	// Stacks in different projects may share a name.
+	refA, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	refB, err := b.ParseStackReference("projb/dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", refA.String())
+	assert.Equal(t, "projb/dev", refB.String())
+	assert.Equal(t, tokens.QName("dev"), refB.Name())
+
+	_, err = b.CreateStack(ctx, refA, nil)
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, refB, nil)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, workspace.BookkeepingDir, workspace.StackDir, "proja", "dev.json"))
+	assert.FileExists(t, filepath.Join(dir, workspace.BookkeepingDir, workspace.StackDir, "projb", "dev.json"))
+
+	// Listing honors the project filter.
+	all, err := b.ListStacks(ctx, backend.ListStacksFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	projb := "projb"
+	filtered, err := b.ListStacks(ctx, backend.ListStacksFilter{Project: &projb})
+	assert.NoError(t, err)
+	if assert.Len(t, filtered, 1) {
+		assert.Equal(t, "projb/dev", filtered[0].Name().String())
+	}
+
+	exists, err := b.DoesProjectExist(ctx, "projb")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = b.DoesProjectExist(ctx, "projc")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Stacks may be renamed within their project or moved to another.
+	stk, err := b.GetStack(ctx, refB)
+	assert.NoError(t, err)
+	assert.NoError(t, b.RenameStack(ctx, stk, "prod"))
+	assert.FileExists(t, filepath.Join(dir, workspace.BookkeepingDir, workspace.StackDir, "projb", "prod.json"))
+	refProd, err := b.ParseStackReference("projb/prod")
+	assert.NoError(t, err)
+	stk, err = b.GetStack(ctx, refProd)
+	assert.NoError(t, err)
+	assert.Error(t, b.RenameStack(ctx, stk, "proja/dev"))
+	assert.NoError(t, b.RenameStack(ctx, stk, "projc/prod"))
+	exists, err = b.DoesProjectExist(ctx, "projc")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// Unqualified names require a current project, and qualified names must be well formed.
+	b.currentProject = nil
+	_, err = b.ParseStackReference("dev")
+	assert.Error(t, err)
+	_, err = b.ParseStackReference("a/b/c")
+	assert.Error(t, err)
+	_, err = b.ParseStackReference("/dev")
+	assert.Error(t, err)
+}
+
+func TestLegacyLayoutMigration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeLegacyCheckpoint(t, dir, "dev", "myproj")
+	writeLegacyCheckpoint(t, dir, "empty", "")
+	history := filepath.Join(dir, workspace.BookkeepingDir, workspace.HistoryDir, "dev")
+	assert.NoError(t, os.MkdirAll(history, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(history, "dev-1.history.json"), []byte("{}"), 0600))
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	bookkeeping := filepath.Join(dir, workspace.BookkeepingDir)
+
+	// A bucket in the original layout can be read, but is not changed until it is upgraded explicitly.
+	legacy := newTestBackend(t, dir)
+	ctx := context.Background()
+	legacy.currentProject = &workspace.Project{Name: "otherproj"}
+	stacks, err := legacy.ListStacks(ctx, backend.ListStacksFilter{})
+	assert.NoError(t, err)
+	var names []string
+	for _, summary := range stacks {
+		names = append(names, summary.Name().String())
+	}
+	assert.ElementsMatch(t, []string{"myproj/dev", "empty"}, names)
+	myproj := "myproj"
+	stacks, err = legacy.ListStacks(ctx, backend.ListStacksFilter{Project: &myproj})
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 1)
+
+	ref, err := legacy.ParseStackReference("myproj/dev")
+	assert.NoError(t, err)
+	stk, err := legacy.GetStack(ctx, ref)
+	assert.NoError(t, err)
+	if assert.NotNil(t, stk) {
+		deployment, err := legacy.ExportDeployment(ctx, stk)
+		assert.NoError(t, err)
+		assert.NotNil(t, deployment)
+		history, err := legacy.GetHistory(ctx, ref)
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+
+		_, err = legacy.RemoveStack(ctx, stk, true)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "pulumi state upgrade")
+		}
+	}
+	ref, err = legacy.ParseStackReference("dev")
+	assert.NoError(t, err)
+	_, err = legacy.GetStack(ctx, ref)
+	assert.Error(t, err)
+	ref, err = legacy.ParseStackReference("new")
+	assert.NoError(t, err)
+	_, err = legacy.CreateStack(ctx, ref, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pulumi state upgrade")
+	}
+
+	_, err = os.Stat(filepath.Join(bookkeeping, metaFile))
+	assert.True(t, os.IsNotExist(err))
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "dev.json"))
+
+	// Stacks without resources cannot be placed without a default project, so nothing is moved.
+	assert.Error(t, UpgradeLayout(sink, "file://"+dir, ""))
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "dev.json"))
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "empty.json"))
+
+	assert.NoError(t, UpgradeLayout(sink, "file://"+dir, "otherproj"))
+	assert.FileExists(t, filepath.Join(bookkeeping, metaFile))
+
+	// The stack with resources was moved into its project, along with its history.
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "myproj", "dev.json"))
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.HistoryDir, "myproj", "dev", "dev-1.history.json"))
+	_, err = os.Stat(filepath.Join(bookkeeping, workspace.StackDir, "dev.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	// The stack without resources was moved into the default project.
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "otherproj", "empty.json"))
+
+	b := newTestBackend(t, dir)
+	stacks, err = b.ListStacks(ctx, backend.ListStacksFilter{})
+	assert.NoError(t, err)
+	names = nil
+	for _, summary := range stacks {
+		names = append(names, summary.Name().String())
+	}
+	assert.ElementsMatch(t, []string{"myproj/dev", "otherproj/empty"}, names)
+
+	// Migration happens only once.
+	writeLegacyCheckpoint(t, dir, "late", "myproj")
+	assert.NoError(t, UpgradeLayout(sink, "file://"+dir, ""))
+	newTestBackend(t, dir)
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "late.json"))
+
+	// Buckets written by newer versions are rejected.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(bookkeeping, metaFile), []byte(`{"version":99}`), 0600))
+	_, err = New(sink, "file://"+dir)
+	assert.Error(t, err)
+	assert.Error(t, UpgradeLayout(sink, "file://"+dir, ""))
+}
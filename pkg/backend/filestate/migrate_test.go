@@ -0,0 +1,151 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestMigrateStack(t *testing.T) {
+	sourceDir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	targetDir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(targetDir)
+
+	project := &workspace.Project{Name: "proj"}
+	source, target := newTestBackend(t, sourceDir), newTestBackend(t, targetDir)
+	source.currentProject, target.currentProject = project, project
+	ctx := context.Background()
+
+	sourceRef, err := source.ParseStackReference("dev")
+	assert.NoError(t, err)
+	sourceStack, err := source.CreateStack(ctx, sourceRef, nil)
+	assert.NoError(t, err)
+	ref, err := source.getReference(sourceRef)
+	assert.NoError(t, err)
+
+	// Record three updates, the last of which leaves a secret in the stack's outputs.
+	recordUpdates(t, source, ref, 2)
+	snap := newTestSnapshot(ref, 3)
+	snap.SecretsManager = b64.NewBase64SecretsManager()
+	snap.Resources[2].Outputs["password"] = resource.MakeSecret(resource.NewStringProperty("hunter2"))
+	_, err = source.saveStack(ref, snap, snap.SecretsManager)
+	assert.NoError(t, err)
+	assert.NoError(t, source.addToHistory(ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate, StartTime: 1}))
+	tags := map[apitype.StackTagName]string{"team": "infra"}
+	assert.NoError(t, source.UpdateStackTags(ctx, sourceStack, tags))
+
+	targetRef, err := target.ParseStackReference("dev")
+	assert.NoError(t, err)
+	targetStack, err := target.CreateStack(ctx, targetRef, nil)
+	assert.NoError(t, err)
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	err = backend.MigrateStack(ctx, sink, sourceStack, targetStack, backend.MigrateOptions{
+		SecretsProvider: stack.DefaultSecretsProvider,
+		SecretsManager:  b64.NewBase64SecretsManager(),
+	})
+	assert.NoError(t, err)
+
+	// The deployment, including its secret, has been copied.
+	targetStack, err = target.GetStack(ctx, targetRef)
+	assert.NoError(t, err)
+	migrated, err := targetStack.Snapshot(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, migrated.Resources, 3) {
+		password := migrated.Resources[2].Outputs["password"]
+		assert.True(t, password.IsSecret())
+		assert.Equal(t, "hunter2", password.SecretValue().Element.StringValue())
+	}
+
+	// As has the history, with each update's deployment.
+	history, err := target.GetHistory(ctx, targetRef)
+	assert.NoError(t, err)
+	sourceHistory, err := source.GetHistory(ctx, sourceRef)
+	assert.NoError(t, err)
+	assert.Equal(t, sourceHistory, history)
+	for version := 1; version <= 3; version++ {
+		deployment, err := target.ExportDeploymentVersion(ctx, targetStack, version)
+		assert.NoError(t, err)
+		snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+		assert.NoError(t, err)
+		assert.Len(t, snap.Resources, version)
+	}
+
+	// And the tags.
+	migratedTags, err := target.GetStackTags(ctx, targetStack)
+	assert.NoError(t, err)
+	assert.Equal(t, tags, migratedTags)
+
+	// A stack's history may only be imported once.
+	err = target.ImportHistory(ctx, targetStack, history, make([]*apitype.DeploymentV3, len(history)))
+	assert.EqualError(t, err, "stack 'dev' already has a history")
+}
+
+func TestStackTags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proj"}
+	ctx := context.Background()
+
+	stackRef, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+
+	tags, err := b.GetStackTags(ctx, s)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	tags = map[apitype.StackTagName]string{"team": "infra"}
+	assert.NoError(t, b.UpdateStackTags(ctx, s, tags))
+
+	// Tags follow the stack when it is renamed.
+	assert.NoError(t, b.RenameStack(ctx, s, "prod"))
+	renamedRef, err := b.ParseStackReference("prod")
+	assert.NoError(t, err)
+	renamed, err := b.GetStack(ctx, renamedRef)
+	assert.NoError(t, err)
+	renamedTags, err := b.GetStackTags(ctx, renamed)
+	assert.NoError(t, err)
+	assert.Equal(t, tags, renamedTags)
+
+	// And are removed with it.
+	_, err = b.RemoveStack(ctx, renamed, false)
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, renamedRef, nil)
+	assert.NoError(t, err)
+	renamedTags, err = b.GetStackTags(ctx, renamed)
+	assert.NoError(t, err)
+	assert.Empty(t, renamedTags)
+}
@@ -0,0 +1,119 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestReadOnlyBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	b := newTestBackend(t, dir)
+	ref, err := b.ParseStackReference("proj/dev")
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, ref, nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.Setenv(backend.ReadOnlyEnvVar, "true"))
+	defer os.Unsetenv(backend.ReadOnlyEnvVar)
+	b = newTestBackend(t, dir)
+	assert.True(t, b.readOnly.Enabled)
+
+	// Stacks may still be read.
+	stk, err := b.GetStack(ctx, ref)
+	assert.NoError(t, err)
+	assert.NotNil(t, stk)
+	deployment, err := b.ExportDeployment(ctx, stk)
+	assert.NoError(t, err)
+	_, err = b.GetHistory(ctx, ref)
+	assert.NoError(t, err)
+
+	// But every operation that would modify them is refused.
+	isReadOnlyError := func(err error) {
+		_, ok := err.(backend.ReadOnlyError)
+		assert.True(t, ok, "expected a read-only error, got %v", err)
+	}
+	other, err := b.ParseStackReference("proj/prod")
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, other, nil)
+	isReadOnlyError(err)
+	_, err = b.RemoveStack(ctx, stk, true)
+	isReadOnlyError(err)
+	isReadOnlyError(b.RenameStack(ctx, stk, "prod"))
+	isReadOnlyError(b.ImportDeployment(ctx, stk, deployment))
+	isReadOnlyError(b.UpdateStackTags(ctx, stk, map[apitype.StackTagName]string{"a": "b"}))
+	_, res := b.Update(ctx, stk, backend.UpdateOperation{})
+	if assert.NotNil(t, res) {
+		isReadOnlyError(res.Error())
+	}
+	_, res = b.Destroy(ctx, stk, backend.UpdateOperation{})
+	if assert.NotNil(t, res) {
+		isReadOnlyError(res.Error())
+	}
+	_, err = b.PruneHistory(ctx, ref, RetentionPolicy{})
+	isReadOnlyError(err)
+
+	// The stack is untouched.
+	assert.FileExists(t, filepath.Join(dir, workspace.BookkeepingDir, workspace.StackDir, "proj", "dev.json"))
+}
+
+func TestReadOnlyLayout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, os.Setenv(backend.ReadOnlyEnvVar, "true"))
+	defer os.Unsetenv(backend.ReadOnlyEnvVar)
+	bookkeeping := filepath.Join(dir, workspace.BookkeepingDir)
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+
+	// An empty bucket can be read without writing the meta file.
+	_, err = New(sink, "file://"+dir)
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(bookkeeping, metaFile))
+	assert.True(t, os.IsNotExist(err))
+
+	// Stacks in the original layout can be listed and read, but must be upgraded by a backend that can write.
+	writeLegacyCheckpoint(t, dir, "dev", "myproj")
+	be, err := New(sink, "file://"+dir)
+	assert.NoError(t, err)
+	stacks, err := be.ListStacks(context.Background(), backend.ListStacksFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, stacks, 1) {
+		assert.Equal(t, "myproj/dev", stacks[0].Name().String())
+	}
+	err = UpgradeLayout(sink, "file://"+dir, "")
+	_, ok := err.(backend.ReadOnlyError)
+	assert.True(t, ok, "expected a read-only error, got %v", err)
+	assert.FileExists(t, filepath.Join(bookkeeping, workspace.StackDir, "dev.json"))
+	_, err = os.Stat(filepath.Join(bookkeeping, metaFile))
+	assert.True(t, os.IsNotExist(err))
+}
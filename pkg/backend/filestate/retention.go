@@ -0,0 +1,196 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// RetainHistoryCountEnvVar may be set to the number of updates whose history, checkpoint copies, and backups are kept
+// for each stack.  Older ones are removed after each update.
+const RetainHistoryCountEnvVar = "PULUMI_RETAIN_HISTORY_COUNT"
+
+// RetainHistoryAgeEnvVar may be set to a duration, such as "720h", after which the history, checkpoint copies, and
+// backups of each stack's updates are removed.
+const RetainHistoryAgeEnvVar = "PULUMI_RETAIN_HISTORY_AGE"
+
+// RetentionPolicy determines which of a stack's past updates have their history, checkpoint copies, and backups kept.
+// An update is removed if it is older than the newest Count updates, or if it is older than MaxAge.  A zero value for
+// either field places no limit, and the newest update is always kept.
+type RetentionPolicy struct {
+	Count  int           // the number of most recent updates to keep, or zero to keep any number.
+	MaxAge time.Duration // the age beyond which updates are removed, or zero to keep updates of any age.
+}
+
+// IsZero returns true if the policy keeps everything.
+func (p RetentionPolicy) IsZero() bool {
+	return p.Count == 0 && p.MaxAge == 0
+}
+
+// retains returns true if the policy keeps the update at the given index, counting from the newest, written at the
+// given time.
+func (p RetentionPolicy) retains(index int, modTime time.Time, now time.Time) bool {
+	if index == 0 {
+		return true
+	}
+	if p.Count > 0 && index >= p.Count {
+		return false
+	}
+	if p.MaxAge > 0 && now.Sub(modTime) > p.MaxAge {
+		return false
+	}
+	return true
+}
+
+// RetentionPolicyFromEnv returns the retention policy given by the PULUMI_RETAIN_HISTORY_COUNT and
+// PULUMI_RETAIN_HISTORY_AGE environment variables.
+func RetentionPolicyFromEnv() (RetentionPolicy, error) {
+	var policy RetentionPolicy
+	if v := os.Getenv(RetainHistoryCountEnvVar); v != "" {
+		count, err := strconv.Atoi(v)
+		if err != nil || count < 0 {
+			return RetentionPolicy{}, errors.Errorf("%s must be a non-negative integer; got '%s'",
+				RetainHistoryCountEnvVar, v)
+		}
+		policy.Count = count
+	}
+	if v := os.Getenv(RetainHistoryAgeEnvVar); v != "" {
+		age, err := time.ParseDuration(v)
+		if err != nil || age < 0 {
+			return RetentionPolicy{}, errors.Errorf("%s must be a non-negative duration, such as '720h'; got '%s'",
+				RetainHistoryAgeEnvVar, v)
+		}
+		policy.MaxAge = age
+	}
+	return policy, nil
+}
+
+// PruneHistory removes the history, checkpoint copies, and backups of the given stack's updates that the given policy
+// does not retain.  It returns the number of updates whose history was removed.
+func (b *localBackend) PruneHistory(ctx context.Context, stackRef backend.StackReference,
+	policy RetentionPolicy) (int, error) {
+
+	if err := b.checkWritable("prune a stack's history"); err != nil {
+		return 0, err
+	}
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return 0, err
+	}
+	return b.pruneHistory(ref, policy)
+}
+
+// applyRetentionPolicy prunes the given stack's history according to the policy given by the environment.  As the
+// update itself has already succeeded, problems are reported as warnings.
+func (b *localBackend) applyRetentionPolicy(ref localBackendReference) {
+	policy, err := RetentionPolicyFromEnv()
+	if err == nil {
+		_, err = b.pruneHistory(ref, policy)
+	}
+	if err != nil {
+		b.d.Warningf(diag.Message("" /*urn*/, "failed to prune the history of stack '%s': %v"), ref, err)
+	}
+}
+
+func (b *localBackend) pruneHistory(ref localBackendReference, policy RetentionPolicy) (int, error) {
+	if policy.IsZero() {
+		return 0, nil
+	}
+	now := time.Now()
+
+	entries, err := b.listHistory(ref)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for i, entry := range entries {
+		if policy.retains(i, entry.modTime, now) {
+			// Record the version of each retained entry that was numbered by its position, so that its version
+			// does not change once the entries before it are removed.
+			if !entry.versioned {
+				if err = b.saveHistoryEntry(entry); err != nil {
+					return pruned, err
+				}
+			}
+			continue
+		}
+
+		logging.V(5).Infof("removing version %d of stack %s from its history", entry.update.Version, ref)
+		if err = deleteIfExists(b.bucket, entry.checkpoint); err != nil {
+			return pruned, errors.Wrap(err, "deleting history checkpoint")
+		}
+		if err = deleteIfExists(b.bucket, entry.file); err != nil {
+			return pruned, errors.Wrap(err, "deleting history file")
+		}
+		pruned++
+	}
+
+	return pruned, b.pruneBackups(ref, policy, now)
+}
+
+// saveHistoryEntry rewrites the history file of the given entry.
+func (b *localBackend) saveHistoryEntry(entry historyEntry) error {
+	byts, err := json.MarshalIndent(&entry.update, "", "    ")
+	if err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(context.TODO(), entry.file, byts, nil)
+}
+
+// pruneBackups removes the backups of the given stack's checkpoint that the given policy does not retain.
+func (b *localBackend) pruneBackups(ref localBackendReference, policy RetentionPolicy, now time.Time) error {
+	files, err := listBucketIfExists(b.bucket, b.backupDirectory(ref))
+	if err != nil {
+		return err
+	}
+
+	// Backups are named with the time they were taken, so sorting their names in reverse puts the newest first.
+	sort.Slice(files, func(i, j int) bool { return files[i].Key > files[j].Key })
+	index := 0
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if !policy.retains(index, file.ModTime, now) {
+			if err = deleteIfExists(b.bucket, file.Key); err != nil {
+				return errors.Wrap(err, "deleting backup")
+			}
+		}
+		index++
+	}
+	return nil
+}
+
+// deleteIfExists deletes the given object, if it exists.
+func deleteIfExists(bucket Bucket, key string) error {
+	err := bucket.Delete(context.TODO(), key)
+	if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+		return nil
+	}
+	return err
+}
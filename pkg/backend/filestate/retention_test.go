@@ -0,0 +1,167 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// recordUpdates saves a checkpoint for each of the given number of updates, with one more resource each time, and
+// records the update in the stack's history and backups.
+func recordUpdates(t *testing.T, b *localBackend, ref localBackendReference, count int) {
+	history, err := b.listHistory(ref)
+	assert.NoError(t, err)
+	for i := len(history) + 1; i <= len(history)+count; i++ {
+		_, err = b.saveStack(ref, newTestSnapshot(ref, i), nil)
+		assert.NoError(t, err)
+		assert.NoError(t, b.addToHistory(ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate}))
+		assert.NoError(t, b.backupStack(ref))
+	}
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	byCount := RetentionPolicy{Count: 2}
+	assert.True(t, byCount.retains(0, old, now))
+	assert.True(t, byCount.retains(1, old, now))
+	assert.False(t, byCount.retains(2, now, now))
+
+	byAge := RetentionPolicy{MaxAge: 24 * time.Hour}
+	assert.True(t, byAge.retains(0, old, now))
+	assert.True(t, byAge.retains(5, now, now))
+	assert.False(t, byAge.retains(1, old, now))
+
+	assert.True(t, RetentionPolicy{}.IsZero())
+}
+
+func TestPruneHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proj"}
+	ctx := context.Background()
+
+	stackRef, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref, err := b.getReference(stackRef)
+	assert.NoError(t, err)
+	recordUpdates(t, b, ref, 4)
+
+	pruned, err := b.PruneHistory(ctx, stackRef, RetentionPolicy{Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+
+	// The newest updates keep their versions, and their checkpoints may still be restored.
+	history, err := b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, 4, history[0].Version)
+		assert.Equal(t, 3, history[1].Version)
+	}
+	_, err = b.ExportDeploymentVersion(ctx, s, 3)
+	assert.NoError(t, err)
+	_, err = b.ExportDeploymentVersion(ctx, s, 2)
+	assert.Error(t, err)
+
+	histFiles, err := listBucket(b.bucket, b.historyDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, histFiles, 4)
+	backups, err := listBucket(b.bucket, b.backupDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+
+	// Later updates are numbered after the newest retained one.
+	recordUpdates(t, b, ref, 1)
+	history, err = b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, history[0].Version)
+}
+
+func TestCompressedCheckpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	b.currentProject = &workspace.Project{Name: "proj"}
+	ctx := context.Background()
+
+	stackRef, err := b.ParseStackReference("dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref, err := b.getReference(stackRef)
+	assert.NoError(t, err)
+	recordUpdates(t, b, ref, 1)
+
+	assert.NoError(t, os.Setenv(CompressCheckpointsEnvVar, "true"))
+	defer os.Unsetenv(CompressCheckpointsEnvVar)
+	recordUpdates(t, b, ref, 1)
+
+	// The checkpoint is replaced by a compressed one, which is read transparently.
+	stacksDir := filepath.Join(dir, b.stacksDirectory("proj"))
+	_, err = os.Stat(filepath.Join(stacksDir, "dev.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(stacksDir, "dev.json.gz"))
+	assert.NoError(t, err)
+
+	snap, _, err := b.getStack(ref)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 2)
+
+	// The checkpoint's path is remembered once it has been written, and found by a backend that hasn't written it.
+	assert.Equal(t, filepath.Join(b.stacksDirectory("proj"), "dev.json.gz"), b.stackPath(ref))
+	other := newTestBackend(t, dir)
+	otherRef := localBackendReference{project: "proj", name: "dev", b: other}
+	assert.Equal(t, b.stackPath(ref), other.stackPath(otherRef))
+
+	stacks, err := b.ListStacks(ctx, backend.ListStacksFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 1)
+
+	// Both compressed and uncompressed copies in the history may be restored.
+	for version := 1; version <= 2; version++ {
+		_, err = b.ExportDeploymentVersion(ctx, s, version)
+		assert.NoError(t, err)
+	}
+
+	// Turning compression off again writes an uncompressed checkpoint.
+	assert.NoError(t, os.Unsetenv(CompressCheckpointsEnvVar))
+	recordUpdates(t, b, ref, 1)
+	_, err = os.Stat(filepath.Join(stacksDir, "dev.json"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(stacksDir, "dev.json.gz"))
+	assert.True(t, os.IsNotExist(err))
+	snap, _, err = b.getStack(ref)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 3)
+}
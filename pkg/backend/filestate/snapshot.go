@@ -15,15 +15,21 @@
 package filestate
 
 import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/secrets"
-	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
 // localSnapshotManager is a simple SnapshotManager implementation that persists snapshots
 // to disk on the local machine.
 type localSnapshotPersister struct {
-	name    tokens.QName
+	ref     localBackendReference
 	backend *localBackend
 	sm      secrets.Manager
 }
@@ -33,11 +39,54 @@ func (sp *localSnapshotPersister) SecretsManager() secrets.Manager {
 }
 
 func (sp *localSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
-	_, err := sp.backend.saveStack(sp.name, snapshot, sp.sm)
+	_, err := sp.backend.saveStack(sp.ref, snapshot, sp.sm)
 	return err
 
 }
 
-func (b *localBackend) newSnapshotPersister(stackName tokens.QName, sm secrets.Manager) *localSnapshotPersister {
-	return &localSnapshotPersister{name: stackName, backend: b, sm: sm}
+// localJournalPersister is a snapshot persister that records the changes made to a snapshot between saves in the
+// stack's journal, rather than rewriting the stack's checkpoint after every change.
+type localJournalPersister struct {
+	localSnapshotPersister
+	seq     int  // the sequence number of the next journal object, which restarts whenever the snapshot is saved.
+	started bool // true once the snapshot has been saved, discarding any journal left by an earlier update.
+}
+
+var _ backend.JournalPersister = (*localJournalPersister)(nil)
+
+func (sp *localJournalPersister) Save(snapshot *deploy.Snapshot) error {
+	if err := sp.localSnapshotPersister.Save(snapshot); err != nil {
+		return err
+	}
+
+	// The new checkpoint includes every change recorded in the journal, so the journal may be discarded.  The first
+	// time, the journal may hold objects written by an earlier update that was interrupted, so all of it is removed;
+	// after that, it holds only the objects that this persister has appended.
+	var err error
+	if !sp.started {
+		err = sp.backend.removeJournal(sp.ref)
+	} else {
+		err = sp.backend.truncateJournal(sp.ref, sp.seq)
+	}
+	if err != nil {
+		return errors.Wrap(err, "An IO error occurred during the current operation")
+	}
+	sp.seq, sp.started = 0, true
+	return nil
+}
+
+func (sp *localJournalPersister) Append(entries []apitype.JournalEntryV1) error {
+	if err := sp.backend.appendToJournal(sp.ref, sp.seq, entries); err != nil {
+		return err
+	}
+	sp.seq++
+	return nil
+}
+
+func (b *localBackend) newSnapshotPersister(ref localBackendReference, sm secrets.Manager) backend.SnapshotPersister {
+	persister := localSnapshotPersister{ref: ref, backend: b, sm: sm}
+	if cmdutil.IsTruthy(os.Getenv(DisableCheckpointJournalEnvVar)) {
+		return &persister
+	}
+	return &localJournalPersister{localSnapshotPersister: persister}
 }
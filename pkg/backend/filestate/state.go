@@ -44,6 +44,10 @@ import (
 
 const DisableCheckpointBackupsEnvVar = "PULUMI_DISABLE_CHECKPOINT_BACKUPS"
 
+// CompressCheckpointsEnvVar may be set to true to compress the checkpoints that are saved, along with the copies of
+// them kept in history and backups, using gzip.  Checkpoints are read whether or not they are compressed.
+const CompressCheckpointsEnvVar = "PULUMI_COMPRESS_CHECKPOINTS"
+
 // DisableIntegrityChecking can be set to true to disable checkpoint state integrity verification.  This is not
 // recommended, because it could mean proceeding even in the face of a corrupted checkpoint state file, but can
 // be used as a last resort when a command absolutely must be run.
@@ -69,11 +73,11 @@ func (u *update) GetTarget() *deploy.Target {
 	return u.target
 }
 
-func (b *localBackend) newUpdate(stackName tokens.QName, op backend.UpdateOperation) (*update, error) {
-	contract.Require(stackName != "", "stackName")
+func (b *localBackend) newUpdate(ref localBackendReference, op backend.UpdateOperation) (*update, error) {
+	contract.Require(ref.name != "", "ref")
 
 	// Construct the deployment target.
-	target, err := b.getTarget(stackName, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
+	target, err := b.getTarget(ref, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
 	if err != nil {
 		return nil, err
 	}
@@ -87,31 +91,41 @@ func (b *localBackend) newUpdate(stackName tokens.QName, op backend.UpdateOperat
 	}, nil
 }
 
-func (b *localBackend) getTarget(stackName tokens.QName, cfg config.Map, dec config.Decrypter) (*deploy.Target, error) {
-	snapshot, _, err := b.getStack(stackName)
+func (b *localBackend) getTarget(ref localBackendReference, cfg config.Map,
+	dec config.Decrypter) (*deploy.Target, error) {
+
+	snapshot, _, err := b.getStack(ref)
 	if err != nil {
 		return nil, err
 	}
 	return &deploy.Target{
-		Name:      stackName,
+		Name:      ref.name,
 		Config:    cfg,
 		Decrypter: dec,
 		Snapshot:  snapshot,
 	}, nil
 }
 
-func (b *localBackend) getStack(name tokens.QName) (*deploy.Snapshot, string, error) {
-	if name == "" {
+func (b *localBackend) getStack(ref localBackendReference) (*deploy.Snapshot, string, error) {
+	if ref.name == "" {
 		return nil, "", errors.New("invalid empty stack name")
 	}
 
-	file := b.stackPath(name)
+	file := b.stackPath(ref)
 
-	chk, err := b.getCheckpoint(name)
+	chk, err := b.getCheckpoint(ref)
 	if err != nil {
 		return nil, file, errors.Wrap(err, "failed to load checkpoint")
 	}
 
+	// Stacks in the original layout are not separated by project, so make sure that this one belongs to the project
+	// it was asked for.
+	if b.legacy {
+		if project := checkpointProject(chk); project != "" && project != ref.project {
+			return nil, file, errors.Errorf("stack '%s' belongs to project '%s'", ref.name, project)
+		}
+	}
+
 	// Materialize an actual snapshot object.
 	snapshot, err := stack.DeserializeCheckpoint(chk)
 	if err != nil {
@@ -130,19 +144,36 @@ func (b *localBackend) getStack(name tokens.QName) (*deploy.Snapshot, string, er
 }
 
 // GetCheckpoint loads a checkpoint file for the given stack in this project, from the current project workspace.
-func (b *localBackend) getCheckpoint(stackName tokens.QName) (*apitype.CheckpointV3, error) {
-	chkpath := b.stackPath(stackName)
+func (b *localBackend) getCheckpoint(ref localBackendReference) (*apitype.CheckpointV3, error) {
+	chkpath := b.stackPath(ref)
 	bytes, err := b.bucket.ReadAll(context.TODO(), chkpath)
 	if err != nil {
 		return nil, err
 	}
+	if bytes, err = encoding.Gunzip(bytes); err != nil {
+		return nil, errors.Wrapf(err, "decompressing %s", chkpath)
+	}
+
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
+	if err != nil {
+		return nil, err
+	}
 
-	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
+	// Apply any changes recorded in the stack's journal since the checkpoint was saved.
+	if err = b.replayJournal(ref, chk); err != nil {
+		return nil, err
+	}
+	return chk, nil
 }
 
-func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm secrets.Manager) (string, error) {
-	// Make a serializable stack and then use the encoder to encode it.
-	file := b.stackPath(name)
+func (b *localBackend) saveStack(ref localBackendReference, snap *deploy.Snapshot, sm secrets.Manager) (string, error) {
+	// Make a serializable stack and then use the encoder to encode it.  If the checkpoint is to be stored in a different
+	// format than the existing one, the existing one is moved aside with the usual backup below.
+	existing := b.stackPath(ref)
+	file := strings.TrimSuffix(existing, encoding.GzipExt)
+	if cmdutil.IsTruthy(os.Getenv(CompressCheckpointsEnvVar)) {
+		file += encoding.GzipExt
+	}
 	m, ext := encoding.Detect(file)
 	if m == nil {
 		return "", errors.Errorf("resource serialization failed; illegal markup extension: '%v'", ext)
@@ -150,7 +181,7 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 	if filepath.Ext(file) == "" {
 		file = file + ext
 	}
-	chk, err := stack.SerializeCheckpoint(name, snap, sm)
+	chk, err := stack.SerializeCheckpoint(ref.name, snap, sm)
 	if err != nil {
 		return "", errors.Wrap(err, "serializaing checkpoint")
 	}
@@ -160,14 +191,16 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 	}
 
 	// Back up the existing file if it already exists.
-	bck := backupTarget(b.bucket, file)
+	bck := backupTarget(b.bucket, existing)
 
 	// And now write out the new snapshot file, overwriting that location.
 	if err = b.bucket.WriteAll(context.TODO(), file, byts, nil); err != nil {
+		b.setStackPath(ref, "")
 		return "", errors.Wrap(err, "An IO error occurred during the current operation")
 	}
+	b.setStackPath(ref, file)
 
-	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", name, file, bck)
+	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", ref, file, bck)
 
 	// And if we are retaining historical checkpoint information, write it out again
 	if cmdutil.IsTruthy(os.Getenv("PULUMI_RETAIN_CHECKPOINTS")) {
@@ -191,14 +224,22 @@ func (b *localBackend) saveStack(name tokens.QName, snap *deploy.Snapshot, sm se
 }
 
 // removeStack removes information about a stack from the current workspace.
-func (b *localBackend) removeStack(name tokens.QName) error {
-	contract.Require(name != "", "name")
+func (b *localBackend) removeStack(ref localBackendReference) error {
+	contract.Require(ref.name != "", "ref")
 
 	// Just make a backup of the file and don't write out anything new.
-	file := b.stackPath(name)
+	file := b.stackPath(ref)
 	backupTarget(b.bucket, file)
+	b.setStackPath(ref, "")
 
-	historyDir := b.historyDirectory(name)
+	if err := b.removeJournal(ref); err != nil {
+		return err
+	}
+	if err := deleteIfExists(b.bucket, b.tagsPath(ref)); err != nil {
+		return err
+	}
+
+	historyDir := b.historyDirectory(ref)
 	return removeAllByPrefix(b.bucket, historyDir)
 }
 
@@ -214,8 +255,8 @@ func backupTarget(bucket Bucket, file string) string {
 }
 
 // backupStack copies the current Checkpoint file to ~/.pulumi/backups.
-func (b *localBackend) backupStack(name tokens.QName) error {
-	contract.Require(name != "", "name")
+func (b *localBackend) backupStack(ref localBackendReference) error {
+	contract.Require(ref.name != "", "ref")
 
 	// Exit early if backups are disabled.
 	if cmdutil.IsTruthy(os.Getenv(DisableCheckpointBackupsEnvVar)) {
@@ -223,48 +264,143 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 	}
 
 	// Read the current checkpoint file. (Assuming it aleady exists.)
-	stackPath := b.stackPath(name)
+	stackPath := b.stackPath(ref)
 	byts, err := b.bucket.ReadAll(context.TODO(), stackPath)
 	if err != nil {
 		return err
 	}
 
 	// Get the backup directory.
-	backupDir := b.backupDirectory(name)
+	backupDir := b.backupDirectory(ref)
 
 	// Write out the new backup checkpoint file.
 	stackFile := filepath.Base(stackPath)
-	ext := filepath.Ext(stackFile)
+	_, ext := encoding.Detect(stackFile)
 	base := strings.TrimSuffix(stackFile, ext)
 	backupFile := fmt.Sprintf("%s.%v%s", base, time.Now().UnixNano(), ext)
 	return b.bucket.WriteAll(context.TODO(), filepath.Join(backupDir, backupFile), byts, nil)
 }
 
-func (b *localBackend) stackPath(stack tokens.QName) string {
-	path := filepath.Join(b.StateDir(), workspace.StackDir)
-	if stack != "" {
-		path = filepath.Join(path, fsutil.QnamePath(stack)+".json")
+// stackPath returns the path of the given stack's checkpoint file, which is stored alongside the checkpoints of the
+// other stacks in its project.  This is the path of the compressed checkpoint if one exists.  The path is cached, so
+// that whether the checkpoint is compressed is only looked up once per stack.
+func (b *localBackend) stackPath(ref localBackendReference) string {
+	contract.Require(ref.name != "", "ref")
+
+	b.stackPathsLock.Lock()
+	defer b.stackPathsLock.Unlock()
+	if file, has := b.stackPaths[ref]; has {
+		return file
 	}
 
-	return path
+	file := filepath.Join(b.stacksDirectory(b.projectDirectory(ref)), fsutil.QnamePath(ref.name)+".json")
+	if compressed, err := b.bucket.Exists(context.TODO(), file+encoding.GzipExt); err == nil && compressed {
+		file += encoding.GzipExt
+	}
+	if b.stackPaths == nil {
+		b.stackPaths = make(map[localBackendReference]string)
+	}
+	b.stackPaths[ref] = file
+	return file
 }
 
-func (b *localBackend) historyDirectory(stack tokens.QName) string {
-	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.HistoryDir, fsutil.QnamePath(stack))
+// setStackPath records that the given stack's checkpoint file has been written to the given path, or, if the path is
+// empty, that it has been moved or removed, so that its path must be looked up again.
+func (b *localBackend) setStackPath(ref localBackendReference, file string) {
+	b.stackPathsLock.Lock()
+	defer b.stackPathsLock.Unlock()
+	if file == "" {
+		delete(b.stackPaths, ref)
+		return
+	}
+	if b.stackPaths == nil {
+		b.stackPaths = make(map[localBackendReference]string)
+	}
+	b.stackPaths[ref] = file
 }
 
-func (b *localBackend) backupDirectory(stack tokens.QName) string {
-	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.BackupDir, fsutil.QnamePath(stack))
+// tagsPath returns the path of the file holding the given stack's tags.
+func (b *localBackend) tagsPath(ref localBackendReference) string {
+	contract.Require(ref.name != "", "ref")
+	return filepath.Join(b.StateDir(), workspace.TagDir, string(b.projectDirectory(ref)),
+		fsutil.QnamePath(ref.name)+".json")
+}
+
+// getTags returns the given stack's tags, if it has any.
+func (b *localBackend) getTags(ref localBackendReference) (map[apitype.StackTagName]string, error) {
+	file := b.tagsPath(ref)
+	byts, err := b.bucket.ReadAll(context.TODO(), file)
+	if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading stack tags")
+	}
+
+	var tags map[apitype.StackTagName]string
+	if err = json.Unmarshal(byts, &tags); err != nil {
+		return nil, errors.Wrapf(err, "reading stack tags from %s", file)
+	}
+	return tags, nil
+}
+
+// saveTags replaces the given stack's tags.
+func (b *localBackend) saveTags(ref localBackendReference, tags map[apitype.StackTagName]string) error {
+	if len(tags) == 0 {
+		return deleteIfExists(b.bucket, b.tagsPath(ref))
+	}
+	byts, err := json.MarshalIndent(tags, "", "    ")
+	if err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(context.TODO(), b.tagsPath(ref), byts, nil)
+}
+
+// stacksDirectory returns the directory holding the checkpoint files of the given project's stacks.  If the project is
+// empty, this is the directory that holds the directories for all projects.
+func (b *localBackend) stacksDirectory(project tokens.PackageName) string {
+	return filepath.Join(b.StateDir(), workspace.StackDir, string(project))
+}
+
+func (b *localBackend) historyDirectory(ref localBackendReference) string {
+	contract.Require(ref.name != "", "ref")
+	return filepath.Join(b.StateDir(), workspace.HistoryDir, string(b.projectDirectory(ref)), fsutil.QnamePath(ref.name))
+}
+
+func (b *localBackend) backupDirectory(ref localBackendReference) string {
+	contract.Require(ref.name != "", "ref")
+	return filepath.Join(b.StateDir(), workspace.BackupDir, string(b.projectDirectory(ref)), fsutil.QnamePath(ref.name))
 }
 
 // getHistory returns locally stored update history. The first element of the result will be
 // the most recent update record.
-func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, error) {
-	contract.Require(name != "", "name")
+func (b *localBackend) getHistory(ref localBackendReference) ([]backend.UpdateInfo, error) {
+	entries, err := b.listHistory(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []backend.UpdateInfo
+	for _, entry := range entries {
+		updates = append(updates, entry.update)
+	}
+	return updates, nil
+}
+
+// historyEntry is an update recorded in a stack's history, along with the copy of the checkpoint it produced.
+type historyEntry struct {
+	update     backend.UpdateInfo
+	versioned  bool      // true if the update's version was recorded, rather than inferred from its position.
+	file       string    // the path of the history file.
+	checkpoint string    // the path of the checkpoint file saved after the update.
+	modTime    time.Time // the time the history file was written.
+}
 
-	dir := b.historyDirectory(name)
+// listHistory returns the entries in the given stack's history, newest first.  Entries recorded before updates were
+// given versions are numbered by their position in the history.
+func (b *localBackend) listHistory(ref localBackendReference) ([]historyEntry, error) {
+	contract.Require(ref.name != "", "ref")
+
+	dir := b.historyDirectory(ref)
 	allFiles, err := listBucket(b.bucket, dir)
 	if err != nil {
 		// History doesn't exist until a stack has been updated.
@@ -274,7 +410,12 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		return nil, err
 	}
 
-	var updates []backend.UpdateInfo
+	keys := make(map[string]bool)
+	for _, file := range allFiles {
+		keys[file.Key] = true
+	}
+
+	var entries []historyEntry
 
 	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
 	// newer ones. Loop backwards so we added the newest updates to the array we will return first.
@@ -287,28 +428,109 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 			continue
 		}
 
-		var update backend.UpdateInfo
-		b, err := b.bucket.ReadAll(context.TODO(), filepath)
+		update, err := b.readHistoryFile(filepath)
 		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+			return nil, err
 		}
-		err = json.Unmarshal(b, &update)
-		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+
+		// The checkpoint is compressed if the stack's checkpoint was compressed when it was copied.
+		checkpoint := strings.TrimSuffix(filepath, ".history.json") + ".checkpoint.json"
+		if keys[checkpoint+encoding.GzipExt] {
+			checkpoint += encoding.GzipExt
 		}
 
-		updates = append(updates, update)
+		entries = append(entries, historyEntry{
+			update:     update,
+			versioned:  update.Version != 0,
+			file:       filepath,
+			checkpoint: checkpoint,
+			modTime:    file.ModTime,
+		})
 	}
 
-	return updates, nil
+	// Number any unversioned entries, which precede all versioned ones, by their position.
+	for i := range entries {
+		if !entries[i].versioned {
+			entries[i].update.Version = len(entries) - i
+		}
+	}
+
+	return entries, nil
 }
 
-func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName) error {
-	contract.Require(oldName != "", "oldName")
-	contract.Require(newName != "", "newName")
+// readHistoryFile reads the update recorded in the given history file.
+func (b *localBackend) readHistoryFile(file string) (backend.UpdateInfo, error) {
+	var update backend.UpdateInfo
+	byts, err := b.bucket.ReadAll(context.TODO(), file)
+	if err != nil {
+		return update, errors.Wrapf(err, "reading history file %s", file)
+	}
+	if err = json.Unmarshal(byts, &update); err != nil {
+		return update, errors.Wrapf(err, "reading history file %s", file)
+	}
+	return update, nil
+}
+
+// latestHistoryVersion returns the version of the newest update in the given stack's history, or 0 if it has none.
+// Unlike listHistory, it only reads the newest history file.
+func (b *localBackend) latestHistoryVersion(ref localBackendReference) (int, error) {
+	files, err := listBucketIfExists(b.bucket, b.historyDirectory(ref))
+	if err != nil {
+		return 0, err
+	}
+
+	// listBucket returns the files sorted by name, so the newest history file is the last one.
+	var historyFiles []string
+	for _, file := range files {
+		if strings.HasSuffix(file.Key, ".history.json") {
+			historyFiles = append(historyFiles, file.Key)
+		}
+	}
+	if len(historyFiles) == 0 {
+		return 0, nil
+	}
+	update, err := b.readHistoryFile(historyFiles[len(historyFiles)-1])
+	if err != nil {
+		return 0, err
+	}
 
-	oldHistory := b.historyDirectory(oldName)
-	newHistory := b.historyDirectory(newName)
+	// Unversioned entries precede all versioned ones, so if the newest entry is unversioned, they all are, and it is
+	// numbered by its position.
+	if update.Version == 0 {
+		return len(historyFiles), nil
+	}
+	return update.Version, nil
+}
+
+// getHistoricalCheckpoint returns the checkpoint that the given stack had after the update with the given version.
+func (b *localBackend) getHistoricalCheckpoint(ref localBackendReference, version int) (*apitype.CheckpointV3, error) {
+	entries, err := b.listHistory(ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.update.Version != version {
+			continue
+		}
+
+		byts, err := b.bucket.ReadAll(context.TODO(), entry.checkpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading checkpoint for version %d", version)
+		}
+		if byts, err = encoding.Gunzip(byts); err != nil {
+			return nil, errors.Wrapf(err, "decompressing checkpoint for version %d", version)
+		}
+		return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(byts)
+	}
+	return nil, errors.Errorf("stack '%s' has no update with version %d", ref, version)
+}
+
+func (b *localBackend) renameHistory(oldRef localBackendReference, newRef localBackendReference) error {
+	contract.Require(oldRef.name != "", "oldRef")
+	contract.Require(newRef.name != "", "newRef")
+
+	oldHistory := b.historyDirectory(oldRef)
+	newHistory := b.historyDirectory(newRef)
 
 	allFiles, err := listBucket(b.bucket, oldHistory)
 	if err != nil {
@@ -325,7 +547,7 @@ func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName)
 
 		// The filename format is <stack-name>-<timestamp>.[checkpoint|history].json, we need to change
 		// the stack name part but retain the other parts.
-		newFileName := string(newName) + fileName[strings.LastIndex(fileName, "-"):]
+		newFileName := string(newRef.name) + fileName[strings.LastIndex(fileName, "-"):]
 		newBlob := path.Join(newHistory, newFileName)
 
 		if err := b.bucket.Copy(context.TODO(), newBlob, oldBlob, nil); err != nil {
@@ -339,14 +561,84 @@ func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName)
 	return nil
 }
 
+// importHistory records the given updates, oldest first, in the history of the given stack, which must have none.
+// Each update is recorded along with the deployment it produced, if that is non-nil.
+func (b *localBackend) importHistory(ref localBackendReference, updates []backend.UpdateInfo,
+	deployments []*apitype.DeploymentV3) error {
+
+	contract.Require(ref.name != "", "ref")
+	contract.Require(len(updates) == len(deployments), "deployments")
+
+	history, err := b.listHistory(ref)
+	if err != nil {
+		return err
+	}
+	if len(history) > 0 {
+		return errors.Errorf("stack '%s' already has a history", ref)
+	}
+
+	m := encoding.JSON
+	if cmdutil.IsTruthy(os.Getenv(CompressCheckpointsEnvVar)) {
+		m = encoding.Gzip(m)
+	}
+
+	dir := b.historyDirectory(ref)
+	for i, update := range updates {
+		if update.Version == 0 {
+			update.Version = i + 1
+		}
+
+		// Name the files after the time the update started, so that they sort in the order the updates were made.
+		started := time.Unix(update.StartTime, int64(i))
+		pathPrefix := path.Join(dir, fmt.Sprintf("%s-%019d", ref.name, started.UnixNano()))
+
+		byts, err := json.MarshalIndent(&update, "", "    ")
+		if err != nil {
+			return err
+		}
+		if err = b.bucket.WriteAll(context.TODO(), pathPrefix+".history.json", byts, nil); err != nil {
+			return errors.Wrap(err, "writing history file")
+		}
+
+		if deployments[i] == nil {
+			continue
+		}
+		chk, err := json.Marshal(apitype.CheckpointV3{Stack: ref.name, Latest: deployments[i]})
+		if err != nil {
+			return errors.Wrap(err, "marshalling checkpoint")
+		}
+		if byts, err = m.Marshal(apitype.VersionedCheckpoint{
+			Version:    apitype.DeploymentSchemaVersionCurrent,
+			Checkpoint: json.RawMessage(chk),
+		}); err != nil {
+			return errors.Wrap(err, "marshalling checkpoint")
+		}
+		checkpointFile := pathPrefix + ".checkpoint.json"
+		if m != encoding.JSON {
+			checkpointFile += encoding.GzipExt
+		}
+		if err = b.bucket.WriteAll(context.TODO(), checkpointFile, byts, nil); err != nil {
+			return errors.Wrap(err, "writing history checkpoint")
+		}
+	}
+	return nil
+}
+
 // addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
-func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo) error {
-	contract.Require(name != "", "name")
+func (b *localBackend) addToHistory(ref localBackendReference, update backend.UpdateInfo) error {
+	contract.Require(ref.name != "", "ref")
 
-	dir := b.historyDirectory(name)
+	// Number this update after the newest one in the stack's history.
+	latest, err := b.latestHistoryVersion(ref)
+	if err != nil {
+		return err
+	}
+	update.Version = latest + 1
+
+	dir := b.historyDirectory(ref)
 
 	// Prefix for the update and checkpoint files.
-	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
+	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", ref.name, time.Now().UnixNano()))
 
 	// Save the history file.
 	byts, err := json.MarshalIndent(&update, "", "    ")
@@ -360,6 +652,10 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	}
 
 	// Make a copy of the checkpoint file. (Assuming it already exists.)
+	stackPath := b.stackPath(ref)
 	checkpointFile := fmt.Sprintf("%s.checkpoint.json", pathPrefix)
-	return b.bucket.Copy(context.TODO(), checkpointFile, b.stackPath(name), nil)
+	if encoding.IsCompressed(stackPath) {
+		checkpointFile += encoding.GzipExt
+	}
+	return b.bucket.Copy(context.TODO(), checkpointFile, stackPath, nil)
 }
@@ -117,6 +117,7 @@ type cloudBackend struct {
 	url            string
 	client         *client.Client
 	currentProject *workspace.Project
+	readOnly       backend.ReadOnlyMode
 }
 
 // New creates a new Pulumi backend for the given cloud API URL and token.
@@ -134,11 +135,17 @@ func New(d diag.Sink, cloudURL string) (Backend, error) {
 		currentProject = nil
 	}
 
+	readOnly, err := backend.GetReadOnlyMode(cloudURL)
+	if err != nil {
+		return nil, err
+	}
+
 	return &cloudBackend{
 		d:              d,
 		url:            cloudURL,
 		client:         client.NewClient(cloudURL, apiToken, d),
 		currentProject: currentProject,
+		readOnly:       readOnly,
 	}, nil
 }
 
@@ -570,6 +577,9 @@ func (b *cloudBackend) GetStack(ctx context.Context, stackRef backend.StackRefer
 func (b *cloudBackend) CreateStack(
 	ctx context.Context, stackRef backend.StackReference, _ interface{} /* No custom options for httpstate backend. */) (
 	backend.Stack, error) {
+	if err := b.readOnly.Check("create a stack"); err != nil {
+		return nil, err
+	}
 	stackID, err := b.getCloudStackIdentifier(stackRef)
 	if err != nil {
 		return nil, err
@@ -639,6 +649,9 @@ func (b *cloudBackend) ListStacks(
 }
 
 func (b *cloudBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
+	if err := b.readOnly.Check("remove a stack"); err != nil {
+		return false, err
+	}
 	stackID, err := b.getCloudStackIdentifier(stack.Ref())
 	if err != nil {
 		return false, err
@@ -648,6 +661,9 @@ func (b *cloudBackend) RemoveStack(ctx context.Context, stack backend.Stack, for
 }
 
 func (b *cloudBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
+	if err := b.readOnly.Check("rename a stack"); err != nil {
+		return err
+	}
 	stackID, err := b.getCloudStackIdentifier(stack.Ref())
 	if err != nil {
 		return err
@@ -689,16 +705,25 @@ func (b *cloudBackend) Preview(ctx context.Context, stack backend.Stack,
 
 func (b *cloudBackend) Update(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.readOnly.Check("update a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.UpdateUpdate, stack, op, b.apply)
 }
 
 func (b *cloudBackend) Refresh(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.readOnly.Check("refresh a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply)
 }
 
 func (b *cloudBackend) Destroy(ctx context.Context, stack backend.Stack,
 	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.readOnly.Check("destroy a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
 
@@ -893,6 +918,9 @@ func (b *cloudBackend) runEngineAction(
 			if callerEventsOpt != nil {
 				callerEventsOpt <- e
 			}
+			if op.Opts.Events != nil {
+				op.Opts.Events <- e
+			}
 		}
 
 		close(eventsDone)
@@ -961,6 +989,9 @@ func (b *cloudBackend) runEngineAction(
 }
 
 func (b *cloudBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	if err := b.readOnly.Check("cancel an update"); err != nil {
+		return err
+	}
 	stackID, err := b.getCloudStackIdentifier(stackRef)
 	if err != nil {
 		return err
@@ -1013,6 +1044,7 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			Result:          backend.UpdateResult(update.Result),
 			StartTime:       update.StartTime,
 			EndTime:         update.EndTime,
+			Version:         update.Version,
 			ResourceChanges: convertResourceChanges(update.ResourceChanges),
 		})
 	}
@@ -1095,9 +1127,28 @@ func (b *cloudBackend) exportDeployment(ctx context.Context,
 	return &deployment, nil
 }
 
+func (b *cloudBackend) ExportDeploymentVersion(ctx context.Context, stack backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	stackID, err := b.getCloudStackIdentifier(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := b.client.ExportStackDeploymentVersion(ctx, stackID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
 func (b *cloudBackend) ImportDeployment(ctx context.Context, stack backend.Stack,
 	deployment *apitype.UntypedDeployment) error {
 
+	if err := b.readOnly.Check("import a deployment"); err != nil {
+		return err
+	}
 	stackID, err := b.getCloudStackIdentifier(stack.Ref())
 	if err != nil {
 		return err
@@ -1320,6 +1371,9 @@ func (b *cloudBackend) GetStackTags(ctx context.Context,
 func (b *cloudBackend) UpdateStackTags(ctx context.Context,
 	stack backend.Stack, tags map[apitype.StackTagName]string) error {
 
+	if err := b.readOnly.Check("update a stack's tags"); err != nil {
+		return err
+	}
 	stackID, err := b.getCloudStackIdentifier(stack.Ref())
 	if err != nil {
 		return err
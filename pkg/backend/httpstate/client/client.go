@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
@@ -354,6 +355,20 @@ func (pc *Client) ExportStackDeployment(ctx context.Context,
 	return apitype.UntypedDeployment(resp), nil
 }
 
+// ExportStackDeploymentVersion exports the deployment that the indicated stack had after the update with the given
+// version as a raw JSON message.
+func (pc *Client) ExportStackDeploymentVersion(ctx context.Context, stack StackIdentifier,
+	version int) (apitype.UntypedDeployment, error) {
+
+	var resp apitype.ExportStackResponse
+	exportPath := getStackPath(stack, "export", strconv.Itoa(version))
+	if err := pc.restCall(ctx, "GET", exportPath, nil, nil, &resp); err != nil {
+		return apitype.UntypedDeployment{}, err
+	}
+
+	return apitype.UntypedDeployment(resp), nil
+}
+
 // ImportStackDeployment imports a new deployment into the indicated stack.
 func (pc *Client) ImportStackDeployment(ctx context.Context, stack StackIdentifier,
 	deployment *apitype.UntypedDeployment) (UpdateIdentifier, error) {
@@ -395,6 +410,11 @@ func (pc *Client) CreateUpdate(
 		description = *proj.Description
 	}
 
+	localPolicyPackConfigs, err := engine.ConvertLocalPolicyPacksToConfigs(opts.LocalPolicyPacks)
+	if err != nil {
+		return UpdateIdentifier{}, nil, err
+	}
+
 	updateRequest := apitype.UpdateProgramRequest{
 		Name:        string(proj.Name),
 		Runtime:     proj.Runtime.Name(),
@@ -402,13 +422,14 @@ func (pc *Client) CreateUpdate(
 		Description: description,
 		Config:      wireConfig,
 		Options: apitype.UpdateOptions{
-			LocalPolicyPackPaths: opts.LocalPolicyPackPaths,
-			Color:                colors.Raw, // force raw colorization, we handle colorization in the CLI
-			DryRun:               dryRun,
-			Parallel:             opts.Parallel,
-			ShowConfig:           false, // This is a legacy option now, the engine will always emit config information
-			ShowReplacementSteps: false, // This is a legacy option now, the engine will always emit this information
-			ShowSames:            false, // This is a legacy option now, the engine will always emit this information
+			LocalPolicyPackPaths:   engine.ConvertLocalPolicyPacksToPaths(opts.LocalPolicyPacks),
+			LocalPolicyPackConfigs: localPolicyPackConfigs,
+			Color:                  colors.Raw, // force raw colorization, we handle colorization in the CLI
+			DryRun:                 dryRun,
+			Parallel:               opts.Parallel,
+			ShowConfig:             false, // This is a legacy option now, the engine will always emit config information
+			ShowReplacementSteps:   false, // This is a legacy option now, the engine will always emit this information
+			ShowSames:              false, // This is a legacy option now, the engine will always emit this information
 		},
 		Metadata: m,
 	}
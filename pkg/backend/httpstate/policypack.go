@@ -3,6 +3,7 @@ package httpstate
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -37,8 +38,9 @@ func newCloudRequiredPolicy(client *client.Client, policy apitype.RequiredPolicy
 	return &cloudRequiredPolicy{client: client, RequiredPolicy: policy}
 }
 
-func (rp *cloudRequiredPolicy) Name() string    { return rp.RequiredPolicy.Name }
-func (rp *cloudRequiredPolicy) Version() string { return strconv.Itoa(rp.RequiredPolicy.Version) }
+func (rp *cloudRequiredPolicy) Name() string                        { return rp.RequiredPolicy.Name }
+func (rp *cloudRequiredPolicy) Version() string                     { return strconv.Itoa(rp.RequiredPolicy.Version) }
+func (rp *cloudRequiredPolicy) Config() map[string]*json.RawMessage { return rp.RequiredPolicy.Config }
 
 func (rp *cloudRequiredPolicy) Install(ctx context.Context) (string, error) {
 	policy := rp.RequiredPolicy
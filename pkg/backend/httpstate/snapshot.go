@@ -25,7 +25,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/secrets"
 )
 
-// cloudSnapshotPersister persists snapshots to the Pulumi service.
+// cloudSnapshotPersister persists snapshots to the Pulumi service.  It is not a backend.JournalPersister: the service
+// only accepts whole checkpoints, so every change made during an update still uploads the whole deployment.
 type cloudSnapshotPersister struct {
 	context     context.Context         // The context to use for client requests.
 	update      client.UpdateIdentifier // The UpdateIdentifier for this update sequence.
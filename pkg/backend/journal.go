@@ -0,0 +1,346 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// JournalPersister is implemented by snapshot persisters that can record changes to a snapshot incrementally.  Rather
+// than saving the whole snapshot after every mutation, the SnapshotManager appends a journal entry for each mutation,
+// and periodically compacts the journal by saving the whole snapshot, which discards the journal, and beginning a new
+// one.  The snapshot that was being built is recovered by replaying the journal against the saved snapshot; see
+// ReplayJournal.  Only the filestate backend's persister journals; the Pulumi service only accepts whole checkpoints.
+type JournalPersister interface {
+	SnapshotPersister
+	// Append persists the given journal entries after those that have already been persisted since the snapshot was
+	// last saved.
+	Append(entries []apitype.JournalEntryV1) error
+}
+
+// DefaultJournalCompactionInterval is the number of journal entries after which the SnapshotManager compacts the
+// journal by saving the whole snapshot.
+const DefaultJournalCompactionInterval = 256
+
+// journal records the mutations made by a SnapshotManager whose persister is a JournalPersister.  Its methods are only
+// called from the manager's service loop, so they need no synchronization.
+type journal struct {
+	persister JournalPersister
+	interval  int                       // the number of entries after which the journal is compacted.
+	ids       map[*resource.State]int64 // the identifiers assigned to resource states.
+	nextID    int64                     // the next identifier to assign.
+
+	current   *journalEntry  // the entry for the mutation being performed, if any.
+	unwritten []journalEntry // entries whose writes have been elided.
+	written   int            // the number of entries persisted since the last checkpoint.
+	compact   bool           // true if the next write must save the whole snapshot.
+}
+
+// journalEntry is a journal entry whose resource states have not yet been serialized.  The states are serialized when
+// the entry is written, so that they capture any changes the engine has made to them in place.
+type journalEntry struct {
+	kind      apitype.JournalEntryKind
+	op        deploy.StepOp
+	states    []*resource.State
+	pending   []resource.Operation
+	completed []*resource.State
+	done      []*resource.State
+	new       []*resource.State
+}
+
+func newJournal(persister JournalPersister) *journal {
+	return &journal{
+		persister: persister,
+		interval:  DefaultJournalCompactionInterval,
+		ids:       make(map[*resource.State]int64),
+		compact:   true, // the first write always saves the whole snapshot, beginning this manager's journal.
+	}
+}
+
+// id returns the identifier of the given resource state, assigning one if necessary.
+func (j *journal) id(state *resource.State) int64 {
+	id, has := j.ids[state]
+	if !has {
+		id = j.nextID
+		j.ids[state] = id
+		j.nextID++
+	}
+	return id
+}
+
+// begin starts the entry for a mutation of the given kind, made on behalf of the given step.
+func (j *journal) begin(kind apitype.JournalEntryKind, step deploy.Step) {
+	j.current = &journalEntry{kind: kind}
+	if step != nil {
+		j.current.op = step.Op()
+		j.current.states = append(j.current.states, step.Old(), step.New())
+	}
+}
+
+// end finishes the current entry.  It is written along with the next non-elided mutation.
+func (j *journal) end() {
+	entry := j.current
+	j.current = nil
+	for _, state := range entry.states {
+		// URN references to aliased resources are fixed up in place when the snapshot is saved, so saving the whole
+		// snapshot is the only way to capture every change that aliases cause.
+		if state != nil && len(state.Aliases) > 0 {
+			j.compact = true
+		}
+	}
+	j.unwritten = append(j.unwritten, *entry)
+}
+
+// rebase notes that the engine has rewritten the base snapshot, which can only be captured by saving it whole.
+func (j *journal) rebase() {
+	j.compact = true
+}
+
+// dirty returns true if the journal holds entries that have not been compacted into a saved snapshot.
+func (j *journal) dirty() bool {
+	return j.written > 0 || len(j.unwritten) > 0
+}
+
+// markDone, markNew, markOperationPending, and markOperationComplete record the corresponding SnapshotManager
+// mutations in the current entry.
+
+func (j *journal) markDone(state *resource.State) {
+	j.current.done = append(j.current.done, state)
+}
+
+func (j *journal) markNew(state *resource.State) {
+	j.current.new = append(j.current.new, state)
+	j.current.states = append(j.current.states, state)
+}
+
+func (j *journal) markOperationPending(op resource.Operation) {
+	j.current.pending = append(j.current.pending, op)
+	j.current.states = append(j.current.states, op.Resource)
+}
+
+func (j *journal) markOperationComplete(state *resource.State) {
+	j.current.completed = append(j.current.completed, state)
+}
+
+// write persists the given snapshot, which reflects every entry recorded so far.  It either appends the unwritten
+// entries to the journal or, if the journal is due to be compacted, saves the whole snapshot and begins a new journal.
+func (j *journal) write(snap *deploy.Snapshot, newResources int) error {
+	if !j.compact && j.written+len(j.unwritten) <= j.interval {
+		enc, err := j.encrypter()
+		if err != nil {
+			return err
+		}
+		var entries []apitype.JournalEntryV1
+		for _, entry := range j.unwritten {
+			sentry, err := j.serializeEntry(entry, enc)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, sentry)
+		}
+		if err = j.persister.Append(entries); err != nil {
+			return err
+		}
+		j.written += len(entries)
+		j.unwritten = nil
+		return nil
+	}
+
+	logging.V(9).Infof("SnapshotManager: compacting journal of %d entries", j.written+len(j.unwritten))
+	if err := j.persister.Save(snap); err != nil {
+		return err
+	}
+	j.written, j.unwritten, j.compact = 0, nil, false
+
+	checkpointTime := snap.Manifest.Time
+	checkpoint := apitype.JournalEntryV1{
+		Kind:           apitype.JournalEntryCheckpoint,
+		CheckpointTime: &checkpointTime,
+		NewResources:   newResources,
+	}
+	for _, res := range snap.Resources {
+		checkpoint.Resources = append(checkpoint.Resources, j.id(res))
+	}
+	for _, op := range snap.PendingOperations {
+		checkpoint.Operations = append(checkpoint.Operations, j.id(op.Resource))
+	}
+	return j.persister.Append([]apitype.JournalEntryV1{checkpoint})
+}
+
+func (j *journal) encrypter() (config.Encrypter, error) {
+	sm := j.persister.SecretsManager()
+	if sm == nil {
+		return config.NewPanicCrypter(), nil
+	}
+	enc, err := sm.Encrypter()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting encrypter for journal")
+	}
+	return enc, nil
+}
+
+func (j *journal) serializeEntry(entry journalEntry, enc config.Encrypter) (apitype.JournalEntryV1, error) {
+	sentry := apitype.JournalEntryV1{Kind: entry.kind, Op: string(entry.op)}
+
+	// Record the contents of every state that the entry touches, other than those it removes.
+	done := make(map[*resource.State]bool)
+	for _, state := range entry.done {
+		done[state] = true
+		sentry.Done = append(sentry.Done, j.id(state))
+	}
+	for _, state := range entry.states {
+		if state == nil || done[state] {
+			continue
+		}
+		id := j.id(state)
+		if _, has := sentry.States[id]; has {
+			continue
+		}
+		sres, err := stack.SerializeResource(state, enc)
+		if err != nil {
+			return apitype.JournalEntryV1{}, errors.Wrap(err, "serializing journal entry")
+		}
+		if sentry.States == nil {
+			sentry.States = make(map[int64]apitype.ResourceV3)
+		}
+		sentry.States[id] = sres
+	}
+
+	for _, op := range entry.pending {
+		sentry.Pending = append(sentry.Pending, apitype.JournalOperationV1{
+			Resource: j.id(op.Resource),
+			Type:     apitype.OperationType(op.Type),
+		})
+	}
+	for _, state := range entry.completed {
+		sentry.Completed = append(sentry.Completed, j.id(state))
+	}
+	for _, state := range entry.new {
+		sentry.New = append(sentry.New, j.id(state))
+	}
+	return sentry, nil
+}
+
+// ReplayJournal applies the given journal to the deployment it was recorded against, and returns the resulting
+// deployment.  Entries before the journal's last checkpoint entry are ignored.  If the journal has no checkpoint entry,
+// or its checkpoint entry does not match the given deployment, the journal is stale and the deployment is returned
+// unchanged.
+func ReplayJournal(deployment apitype.DeploymentV3, entries []apitype.JournalEntryV1) (apitype.DeploymentV3, error) {
+	start := -1
+	for i, entry := range entries {
+		if entry.Kind == apitype.JournalEntryCheckpoint {
+			start = i
+		}
+	}
+	if start == -1 {
+		return deployment, nil
+	}
+	checkpoint := entries[start]
+	if checkpoint.CheckpointTime == nil || !checkpoint.CheckpointTime.Equal(deployment.Manifest.Time) {
+		logging.V(5).Infof("ignoring stale journal for checkpoint at %v", checkpoint.CheckpointTime)
+		return deployment, nil
+	}
+	if len(checkpoint.Resources) != len(deployment.Resources) ||
+		len(checkpoint.Operations) != len(deployment.PendingOperations) ||
+		checkpoint.NewResources > len(checkpoint.Resources) {
+		return apitype.DeploymentV3{}, errors.New("journal does not match its checkpoint")
+	}
+
+	// Rebuild the state of the SnapshotManager that recorded the journal as of its checkpoint: the checkpoint's leading
+	// resources were produced by the current deployment, and the rest were carried over from the previous one.
+	states := make(map[int64]apitype.ResourceV3)
+	for i, id := range checkpoint.Resources {
+		states[id] = deployment.Resources[i]
+	}
+	news := append([]int64(nil), checkpoint.Resources[:checkpoint.NewResources]...)
+	base := checkpoint.Resources[checkpoint.NewResources:]
+	var operations []apitype.JournalOperationV1
+	for i, id := range checkpoint.Operations {
+		states[id] = deployment.PendingOperations[i].Resource
+		operations = append(operations, apitype.JournalOperationV1{
+			Resource: id,
+			Type:     deployment.PendingOperations[i].Type,
+		})
+	}
+	dones, completed := make(map[int64]bool), make(map[int64]bool)
+
+	// Now apply each subsequent entry, exactly as the SnapshotManager applied the corresponding mutations.
+	for _, entry := range entries[start+1:] {
+		if entry.Kind == apitype.JournalEntryCheckpoint {
+			continue
+		}
+		for id, state := range entry.States {
+			states[id] = state
+		}
+		operations = append(operations, entry.Pending...)
+		for _, id := range entry.Completed {
+			completed[id] = true
+		}
+		for _, id := range entry.Done {
+			dones[id] = true
+		}
+		news = append(news, entry.New...)
+	}
+
+	// Finally, produce the deployment in the same way as SnapshotManager.snap.
+	lookup := func(id int64) (apitype.ResourceV3, error) {
+		state, has := states[id]
+		if !has {
+			return apitype.ResourceV3{}, errors.Errorf("journal refers to unknown resource state %d", id)
+		}
+		return state, nil
+	}
+	result := apitype.DeploymentV3{
+		Manifest:         deployment.Manifest,
+		SecretsProviders: deployment.SecretsProviders,
+	}
+	for _, id := range news {
+		state, err := lookup(id)
+		if err != nil {
+			return apitype.DeploymentV3{}, err
+		}
+		result.Resources = append(result.Resources, state)
+	}
+	for _, id := range base {
+		if dones[id] {
+			continue
+		}
+		state, err := lookup(id)
+		contract.AssertNoError(err)
+		result.Resources = append(result.Resources, state)
+	}
+	for _, op := range operations {
+		if completed[op.Resource] {
+			continue
+		}
+		state, err := lookup(op.Resource)
+		if err != nil {
+			return apitype.DeploymentV3{}, err
+		}
+		result.PendingOperations = append(result.PendingOperations, apitype.OperationV2{
+			Resource: state,
+			Type:     op.Type,
+		})
+	}
+	return result, nil
+}
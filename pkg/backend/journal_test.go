@@ -0,0 +1,247 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+)
+
+// MockJournalPersister keeps a serialized checkpoint and journal, as a backend would store them.
+type MockJournalPersister struct {
+	MockStackPersister
+	Checkpoint *apitype.DeploymentV3
+	Journal    []json.RawMessage
+	Appends    int
+}
+
+func (m *MockJournalPersister) Save(snap *deploy.Snapshot) error {
+	deployment, err := stack.SerializeDeployment(snap, m.SecretsManager())
+	if err != nil {
+		return err
+	}
+	m.Checkpoint, m.Journal = deployment, nil
+	return m.MockStackPersister.Save(snap)
+}
+
+func (m *MockJournalPersister) Append(entries []apitype.JournalEntryV1) error {
+	for _, entry := range entries {
+		byts, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		m.Journal = append(m.Journal, byts)
+	}
+	m.Appends++
+	return nil
+}
+
+// Replay returns the deployment recovered from the stored checkpoint and journal.
+func (m *MockJournalPersister) Replay(t *testing.T) apitype.DeploymentV3 {
+	var entries []apitype.JournalEntryV1
+	for _, byts := range m.Journal {
+		var entry apitype.JournalEntryV1
+		assert.NoError(t, json.Unmarshal(byts, &entry))
+		entries = append(entries, entry)
+	}
+	deployment, err := ReplayJournal(*m.Checkpoint, entries)
+	assert.NoError(t, err)
+	return deployment
+}
+
+// journalEquivalence drives a SnapshotManager that saves whole snapshots and one that keeps a journal with the same
+// steps, and checks that replaying the journal always recovers the snapshot that was last saved whole.
+type journalEquivalence struct {
+	t         *testing.T
+	full      *SnapshotManager
+	fullSP    *MockStackPersister
+	journaled *SnapshotManager
+	journalSP *MockJournalPersister
+}
+
+func newJournalEquivalence(t *testing.T, baseSnap *deploy.Snapshot, interval int) *journalEquivalence {
+	full, fullSP := MockSetup(t, baseSnap)
+	journalSP := &MockJournalPersister{}
+	journaled := NewSnapshotManager(journalSP, baseSnap)
+	journaled.journal.interval = interval
+	return &journalEquivalence{t: t, full: full, fullSP: fullSP, journaled: journaled, journalSP: journalSP}
+}
+
+// check asserts that the journaled manager has persisted exactly what the full manager last saved.
+func (e *journalEquivalence) check() {
+	if len(e.fullSP.SavedSnapshots) == 0 {
+		assert.Nil(e.t, e.journalSP.Checkpoint)
+		return
+	}
+	expected, err := stack.SerializeDeployment(e.fullSP.LastSnap(), e.fullSP.SecretsManager())
+	assert.NoError(e.t, err)
+	actual := e.journalSP.Replay(e.t)
+	assert.Equal(e.t, encodeJSON(e.t, expected.Resources), encodeJSON(e.t, actual.Resources))
+	assert.Equal(e.t, encodeJSON(e.t, expected.PendingOperations), encodeJSON(e.t, actual.PendingOperations))
+}
+
+// encodeJSON returns the JSON encoding of the given value, which is how both checkpoints and journals are stored.
+func encodeJSON(t *testing.T, v interface{}) string {
+	byts, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return string(byts)
+}
+
+func (e *journalEquivalence) begin(step deploy.Step) (func(bool), func(bool)) {
+	fullMutation, err := e.full.BeginMutation(step)
+	assert.NoError(e.t, err)
+	journaledMutation, err := e.journaled.BeginMutation(step)
+	assert.NoError(e.t, err)
+	e.check()
+	return func(successful bool) { assert.NoError(e.t, fullMutation.End(step, successful)) },
+		func(successful bool) { assert.NoError(e.t, journaledMutation.End(step, successful)) }
+}
+
+// apply runs the given step to completion.
+func (e *journalEquivalence) apply(step deploy.Step, successful bool) {
+	endFull, endJournaled := e.begin(step)
+	endFull(successful)
+	endJournaled(successful)
+	e.check()
+}
+
+func (e *journalEquivalence) registerOutputs(step deploy.Step) {
+	assert.NoError(e.t, e.full.RegisterResourceOutputs(step))
+	assert.NoError(e.t, e.journaled.RegisterResourceOutputs(step))
+	e.check()
+}
+
+func (e *journalEquivalence) close() {
+	assert.NoError(e.t, e.full.Close())
+	assert.NoError(e.t, e.journaled.Close())
+	e.check()
+
+	// Closing the journaled manager compacts its journal.
+	if e.journalSP.Checkpoint != nil {
+		assert.Len(e.t, e.journalSP.Journal, 1)
+	}
+}
+
+func TestJournalEquivalence(t *testing.T) {
+	a := NewResource("a")
+	b := NewResource("b", a.URN)
+	c := NewResource("c", a.URN, b.URN)
+	d := NewResource("d", c.URN)
+	e := NewResource("e", c.URN)
+	f := NewResource("f")
+	snap := NewSnapshot([]*resource.State{a, b, c, d, e, f})
+
+	eq := newJournalEquivalence(t, snap, DefaultJournalCompactionInterval)
+
+	// The first write saves the whole snapshot; later ones append to the journal.
+	bPrime := NewResource(string(b.URN))
+	bPrime.Outputs["changed"] = resource.NewBoolProperty(true)
+	eq.apply(deploy.NewSameStep(nil, MockRegisterResourceEvent{}, b, bPrime), true)
+	assert.Len(t, eq.journalSP.SavedSnapshots, 1)
+
+	// A create-before-delete replacement, which marks the old resource for deletion in place.
+	cPrime := NewResource(string(c.URN), bPrime.URN)
+	createReplacement := deploy.NewCreateReplacementStep(nil, MockRegisterResourceEvent{}, c, cPrime, nil, nil, nil, true)
+	replace := deploy.NewReplaceStep(nil, c, cPrime, nil, nil, nil, true)
+	c.Delete = true
+	eq.apply(createReplacement, true)
+	eq.apply(replace, true)
+
+	// A failed update leaves its operation pending.
+	dPrime := NewResource(string(d.URN), cPrime.URN)
+	eq.apply(deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, d, dPrime, nil, nil, nil, nil), false)
+
+	// A create whose outputs are registered after it completes.
+	g := NewResource("g")
+	create := deploy.NewCreateStep(nil, MockRegisterResourceEvent{}, g)
+	eq.apply(create, true)
+	g.Outputs["registered"] = resource.NewStringProperty("yes")
+	eq.registerOutputs(create)
+
+	// A step whose end is recorded while another mutation is underway.
+	endFull, endJournaled := eq.begin(deploy.NewDeleteStep(nil, f))
+	eq.apply(deploy.NewDeleteReplacementStep(nil, c, false), true)
+	endFull(true)
+	endJournaled(true)
+	eq.check()
+
+	// An elided same step, followed by a read.
+	ePrime := NewResource(string(e.URN), c.URN)
+	eq.apply(deploy.NewSameStep(nil, MockRegisterResourceEvent{}, e, ePrime), true)
+	h := NewResource("h")
+	h.External, h.Custom = true, true
+	eq.apply(deploy.NewReadStep(nil, nil, nil, h), true)
+
+	assert.Len(t, eq.journalSP.SavedSnapshots, 1)
+	assert.True(t, eq.journalSP.Appends > 1)
+	eq.close()
+	assert.Len(t, eq.journalSP.SavedSnapshots, 2)
+}
+
+func TestJournalCompaction(t *testing.T) {
+	var resources []*resource.State
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		resources = append(resources, NewResource(name))
+	}
+	snap := NewSnapshot(resources)
+
+	// Compact after every two entries, so that entries are recorded against several checkpoints.
+	eq := newJournalEquivalence(t, snap, 2)
+	var news []*resource.State
+	for i, res := range resources {
+		updated := NewResource(string(res.URN))
+		updated.Inputs["index"] = resource.NewNumberProperty(float64(i))
+		news = append(news, updated)
+
+		if i%2 == 0 {
+			eq.apply(deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, res, updated, nil, nil, nil, nil), true)
+		} else {
+			eq.apply(deploy.NewDeleteStep(nil, res), true)
+		}
+	}
+	assert.True(t, len(eq.journalSP.SavedSnapshots) > 2)
+
+	// Creates recorded after a compaction must still follow the resources created before it.
+	eq.apply(deploy.NewCreateStep(nil, MockRegisterResourceEvent{}, NewResource("z", news[0].URN)), true)
+	eq.close()
+}
+
+func TestReplayStaleJournal(t *testing.T) {
+	sp := &MockJournalPersister{}
+	a := NewResource("a")
+	manager := NewSnapshotManager(sp, NewSnapshot([]*resource.State{a}))
+	step := deploy.NewCreateStep(nil, MockRegisterResourceEvent{}, NewResource("b"))
+	mutation, err := manager.BeginMutation(step)
+	assert.NoError(t, err)
+	assert.NoError(t, mutation.End(step, true))
+
+	// A journal recorded against a different checkpoint is ignored.
+	checkpoint := *sp.Checkpoint
+	checkpoint.Manifest.Time = checkpoint.Manifest.Time.Add(1)
+	checkpoint.Resources = checkpoint.Resources[:1]
+	sp.Checkpoint = &checkpoint
+	assert.Equal(t, checkpoint, sp.Replay(t))
+
+	// As is an empty one.
+	sp.Journal = nil
+	assert.Equal(t, checkpoint, sp.Replay(t))
+}
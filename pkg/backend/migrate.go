@@ -0,0 +1,139 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+)
+
+// HistoryImporter is implemented by backends that can add updates made elsewhere to a stack's history, such as those
+// of a stack that is being migrated from another backend.
+type HistoryImporter interface {
+	// ImportHistory adds the given updates, oldest first, to the history of the given stack, which must have none.
+	// Each update is recorded along with the deployment it produced, or without one if its deployment is nil.
+	ImportHistory(ctx context.Context, stack Stack, updates []UpdateInfo, deployments []*apitype.DeploymentV3) error
+}
+
+// MigrateOptions controls how a stack is copied from one backend to another.
+type MigrateOptions struct {
+	// SecretsProvider is used to decrypt the secrets in the source stack's deployments.
+	SecretsProvider stack.SecretsProvider
+	// SecretsManager is used to encrypt the secrets in the deployments written to the target stack.
+	SecretsManager secrets.Manager
+}
+
+// MigrateStack copies the deployment, update history, and tags of the source stack into the target stack, which
+// should have just been created in a different backend.  The secrets in each deployment are re-encrypted using the
+// given secrets manager.  History and tags are copied only if both backends support them; if they cannot be, a
+// warning is issued to the given sink, but the migration proceeds.
+func MigrateStack(ctx context.Context, d diag.Sink, source Stack, target Stack, opts MigrateOptions) error {
+	// Copy the history first, so that the target's latest deployment is the one that is imported last.
+	if err := migrateHistory(ctx, d, source, target, opts); err != nil {
+		return errors.Wrap(err, "copying update history")
+	}
+
+	untyped, err := source.ExportDeployment(ctx)
+	if err != nil {
+		return errors.Wrap(err, "exporting deployment")
+	}
+	deployment, err := reencryptDeployment(untyped, opts)
+	if err != nil {
+		return err
+	}
+	byts, err := json.Marshal(deployment)
+	if err != nil {
+		return err
+	}
+	if err = target.ImportDeployment(ctx, &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: byts,
+	}); err != nil {
+		return errors.Wrap(err, "importing deployment")
+	}
+
+	tags, err := GetStackTags(ctx, source)
+	if err != nil {
+		d.Warningf(diag.Message("" /*urn*/, "could not copy the tags of stack '%s': %v"), source.Ref(), err)
+	} else if len(tags) > 0 {
+		if err = UpdateStackTags(ctx, target, tags); err != nil {
+			d.Warningf(diag.Message("" /*urn*/, "could not copy the tags of stack '%s': %v"), source.Ref(), err)
+		}
+	}
+	return nil
+}
+
+// migrateHistory copies the source stack's update history, along with the deployment each update produced, to the
+// target stack.
+func migrateHistory(ctx context.Context, d diag.Sink, source Stack, target Stack, opts MigrateOptions) error {
+	updates, err := source.Backend().GetHistory(ctx, source.Ref())
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	importer, ok := target.Backend().(HistoryImporter)
+	if !ok {
+		d.Warningf(diag.Message("" /*urn*/, "the history of stack '%s' was not copied, as the target backend "+
+			"does not support importing history"), source.Ref())
+		return nil
+	}
+
+	// History is listed newest first, but is imported oldest first.
+	var history []UpdateInfo
+	var deployments []*apitype.DeploymentV3
+	for i := len(updates) - 1; i >= 0; i-- {
+		update := updates[i]
+		var deployment *apitype.DeploymentV3
+		if update.Version > 0 {
+			untyped, err := source.Backend().ExportDeploymentVersion(ctx, source, update.Version)
+			if err != nil {
+				return errors.Wrapf(err, "exporting the deployment of version %d", update.Version)
+			}
+			if deployment, err = reencryptDeployment(untyped, opts); err != nil {
+				return errors.Wrapf(err, "version %d", update.Version)
+			}
+		}
+		history = append(history, update)
+		deployments = append(deployments, deployment)
+	}
+	return importer.ImportHistory(ctx, target, history, deployments)
+}
+
+// reencryptDeployment decrypts the secrets in the given deployment and returns it with them encrypted by the given
+// secrets manager.
+func reencryptDeployment(untyped *apitype.UntypedDeployment, opts MigrateOptions) (*apitype.DeploymentV3, error) {
+	snap, err := stack.DeserializeUntypedDeployment(untyped, opts.SecretsProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting deployment")
+	}
+	if snap == nil {
+		snap = deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)
+	}
+	deployment, err := stack.SerializeDeployment(snap, opts.SecretsManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting deployment")
+	}
+	return deployment, nil
+}
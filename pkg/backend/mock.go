@@ -32,28 +32,29 @@ import (
 //
 
 type MockBackend struct {
-	NameF                   func() string
-	URLF                    func() string
-	GetPolicyPackF          func(ctx context.Context, policyPack string, d diag.Sink) (PolicyPack, error)
-	SupportsOrganizationsF  func() bool
-	ParseStackReferenceF    func(s string) (StackReference, error)
-	DoesProjectExistF       func(context.Context, string) (bool, error)
-	GetStackF               func(context.Context, StackReference) (Stack, error)
-	CreateStackF            func(context.Context, StackReference, interface{}) (Stack, error)
-	RemoveStackF            func(context.Context, Stack, bool) (bool, error)
-	ListStacksF             func(context.Context, ListStacksFilter) ([]StackSummary, error)
-	RenameStackF            func(context.Context, Stack, tokens.QName) error
-	GetStackCrypterF        func(StackReference) (config.Crypter, error)
-	QueryF                  func(context.Context, QueryOperation) result.Result
-	GetLatestConfigurationF func(context.Context, Stack) (config.Map, error)
-	GetHistoryF             func(context.Context, StackReference) ([]UpdateInfo, error)
-	GetStackTagsF           func(context.Context, Stack) (map[apitype.StackTagName]string, error)
-	UpdateStackTagsF        func(context.Context, Stack, map[apitype.StackTagName]string) error
-	ExportDeploymentF       func(context.Context, Stack) (*apitype.UntypedDeployment, error)
-	ImportDeploymentF       func(context.Context, Stack, *apitype.UntypedDeployment) error
-	LogoutF                 func() error
-	CurrentUserF            func() (string, error)
-	PreviewF                func(context.Context, Stack,
+	NameF                    func() string
+	URLF                     func() string
+	GetPolicyPackF           func(ctx context.Context, policyPack string, d diag.Sink) (PolicyPack, error)
+	SupportsOrganizationsF   func() bool
+	ParseStackReferenceF     func(s string) (StackReference, error)
+	DoesProjectExistF        func(context.Context, string) (bool, error)
+	GetStackF                func(context.Context, StackReference) (Stack, error)
+	CreateStackF             func(context.Context, StackReference, interface{}) (Stack, error)
+	RemoveStackF             func(context.Context, Stack, bool) (bool, error)
+	ListStacksF              func(context.Context, ListStacksFilter) ([]StackSummary, error)
+	RenameStackF             func(context.Context, Stack, tokens.QName) error
+	GetStackCrypterF         func(StackReference) (config.Crypter, error)
+	QueryF                   func(context.Context, QueryOperation) result.Result
+	GetLatestConfigurationF  func(context.Context, Stack) (config.Map, error)
+	GetHistoryF              func(context.Context, StackReference) ([]UpdateInfo, error)
+	GetStackTagsF            func(context.Context, Stack) (map[apitype.StackTagName]string, error)
+	UpdateStackTagsF         func(context.Context, Stack, map[apitype.StackTagName]string) error
+	ExportDeploymentF        func(context.Context, Stack) (*apitype.UntypedDeployment, error)
+	ExportDeploymentVersionF func(context.Context, Stack, int) (*apitype.UntypedDeployment, error)
+	ImportDeploymentF        func(context.Context, Stack, *apitype.UntypedDeployment) error
+	LogoutF                  func() error
+	CurrentUserF             func() (string, error)
+	PreviewF                 func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
 	UpdateF func(context.Context, Stack,
 		UpdateOperation) (engine.ResourceChanges, result.Result)
@@ -249,6 +250,15 @@ func (be *MockBackend) ExportDeployment(ctx context.Context,
 	panic("not implemented")
 }
 
+func (be *MockBackend) ExportDeploymentVersion(ctx context.Context, stack Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	if be.ExportDeploymentVersionF != nil {
+		return be.ExportDeploymentVersionF(ctx, stack, version)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) ImportDeployment(ctx context.Context, stack Stack,
 	deployment *apitype.UntypedDeployment) error {
 
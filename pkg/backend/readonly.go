@@ -0,0 +1,86 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+const (
+	// ReadOnlyEnvVar is the environment variable that, when set to a truthy value, puts every backend into
+	// read-only mode.
+	ReadOnlyEnvVar = "PULUMI_READ_ONLY"
+	// ReadOnlyShowSecretsEnvVar is the environment variable that, when set to a truthy value, allows secret values
+	// to be shown while a backend is in read-only mode.
+	ReadOnlyShowSecretsEnvVar = "PULUMI_READ_ONLY_SHOW_SECRETS"
+)
+
+// ReadOnlyMode describes whether a backend refuses to modify its stacks, so that it may be safely used by those who
+// only need to inspect them.
+type ReadOnlyMode struct {
+	Enabled     bool // true if operations that modify stacks are refused.
+	ShowSecrets bool // true if secret values may be shown even though the backend is read-only.
+}
+
+// GetReadOnlyMode returns the read-only mode of the backend with the given URL.  The backend is read-only if
+// ReadOnlyEnvVar is set, or if its account was stored by `pulumi login --read-only`.
+func GetReadOnlyMode(url string) (ReadOnlyMode, error) {
+	account, err := workspace.GetAccount(url)
+	if err != nil {
+		return ReadOnlyMode{}, errors.Wrap(err, "getting stored credentials")
+	}
+	mode := ReadOnlyMode{Enabled: account.ReadOnly, ShowSecrets: account.ShowSecrets}
+	if cmdutil.IsTruthy(os.Getenv(ReadOnlyEnvVar)) {
+		mode.Enabled = true
+	}
+	if cmdutil.IsTruthy(os.Getenv(ReadOnlyShowSecretsEnvVar)) {
+		mode.ShowSecrets = true
+	}
+	return mode, nil
+}
+
+// Check returns a ReadOnlyError for the given operation if the backend is read-only.
+func (m ReadOnlyMode) Check(operation string) error {
+	if m.Enabled {
+		return ReadOnlyError{Operation: operation}
+	}
+	return nil
+}
+
+// CheckShowSecrets returns an error if the backend is read-only and secret values may not be shown.
+func (m ReadOnlyMode) CheckShowSecrets() error {
+	if m.Enabled && !m.ShowSecrets {
+		return errors.Errorf("secret values may not be shown because the backend is in read-only mode; "+
+			"log in with `pulumi login --read-only --show-secrets` or set %s=true to allow this",
+			ReadOnlyShowSecretsEnvVar)
+	}
+	return nil
+}
+
+// ReadOnlyError is returned when an operation that would modify a stack is attempted against a read-only backend.
+type ReadOnlyError struct {
+	Operation string // The operation that was refused.
+}
+
+func (e ReadOnlyError) Error() string {
+	return fmt.Sprintf("cannot %s: the backend is in read-only mode; log in without --read-only and unset %s "+
+		"to make changes", e.Operation, ReadOnlyEnvVar)
+}
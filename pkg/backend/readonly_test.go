@@ -0,0 +1,59 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestGetReadOnlyMode(t *testing.T) {
+	home, err := ioutil.TempDir("", "readonly")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	assert.NoError(t, os.Setenv(workspace.PulumiHomeEnvVar, home))
+	defer os.Unsetenv(workspace.PulumiHomeEnvVar)
+
+	// Backends are writable by default.
+	mode, err := GetReadOnlyMode("file:///state")
+	assert.NoError(t, err)
+	assert.False(t, mode.Enabled)
+	assert.NoError(t, mode.Check("update a stack"))
+	assert.NoError(t, mode.CheckShowSecrets())
+
+	// The mode may be stored with the backend's account.
+	assert.NoError(t, workspace.StoreAccount("file:///state", workspace.Account{ReadOnly: true}, true))
+	mode, err = GetReadOnlyMode("file:///state")
+	assert.NoError(t, err)
+	assert.Equal(t, ReadOnlyMode{Enabled: true}, mode)
+	assert.Equal(t, ReadOnlyError{Operation: "update a stack"}, mode.Check("update a stack"))
+	assert.Error(t, mode.CheckShowSecrets())
+
+	// Or set by the environment, for any backend.
+	assert.NoError(t, os.Setenv(ReadOnlyEnvVar, "true"))
+	defer os.Unsetenv(ReadOnlyEnvVar)
+	assert.NoError(t, os.Setenv(ReadOnlyShowSecretsEnvVar, "1"))
+	defer os.Unsetenv(ReadOnlyShowSecretsEnvVar)
+	mode, err = GetReadOnlyMode("https://api.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, ReadOnlyMode{Enabled: true, ShowSecrets: true}, mode)
+	assert.Error(t, mode.Check("destroy a stack"))
+	assert.NoError(t, mode.CheckShowSecrets())
+}
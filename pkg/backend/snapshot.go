@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
@@ -62,6 +63,7 @@ type SnapshotManager struct {
 	dones            map[*resource.State]bool // The set of resources that have been operated upon already by this plan
 	completeOps      map[*resource.State]bool // The set of resources that have completed their operation
 	doVerify         bool                     // If true, verify the snapshot before persisting it
+	journal          *journal                 // The journal of mutations, if the persister supports journaling
 	mutationRequests chan<- mutationRequest   // The queue of mutation requests, to be retired serially by the manager
 	cancel           chan bool                // A channel used to request cancellation of any new mutation requests.
 	done             <-chan error             // A channel that sends a single result when the manager has shut down.
@@ -70,6 +72,8 @@ type SnapshotManager struct {
 var _ engine.SnapshotManager = (*SnapshotManager)(nil)
 
 type mutationRequest struct {
+	kind    apitype.JournalEntryKind
+	step    deploy.Step
 	mutator func() bool
 	result  chan<- error
 }
@@ -94,12 +98,15 @@ func (sm *SnapshotManager) Close() error {
 // meaningful changes (see sameSnapshotMutation.mustWrite for details). Any elided writes
 // are flushed by the next non-elided write or the next call to Close.
 //
+// If the persister supports journaling, each mutation is also recorded as a journal entry of
+// the given kind for the given step, which may be nil.
+//
 // You should never observe or mutate the global snapshot without using this function unless
 // you have a very good justification.
-func (sm *SnapshotManager) mutate(mutator func() bool) error {
+func (sm *SnapshotManager) mutate(kind apitype.JournalEntryKind, step deploy.Step, mutator func() bool) error {
 	result := make(chan error)
 	select {
-	case sm.mutationRequests <- mutationRequest{mutator: mutator, result: result}:
+	case sm.mutationRequests <- mutationRequest{kind: kind, step: step, mutator: mutator, result: result}:
 		return <-result
 	case <-sm.cancel:
 		return errors.New("snapshot manager closed")
@@ -118,7 +125,7 @@ func (sm *SnapshotManager) mutate(mutator func() bool) error {
 // Note that this is completely not thread-safe and defeats the purpose of having a `mutate` callback
 // entirely, but the hope is that this state of things will not be permament.
 func (sm *SnapshotManager) RegisterResourceOutputs(step deploy.Step) error {
-	return sm.mutate(func() bool { return true })
+	return sm.mutate(apitype.JournalEntryOutputs, step, func() bool { return true })
 }
 
 // BeginMutation signals to the SnapshotManager that the engine intends to mutate the global snapshot
@@ -237,7 +244,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step.Op() == deploy.OpSame, "step.Op() == deploy.OpSame")
 	contract.Assert(successful)
 	logging.V(9).Infof("SnapshotManager: sameSnapshotMutation.End(..., %v)", successful)
-	return ssm.manager.mutate(func() bool {
+	return ssm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		ssm.manager.markDone(step.Old())
 		ssm.manager.markNew(step.New())
 
@@ -256,7 +263,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 
 func (sm *SnapshotManager) doCreate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doCreate(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(apitype.JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeCreating)
 		return true
 	})
@@ -274,7 +281,7 @@ type createSnapshotMutation struct {
 func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: createSnapshotMutation.End(..., %v)", successful)
-	return csm.manager.mutate(func() bool {
+	return csm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		csm.manager.markOperationComplete(step.New())
 		if successful {
 			// There is some very subtle behind-the-scenes magic here that
@@ -300,7 +307,7 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doUpdate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doUpdate(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(apitype.JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeUpdating)
 		return true
 	})
@@ -318,7 +325,7 @@ type updateSnapshotMutation struct {
 func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: updateSnapshotMutation.End(..., %v)", successful)
-	return usm.manager.mutate(func() bool {
+	return usm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		usm.manager.markOperationComplete(step.New())
 		if successful {
 			usm.manager.markDone(step.Old())
@@ -330,7 +337,7 @@ func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doDelete(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doDelete(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(apitype.JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.Old(), resource.OperationTypeDeleting)
 		return true
 	})
@@ -348,7 +355,7 @@ type deleteSnapshotMutation struct {
 func (dsm *deleteSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: deleteSnapshotMutation.End(..., %v)", successful)
-	return dsm.manager.mutate(func() bool {
+	return dsm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		dsm.manager.markOperationComplete(step.Old())
 		if successful {
 			contract.Assert(!step.Old().Protect)
@@ -371,7 +378,7 @@ func (rsm *replaceSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doRead(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doRead(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(apitype.JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeReading)
 		return true
 	})
@@ -389,7 +396,7 @@ type readSnapshotMutation struct {
 func (rsm *readSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: readSnapshotMutation.End(..., %v)", successful)
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		rsm.manager.markOperationComplete(step.New())
 		if successful {
 			if step.Old() != nil {
@@ -410,11 +417,14 @@ func (rsm *refreshSnapshotMutation) End(step deploy.Step, successful bool) error
 	contract.Require(step != nil, "step != nil")
 	contract.Require(step.Op() == deploy.OpRefresh, "step.Op() == deploy.OpRefresh")
 	logging.V(9).Infof("SnapshotManager: refreshSnapshotMutation.End(..., %v)", successful)
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		// We always elide refreshes. The expectation is that all of these run before any actual mutations and that
 		// some other component will rewrite the base snapshot in-memory, so there's no action the snapshot
 		// manager needs to take other than to remember that the base snapshot--and therefore the actual snapshot--may
 		// have changed.
+		if rsm.manager.journal != nil {
+			rsm.manager.journal.rebase()
+		}
 		return false
 	})
 }
@@ -426,7 +436,7 @@ type removePendingReplaceSnapshotMutation struct {
 func (rsm *removePendingReplaceSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	contract.Require(step.Op() == deploy.OpRemovePendingReplace, "step.Op() == deploy.OpRemovePendingReplace")
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		res := step.Old()
 		contract.Assert(res.PendingReplacement)
 		rsm.manager.markDone(res)
@@ -436,7 +446,7 @@ func (rsm *removePendingReplaceSnapshotMutation) End(step deploy.Step, successfu
 
 func (sm *SnapshotManager) doImport(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doImport(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(apitype.JournalEntryBegin, step, func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeImporting)
 		return true
 	})
@@ -456,7 +466,7 @@ func (ism *importSnapshotMutation) End(step deploy.Step, successful bool) error
 	contract.Require(step.Op() == deploy.OpImport || step.Op() == deploy.OpImportReplacement,
 		"step.Op() == deploy.OpImport || step.Op() == deploy.OpImportReplacement")
 
-	return ism.manager.mutate(func() bool {
+	return ism.manager.mutate(apitype.JournalEntryEnd, step, func() bool {
 		ism.manager.markOperationComplete(step.New())
 		if successful {
 			ism.manager.markNew(step.New())
@@ -470,6 +480,9 @@ func (ism *importSnapshotMutation) End(step deploy.Step, successful bool) error
 func (sm *SnapshotManager) markDone(state *resource.State) {
 	contract.Assert(state != nil)
 	sm.dones[state] = true
+	if sm.journal != nil {
+		sm.journal.markDone(state)
+	}
 	logging.V(9).Infof("Marked old state snapshot as done: %v", state.URN)
 }
 
@@ -479,6 +492,9 @@ func (sm *SnapshotManager) markDone(state *resource.State) {
 func (sm *SnapshotManager) markNew(state *resource.State) {
 	contract.Assert(state != nil)
 	sm.resources = append(sm.resources, state)
+	if sm.journal != nil {
+		sm.journal.markNew(state)
+	}
 	logging.V(9).Infof("Appended new state snapshot to be written: %v", state.URN)
 }
 
@@ -486,6 +502,9 @@ func (sm *SnapshotManager) markNew(state *resource.State) {
 func (sm *SnapshotManager) markOperationPending(state *resource.State, op resource.OperationType) {
 	contract.Assert(state != nil)
 	sm.operations = append(sm.operations, resource.NewOperation(state, op))
+	if sm.journal != nil {
+		sm.journal.markOperationPending(sm.operations[len(sm.operations)-1])
+	}
 	logging.V(9).Infof("SnapshotManager.markPendingOperation(%s, %s)", state.URN, string(op))
 }
 
@@ -493,6 +512,9 @@ func (sm *SnapshotManager) markOperationPending(state *resource.State, op resour
 func (sm *SnapshotManager) markOperationComplete(state *resource.State) {
 	contract.Assert(state != nil)
 	sm.completeOps[state] = true
+	if sm.journal != nil {
+		sm.journal.markOperationComplete(state)
+	}
 	logging.V(9).Infof("SnapshotManager.markOperationComplete(%s)", state.URN)
 }
 
@@ -559,11 +581,17 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	return deploy.NewSnapshot(manifest, sm.persister.SecretsManager(), resources, operations)
 }
 
-// saveSnapshot persists the current snapshot and optionally verifies it afterwards.
+// saveSnapshot persists the current snapshot and optionally verifies it afterwards.  If the persister supports
+// journaling, the mutations made since the last write are appended to the journal instead, unless it is due to be
+// compacted.
 func (sm *SnapshotManager) saveSnapshot() error {
 	snap := sm.snap()
 	snap.NormalizeURNReferences()
-	if err := sm.persister.Save(snap); err != nil {
+	if sm.journal != nil {
+		if err := sm.journal.write(snap, len(sm.resources)); err != nil {
+			return errors.Wrap(err, "failed to save snapshot")
+		}
+	} else if err := sm.persister.Save(snap); err != nil {
 		return errors.Wrap(err, "failed to save snapshot")
 	}
 	if sm.doVerify {
@@ -593,6 +621,9 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 		cancel:           cancel,
 		done:             done,
 	}
+	if jp, ok := persister.(JournalPersister); ok {
+		manager.journal = newJournal(jp)
+	}
 
 	go func() {
 		// True if we have elided writes since the last actual write.
@@ -604,7 +635,14 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 			select {
 			case request := <-mutationRequests:
 				var err error
-				if request.mutator() {
+				if manager.journal != nil {
+					manager.journal.begin(request.kind, request.step)
+				}
+				mustWrite := request.mutator()
+				if manager.journal != nil {
+					manager.journal.end()
+				}
+				if mustWrite {
 					err = manager.saveSnapshot()
 					hasElidedWrites = false
 				} else {
@@ -616,9 +654,14 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 			}
 		}
 
-		// If we still have elided writes once the channel has closed, flush the snapshot.
+		// If we still have elided writes once the channel has closed, flush the snapshot.  If we have been keeping a
+		// journal, compact it, so that the final snapshot is saved whole.
 		var err error
-		if hasElidedWrites {
+		if manager.journal != nil && manager.journal.dirty() {
+			logging.V(9).Infof("SnapshotManager: compacting journal...")
+			manager.journal.rebase()
+			err = manager.saveSnapshot()
+		} else if hasElidedWrites {
 			logging.V(9).Infof("SnapshotManager: flushing elided writes...")
 			err = manager.saveSnapshot()
 		}
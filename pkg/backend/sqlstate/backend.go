@@ -0,0 +1,829 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlstate implements a backend that keeps the state of stacks, along with their update history, tags, locks,
+// and engine events, in a SQL database.  Both SQLite (sqlite://<path>) and PostgreSQL (postgres://<connection>)
+// databases are supported.
+package sqlstate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/operations"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/result"
+	"github.com/pulumi/pulumi/pkg/util/validation"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// Backend extends the base backend interface with specific information about SQL backends.
+type Backend interface {
+	backend.Backend
+
+	// CancelCurrentUpdate marks the given stack's in-progress update as failed and releases the stack's lock, so that
+	// the stack may be updated again after the process that was updating it has exited without finishing.
+	CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error
+	// GetEngineEvents returns the engine events that were emitted during the given stack's update with the given
+	// version, in the order they were emitted.
+	GetEngineEvents(ctx context.Context, stackRef backend.StackReference, version int) ([]apitype.EngineEvent, error)
+}
+
+type sqlBackend struct {
+	d diag.Sink
+
+	// url is the URL provided when the sqlBackend was initialized, for example "sqlite://~/state.db".  It may contain
+	// a password, so it must be redacted before it is displayed or stored.
+	url *url.URL
+
+	db      *sql.DB
+	dialect dialect
+
+	currentProject *workspace.Project
+
+	// readOnly records whether operations that modify stacks are refused.
+	readOnly backend.ReadOnlyMode
+}
+
+// sqlBackendReference identifies a stack by its project and name.  Stacks in different projects may share a name.
+type sqlBackendReference struct {
+	project tokens.PackageName
+	name    tokens.QName
+	b       *sqlBackend
+}
+
+func (r sqlBackendReference) String() string {
+	// If the project is the current project, we can elide it.
+	if r.b != nil && r.b.currentProject != nil && r.project == r.b.currentProject.Name {
+		return string(r.name)
+	}
+	return fmt.Sprintf("%s/%s", r.project, r.name)
+}
+
+func (r sqlBackendReference) Name() tokens.QName {
+	return r.name
+}
+
+// IsSQLStateBackendURL returns true if the given URL refers to a database supported by the SQL backend.
+func IsSQLStateBackendURL(urlstr string) bool {
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return false
+	}
+	_, has := dialects[u.Scheme]
+	return has
+}
+
+func New(d diag.Sink, originalURL string) (Backend, error) {
+	if !IsSQLStateBackendURL(originalURL) {
+		var schemes []string
+		for scheme := range dialects {
+			schemes = append(schemes, scheme)
+		}
+		sort.Strings(schemes)
+		return nil, errors.Errorf("database URL has an illegal prefix; expected one of: %s",
+			strings.Join(schemes, ", "))
+	}
+
+	u, err := url.Parse(originalURL)
+	if err != nil {
+		return nil, err
+	}
+	readOnly, err := backend.GetReadOnlyMode(redact(u))
+	if err != nil {
+		return nil, err
+	}
+	db, dialect, err := openDatabase(u, readOnly.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	// When stacks are referred to by name alone, they belong to the current project, if there is one.
+	currentProject, err := workspace.DetectProject()
+	if err != nil {
+		currentProject = nil
+	}
+
+	return &sqlBackend{
+		d:              d,
+		url:            u,
+		db:             db,
+		dialect:        dialect,
+		currentProject: currentProject,
+		readOnly:       readOnly,
+	}, nil
+}
+
+func Login(d diag.Sink, url string) (Backend, error) {
+	be, err := New(d, url)
+	if err != nil {
+		return nil, err
+	}
+	return be, workspace.StoreAccount(be.URL(), workspace.Account{}, true)
+}
+
+func (b *sqlBackend) Name() string {
+	if b.url.Host != "" && b.dialect.driver != "sqlite3" {
+		return b.url.Host
+	}
+	name, err := os.Hostname()
+	contract.IgnoreError(err)
+	if name == "" {
+		name = "local"
+	}
+	return name
+}
+
+// URL returns the URL of the database, without its password, if any.
+func (b *sqlBackend) URL() string {
+	return redact(b.url)
+}
+
+func (b *sqlBackend) GetPolicyPack(ctx context.Context, policyPack string,
+	d diag.Sink) (backend.PolicyPack, error) {
+
+	return nil, fmt.Errorf("SQL state backend does not support resource policy")
+}
+
+// SupportsOrganizations tells whether a user can belong to multiple organizations in this backend.
+func (b *sqlBackend) SupportsOrganizations() bool {
+	return false
+}
+
+// ParseStackReference parses a stack reference of the form <stack> or <project>/<stack>.  If the project is omitted,
+// the stack belongs to the current project.
+func (b *sqlBackend) ParseStackReference(stackRefName string) (backend.StackReference, error) {
+	var project, name string
+	split := strings.Split(stackRefName, "/")
+	switch len(split) {
+	case 1:
+		name = split[0]
+	case 2:
+		project, name = split[0], split[1]
+		if project == "" {
+			return nil, errors.Errorf("could not parse stack name '%s'", stackRefName)
+		}
+	default:
+		return nil, errors.Errorf("could not parse stack name '%s'", stackRefName)
+	}
+
+	if project == "" {
+		if b.currentProject == nil {
+			return nil, errors.Errorf("could not determine the project of stack '%s'; run this command in a "+
+				"project directory, or qualify the stack name as <project>/<stack>", stackRefName)
+		}
+		project = string(b.currentProject.Name)
+	} else if err := workspace.ValidateProjectName(project); err != nil {
+		return nil, errors.Wrapf(err, "invalid project name in stack name '%s'", stackRefName)
+	}
+
+	return sqlBackendReference{project: tokens.PackageName(project), name: tokens.QName(name), b: b}, nil
+}
+
+// getReference returns the SQL reference underlying the given stack reference.
+func (b *sqlBackend) getReference(stackRef backend.StackReference) (sqlBackendReference, error) {
+	ref, ok := stackRef.(sqlBackendReference)
+	if !ok {
+		return sqlBackendReference{}, errors.New("bad stack reference type")
+	}
+	return ref, nil
+}
+
+func (b *sqlBackend) DoesProjectExist(ctx context.Context, projectName string) (bool, error) {
+	var count int
+	err := b.conn(ctx).queryRow(`SELECT COUNT(*) FROM stacks WHERE project = ?`, projectName).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "counting stacks")
+	}
+	return count > 0, nil
+}
+
+func (b *sqlBackend) CreateStack(ctx context.Context, stackRef backend.StackReference,
+	opts interface{}) (backend.Stack, error) {
+
+	contract.Requiref(opts == nil, "opts", "SQL stacks do not support any options")
+
+	if err := b.readOnly.Check("create a stack"); err != nil {
+		return nil, err
+	}
+
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	stackName := ref.name
+	if stackName == "" {
+		return nil, errors.New("invalid empty stack name")
+	}
+
+	tags, err := backend.GetEnvironmentTagsForCurrentStack()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting stack tags")
+	}
+	if err = validation.ValidateStackProperties(string(stackName), tags); err != nil {
+		return nil, errors.Wrap(err, "validating stack properties")
+	}
+
+	if err = b.createStack(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	stack := newStack(stackRef, nil, b)
+	fmt.Printf("Created stack '%s'\n", stack.Ref())
+
+	return stack, nil
+}
+
+func (b *sqlBackend) GetStack(ctx context.Context, stackRef backend.StackReference) (backend.Stack, error) {
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := b.getStack(ctx, ref)
+	switch {
+	case errors.Cause(err) == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return newStack(stackRef, snapshot, b), nil
+	}
+}
+
+// ListStacks returns summaries of the stacks that match the given filter.  The project and tag filters are honored;
+// there are no organizations in the SQL backend.
+func (b *sqlBackend) ListStacks(
+	ctx context.Context, filter backend.ListStacksFilter) ([]backend.StackSummary, error) {
+
+	query := `SELECT s.project, s.name, s.last_update, s.resource_count FROM stacks s`
+	var args []interface{}
+	if filter.TagName != nil {
+		query += ` JOIN stack_tags t ON t.project = s.project AND t.name = s.name AND t.tag = ?`
+		args = append(args, *filter.TagName)
+		if filter.TagValue != nil {
+			query += ` AND t.value = ?`
+			args = append(args, *filter.TagValue)
+		}
+	}
+	if filter.Project != nil {
+		query += ` WHERE s.project = ?`
+		args = append(args, *filter.Project)
+	}
+	query += ` ORDER BY s.project, s.name`
+
+	rows, err := b.conn(ctx).query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing stacks")
+	}
+	defer contract.IgnoreClose(rows)
+
+	var results []backend.StackSummary
+	for rows.Next() {
+		var project, name string
+		var lastUpdate sql.NullInt64
+		var resourceCount sql.NullInt64
+		if err = rows.Scan(&project, &name, &lastUpdate, &resourceCount); err != nil {
+			return nil, errors.Wrap(err, "listing stacks")
+		}
+
+		summary := sqlStackSummary{
+			ref: sqlBackendReference{project: tokens.PackageName(project), name: tokens.QName(name), b: b},
+		}
+		if lastUpdate.Valid {
+			t := time.Unix(lastUpdate.Int64, 0)
+			summary.lastUpdate = &t
+		}
+		if resourceCount.Valid {
+			count := int(resourceCount.Int64)
+			summary.resourceCount = &count
+		}
+		results = append(results, summary)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "listing stacks")
+	}
+	return results, nil
+}
+
+func (b *sqlBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
+	if err := b.readOnly.Check("remove a stack"); err != nil {
+		return false, err
+	}
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return false, err
+	}
+	snapshot, err := b.getStack(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+
+	// Don't remove stacks that still have resources.
+	if !force && snapshot != nil && len(snapshot.Resources) > 0 {
+		return true, errors.New("refusing to remove stack because it still contains resources")
+	}
+
+	return false, b.removeStack(ctx, ref)
+}
+
+// RenameStack renames a stack.  The new name may be qualified as <project>/<stack> to move the stack to a different
+// project; otherwise, the stack remains in its current project.
+func (b *sqlBackend) RenameStack(ctx context.Context, stack backend.Stack, newName tokens.QName) error {
+	if err := b.readOnly.Check("rename a stack"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return err
+	}
+	snap, err := b.getStack(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	newRef := sqlBackendReference{project: ref.project, name: newName, b: b}
+	if strings.Contains(string(newName), "/") {
+		parsed, err := b.ParseStackReference(string(newName))
+		if err != nil {
+			return err
+		}
+		newRef = parsed.(sqlBackendReference)
+	}
+
+	// If we have a snapshot, we need to rename the URNs inside it to use the new stack and project names.
+	if snap != nil {
+		var newProject tokens.PackageName
+		if newRef.project != ref.project {
+			newProject = newRef.project
+		}
+		if err = edit.RenameStack(snap, newRef.name, newProject); err != nil {
+			return err
+		}
+	}
+
+	return b.renameStack(ctx, ref, newRef, snap)
+}
+
+func (b *sqlBackend) GetLatestConfiguration(ctx context.Context,
+	stack backend.Stack) (config.Map, error) {
+
+	hist, err := b.GetHistory(ctx, stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	if len(hist) == 0 {
+		return nil, backend.ErrNoPreviousDeployment
+	}
+
+	return hist[0].Config, nil
+}
+
+func (b *sqlBackend) PackPolicies(
+	ctx context.Context, policyPackRef backend.PolicyPackReference,
+	cancellationScopes backend.CancellationScopeSource,
+	callerEventsOpt chan<- engine.Event) result.Result {
+
+	return result.Error("SQL state backend does not support resource policy")
+}
+
+func (b *sqlBackend) Preview(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	// We can skip PreviewThenPromptThenExecute and just go straight to Execute.
+	opts := backend.ApplierOptions{
+		DryRun:   true,
+		ShowLink: true,
+	}
+	return b.apply(ctx, apitype.PreviewUpdate, stack, op, opts, nil /*events*/)
+}
+
+func (b *sqlBackend) Update(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.readOnly.Check("update a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.UpdateUpdate, stack, op, b.apply)
+}
+
+func (b *sqlBackend) Refresh(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.readOnly.Check("refresh a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply)
+}
+
+func (b *sqlBackend) Destroy(ctx context.Context, stack backend.Stack,
+	op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	if err := b.readOnly.Check("destroy a stack"); err != nil {
+		return nil, result.FromError(err)
+	}
+	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
+}
+
+func (b *sqlBackend) Query(ctx context.Context, op backend.QueryOperation) result.Result {
+	return result.Error("SQL state backend does not support querying over the state")
+}
+
+// apply actually performs the provided type of update on a stack whose state is held in the database.  Updates other
+// than previews hold the stack's lock for their duration, and are recorded in the stack's history, along with the
+// engine events they emit, when they finish.
+func (b *sqlBackend) apply(
+	ctx context.Context, kind apitype.UpdateKind, stack backend.Stack,
+	op backend.UpdateOperation, opts backend.ApplierOptions,
+	events chan<- engine.Event) (engine.ResourceChanges, result.Result) {
+
+	stackRef := stack.Ref()
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	stackName := ref.name
+	actionLabel := backend.ActionLabel(kind, opts.DryRun)
+
+	if !op.Opts.Display.JSONDisplay {
+		// Print a banner so it's clear where the stack's state is kept.
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	}
+
+	info := backend.UpdateInfo{
+		Kind:        kind,
+		StartTime:   time.Now().Unix(),
+		Message:     op.M.Message,
+		Environment: op.M.Environment,
+		Config:      op.StackConfiguration.Config,
+		Result:      backend.InProgressResult,
+	}
+
+	// Lock the stack, and reserve the update's place in its history, before reading its state.
+	if !opts.DryRun {
+		if info.Version, err = b.beginUpdate(ctx, ref, info); err != nil {
+			return nil, result.FromError(err)
+		}
+	}
+
+	// Start the update.
+	update, err := b.newUpdate(ctx, ref, op)
+	if err != nil {
+		if !opts.DryRun {
+			info.Result, info.EndTime = backend.FailedResult, time.Now().Unix()
+			contract.IgnoreError(b.finishUpdate(ctx, ref, info, nil))
+		}
+		return nil, result.FromError(err)
+	}
+
+	// Spawn a display loop to show events on the CLI.
+	displayEvents := make(chan engine.Event)
+	displayDone := make(chan bool)
+	go display.ShowEvents(
+		strings.ToLower(actionLabel), kind, stackName, op.Proj.Name,
+		displayEvents, displayDone, op.Opts.Display, opts.DryRun)
+
+	// Create a separate event channel for engine events that we'll pipe to both listening streams.
+	engineEvents := make(chan engine.Event)
+
+	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
+	eventsDone := make(chan bool)
+	var recorded []apitype.EngineEvent
+	go func() {
+		// Pull in all events from the engine and send them to the two listeners.
+		for e := range engineEvents {
+			displayEvents <- e
+
+			// If the caller also wants to see the events, stream them there also.
+			if events != nil {
+				events <- e
+			}
+			if op.Opts.Events != nil {
+				op.Opts.Events <- e
+			}
+
+			// Keep the events of updates that will be recorded in the stack's history.
+			if !opts.DryRun {
+				apiEvent, convErr := display.ConvertEngineEvent(e)
+				if convErr != nil {
+					logging.V(7).Infof("not recording engine event: %v", convErr)
+					continue
+				}
+				apiEvent.Sequence = len(recorded)
+				apiEvent.Timestamp = int(time.Now().Unix())
+				recorded = append(recorded, apiEvent)
+			}
+		}
+
+		close(eventsDone)
+	}()
+
+	// Create the management machinery.
+	persister := b.newSnapshotPersister(ctx, ref, info.Version, op.SecretsManager)
+	manager := backend.NewSnapshotManager(persister, update.GetTarget().Snapshot)
+	engineCtx := &engine.Context{
+		Cancel:          scope.Context(),
+		Events:          engineEvents,
+		SnapshotManager: manager,
+		BackendClient:   backend.NewBackendClient(b),
+	}
+
+	// Perform the update
+	var changes engine.ResourceChanges
+	var updateRes result.Result
+	switch kind {
+	case apitype.PreviewUpdate:
+		changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, true)
+	case apitype.UpdateUpdate:
+		changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.RefreshUpdate:
+		changes, updateRes = engine.Refresh(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.DestroyUpdate:
+		changes, updateRes = engine.Destroy(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	default:
+		contract.Failf("Unrecognized update kind: %s", kind)
+	}
+	info.EndTime = time.Now().Unix()
+
+	// Wait for the display to finish showing all the events.
+	<-displayDone
+	scope.Close() // Don't take any cancellations anymore, we're shutting down.
+	close(engineEvents)
+	closeErr := manager.Close()
+
+	// Make sure the goroutine writing to displayEvents and events has exited before proceeding.
+	<-eventsDone
+	close(displayEvents)
+
+	// Save update results.
+	info.Result = backend.SucceededResult
+	if updateRes != nil || closeErr != nil {
+		info.Result = backend.FailedResult
+	}
+	info.ResourceChanges = changes
+
+	var saveErr error
+	if !opts.DryRun {
+		saveErr = b.finishUpdate(ctx, ref, info, recorded)
+	}
+
+	if updateRes != nil {
+		// We swallow closeErr and saveErr as they are less important than the updateErr.
+		return changes, updateRes
+	}
+
+	if closeErr != nil {
+		return changes, result.FromError(errors.Wrap(closeErr, "saving checkpoint"))
+	}
+
+	if saveErr != nil {
+		return changes, result.FromError(errors.Wrap(saveErr, "saving update info"))
+	}
+
+	return changes, nil
+}
+
+func (b *sqlBackend) GetHistory(ctx context.Context, stackRef backend.StackReference) ([]backend.UpdateInfo, error) {
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	return b.getHistory(ctx, ref)
+}
+
+func (b *sqlBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg backend.StackConfiguration,
+	query operations.LogQuery) ([]operations.LogEntry, error) {
+
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	target, err := b.getTarget(ctx, ref, cfg.Config, cfg.Decrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	return filestate.GetLogsForTarget(target, query)
+}
+
+func (b *sqlBackend) ExportDeployment(ctx context.Context,
+	stk backend.Stack) (*apitype.UntypedDeployment, error) {
+
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	snap, err := b.getStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if snap == nil {
+		snap = deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)
+	}
+
+	sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing deployment")
+	}
+
+	data, err := json.Marshal(sdep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+var _ backend.UncheckedDeploymentExporter = (*sqlBackend)(nil)
+
+// ExportDeploymentUnchecked exports the deployment of the given stack without checking its integrity.
+func (b *sqlBackend) ExportDeploymentUnchecked(ctx context.Context,
+	stk backend.Stack) (*apitype.UntypedDeployment, error) {
+
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	if ref.name == "" {
+		return nil, errors.New("invalid empty stack name")
+	}
+	chk, err := b.getCheckpoint(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := chk.Latest
+	if deployment == nil {
+		deployment = &apitype.DeploymentV3{}
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+func (b *sqlBackend) ExportDeploymentVersion(ctx context.Context, stk backend.Stack,
+	version int) (*apitype.UntypedDeployment, error) {
+
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	chk, err := b.getHistoricalCheckpoint(ctx, ref, version)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := chk.Latest
+	if deployment == nil {
+		deployment = &apitype.DeploymentV3{}
+	}
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
+var _ backend.HistoryImporter = (*sqlBackend)(nil)
+
+// ImportHistory adds the given updates, oldest first, to the history of the given stack, which must have none.
+func (b *sqlBackend) ImportHistory(ctx context.Context, stk backend.Stack, updates []backend.UpdateInfo,
+	deployments []*apitype.DeploymentV3) error {
+
+	if err := b.readOnly.Check("import a stack's history"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return err
+	}
+	return b.importHistory(ctx, ref, updates, deployments)
+}
+
+func (b *sqlBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
+	deployment *apitype.UntypedDeployment) error {
+
+	if err := b.readOnly.Check("import a deployment"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stk.Ref())
+	if err != nil {
+		return err
+	}
+	if _, err = b.getStack(ctx, ref); err != nil {
+		return err
+	}
+
+	snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return err
+	}
+
+	// Passing no version ensures that the import cannot overwrite the checkpoint of an update that is running.
+	return b.saveStack(ctx, ref, 0 /*version*/, snap, snap.SecretsManager)
+}
+
+func (b *sqlBackend) Logout() error {
+	return workspace.DeleteAccount(b.URL())
+}
+
+func (b *sqlBackend) CurrentUser() (string, error) {
+	user, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+// GetStackTags fetches the stack's existing tags.
+func (b *sqlBackend) GetStackTags(ctx context.Context,
+	stack backend.Stack) (map[apitype.StackTagName]string, error) {
+
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	return b.getTags(ctx, ref)
+}
+
+// UpdateStackTags updates the stacks's tags, replacing all existing tags.
+func (b *sqlBackend) UpdateStackTags(ctx context.Context,
+	stack backend.Stack, tags map[apitype.StackTagName]string) error {
+
+	if err := b.readOnly.Check("update a stack's tags"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stack.Ref())
+	if err != nil {
+		return err
+	}
+	if err = validation.ValidateStackTags(tags); err != nil {
+		return err
+	}
+	return b.saveTags(ctx, ref, tags)
+}
+
+func (b *sqlBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	if err := b.readOnly.Check("cancel an update"); err != nil {
+		return err
+	}
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return err
+	}
+	return b.cancelUpdate(ctx, ref)
+}
+
+func (b *sqlBackend) GetEngineEvents(ctx context.Context, stackRef backend.StackReference,
+	version int) ([]apitype.EngineEvent, error) {
+
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	return b.getEngineEvents(ctx, ref, version)
+}
@@ -0,0 +1,320 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"             // driver for postgres://
+	_ "github.com/mattn/go-sqlite3" // driver for sqlite://
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// dialect describes how the SQL spoken by one kind of database differs from that spoken by the others.
+type dialect struct {
+	driver   string // the name of the database/sql driver for the database.
+	numbered bool   // true if query parameters are numbered ($1, $2, ...) rather than positional (?).
+	// migrationLock, if set, is run at the start of a schema migration to wait for any other migration to finish.
+	migrationLock string
+}
+
+// schemaLockKey identifies the Postgres advisory lock that serializes schema migrations.
+const schemaLockKey = 0x70756c756d69 // "pulumi"
+
+// postgresMigrationLock takes a lock, held until the end of the transaction, that serializes schema migrations.
+// Without it, two clients migrating the same database at once under READ COMMITTED would both find no schema version
+// and both try to create the tables.  SQLite needs no such lock, since its transactions take the database's write
+// lock as soon as they begin.
+var postgresMigrationLock = `SELECT pg_advisory_xact_lock(` + strconv.Itoa(schemaLockKey) + `)`
+
+// dialects maps the URL schemes of the supported databases to their dialects.
+var dialects = map[string]dialect{
+	"sqlite":     {driver: "sqlite3"},
+	"postgres":   {driver: "postgres", numbered: true, migrationLock: postgresMigrationLock},
+	"postgresql": {driver: "postgres", numbered: true, migrationLock: postgresMigrationLock},
+}
+
+// rebind rewrites a query written with positional parameters into this dialect.
+func (d dialect) rebind(query string) string {
+	if !d.numbered {
+		return query
+	}
+	var result strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			result.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		result.WriteRune(c)
+	}
+	return result.String()
+}
+
+// openDatabase opens the database with the given URL, creating or upgrading the tables that hold state as necessary.
+// If readOnly is true, the tables are never changed; instead, they must already be up to date.
+func openDatabase(u *url.URL, readOnly bool) (*sql.DB, dialect, error) {
+	d, has := dialects[u.Scheme]
+	contract.Assertf(has, "unexpected database URL scheme %s", u.Scheme)
+
+	dsn := u.String()
+	if d.driver == "sqlite3" {
+		path, err := sqlitePath(u)
+		if err != nil {
+			return nil, dialect{}, err
+		}
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, dialect{}, errors.Wrap(err, "creating database directory")
+		}
+
+		// Writers wait for each other rather than failing, and take the database's write lock as soon as they begin
+		// a transaction, so that two transactions that read before they write cannot deadlock.
+		query := u.Query()
+		if query.Get("_busy_timeout") == "" {
+			query.Set("_busy_timeout", "10000")
+		}
+		if query.Get("_txlock") == "" {
+			query.Set("_txlock", "immediate")
+		}
+		dsn = path + "?" + query.Encode()
+	}
+
+	db, err := sql.Open(d.driver, dsn)
+	if err != nil {
+		return nil, dialect{}, errors.Wrapf(err, "opening database %s", redact(u))
+	}
+	if d.driver == "sqlite3" {
+		// SQLite permits only one writer at a time, so there is nothing to gain from more connections.
+		db.SetMaxOpenConns(1)
+	}
+	prepare := migrateSchema
+	if readOnly {
+		prepare = checkSchema
+	}
+	if err = prepare(db, d); err != nil {
+		contract.IgnoreClose(db)
+		return nil, dialect{}, errors.Wrapf(err, "preparing database %s", redact(u))
+	}
+	return db, d, nil
+}
+
+// redact returns the given database URL with its password, if it has one, removed, so that it may be displayed or
+// stored.  A database that requires a password should get it from the PGPASSWORD or PGPASSFILE environment variables,
+// which the driver consults whenever the URL has none.
+func redact(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	if _, has := u.User.Password(); !has {
+		return u.String()
+	}
+	redacted := *u
+	redacted.User = url.User(u.User.Username())
+	return redacted.String()
+}
+
+// sqlitePath returns the absolute path of the SQLite database with the given URL.  Paths may be absolute, as in
+// sqlite:///var/pulumi/state.db, relative to the working directory, as in sqlite://state.db, or relative to the
+// user's home directory, as in sqlite://~/state.db.
+func sqlitePath(u *url.URL) (string, error) {
+	path := u.Host + u.Path
+	if path == "" {
+		return "", errors.Errorf("SQLite URL %s does not name a database file", u)
+	}
+	if strings.HasPrefix(path, "~") {
+		usr, err := user.Current()
+		if err != nil {
+			return "", errors.Wrap(err, "Could not determine current user to resolve `sqlite://~` path.")
+		}
+		path = filepath.Join(usr.HomeDir, strings.TrimPrefix(path, "~"))
+	}
+	return filepath.Abs(filepath.FromSlash(path))
+}
+
+// schema holds the statements that create the tables that hold state, in the order that they were introduced.  The
+// number of statements that have been run against a database is recorded in its schema_version table, so that the
+// statements added by newer versions of the CLI are run against databases created by older ones.
+var schema = []string{
+	`CREATE TABLE stacks (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		checkpoint TEXT NOT NULL,
+		last_update BIGINT,
+		resource_count INTEGER,
+		PRIMARY KEY (project, name))`,
+	`CREATE TABLE stack_tags (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (project, name, tag))`,
+	`CREATE TABLE stack_locks (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		owner TEXT NOT NULL,
+		acquired BIGINT NOT NULL,
+		PRIMARY KEY (project, name))`,
+	`CREATE TABLE updates (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		kind TEXT NOT NULL,
+		result TEXT NOT NULL,
+		start_time BIGINT NOT NULL,
+		end_time BIGINT NOT NULL,
+		info TEXT NOT NULL,
+		checkpoint TEXT,
+		PRIMARY KEY (project, name, version))`,
+	`CREATE TABLE engine_events (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		sequence INTEGER NOT NULL,
+		event TEXT NOT NULL,
+		PRIMARY KEY (project, name, version, sequence))`,
+}
+
+// migrateSchema runs the statements in the schema that have not yet been run against the given database.
+func migrateSchema(db *sql.DB, d dialect) error {
+	err := transact(context.TODO(), db, d, func(c conn) error {
+		if d.migrationLock != "" {
+			if _, err := c.exec(d.migrationLock); err != nil {
+				return errors.Wrap(err, "waiting for other schema migrations")
+			}
+		}
+		if _, err := c.exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+			return err
+		}
+
+		version := -1
+		err := c.queryRow(`SELECT version FROM schema_version`).Scan(&version)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if version > len(schema) {
+			return errors.Errorf("the database was created by a newer version of the CLI (schema version %d); "+
+				"upgrade the CLI to use it", version)
+		}
+
+		start := version
+		if start < 0 {
+			start = 0
+		}
+		for _, stmt := range schema[start:] {
+			if _, err = c.exec(stmt); err != nil {
+				return err
+			}
+		}
+		if version < 0 {
+			_, err = c.exec(`INSERT INTO schema_version (version) VALUES (?)`, len(schema))
+		} else if version < len(schema) {
+			_, err = c.exec(`UPDATE schema_version SET version = ?`, len(schema))
+		}
+		return err
+	})
+
+	// A client that does not take the migration lock, such as an older CLI, may still have raced us to create the
+	// same tables.  If it did, and it finished the job, there is nothing left for us to do.
+	if isLostMigrationRace(err) && checkSchema(db, d) == nil {
+		logging.V(5).Infof("another client migrated the schema concurrently: %v", err)
+		return nil
+	}
+	return err
+}
+
+// isLostMigrationRace returns true if the given error shows that another client created a table or row that this
+// migration was about to create.
+func isLostMigrationRace(err error) bool {
+	if pqerr, ok := errors.Cause(err).(*pq.Error); ok {
+		switch pqerr.Code.Name() {
+		case "duplicate_table", "unique_violation":
+			return true
+		}
+	}
+	return false
+}
+
+// checkSchema returns an error unless every statement in the schema has already been run against the given database.
+func checkSchema(db *sql.DB, d dialect) error {
+	var version int
+	if err := (conn{ctx: context.TODO(), e: db, d: d}).queryRow(`SELECT version FROM schema_version`).Scan(
+		&version); err != nil {
+		// A database whose schema was never created has no version, or no table to hold one.
+		logging.V(5).Infof("reading the schema version: %v", err)
+		version = -1
+	}
+	switch {
+	case version > len(schema):
+		return errors.Errorf("the database was created by a newer version of the CLI (schema version %d); "+
+			"upgrade the CLI to use it", version)
+	case version < len(schema):
+		return errors.Errorf("the database must be upgraded before it can be read; log in without --read-only "+
+			"and unset %s to upgrade it", backend.ReadOnlyEnvVar)
+	}
+	return nil
+}
+
+// execer is implemented by both databases and the transactions begun on them.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn runs queries, which are written with positional parameters, against a database or transaction.
+type conn struct {
+	ctx context.Context
+	e   execer
+	d   dialect
+}
+
+func (c conn) exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.e.ExecContext(c.ctx, c.d.rebind(query), args...)
+}
+
+func (c conn) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.e.QueryContext(c.ctx, c.d.rebind(query), args...)
+}
+
+func (c conn) queryRow(query string, args ...interface{}) *sql.Row {
+	return c.e.QueryRowContext(c.ctx, c.d.rebind(query), args...)
+}
+
+// transact runs the given function in a transaction, which is committed if the function succeeds and rolled back
+// otherwise.  The function must use only the connection it is given, as a SQLite database has only one.
+func transact(ctx context.Context, db *sql.DB, d dialect, fn func(c conn) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "beginning transaction")
+	}
+	if err = fn(conn{ctx: ctx, e: tx, d: d}); err != nil {
+		contract.IgnoreError(tx.Rollback())
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "committing transaction")
+}
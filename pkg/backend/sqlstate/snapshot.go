@@ -0,0 +1,46 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/secrets"
+)
+
+// sqlSnapshotPersister saves the snapshots produced by an update as the stack's checkpoint, as long as the update
+// still holds the stack's lock.
+type sqlSnapshotPersister struct {
+	ctx     context.Context
+	ref     sqlBackendReference
+	version int // the version of the update, which holds the stack's lock.
+	backend *sqlBackend
+	sm      secrets.Manager
+}
+
+func (sp *sqlSnapshotPersister) SecretsManager() secrets.Manager {
+	return sp.sm
+}
+
+func (sp *sqlSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
+	return sp.backend.saveStack(sp.ctx, sp.ref, sp.version, snapshot, sp.sm)
+}
+
+func (b *sqlBackend) newSnapshotPersister(ctx context.Context, ref sqlBackendReference, version int,
+	sm secrets.Manager) *sqlSnapshotPersister {
+
+	return &sqlSnapshotPersister{ctx: ctx, ref: ref, version: version, backend: b, sm: sm}
+}
@@ -0,0 +1,111 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/operations"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/result"
+)
+
+// Stack is a stack whose state is held in a SQL database.
+type Stack interface {
+	backend.Stack
+	Project() tokens.PackageName // the project that the stack belongs to.
+}
+
+// sqlStack is a SQL stack descriptor.
+type sqlStack struct {
+	ref      backend.StackReference // the stack's reference (qualified name).
+	snapshot *deploy.Snapshot       // a snapshot representing the latest deployment state.
+	b        *sqlBackend            // a pointer to the backend this stack belongs to.
+}
+
+func newStack(ref backend.StackReference, snapshot *deploy.Snapshot, b *sqlBackend) Stack {
+	return &sqlStack{
+		ref:      ref,
+		snapshot: snapshot,
+		b:        b,
+	}
+}
+
+func (s *sqlStack) Ref() backend.StackReference                            { return s.ref }
+func (s *sqlStack) Snapshot(ctx context.Context) (*deploy.Snapshot, error) { return s.snapshot, nil }
+func (s *sqlStack) Backend() backend.Backend                               { return s.b }
+func (s *sqlStack) Project() tokens.PackageName                            { return s.ref.(sqlBackendReference).project }
+
+func (s *sqlStack) Remove(ctx context.Context, force bool) (bool, error) {
+	return backend.RemoveStack(ctx, s, force)
+}
+
+func (s *sqlStack) Rename(ctx context.Context, newName tokens.QName) error {
+	return backend.RenameStack(ctx, s, newName)
+}
+
+func (s *sqlStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.PreviewStack(ctx, s, op)
+}
+
+func (s *sqlStack) Update(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.UpdateStack(ctx, s, op)
+}
+
+func (s *sqlStack) Refresh(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.RefreshStack(ctx, s, op)
+}
+
+func (s *sqlStack) Destroy(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, result.Result) {
+	return backend.DestroyStack(ctx, s, op)
+}
+
+func (s *sqlStack) GetLogs(ctx context.Context, cfg backend.StackConfiguration,
+	query operations.LogQuery) ([]operations.LogEntry, error) {
+	return backend.GetStackLogs(ctx, s, cfg, query)
+}
+
+func (s *sqlStack) ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error) {
+	return backend.ExportStackDeployment(ctx, s)
+}
+
+func (s *sqlStack) ImportDeployment(ctx context.Context, deployment *apitype.UntypedDeployment) error {
+	return backend.ImportStackDeployment(ctx, s, deployment)
+}
+
+// sqlStackSummary summarizes a stack using the columns stored alongside its checkpoint, so that listing stacks does
+// not require reading their checkpoints.
+type sqlStackSummary struct {
+	ref           backend.StackReference
+	lastUpdate    *time.Time
+	resourceCount *int
+}
+
+func (sss sqlStackSummary) Name() backend.StackReference {
+	return sss.ref
+}
+
+func (sss sqlStackSummary) LastUpdate() *time.Time {
+	return sss.lastUpdate
+}
+
+func (sss sqlStackSummary) ResourceCount() *int {
+	return sss.resourceCount
+}
@@ -0,0 +1,619 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// update is an implementation of engine.Update backed by state held in a database.
+type update struct {
+	root    string
+	proj    *workspace.Project
+	target  *deploy.Target
+	backend *sqlBackend
+}
+
+func (u *update) GetRoot() string {
+	return u.root
+}
+
+func (u *update) GetProject() *workspace.Project {
+	return u.proj
+}
+
+func (u *update) GetTarget() *deploy.Target {
+	return u.target
+}
+
+func (b *sqlBackend) newUpdate(ctx context.Context, ref sqlBackendReference, op backend.UpdateOperation) (*update,
+	error) {
+
+	contract.Require(ref.name != "", "ref")
+
+	// Construct the deployment target.
+	target, err := b.getTarget(ctx, ref, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct and return a new update.
+	return &update{
+		root:    op.Root,
+		proj:    op.Proj,
+		target:  target,
+		backend: b,
+	}, nil
+}
+
+func (b *sqlBackend) getTarget(ctx context.Context, ref sqlBackendReference, cfg config.Map,
+	dec config.Decrypter) (*deploy.Target, error) {
+
+	snapshot, err := b.getStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &deploy.Target{
+		Name:      ref.name,
+		Config:    cfg,
+		Decrypter: dec,
+		Snapshot:  snapshot,
+	}, nil
+}
+
+// conn returns a connection that runs queries against the database outside of any transaction.
+func (b *sqlBackend) conn(ctx context.Context) conn {
+	return conn{ctx: ctx, e: b.db, d: b.dialect}
+}
+
+// transact runs the given function in a transaction against the database.
+func (b *sqlBackend) transact(ctx context.Context, fn func(c conn) error) error {
+	return transact(ctx, b.db, b.dialect, fn)
+}
+
+// getStack returns the snapshot of the given stack.  If the stack does not exist, the cause of the error returned is
+// sql.ErrNoRows.
+func (b *sqlBackend) getStack(ctx context.Context, ref sqlBackendReference) (*deploy.Snapshot, error) {
+	if ref.name == "" {
+		return nil, errors.New("invalid empty stack name")
+	}
+
+	chk, err := b.getCheckpoint(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Materialize an actual snapshot object.
+	snapshot, err := stack.DeserializeCheckpoint(chk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the snapshot passes verification before returning it, to catch bugs early.
+	if !filestate.DisableIntegrityChecking {
+		if verifyerr := snapshot.VerifyIntegrity(); verifyerr != nil {
+			return nil, errors.Wrapf(verifyerr, "%s: snapshot integrity failure; refusing to use it", ref)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// getCheckpoint returns the checkpoint of the given stack, without checking its integrity.  If the stack does not exist,
+// the cause of the error returned is sql.ErrNoRows.
+func (b *sqlBackend) getCheckpoint(ctx context.Context, ref sqlBackendReference) (*apitype.CheckpointV3, error) {
+	var byts string
+	err := b.conn(ctx).queryRow(`SELECT checkpoint FROM stacks WHERE project = ? AND name = ?`,
+		string(ref.project), string(ref.name)).Scan(&byts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load checkpoint")
+	}
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint([]byte(byts))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load checkpoint")
+	}
+	return chk, nil
+}
+
+// createStack adds a stack with no deployment to the database.
+func (b *sqlBackend) createStack(ctx context.Context, ref sqlBackendReference) error {
+	return b.transact(ctx, func(c conn) error {
+		exists, err := stackExists(c, ref)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return &backend.StackAlreadyExistsError{StackName: string(ref.name)}
+		}
+		return writeCheckpoint(c, ref, nil, nil)
+	})
+}
+
+// saveStack replaces the checkpoint of the given stack.  If the version is non-zero, the checkpoint is only replaced
+// if the stack is locked by the update with that version; otherwise, it is only replaced if no update holds the
+// stack's lock, so that it cannot overwrite the checkpoint of an update that is running.
+func (b *sqlBackend) saveStack(ctx context.Context, ref sqlBackendReference, version int, snap *deploy.Snapshot,
+	sm secrets.Manager) error {
+
+	if err := b.transact(ctx, func(c conn) error {
+		if err := checkLock(c, ref, version); err != nil {
+			return err
+		}
+		return writeCheckpoint(c, ref, snap, sm)
+	}); err != nil {
+		return err
+	}
+	logging.V(7).Infof("Saved stack %s checkpoint", ref)
+
+	if !filestate.DisableIntegrityChecking {
+		// Finally, *after* writing the checkpoint, check the integrity.  This is done afterwards so that we write
+		// out the checkpoint since it may contain resource state updates.  But we will warn the user that the
+		// checkpoint is already written and might be bad.
+		if verifyerr := snap.VerifyIntegrity(); verifyerr != nil {
+			return errors.Wrapf(verifyerr, "%s: snapshot integrity failure; it was already written, but is invalid",
+				ref)
+		}
+	}
+	return nil
+}
+
+// stackExists returns true if the given stack is in the database.
+func stackExists(c conn, ref sqlBackendReference) (bool, error) {
+	var count int
+	err := c.queryRow(`SELECT COUNT(*) FROM stacks WHERE project = ? AND name = ?`,
+		string(ref.project), string(ref.name)).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "checking for stack")
+	}
+	return count > 0, nil
+}
+
+// writeCheckpoint writes the checkpoint of the given stack, which is added to the database if it is not already
+// there.  The stack's last update time and resource count are stored alongside the checkpoint for listing stacks.
+func writeCheckpoint(c conn, ref sqlBackendReference, snap *deploy.Snapshot, sm secrets.Manager) error {
+	chk, err := stack.SerializeCheckpoint(ref.name, snap, sm)
+	if err != nil {
+		return errors.Wrap(err, "serializing checkpoint")
+	}
+	byts, err := json.Marshal(chk)
+	if err != nil {
+		return errors.Wrap(err, "marshalling checkpoint")
+	}
+
+	var lastUpdate, resourceCount sql.NullInt64
+	if snap != nil {
+		if t := snap.Manifest.Time; !t.IsZero() {
+			lastUpdate = sql.NullInt64{Int64: t.Unix(), Valid: true}
+		}
+		resourceCount = sql.NullInt64{Int64: int64(len(snap.Resources)), Valid: true}
+	}
+
+	res, err := c.exec(`UPDATE stacks SET checkpoint = ?, last_update = ?, resource_count = ? `+
+		`WHERE project = ? AND name = ?`,
+		string(byts), lastUpdate, resourceCount, string(ref.project), string(ref.name))
+	if err != nil {
+		return errors.Wrap(err, "writing checkpoint")
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return errors.Wrap(err, "writing checkpoint")
+	}
+	_, err = c.exec(`INSERT INTO stacks (project, name, checkpoint, last_update, resource_count) `+
+		`VALUES (?, ?, ?, ?, ?)`,
+		string(ref.project), string(ref.name), string(byts), lastUpdate, resourceCount)
+	return errors.Wrap(err, "writing checkpoint")
+}
+
+// stackTables lists the tables that hold information about stacks, all of which are keyed by project and stack name.
+var stackTables = []string{"stacks", "stack_tags", "stack_locks", "updates", "engine_events"}
+
+// removeStack removes the given stack, along with its history, tags, lock, and engine events, from the database.
+func (b *sqlBackend) removeStack(ctx context.Context, ref sqlBackendReference) error {
+	contract.Require(ref.name != "", "ref")
+
+	return b.transact(ctx, func(c conn) error {
+		for _, table := range stackTables {
+			if _, err := c.exec(fmt.Sprintf(`DELETE FROM %s WHERE project = ? AND name = ?`, table),
+				string(ref.project), string(ref.name)); err != nil {
+				return errors.Wrapf(err, "removing stack from %s", table)
+			}
+		}
+		return nil
+	})
+}
+
+// renameStack moves the given stack, along with its history, tags, lock, and engine events, to the given new
+// reference, and saves the given snapshot, whose URNs have been renamed, as its checkpoint.
+func (b *sqlBackend) renameStack(ctx context.Context, ref sqlBackendReference, newRef sqlBackendReference,
+	snap *deploy.Snapshot) error {
+
+	contract.Require(ref.name != "", "ref")
+	contract.Require(newRef.name != "", "newRef")
+
+	return b.transact(ctx, func(c conn) error {
+		// Ensure the destination stack does not already exist.
+		exists, err := stackExists(c, newRef)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Errorf("a stack named %s already exists", newRef)
+		}
+
+		for _, table := range stackTables {
+			if _, err = c.exec(fmt.Sprintf(`UPDATE %s SET project = ?, name = ? WHERE project = ? AND name = ?`,
+				table), string(newRef.project), string(newRef.name), string(ref.project), string(ref.name)); err != nil {
+				return errors.Wrapf(err, "renaming stack in %s", table)
+			}
+		}
+
+		// Now save the snapshot under the new name (passing nil re-uses the existing secrets manager from the snapshot).
+		return writeCheckpoint(c, newRef, snap, nil)
+	})
+}
+
+// getTags returns the given stack's tags, if it has any.
+func (b *sqlBackend) getTags(ctx context.Context, ref sqlBackendReference) (map[apitype.StackTagName]string, error) {
+	rows, err := b.conn(ctx).query(`SELECT tag, value FROM stack_tags WHERE project = ? AND name = ?`,
+		string(ref.project), string(ref.name))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading stack tags")
+	}
+	defer contract.IgnoreClose(rows)
+
+	var tags map[apitype.StackTagName]string
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &value); err != nil {
+			return nil, errors.Wrap(err, "reading stack tags")
+		}
+		if tags == nil {
+			tags = make(map[apitype.StackTagName]string)
+		}
+		tags[name] = value
+	}
+	return tags, errors.Wrap(rows.Err(), "reading stack tags")
+}
+
+// saveTags replaces the given stack's tags.
+func (b *sqlBackend) saveTags(ctx context.Context, ref sqlBackendReference, tags map[apitype.StackTagName]string) error {
+	return b.transact(ctx, func(c conn) error {
+		if _, err := c.exec(`DELETE FROM stack_tags WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name)); err != nil {
+			return errors.Wrap(err, "saving stack tags")
+		}
+		for name, value := range tags {
+			if _, err := c.exec(`INSERT INTO stack_tags (project, name, tag, value) VALUES (?, ?, ?, ?)`,
+				string(ref.project), string(ref.name), name, value); err != nil {
+				return errors.Wrap(err, "saving stack tags")
+			}
+		}
+		return nil
+	})
+}
+
+// getHistory returns the given stack's update history. The first element of the result will be the most recent
+// update record.
+func (b *sqlBackend) getHistory(ctx context.Context, ref sqlBackendReference) ([]backend.UpdateInfo, error) {
+	rows, err := b.conn(ctx).query(`SELECT version, info FROM updates WHERE project = ? AND name = ? `+
+		`ORDER BY version DESC`, string(ref.project), string(ref.name))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading history")
+	}
+	defer contract.IgnoreClose(rows)
+
+	var updates []backend.UpdateInfo
+	for rows.Next() {
+		var version int
+		var info string
+		if err = rows.Scan(&version, &info); err != nil {
+			return nil, errors.Wrap(err, "reading history")
+		}
+		var update backend.UpdateInfo
+		if err = json.Unmarshal([]byte(info), &update); err != nil {
+			return nil, errors.Wrapf(err, "reading history of version %d", version)
+		}
+		update.Version = version
+		updates = append(updates, update)
+	}
+	return updates, errors.Wrap(rows.Err(), "reading history")
+}
+
+// getHistoricalCheckpoint returns the checkpoint that the given stack had after the update with the given version.
+func (b *sqlBackend) getHistoricalCheckpoint(ctx context.Context, ref sqlBackendReference,
+	version int) (*apitype.CheckpointV3, error) {
+
+	var byts sql.NullString
+	err := b.conn(ctx).queryRow(`SELECT checkpoint FROM updates WHERE project = ? AND name = ? AND version = ?`,
+		string(ref.project), string(ref.name), version).Scan(&byts)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, errors.Errorf("stack '%s' has no update with version %d", ref, version)
+	case err != nil:
+		return nil, errors.Wrapf(err, "reading checkpoint for version %d", version)
+	case !byts.Valid:
+		return nil, errors.Errorf("update %d of stack '%s' did not record a checkpoint", version, ref)
+	}
+	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint([]byte(byts.String))
+}
+
+// importHistory records the given updates, oldest first, in the history of the given stack, which must have none.
+// Each update is recorded along with the deployment it produced, if that is non-nil.
+func (b *sqlBackend) importHistory(ctx context.Context, ref sqlBackendReference, updates []backend.UpdateInfo,
+	deployments []*apitype.DeploymentV3) error {
+
+	contract.Require(ref.name != "", "ref")
+	contract.Require(len(updates) == len(deployments), "deployments")
+
+	return b.transact(ctx, func(c conn) error {
+		var count int
+		if err := c.queryRow(`SELECT COUNT(*) FROM updates WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name)).Scan(&count); err != nil {
+			return errors.Wrap(err, "reading history")
+		}
+		if count > 0 {
+			return errors.Errorf("stack '%s' already has a history", ref)
+		}
+
+		for i, update := range updates {
+			if update.Version == 0 {
+				update.Version = i + 1
+			}
+
+			var checkpoint sql.NullString
+			if deployments[i] != nil {
+				chk, err := json.Marshal(apitype.CheckpointV3{Stack: ref.name, Latest: deployments[i]})
+				if err != nil {
+					return errors.Wrap(err, "marshalling checkpoint")
+				}
+				byts, err := json.Marshal(apitype.VersionedCheckpoint{
+					Version:    apitype.DeploymentSchemaVersionCurrent,
+					Checkpoint: json.RawMessage(chk),
+				})
+				if err != nil {
+					return errors.Wrap(err, "marshalling checkpoint")
+				}
+				checkpoint = sql.NullString{String: string(byts), Valid: true}
+			}
+			if err := insertUpdate(c, ref, update, checkpoint); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertUpdate adds the given update, along with the checkpoint it produced, to the given stack's history.
+func insertUpdate(c conn, ref sqlBackendReference, update backend.UpdateInfo, checkpoint sql.NullString) error {
+	info, err := json.Marshal(&update)
+	if err != nil {
+		return err
+	}
+	_, err = c.exec(`INSERT INTO updates `+
+		`(project, name, version, kind, result, start_time, end_time, info, checkpoint) `+
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		string(ref.project), string(ref.name), update.Version, string(update.Kind), string(update.Result),
+		update.StartTime, update.EndTime, string(info), checkpoint)
+	return errors.Wrapf(err, "recording update %d", update.Version)
+}
+
+// lockOwner describes the process that is taking a stack's lock, so that users can find it if it has not finished.
+func lockOwner() string {
+	owner := "unknown"
+	if usr, err := user.Current(); err == nil {
+		owner = usr.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		owner += "@" + host
+	}
+	return fmt.Sprintf("%s (pid %d)", owner, os.Getpid())
+}
+
+// beginUpdate locks the given stack for the given update, and records the update in the stack's history as being in
+// progress.  It returns the version assigned to the update.  If another update holds the stack's lock, beginUpdate
+// fails.
+func (b *sqlBackend) beginUpdate(ctx context.Context, ref sqlBackendReference, update backend.UpdateInfo) (int,
+	error) {
+
+	contract.Require(ref.name != "", "ref")
+	contract.Require(update.Result == backend.InProgressResult, "update")
+
+	err := b.transact(ctx, func(c conn) error {
+		var version int
+		var owner string
+		var acquired int64
+		err := c.queryRow(`SELECT version, owner, acquired FROM stack_locks WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name)).Scan(&version, &owner, &acquired)
+		switch {
+		case err == nil:
+			return errors.Errorf("stack '%s' is locked by update %d, which was started by %s at %s; if that "+
+				"update is no longer running, run `pulumi cancel` to unlock the stack",
+				ref, version, owner, time.Unix(acquired, 0).Format(time.RFC1123))
+		case err != sql.ErrNoRows:
+			return errors.Wrap(err, "reading stack lock")
+		}
+
+		// Number this update after the newest one in the stack's history.
+		var latest sql.NullInt64
+		if err = c.queryRow(`SELECT MAX(version) FROM updates WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name)).Scan(&latest); err != nil {
+			return errors.Wrap(err, "reading history")
+		}
+		update.Version = int(latest.Int64) + 1
+
+		if _, err = c.exec(`INSERT INTO stack_locks (project, name, version, owner, acquired) VALUES (?, ?, ?, ?, ?)`,
+			string(ref.project), string(ref.name), update.Version, lockOwner(), update.StartTime); err != nil {
+			return errors.Wrap(err, "locking stack")
+		}
+		return insertUpdate(c, ref, update, sql.NullString{})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return update.Version, nil
+}
+
+// checkLock ensures that the given stack is locked by the update with the given version or, if the version is zero,
+// that the stack is not locked at all.
+func checkLock(c conn, ref sqlBackendReference, version int) error {
+	var locked int
+	err := c.queryRow(`SELECT version FROM stack_locks WHERE project = ? AND name = ?`,
+		string(ref.project), string(ref.name)).Scan(&locked)
+	switch {
+	case version == 0 && err == nil:
+		return errors.Errorf("stack '%s' is locked by update %d; wait for it to finish or, if it is no longer "+
+			"running, run `pulumi cancel` to unlock the stack", ref, locked)
+	case version == 0 && err == sql.ErrNoRows:
+		return nil
+	case err == sql.ErrNoRows || (err == nil && locked != version):
+		return errors.Errorf("update %d of stack '%s' was canceled", version, ref)
+	case err != nil:
+		return errors.Wrap(err, "reading stack lock")
+	}
+	return nil
+}
+
+// finishUpdate records the results of the given update, which must hold the given stack's lock, along with the engine
+// events it emitted, and releases the lock.  The update's record, its copy of the stack's checkpoint, and the
+// release of the lock are all written in one transaction.
+func (b *sqlBackend) finishUpdate(ctx context.Context, ref sqlBackendReference, update backend.UpdateInfo,
+	events []apitype.EngineEvent) error {
+
+	contract.Require(ref.name != "", "ref")
+
+	return b.transact(ctx, func(c conn) error {
+		if err := checkLock(c, ref, update.Version); err != nil {
+			return err
+		}
+
+		var checkpoint string
+		if err := c.queryRow(`SELECT checkpoint FROM stacks WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name)).Scan(&checkpoint); err != nil {
+			return errors.Wrap(err, "reading checkpoint")
+		}
+		info, err := json.Marshal(&update)
+		if err != nil {
+			return err
+		}
+		if _, err = c.exec(`UPDATE updates SET result = ?, end_time = ?, info = ?, checkpoint = ? `+
+			`WHERE project = ? AND name = ? AND version = ?`,
+			string(update.Result), update.EndTime, string(info), checkpoint,
+			string(ref.project), string(ref.name), update.Version); err != nil {
+			return errors.Wrapf(err, "recording update %d", update.Version)
+		}
+
+		for _, event := range events {
+			byts, err := json.Marshal(event)
+			if err != nil {
+				return errors.Wrap(err, "marshalling engine event")
+			}
+			if _, err = c.exec(`INSERT INTO engine_events (project, name, version, sequence, event) `+
+				`VALUES (?, ?, ?, ?, ?)`,
+				string(ref.project), string(ref.name), update.Version, event.Sequence, string(byts)); err != nil {
+				return errors.Wrap(err, "recording engine events")
+			}
+		}
+
+		_, err = c.exec(`DELETE FROM stack_locks WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name))
+		return errors.Wrap(err, "unlocking stack")
+	})
+}
+
+// cancelUpdate marks the update that holds the given stack's lock as failed, and releases the lock.
+func (b *sqlBackend) cancelUpdate(ctx context.Context, ref sqlBackendReference) error {
+	contract.Require(ref.name != "", "ref")
+
+	return b.transact(ctx, func(c conn) error {
+		var version int
+		err := c.queryRow(`SELECT version FROM stack_locks WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name)).Scan(&version)
+		switch {
+		case err == sql.ErrNoRows:
+			return errors.Errorf("stack '%s' has no update in progress", ref)
+		case err != nil:
+			return errors.Wrap(err, "reading stack lock")
+		}
+
+		var info string
+		if err = c.queryRow(`SELECT info FROM updates WHERE project = ? AND name = ? AND version = ?`,
+			string(ref.project), string(ref.name), version).Scan(&info); err != nil {
+			return errors.Wrapf(err, "reading update %d", version)
+		}
+		var update backend.UpdateInfo
+		if err = json.Unmarshal([]byte(info), &update); err != nil {
+			return errors.Wrapf(err, "reading update %d", version)
+		}
+		update.Result, update.EndTime = backend.FailedResult, time.Now().Unix()
+		byts, err := json.Marshal(&update)
+		if err != nil {
+			return err
+		}
+		if _, err = c.exec(`UPDATE updates SET result = ?, end_time = ?, info = ? `+
+			`WHERE project = ? AND name = ? AND version = ?`,
+			string(update.Result), update.EndTime, string(byts),
+			string(ref.project), string(ref.name), version); err != nil {
+			return errors.Wrapf(err, "recording update %d", version)
+		}
+
+		_, err = c.exec(`DELETE FROM stack_locks WHERE project = ? AND name = ?`,
+			string(ref.project), string(ref.name))
+		return errors.Wrap(err, "unlocking stack")
+	})
+}
+
+// getEngineEvents returns the engine events recorded for the given stack's update with the given version.
+func (b *sqlBackend) getEngineEvents(ctx context.Context, ref sqlBackendReference,
+	version int) ([]apitype.EngineEvent, error) {
+
+	rows, err := b.conn(ctx).query(`SELECT event FROM engine_events WHERE project = ? AND name = ? AND version = ? `+
+		`ORDER BY sequence`, string(ref.project), string(ref.name), version)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading engine events")
+	}
+	defer contract.IgnoreClose(rows)
+
+	var events []apitype.EngineEvent
+	for rows.Next() {
+		var byts string
+		if err = rows.Scan(&byts); err != nil {
+			return nil, errors.Wrap(err, "reading engine events")
+		}
+		var event apitype.EngineEvent
+		if err = json.Unmarshal([]byte(byts), &event); err != nil {
+			return nil, errors.Wrap(err, "reading engine events")
+		}
+		events = append(events, event)
+	}
+	return events, errors.Wrap(rows.Err(), "reading engine events")
+}
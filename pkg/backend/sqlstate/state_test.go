@@ -0,0 +1,216 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/secrets/b64"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// recordUpdate performs an update of the given stack that leaves it with the given number of resources.
+func recordUpdate(t *testing.T, b *sqlBackend, ref sqlBackendReference, count int,
+	events []apitype.EngineEvent) int {
+
+	ctx := context.Background()
+	update := backend.UpdateInfo{Kind: apitype.UpdateUpdate, StartTime: 1, Result: backend.InProgressResult}
+	version, err := b.beginUpdate(ctx, ref, update)
+	assert.NoError(t, err)
+	assert.NoError(t, b.newSnapshotPersister(ctx, ref, version, nil).Save(newTestSnapshot(ref, count)))
+
+	update.Version, update.Result, update.EndTime = version, backend.SucceededResult, 2
+	assert.NoError(t, b.finishUpdate(ctx, ref, update, events))
+	return version
+}
+
+func TestUpdateLocking(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlstate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := newTestBackend(t, dir)
+	defer contract.IgnoreClose(b.db)
+	ctx := context.Background()
+	stackRef, err := b.ParseStackReference("proj/dev")
+	assert.NoError(t, err)
+	s, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref, err := b.getReference(stackRef)
+	assert.NoError(t, err)
+
+	// A finished update is recorded along with its checkpoint and engine events.
+	events := []apitype.EngineEvent{
+		{Sequence: 0, StdoutEvent: &apitype.StdoutEngineEvent{Message: "hello"}},
+		{Sequence: 1, CancelEvent: &apitype.CancelEvent{}},
+	}
+	assert.Equal(t, 1, recordUpdate(t, b, ref, 2, events))
+	history, err := b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, 1, history[0].Version)
+		assert.Equal(t, backend.SucceededResult, history[0].Result)
+	}
+	deployment, err := b.ExportDeploymentVersion(ctx, s, 1)
+	assert.NoError(t, err)
+	snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 2)
+	recorded, err := b.GetEngineEvents(ctx, stackRef, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, events, recorded)
+
+	// While an update is running, the stack is locked.
+	update := backend.UpdateInfo{Kind: apitype.DestroyUpdate, StartTime: 3, Result: backend.InProgressResult}
+	version, err := b.beginUpdate(ctx, ref, update)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+	_, err = b.beginUpdate(ctx, ref, update)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "stack 'proj/dev' is locked by update 2")
+	}
+	err = b.saveStack(ctx, ref, 0 /*version*/, newTestSnapshot(ref, 0), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "stack 'proj/dev' is locked by update 2")
+	}
+	history, err = b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, backend.InProgressResult, history[0].Result)
+	}
+
+	// Canceling the update fails it and releases the lock, after which the update can no longer save its state.
+	assert.NoError(t, b.CancelCurrentUpdate(ctx, stackRef))
+	assert.EqualError(t, b.CancelCurrentUpdate(ctx, stackRef), "stack 'proj/dev' has no update in progress")
+	err = b.newSnapshotPersister(ctx, ref, version, nil).Save(newTestSnapshot(ref, 0))
+	assert.EqualError(t, err, "update 2 of stack 'proj/dev' was canceled")
+	update.Version, update.Result = version, backend.SucceededResult
+	assert.EqualError(t, b.finishUpdate(ctx, ref, update, nil), "update 2 of stack 'proj/dev' was canceled")
+	history, err = b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, backend.FailedResult, history[0].Result)
+		assert.NotZero(t, history[0].EndTime)
+	}
+	_, err = b.ExportDeploymentVersion(ctx, s, 2)
+	assert.EqualError(t, err, "update 2 of stack 'proj/dev' did not record a checkpoint")
+	snap, err = b.getStack(ctx, ref)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 2)
+
+	// The next update takes the next version.
+	assert.Equal(t, 3, recordUpdate(t, b, ref, 1, nil))
+
+	// Removing the stack removes its history and events.
+	_, err = b.RemoveStack(ctx, s, true)
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	history, err = b.GetHistory(ctx, stackRef)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+	recorded, err = b.GetEngineEvents(ctx, stackRef, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, recorded)
+}
+
+func TestMigrateStack(t *testing.T) {
+	sourceDir, err := ioutil.TempDir("", "sqlstate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	targetDir, err := ioutil.TempDir("", "sqlstate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(targetDir)
+
+	source, target := newTestBackend(t, sourceDir), newTestBackend(t, targetDir)
+	defer contract.IgnoreClose(source.db)
+	defer contract.IgnoreClose(target.db)
+	ctx := context.Background()
+
+	sourceRef, err := source.ParseStackReference("proj/dev")
+	assert.NoError(t, err)
+	sourceStack, err := source.CreateStack(ctx, sourceRef, nil)
+	assert.NoError(t, err)
+	ref, err := source.getReference(sourceRef)
+	assert.NoError(t, err)
+
+	// Record two updates, the last of which leaves a secret in the stack's outputs.
+	recordUpdate(t, source, ref, 1, nil)
+	version, err := source.beginUpdate(ctx, ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate, StartTime: 3,
+		Result: backend.InProgressResult})
+	assert.NoError(t, err)
+	snap := newTestSnapshot(ref, 2)
+	snap.SecretsManager = b64.NewBase64SecretsManager()
+	snap.Resources[1].Outputs["password"] = resource.MakeSecret(resource.NewStringProperty("hunter2"))
+	assert.NoError(t, source.newSnapshotPersister(ctx, ref, version, snap.SecretsManager).Save(snap))
+	assert.NoError(t, source.finishUpdate(ctx, ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate, StartTime: 3,
+		EndTime: 4, Result: backend.SucceededResult, Version: version}, nil))
+	tags := map[apitype.StackTagName]string{"team": "infra"}
+	assert.NoError(t, source.UpdateStackTags(ctx, sourceStack, tags))
+
+	targetRef, err := target.ParseStackReference("proj/dev")
+	assert.NoError(t, err)
+	targetStack, err := target.CreateStack(ctx, targetRef, nil)
+	assert.NoError(t, err)
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	err = backend.MigrateStack(ctx, sink, sourceStack, targetStack, backend.MigrateOptions{
+		SecretsProvider: stack.DefaultSecretsProvider,
+		SecretsManager:  b64.NewBase64SecretsManager(),
+	})
+	assert.NoError(t, err)
+
+	// The deployment, including its secret, has been copied.
+	targetStack, err = target.GetStack(ctx, targetRef)
+	assert.NoError(t, err)
+	migrated, err := targetStack.Snapshot(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, migrated.Resources, 2) {
+		password := migrated.Resources[1].Outputs["password"]
+		assert.True(t, password.IsSecret())
+		assert.Equal(t, "hunter2", password.SecretValue().Element.StringValue())
+	}
+
+	// As have the history, with each update's deployment, and the tags.
+	history, err := target.GetHistory(ctx, targetRef)
+	assert.NoError(t, err)
+	sourceHistory, err := source.GetHistory(ctx, sourceRef)
+	assert.NoError(t, err)
+	assert.Equal(t, sourceHistory, history)
+	for version := 1; version <= 2; version++ {
+		deployment, err := target.ExportDeploymentVersion(ctx, targetStack, version)
+		assert.NoError(t, err)
+		snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+		assert.NoError(t, err)
+		assert.Len(t, snap.Resources, version)
+	}
+	migratedTags, err := target.GetStackTags(ctx, targetStack)
+	assert.NoError(t, err)
+	assert.Equal(t, tags, migratedTags)
+
+	// A stack's history may only be imported once.
+	err = target.ImportHistory(ctx, targetStack, history, make([]*apitype.DeploymentV3, len(history)))
+	assert.EqualError(t, err, "stack 'proj/dev' already has a history")
+}
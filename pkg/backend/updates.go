@@ -99,6 +99,9 @@ type UpdateInfo struct {
 	// Config used for the update.
 	Config config.Map `json:"config"`
 
+	// Version is the number of the update within its stack's history, counting from 1.
+	Version int `json:"version,omitempty"`
+
 	// Information obtained from an update completing.
 	Result          UpdateResult           `json:"result"`
 	EndTime         int64                  `json:"endTime"`
@@ -0,0 +1,89 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+)
+
+// GzipExt is the extension appended to the name of a file whose contents are compressed with gzip.
+const GzipExt = ".gz"
+
+// gzipMagic is the header that begins every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// IsCompressed returns true if the given path names a file compressed with gzip.
+func IsCompressed(path string) bool {
+	return strings.HasSuffix(path, GzipExt)
+}
+
+// Gzip returns a marshaler that compresses the output of the given marshaler with gzip.  Its Unmarshal accepts both
+// compressed and uncompressed data.
+func Gzip(m Marshaler) Marshaler {
+	return &gzipMarshaler{inner: m}
+}
+
+// Gunzip decompresses the given data if it is compressed with gzip, and otherwise returns it unchanged.
+func Gunzip(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	return ioutil.ReadAll(r)
+}
+
+type gzipMarshaler struct {
+	inner Marshaler
+}
+
+func (m *gzipMarshaler) IsJSONLike() bool {
+	return m.inner.IsJSONLike()
+}
+
+func (m *gzipMarshaler) IsYAMLLike() bool {
+	return m.inner.IsYAMLLike()
+}
+
+func (m *gzipMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.inner.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *gzipMarshaler) Unmarshal(data []byte, v interface{}) error {
+	data, err := Gunzip(data)
+	if err != nil {
+		return err
+	}
+	return m.inner.Unmarshal(data, v)
+}
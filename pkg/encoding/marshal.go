@@ -17,6 +17,7 @@ package encoding
 import (
 	"encoding/json"
 	"path/filepath"
+	"strings"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -32,9 +33,19 @@ var Exts = []string{
 	".yml",
 }
 
-// Detect auto-detects a marshaler for the given path.
+// Detect auto-detects a marshaler for the given path.  If the path ends in GzipExt, the marshaler compresses its output
+// with gzip, and the returned extension includes that of the underlying format (e.g., ".json.gz").
 func Detect(path string) (Marshaler, string) {
 	ext := filepath.Ext(path)
+	if ext == GzipExt {
+		// The compressed file must name its underlying format explicitly.
+		base := strings.TrimSuffix(path, ext)
+		m, inner := Detect(base)
+		if m == nil || !strings.HasSuffix(base, inner) {
+			return nil, ext
+		}
+		return Gzip(m), inner + ext
+	}
 	if ext == "" {
 		ext = DefaultExt() // default to the first (preferred) marshaler.
 	}
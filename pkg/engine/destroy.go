@@ -54,6 +54,12 @@ func newDestroySource(
 	client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
 	target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
 
+	// Destroy does not run the program, so it cannot reach any providers that the program serves.
+	if err := checkServedProviders(target, opts.DestroyTargets, "destroy",
+		"to delete them, first run an update whose program registers their providers but not the resources"); err != nil {
+		return nil, err
+	}
+
 	// Like Update, we need to gather the set of plugins necessary to delete everything in the snapshot.
 	// Unlike Update, we don't actually run the user's program so we only need the set of plugins described
 	// in the snapshot.
@@ -61,6 +67,9 @@ func newDestroySource(
 	if err != nil {
 		return nil, err
 	}
+	if err = applyPluginLocks(pwd, plugins); err != nil {
+		return nil, err
+	}
 
 	// Like Update, if we're missing plugins, attempt to download the missing plugins.
 	if err := ensurePluginsAreInstalled(plugins); err != nil {
@@ -16,7 +16,9 @@ package engine
 
 import (
 	"bytes"
+	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
@@ -287,6 +289,12 @@ func makeStepEventMetadata(op deploy.StepOp, step deploy.Step, debug bool) StepE
 	}
 }
 
+// NewStepEventStateMetadata creates the metadata that describes the given resource state in step events, filtering
+// its properties in the same way as the engine does for the events that it emits.
+func NewStepEventStateMetadata(state *resource.State, debug bool) *StepEventStateMetadata {
+	return makeStepEventStateMetadata(state, debug)
+}
+
 func makeStepEventStateMetadata(state *resource.State, debug bool) *StepEventStateMetadata {
 	if state == nil {
 		return nil
@@ -529,6 +537,8 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 		prefix.WriteString(colors.SpecError)
 	case apitype.Advisory:
 		prefix.WriteString(colors.SpecWarning)
+	case apitype.Remediate:
+		prefix.WriteString(colors.SpecInfo)
 	default:
 		contract.Failf("Unrecognized diagnostic severity: %v", d)
 	}
@@ -561,6 +571,37 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 	}
 }
 
+// policyRemediationEvent reports that a policy transformed a resource's properties.  It is reported as a policy
+// violation with the remediate enforcement level, whose message names the properties that the policy changed.
+func (e *eventEmitter) policyRemediationEvent(urn resource.URN, r plugin.Remediation,
+	before, after resource.PropertyMap) {
+
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	var changed []string
+	if diff := before.Diff(after); diff != nil {
+		for _, k := range diff.Keys() {
+			if diff.Changed(k) {
+				changed = append(changed, string(k))
+			}
+		}
+	}
+	message := fmt.Sprintf("policy %q from policy pack %q changed %s", r.PolicyName, r.PolicyPackName,
+		strings.Join(changed, ", "))
+	if r.Description != "" {
+		message = fmt.Sprintf("%s (%s)", message, r.Description)
+	}
+
+	e.policyViolationEvent(urn, plugin.AnalyzeDiagnostic{
+		PolicyName:        r.PolicyName,
+		PolicyPackName:    r.PolicyPackName,
+		PolicyPackVersion: r.PolicyPackVersion,
+		Description:       r.Description,
+		Message:           message,
+		EnforcementLevel:  apitype.Remediate,
+	})
+}
+
 func diagEvent(e *eventEmitter, d *diag.Diag, prefix, msg string, sev diag.Severity,
 	ephemeral bool) {
 	contract.Requiref(e != nil, "e", "!= nil")
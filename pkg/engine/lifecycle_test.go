@@ -16,6 +16,7 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -4668,3 +4670,636 @@ func TestDependencyChangeDBR(t *testing.T) {
 	}
 	p.Run(t, snap)
 }
+
+// crashingProvider is a test provider whose "plugin" exits during its first Create.
+type crashingProvider struct {
+	*deploytest.Provider
+
+	crashed  bool
+	restarts int
+}
+
+func (p *crashingProvider) Exited() error {
+	if p.crashed {
+		return errors.New("plugin exited unexpectedly (exit status 2)")
+	}
+	return nil
+}
+
+func (p *crashingProvider) Restart() error {
+	p.crashed = false
+	p.restarts++
+	return nil
+}
+
+func TestRestartCrashedProviders(t *testing.T) {
+	for _, restart := range []bool{false, true} {
+		prov := &crashingProvider{}
+		reads, creates := 0, 0
+		prov.Provider = &deploytest.Provider{
+			ReadF: func(urn resource.URN, id resource.ID,
+				inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+
+				reads++
+				if reads == 1 {
+					prov.crashed = true
+					return plugin.ReadResult{}, resource.StatusUnknown, errors.New("transport is closing")
+				}
+				return plugin.ReadResult{Outputs: resource.PropertyMap{}}, resource.StatusOK, nil
+			},
+			CreateF: func(urn resource.URN,
+				news resource.PropertyMap, timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+				creates++
+				prov.crashed = true
+				return "", nil, resource.StatusUnknown, errors.New("transport is closing")
+			},
+		}
+		loaders := []*deploytest.ProviderLoader{
+			deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+				return prov, nil
+			}),
+		}
+
+		program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+			_, _, err := monitor.ReadResource("pkgA:m:typA", "resB", "b-id", "", resource.PropertyMap{}, "", "")
+			if err != nil {
+				return err
+			}
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resA", true)
+			return err
+		})
+		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+		p := &TestPlan{
+			Options: UpdateOptions{host: host, RestartCrashedProviders: restart},
+		}
+		resURN := p.NewURN("pkgA:m:typA", "resA", "")
+		readURN := p.NewURN("pkgA:m:typA", "resB", "")
+
+		snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient,
+			func(_ workspace.Project, _ deploy.Target, _ *Journal, events []Event, res result.Result) result.Result {
+				retried, leftPending := false, false
+				for _, e := range events {
+					if e.Type == DiagEvent {
+						p := e.Payload.(DiagEventPayload)
+						if p.URN == readURN && p.Severity == diag.Warning &&
+							strings.Contains(p.Message, "restarted the provider and retrying the read operation") {
+							retried = true
+						}
+						if p.URN == resURN && p.Severity == diag.Error &&
+							strings.Contains(p.Message, "left the operation pending") {
+							leftPending = true
+						}
+					}
+				}
+				assert.Equal(t, restart, retried)
+				assert.Equal(t, restart, leftPending)
+				return res
+			})
+		assert.NotNil(t, res)
+
+		if !restart {
+			// Without the opt-in, the crash fails the update.
+			assert.Equal(t, 1, reads)
+			assert.Equal(t, 0, creates)
+			assert.Equal(t, 0, prov.restarts)
+			continue
+		}
+
+		// With it, the provider is restarted after each crash. The read, which has no side effects, is retried, but
+		// the create, which may have completed, is not: its pending operation is kept so that it can be reconciled.
+		assert.Equal(t, 2, reads)
+		assert.Equal(t, 1, creates)
+		assert.Equal(t, 2, prov.restarts)
+		if assert.Len(t, snap.PendingOperations, 1) {
+			assert.Equal(t, resURN, snap.PendingOperations[0].Resource.URN)
+			assert.Equal(t, resource.OperationTypeCreating, snap.PendingOperations[0].Type)
+		}
+		found := false
+		for _, r := range snap.Resources {
+			assert.NotEqual(t, resURN, r.URN)
+			if r.URN == readURN {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	}
+}
+
+func TestStreamInvoke(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				StreamInvokeF: func(tok tokens.ModuleMember, args resource.PropertyMap,
+					onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+
+					if _, ok := args["count"]; !ok {
+						return []plugin.CheckFailure{{Property: "count", Reason: "missing"}}, nil
+					}
+					for i := 0; i < int(args["count"].NumberValue()); i++ {
+						if err := onNext(resource.PropertyMap{"n": resource.NewNumberProperty(float64(i))}); err != nil {
+							return nil, err
+						}
+					}
+					return nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var results []float64
+		failures, err := monitor.StreamInvoke("pkgA:m:count", resource.PropertyMap{
+			"count": resource.NewNumberProperty(3),
+		}, "", "", func(ret resource.PropertyMap) error {
+			results = append(results, ret["n"].NumberValue())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		assert.Equal(t, []float64{0, 1, 2}, results)
+
+		failures, err = monitor.StreamInvoke("pkgA:m:count", resource.PropertyMap{}, "", "",
+			func(resource.PropertyMap) error {
+				assert.Fail(t, "unexpected result")
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Len(t, failures, 1)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+		Steps:   MakeBasicLifecycleSteps(t, 1),
+	}
+	p.Run(t, nil)
+}
+
+func TestCall(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return resource.ID("id-" + urn.Name()), news, resource.StatusOK, nil
+				},
+				CallF: func(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+					args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					assert.Equal(t, tokens.ModuleMember("pkgA:m:typA/sign"), tok)
+					assert.Equal(t, resource.ID("id-resA"), id)
+					url := state["name"].StringValue() + "/" + args["suffix"].StringValue()
+					return resource.PropertyMap{
+						"url":       resource.NewStringProperty(url),
+						"signature": resource.MakeSecret(resource.NewStringProperty("sig-" + url)),
+					}, nil, nil
+				},
+			}, nil
+		}),
+	}
+
+	// The program calls a method of resA, passing an argument that depends on resB.
+	var ret resource.PropertyMap
+	var deps []resource.URN
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		resB, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.NoError(t, err)
+		resA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"name": resource.NewStringProperty("a")},
+		})
+		assert.NoError(t, err)
+
+		r, d, failures, err := monitor.Call("pkgA:m:typA/sign", resA, resource.PropertyMap{
+			"suffix": resource.NewStringProperty("x"),
+		}, map[resource.PropertyKey][]resource.URN{"suffix": {resB}})
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		ret, deps = r, d
+
+		// Methods may only be called on resources that the program has registered or read.
+		_, _, _, err = monitor.Call("pkgA:m:typA/sign", "urn:pulumi:test::test::pkgA:m:typA::resC", nil, nil)
+		assert.Error(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	project := p.GetProject()
+	resA, resB := p.NewURN("pkgA:m:typA", "resA", ""), p.NewURN("pkgA:m:typA", "resB", "")
+
+	// During a preview, resA has not been created, so the result is unknown.
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, true, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Nil(t, ret)
+	assert.Equal(t, []resource.URN{resA, resB}, deps)
+
+	// During an update, the method is called with the resource's ID and state.  Secret results stay secret.
+	_, res = TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, resource.PropertyMap{
+		"url":       resource.NewStringProperty("a/x"),
+		"signature": resource.MakeSecret(resource.NewStringProperty("sig-a/x")),
+	}, ret)
+	assert.Equal(t, []resource.URN{resA, resB}, deps)
+}
+
+func TestAnalyzerResources(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					outs := news.Copy()
+					outs["arn"] = resource.NewStringProperty("arn:" + string(urn.Name()))
+					return resource.ID("id-" + urn.Name()), outs, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	dbr := true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		provURN, provID, _, err := monitor.RegisterResource(providers.MakeProviderType("pkgA"), "provA", true,
+			deploytest.ResourceOptions{
+				Inputs: resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")},
+			})
+		assert.NoError(t, err)
+		if provID == "" {
+			provID = providers.UnknownID
+		}
+		provRef, err := providers.NewReference(provURN, provID)
+		assert.NoError(t, err)
+
+		comp, _, _, err := monitor.RegisterResource("my:mod:Component", "comp", false)
+		assert.NoError(t, err)
+		resB, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+			Provider: provRef.String(),
+		})
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Parent:              comp,
+			Protect:             true,
+			Dependencies:        []resource.URN{resB},
+			Provider:            provRef.String(),
+			Inputs:              resource.PropertyMap{"bucket": resource.NewStringProperty("b")},
+			PropertyDeps:        map[resource.PropertyKey][]resource.URN{"bucket": {resB}},
+			DeleteBeforeReplace: &dbr,
+			IgnoreChanges:       []string{"tags"},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	analyzed := make(map[resource.URN]plugin.AnalyzerResource)
+	stackAnalyzed := make(map[resource.URN]plugin.AnalyzerResource)
+	analyzer := &deploytest.Analyzer{
+		Info: plugin.AnalyzerInfo{Name: "analyzerA"},
+		AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			analyzed[r.URN] = r
+			return nil, nil
+		},
+		AnalyzeStackF: func(resources []plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			for _, r := range resources {
+				stackAnalyzed[r.URN] = r
+			}
+			return nil, nil
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{analyzer}, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{host: host},
+	}
+	project := p.GetProject()
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	provURN := p.NewProviderURN("pkgA", "provA", "")
+	compURN := p.NewURN("my:mod:Component", "comp", "")
+	resBURN := p.NewURN("pkgA:m:typA", "resB", "")
+	resAURN := p.NewURN("pkgA:m:typA", "resA", compURN)
+	expectedProvider := &plugin.AnalyzerProviderResource{
+		URN:        provURN,
+		Type:       providers.MakeProviderType("pkgA"),
+		Name:       "provA",
+		Properties: resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")},
+	}
+
+	// Analyze is passed the resource's inputs, options, provider, and dependencies.
+	r, ok := analyzed[resAURN]
+	assert.True(t, ok)
+	assert.Equal(t, tokens.Type("pkgA:m:typA"), r.Type)
+	assert.Equal(t, tokens.QName("resA"), r.Name)
+	assert.Equal(t, resource.PropertyMap{"bucket": resource.NewStringProperty("b")}, r.Properties)
+	assert.Equal(t, compURN, r.Parent)
+	assert.Equal(t, []resource.URN{resBURN}, r.Dependencies)
+	assert.Equal(t, map[resource.PropertyKey][]resource.URN{"bucket": {resBURN}}, r.PropertyDependencies)
+	assert.True(t, r.Options.Protect)
+	assert.True(t, r.Options.DeleteBeforeReplace)
+	assert.True(t, r.Options.DeleteBeforeReplaceDefined)
+	assert.Equal(t, []string{"tags"}, r.Options.IgnoreChanges)
+	assert.Equal(t, expectedProvider, r.Provider)
+
+	// Provider resources and component resources have no provider.
+	assert.Nil(t, analyzed[provURN].Provider)
+	assert.Nil(t, analyzed[compURN].Provider)
+
+	// AnalyzeStack is passed the resource's outputs, and the options that are recorded in its state.
+	r, ok = stackAnalyzed[resAURN]
+	assert.True(t, ok)
+	assert.Equal(t, resource.NewStringProperty("arn:resA"), r.Properties["arn"])
+	assert.Equal(t, compURN, r.Parent)
+	assert.True(t, r.Options.Protect)
+	assert.Empty(t, r.Options.IgnoreChanges)
+	assert.Equal(t, expectedProvider, r.Provider)
+}
+
+func TestAnalyzerSecretProviderConfig(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+
+	analyzed := make(map[resource.URN]plugin.AnalyzerResource)
+	analyzer := &deploytest.Analyzer{
+		Info: plugin.AnalyzerInfo{Name: "analyzerA"},
+		AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			analyzed[r.URN] = r
+			return nil, nil
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{analyzer}, loaders...)
+
+	p := &TestPlan{
+		Options:   UpdateOptions{host: host},
+		Decrypter: config.NopDecrypter,
+		Config: config.Map{
+			config.MustMakeKey("pkgA", "region"):   config.NewValue("us-west-2"),
+			config.MustMakeKey("pkgA", "password"): config.NewSecureValue("hunter2"),
+		},
+	}
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// The default provider's secret configuration reaches the analyzer, and the checkpoint, only as a secret.
+	r, ok := analyzed[p.NewURN("pkgA:m:typA", "resA", "")]
+	assert.True(t, ok)
+	if assert.NotNil(t, r.Provider) {
+		assert.Equal(t, resource.NewStringProperty("us-west-2"), r.Provider.Properties["region"])
+		assert.Equal(t, resource.MakeSecret(resource.NewStringProperty("hunter2")), r.Provider.Properties["password"])
+	}
+	if assert.Len(t, snap.Resources, 2) {
+		assert.True(t, snap.Resources[0].Inputs["password"].IsSecret())
+	}
+}
+
+func TestPolicyRemediation(t *testing.T) {
+	var created resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					created = news
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"acl": resource.NewStringProperty("public-read")},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	p := &TestPlan{}
+	resURN := p.NewURN("pkgA:m:typA", "resA", "")
+
+	// The first analyzer tags resources, and the second makes them private.  Each sees the results of the remediations
+	// before it, and analysis sees the results of all of them.
+	tagger := &deploytest.Analyzer{
+		Info: plugin.AnalyzerInfo{Name: "tagger"},
+		RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+			if providers.IsProviderType(r.Type) {
+				return nil, nil
+			}
+			props := r.Properties.Copy()
+			props["tags"] = resource.NewObjectProperty(resource.PropertyMap{
+				"owner": resource.NewStringProperty("platform"),
+			})
+			return []plugin.Remediation{{PolicyName: "require-tags", PolicyPackName: "tagger", Properties: props}}, nil
+		},
+	}
+	var analyzed resource.PropertyMap
+	privatizer := &deploytest.Analyzer{
+		Info: plugin.AnalyzerInfo{Name: "privatizer"},
+		RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+			if providers.IsProviderType(r.Type) {
+				return nil, nil
+			}
+			_, tagged := r.Properties["tags"]
+			assert.True(t, tagged)
+			props := r.Properties.Copy()
+			props["acl"] = resource.NewStringProperty("private")
+			return []plugin.Remediation{
+				{PolicyName: "private-acl", PolicyPackName: "privatizer", Properties: props},
+				// Remediations that make no changes are not reported.
+				{PolicyName: "no-op", PolicyPackName: "privatizer", Properties: props},
+			}, nil
+		},
+		AnalyzeF: func(r plugin.AnalyzerResource) ([]plugin.AnalyzeDiagnostic, error) {
+			if r.URN == resURN {
+				analyzed = r.Properties
+			}
+			return nil, nil
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{tagger, privatizer}, loaders...)
+
+	p.Options.host = host
+
+	expected := resource.PropertyMap{
+		"acl": resource.NewStringProperty("private"),
+		"tags": resource.NewObjectProperty(resource.PropertyMap{
+			"owner": resource.NewStringProperty("platform"),
+		}),
+	}
+
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ *Journal, events []Event, res result.Result) result.Result {
+			var remediated []string
+			for _, e := range events {
+				if e.Type == PolicyViolationEvent {
+					payload := e.Payload.(PolicyViolationEventPayload)
+					assert.Equal(t, resURN, payload.ResourceURN)
+					assert.Equal(t, apitype.Remediate, payload.EnforcementLevel)
+					remediated = append(remediated, payload.PolicyName)
+					switch payload.PolicyName {
+					case "require-tags":
+						assert.Contains(t, payload.Message, "changed tags")
+					case "private-acl":
+						assert.Contains(t, payload.Message, "changed acl")
+					}
+				}
+			}
+			assert.Equal(t, []string{"require-tags", "private-acl"}, remediated)
+			return res
+		})
+	assert.Nil(t, res)
+	assert.Equal(t, expected, analyzed)
+	assert.Equal(t, expected, created)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, expected, snap.Resources[1].Inputs)
+}
+
+func TestPolicyRemediationWithoutProperties(t *testing.T) {
+	var created resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					created = news
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	inputs := resource.PropertyMap{"acl": resource.NewStringProperty("public-read")}
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Inputs: inputs,
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+
+	// A remediation that only describes a problem leaves the resource's inputs unchanged and is not reported.
+	describer := &deploytest.Analyzer{
+		Info: plugin.AnalyzerInfo{Name: "describer"},
+		RemediateF: func(r plugin.AnalyzerResource) ([]plugin.Remediation, error) {
+			return []plugin.Remediation{{
+				PolicyName:     "describe-only",
+				PolicyPackName: "describer",
+				Description:    "this resource is misconfigured",
+			}}, nil
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{describer}, loaders...)
+
+	p := &TestPlan{}
+	p.Options.host = host
+
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ *Journal, events []Event, res result.Result) result.Result {
+			for _, e := range events {
+				assert.NotEqual(t, PolicyViolationEvent, e.Type)
+			}
+			return res
+		})
+	assert.Nil(t, res)
+	assert.Equal(t, inputs, created)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, inputs, snap.Resources[1].Inputs)
+}
+
+type testRequiredPolicy struct {
+	name   string
+	config map[string]*json.RawMessage
+}
+
+func (p *testRequiredPolicy) Name() string                              { return p.name }
+func (p *testRequiredPolicy) Version() string                           { return "1" }
+func (p *testRequiredPolicy) Install(_ context.Context) (string, error) { return "", nil }
+func (p *testRequiredPolicy) Config() map[string]*json.RawMessage       { return p.config }
+
+func TestPolicyPackConfig(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+
+	var configured map[string]plugin.AnalyzerPolicyConfig
+	analyzer := &deploytest.Analyzer{
+		Info: plugin.AnalyzerInfo{
+			Name: "analyzerA",
+			Policies: []apitype.Policy{
+				{
+					Name:             "max-size",
+					EnforcementLevel: apitype.Mandatory,
+					ConfigSchema: &apitype.PolicyConfigSchema{
+						Properties: map[string]interface{}{
+							"maxSize": map[string]interface{}{"type": "integer"},
+						},
+						Required: []string{"maxSize"},
+					},
+				},
+				{Name: "no-public", EnforcementLevel: apitype.Mandatory},
+			},
+		},
+		ConfigureF: func(policyConfig map[string]plugin.AnalyzerPolicyConfig) error {
+			configured = policyConfig
+			return nil
+		},
+	}
+	host := deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{analyzer}, loaders...)
+
+	run := func(config string) result.Result {
+		var raw map[string]*json.RawMessage
+		err := json.Unmarshal([]byte(config), &raw)
+		assert.NoError(t, err)
+
+		p := &TestPlan{
+			Options: UpdateOptions{
+				RequiredPolicies: []RequiredPolicy{&testRequiredPolicy{name: "analyzerA", config: raw}},
+				host:             host,
+			},
+		}
+		_, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+		return res
+	}
+
+	// A valid configuration is reconciled with the policies' defaults and passed to the analyzer.
+	res := run(`{"max-size": {"enforcementLevel": "advisory", "maxSize": 4}, "no-public": "disabled"}`)
+	assert.Nil(t, res)
+	assert.Equal(t, map[string]plugin.AnalyzerPolicyConfig{
+		"max-size":  {EnforcementLevel: apitype.Advisory, Properties: map[string]interface{}{"maxSize": float64(4)}},
+		"no-public": {EnforcementLevel: apitype.Disabled},
+	}, configured)
+
+	// An invalid configuration fails the update before the analyzer is configured.
+	configured = nil
+	res = run(`{"max-size": {"maxSize": "large"}, "unknown": "advisory"}`)
+	assert.NotNil(t, res)
+	assert.Nil(t, configured)
+	assert.Contains(t, res.Error().Error(), "analyzerA: max-size.maxSize: expected integer, but got string")
+	assert.Contains(t, res.Error().Error(), "analyzerA: unknown: no policy with this name exists")
+}
@@ -41,12 +41,19 @@ func ProjectInfoContext(projinfo *Projinfo, host plugin.Host, config plugin.Conf
 		return "", "", nil, err
 	}
 
+	// Providers that are already running, for example under a debugger, are attached to rather than launched.
+	debugProviders, err := plugin.GetDebugProviders(projinfo.Proj)
+	if err != nil {
+		return "", "", nil, err
+	}
+
 	// Create a context for plugins.
 	ctx, err := plugin.NewContext(diag, statusDiag, host, config, pwd,
 		projinfo.Proj.Runtime.Options(), tracingSpan)
 	if err != nil {
 		return "", "", nil, err
 	}
+	ctx.DebugProviders = debugProviders
 
 	return pwd, main, ctx, nil
 }
@@ -124,6 +131,9 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	if err != nil {
 		return nil, err
 	}
+	if opts.LanguageRuntime != nil {
+		plugctx.Host = &languageRuntimeHost{Host: plugctx.Host, runtime: opts.LanguageRuntime}
+	}
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
@@ -135,8 +145,9 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	}
 
 	// Generate a plan; this API handles all interesting cases (create, update, delete).
+	localPolicyPackPaths := ConvertLocalPolicyPacksToPaths(opts.LocalPolicyPacks)
 	plan, err := deploy.NewPlan(
-		plugctx, target, target.Snapshot, source, opts.LocalPolicyPackPaths, dryRun, ctx.BackendClient)
+		plugctx, target, target.Snapshot, source, localPolicyPackPaths, dryRun, ctx.BackendClient)
 	if err != nil {
 		contract.IgnoreClose(plugctx)
 		return nil, err
@@ -186,6 +197,8 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 			UpdateTargets:     planResult.Options.UpdateTargets,
 			TrustDependencies: planResult.Options.trustDependencies,
 			UseLegacyDiff:     planResult.Options.UseLegacyDiff,
+
+			RestartCrashedProviders: planResult.Options.RestartCrashedProviders,
 		}
 		walkResult = planResult.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -346,6 +359,11 @@ func (acts *planActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDia
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *planActions) OnPolicyRemediation(urn resource.URN, r plugin.Remediation,
+	before, after resource.PropertyMap) {
+	acts.Opts.Events.policyRemediationEvent(urn, r, before, after)
+}
+
 func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 	_, has := seen[step.URN()]
 	contract.Assertf(has, "URN '%v' had not been marked as seen", step.URN())
@@ -354,3 +372,25 @@ func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 func isDefaultProviderStep(step deploy.Step) bool {
 	return providers.IsDefaultProvider(step.URN())
 }
+
+// languageRuntimeHost wraps a plugin host so that the program is run by a specific language runtime, rather than by
+// the language plugin named in the project.
+type languageRuntimeHost struct {
+	plugin.Host
+	runtime plugin.LanguageRuntime
+}
+
+func (host *languageRuntimeHost) LanguageRuntime(runtime string) (plugin.LanguageRuntime, error) {
+	return host.runtime, nil
+}
+
+func (host *languageRuntimeHost) GetRequiredPlugins(info plugin.ProgInfo,
+	kinds plugin.Flags) ([]workspace.PluginInfo, error) {
+
+	// The language runtime is supplied directly, so there is no language plugin to require; just ask the runtime for
+	// the set of resource plugins the program needs.
+	if kinds&plugin.ResourcePlugins == 0 {
+		return nil, nil
+	}
+	return host.runtime.GetRequiredPlugins(info)
+}
@@ -19,8 +19,10 @@ import (
 	"sort"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
@@ -120,8 +122,119 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 	return set, nil
 }
 
+// checkServedProviders returns an error if any of the given resources in the target's snapshot, or any resource if
+// none are given, is managed by a dynamic provider that was served by the program rather than by a plugin.  Such a
+// provider can only be reached while the program is running and serving it, so operations that act on these resources
+// without running the program fail up front rather than partway through.
+func checkServedProviders(target *deploy.Target, urns []resource.URN, op, advice string) error {
+	if target == nil || target.Snapshot == nil {
+		return nil
+	}
+
+	only := make(map[resource.URN]bool)
+	for _, urn := range urns {
+		only[urn] = true
+	}
+
+	served := make(map[string]bool)
+	var managed []resource.URN
+	for _, res := range target.Snapshot.Resources {
+		if providers.IsProviderType(res.URN.Type()) {
+			if endpoint, err := providers.GetProviderEndpoint(res.Inputs); err == nil && endpoint != "" {
+				ref, err := providers.NewReference(res.URN, res.ID)
+				if err != nil {
+					return err
+				}
+				served[ref.String()] = true
+			}
+			continue
+		}
+		if served[res.Provider] && (len(only) == 0 || only[res.URN]) {
+			managed = append(managed, res.URN)
+		}
+	}
+	if len(managed) == 0 {
+		return nil
+	}
+
+	return errors.Errorf("cannot %s %d resource(s), including '%v', that are managed by dynamic providers served by "+
+		"the program, because the program is not running; %s", op, len(managed), managed[0], advice)
+}
+
+// loadPluginLocks loads the lock file of the project containing the given directory.  If there is no such project, a
+// nil lock file is returned.
+func loadPluginLocks(pwd string) (*workspace.LockFile, string, error) {
+	if pwd == "" {
+		return nil, "", nil
+	}
+	projPath, err := workspace.DetectProjectPathFrom(pwd)
+	if err != nil || projPath == "" {
+		return nil, "", err
+	}
+	path := workspace.LockFilePath(projPath)
+	lock, err := workspace.LoadLockFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return lock, path, nil
+}
+
+// applyPluginLocks fills in the checksums, and servers, of the plugins in the given set that are recorded in the lock
+// file of the project containing the given directory, if there is one.
+func applyPluginLocks(pwd string, plugins pluginSet) error {
+	lock, _, err := loadPluginLocks(pwd)
+	if err != nil || lock == nil {
+		return err
+	}
+	for key, plug := range plugins {
+		plugins[key] = lock.Apply(plug)
+	}
+	return nil
+}
+
+// recordPluginLocks records the plugins in the given set that are installed, but whose executables have no checksums
+// recorded for this platform, in the lock file of the project containing the given directory, if there is one.
+// Language plugins and attached providers are not installed from tarballs, so they are not recorded.
+func recordPluginLocks(pwd string, plugctx *plugin.Context, plugins pluginSet) error {
+	lock, path, err := loadPluginLocks(pwd)
+	if err != nil || lock == nil {
+		return err
+	}
+
+	changed := false
+	for _, plug := range plugins.Values() {
+		if plug.Kind == workspace.LanguagePlugin || plug.Version == nil || plug.ExecutableChecksum != "" ||
+			!workspace.HasPlugin(plug) {
+			continue
+		}
+		if _, attached := plugctx.DebugProviders[tokens.Package(plug.Name)]; attached &&
+			plug.Kind == workspace.ResourcePlugin {
+			continue
+		}
+
+		checksum, err := workspace.GetPluginChecksum(plug)
+		if err != nil {
+			return err
+		}
+		executable, err := workspace.GetPluginExecutableChecksum(plug)
+		if err != nil {
+			return err
+		}
+		if lock.Record(plug, checksum, executable) {
+			changed = true
+		}
+	}
+	if changed {
+		if err = lock.Save(path); err != nil {
+			return errors.Wrapf(err, "saving %s", path)
+		}
+	}
+	return nil
+}
+
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
-// uses the given backend client to install them. Installations are processed in parallel, though
+// uses the given backend client to install them. Plugins that are installed, but whose executables don't match the
+// checksums recorded in the project's lock file, are reinstalled. Installations are processed in parallel, though
 // ensurePluginsAreInstalled does not return until all installations are completed.
 func ensurePluginsAreInstalled(plugins pluginSet) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
@@ -129,9 +242,14 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 	for _, plug := range plugins.Values() {
 		_, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version)
 		if err == nil && path != "" {
+			if err = workspace.VerifyPluginChecksum(plug); err == nil || plug.ExecutableChecksum == "" {
+				logging.V(preparePluginLog).Infof(
+					"ensurePluginsAreInstalled(): plugin %s %s already installed", plug.Name, plug.Version)
+				continue
+			}
 			logging.V(preparePluginLog).Infof(
-				"ensurePluginsAreInstalled(): plugin %s %s already installed", plug.Name, plug.Version)
-			continue
+				"ensurePluginsAreInstalled(): plugin %s %s does not match its lock, reinstalling: %v",
+				plug.Name, plug.Version, err)
 		}
 
 		// Launch an install task asynchronously and add it to the current error group.
@@ -163,6 +281,15 @@ func installPlugin(plugin workspace.PluginInfo) error {
 		return nil
 	}
 
+	// If the project doesn't record which tarball to expect, use the checksum published by the plugin's server, if any.
+	if plugin.Checksum == "" {
+		checksum, err := plugin.DownloadChecksum()
+		if err != nil {
+			return err
+		}
+		plugin.Checksum = checksum
+	}
+
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
 	stream, size, err := plugin.Download()
@@ -20,6 +20,10 @@ import (
 	"github.com/blang/semver"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/workspace"
@@ -127,3 +131,49 @@ func TestDefaultProvidersSnapshotOverrides(t *testing.T) {
 	assert.NotNil(t, awsVer)
 	assert.Equal(t, "0.17.0", awsVer.String())
 }
+
+func TestLanguageRuntimeHostRequiredPlugins(t *testing.T) {
+	required := workspace.PluginInfo{
+		Name:    "aws",
+		Version: mustMakeVersion("0.17.1"),
+		Kind:    workspace.ResourcePlugin,
+	}
+	host := &languageRuntimeHost{runtime: deploytest.NewLanguageRuntime(nil, required)}
+
+	// The runtime's resource plugins are required whenever resource plugins are asked for, with or without language
+	// plugins, and never otherwise.
+	for _, kinds := range []plugin.Flags{plugin.ResourcePlugins, plugin.AllPlugins} {
+		plugins, err := host.GetRequiredPlugins(plugin.ProgInfo{}, kinds)
+		assert.NoError(t, err)
+		assert.Equal(t, []workspace.PluginInfo{required}, plugins)
+	}
+	for _, kinds := range []plugin.Flags{plugin.LanguagePlugins, plugin.AnalyzerPlugins} {
+		plugins, err := host.GetRequiredPlugins(plugin.ProgInfo{}, kinds)
+		assert.NoError(t, err)
+		assert.Empty(t, plugins)
+	}
+}
+
+func TestCheckServedProviders(t *testing.T) {
+	served := resource.NewURN("stack", "proj", "", "pulumi:providers:pulumi-go", "widgets")
+	aws := resource.NewURN("stack", "proj", "", "pulumi:providers:aws", "default")
+	widget := resource.NewURN("stack", "proj", "", "pulumi-go:dynamic:Widget", "w")
+	bucket := resource.NewURN("stack", "proj", "", "aws:s3/bucket:Bucket", "b")
+	target := &deploy.Target{Snapshot: &deploy.Snapshot{Resources: []*resource.State{
+		{URN: served, ID: "id1", Inputs: resource.PropertyMap{"__endpoint": resource.NewStringProperty("127.0.0.1:1")}},
+		{URN: aws, ID: "id2", Inputs: resource.PropertyMap{}},
+		{URN: widget, Provider: string(served) + "::id1"},
+		{URN: bucket, Provider: string(aws) + "::id2"},
+	}}}
+
+	err := checkServedProviders(target, nil, "destroy", "do something else")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot destroy 1 resource(s), including '"+string(widget)+"'")
+		assert.Contains(t, err.Error(), "do something else")
+	}
+	assert.Error(t, checkServedProviders(target, []resource.URN{widget}, "refresh", ""))
+
+	// Resources that are managed by provider plugins may be acted upon.
+	assert.NoError(t, checkServedProviders(target, []resource.URN{bucket}, "refresh", ""))
+	assert.NoError(t, checkServedProviders(nil, nil, "destroy", ""))
+}
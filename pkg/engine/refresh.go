@@ -57,6 +57,12 @@ func Refresh(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (Resou
 func newRefreshSource(client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
 	target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
 
+	// Refresh does not run the program, so it cannot reach any providers that the program serves.
+	if err := checkServedProviders(target, opts.RefreshTargets, "refresh",
+		"target only resources that are managed by provider plugins"); err != nil {
+		return nil, err
+	}
+
 	// Like Update, we need to gather the set of plugins necessary to refresh everything in the snapshot.
 	// Unlike Update, we don't actually run the user's program so we only need the set of plugins described
 	// in the snapshot.
@@ -16,10 +16,16 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
@@ -39,6 +45,63 @@ type RequiredPolicy interface {
 	Version() string
 	// Install will install the PolicyPack locally, returning the path it was installed to.
 	Install(ctx context.Context) (string, error)
+	// Config returns the PolicyPack's configuration.
+	Config() map[string]*json.RawMessage
+}
+
+// LocalPolicyPack represents a set of local policies to apply during an update.
+type LocalPolicyPack struct {
+	// Path of the local PolicyPack.
+	Path string
+	// Path of the local PolicyPack's JSON configuration file, if any.
+	Config string
+}
+
+// MakeLocalPolicyPacks pairs each path to a local PolicyPack with the path to its configuration file, if any.
+func MakeLocalPolicyPacks(localPaths []string, configPaths []string) []LocalPolicyPack {
+	packs := make([]LocalPolicyPack, len(localPaths))
+	for i, path := range localPaths {
+		var config string
+		if i < len(configPaths) {
+			config = configPaths[i]
+		}
+		packs[i] = LocalPolicyPack{Path: path, Config: config}
+	}
+	return packs
+}
+
+// ConvertLocalPolicyPacksToPaths returns the paths of the given local PolicyPacks.
+func ConvertLocalPolicyPacksToPaths(packs []LocalPolicyPack) []string {
+	paths := make([]string, len(packs))
+	for i, pack := range packs {
+		paths[i] = pack.Path
+	}
+	return paths
+}
+
+// ConvertLocalPolicyPacksToConfigs reads the configuration files of the given local PolicyPacks, returning the
+// configuration of each in the same order.  PolicyPacks without a configuration file have a nil configuration, and if
+// none of them has one, nil is returned.
+func ConvertLocalPolicyPacksToConfigs(packs []LocalPolicyPack) ([]map[string]*json.RawMessage, error) {
+	var configs []map[string]*json.RawMessage
+	for i, pack := range packs {
+		if pack.Config == "" {
+			continue
+		}
+		b, err := ioutil.ReadFile(pack.Config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading policy pack config %s", pack.Config)
+		}
+		var config map[string]*json.RawMessage
+		if err = json.Unmarshal(b, &config); err != nil {
+			return nil, errors.Wrapf(err, "parsing policy pack config %s", pack.Config)
+		}
+		if configs == nil {
+			configs = make([]map[string]*json.RawMessage, len(packs))
+		}
+		configs[i] = config
+	}
+	return configs, nil
 }
 
 // UpdateOptions contains all the settings for customizing how an update (deploy, preview, or destroy) is performed.
@@ -47,8 +110,8 @@ type RequiredPolicy interface {
 // linter.
 // nolint: structcheck
 type UpdateOptions struct {
-	// LocalPolicyPackPaths contains an optional set of paths to policy packs to run as part of this deployment.
-	LocalPolicyPackPaths []string
+	// LocalPolicyPacks contains an optional set of policy packs to run as part of this deployment.
+	LocalPolicyPacks []LocalPolicyPack
 
 	// RequiredPolicies is the set of policies that are required to run as part of the update.
 	RequiredPolicies []RequiredPolicy
@@ -74,6 +137,14 @@ type UpdateOptions struct {
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
+	// true if the engine should restart providers whose plugins exit during the update, and retry the steps without
+	// side effects that failed because they did.
+	RestartCrashedProviders bool
+
+	// an optional language runtime to use in place of the project's language plugin, e.g. to run a program that is
+	// hosted in the same process as the engine.
+	LanguageRuntime plugin.LanguageRuntime
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -155,6 +226,9 @@ func installPlugins(
 	}
 
 	allPlugins := languagePlugins.Union(snapshotPlugins)
+	if err := applyPluginLocks(pwd, allPlugins); err != nil {
+		return nil, nil, err
+	}
 
 	// If there are any plugins that are not available, we can attempt to install them here.
 	//
@@ -170,26 +244,103 @@ func installPlugins(
 	return allPlugins, defaultProviderVersions, nil
 }
 
-func installAndLoadPolicyPlugins(plugctx *plugin.Context, policies []RequiredPolicy) error {
+func installAndLoadPolicyPlugins(plugctx *plugin.Context, policies []RequiredPolicy,
+	localPolicyPacks []LocalPolicyPack) error {
+
+	var allValidationErrors []string
+
+	// Install and load the required policy packs, which are configured by the backend.
 	for _, policy := range policies {
 		policyPath, err := policy.Install(context.Background())
 		if err != nil {
 			return err
 		}
 
-		_, err = plugctx.Host.PolicyAnalyzer(tokens.QName(policy.Name()), policyPath)
+		analyzer, err := plugctx.Host.PolicyAnalyzer(tokens.QName(policy.Name()), policyPath)
+		if err != nil {
+			return err
+		}
+
+		config, err := plugin.ParsePolicyPackConfig(policy.Config())
+		if err != nil {
+			return errors.Wrapf(err, "parsing the configuration of policy pack %q", policy.Name())
+		}
+		validationErrors, err := configureAnalyzer(analyzer, policy.Name(), config)
 		if err != nil {
 			return err
 		}
+		allValidationErrors = append(allValidationErrors, validationErrors...)
 	}
 
+	// Load the local policy packs, which are configured by their JSON configuration files, if any.
+	for _, pack := range localPolicyPacks {
+		abs, err := filepath.Abs(pack.Path)
+		if err != nil {
+			return err
+		}
+
+		analyzer, err := plugctx.Host.PolicyAnalyzer(tokens.QName(abs), pack.Path)
+		if err != nil {
+			return err
+		} else if analyzer == nil {
+			return errors.Errorf("analyzer could not be loaded from path %q", pack.Path)
+		}
+
+		var config map[string]plugin.AnalyzerPolicyConfig
+		if pack.Config != "" {
+			if config, err = plugin.ParsePolicyPackConfigFromFile(pack.Config); err != nil {
+				return err
+			}
+		}
+		validationErrors, err := configureAnalyzer(analyzer, pack.Path, config)
+		if err != nil {
+			return err
+		}
+		allValidationErrors = append(allValidationErrors, validationErrors...)
+	}
+
+	if len(allValidationErrors) > 0 {
+		return errors.Errorf("invalid policy pack configuration:\n  %s", strings.Join(allValidationErrors, "\n  "))
+	}
 	return nil
 }
 
+// configureAnalyzer validates the given configuration against the schemas of the analyzer's policies, and, if it is
+// valid, configures the analyzer.  Any validation errors are returned rather than configuring the analyzer.
+func configureAnalyzer(analyzer plugin.Analyzer, packName string,
+	config map[string]plugin.AnalyzerPolicyConfig) ([]string, error) {
+
+	info, err := analyzer.GetAnalyzerInfo()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the policies of policy pack %q", packName)
+	}
+
+	policyConfig, validationErrors := plugin.ReconcilePolicyPackConfig(info.Policies, config)
+	if len(validationErrors) > 0 {
+		for i, e := range validationErrors {
+			validationErrors[i] = fmt.Sprintf("%s: %s", packName, e)
+		}
+		return validationErrors, nil
+	}
+
+	if err = analyzer.Configure(policyConfig); err != nil {
+		return nil, errors.Wrapf(err, "configuring policy pack %q", packName)
+	}
+	return nil, nil
+}
+
 func newUpdateSource(
 	client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
 	target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
 
+	// A refresh runs before the program does, so it cannot reach any providers that the program serves.
+	if opts.Refresh {
+		if err := checkServedProviders(target, opts.RefreshTargets, "refresh",
+			"run the update without refreshing"); err != nil {
+			return nil, err
+		}
+	}
+
 	//
 	// Step 1: Install and load plugins.
 	//
@@ -208,11 +359,18 @@ func newUpdateSource(
 		return nil, err
 	}
 
+	// Record the plugins that the update resolved in the project's lock file, so that later updates use the same ones.
+	if !dryRun {
+		if err := recordPluginLocks(pwd, plugctx, allPlugins); err != nil {
+			return nil, err
+		}
+	}
+
 	//
 	// Step 2: Install and load policy plugins.
 	//
 
-	if err := installAndLoadPolicyPlugins(plugctx, opts.RequiredPolicies); err != nil {
+	if err := installAndLoadPolicyPlugins(plugctx, opts.RequiredPolicies, opts.LocalPolicyPacks); err != nil {
 		return nil, err
 	}
 
@@ -236,8 +394,8 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 	for _, p := range opts.RequiredPolicies {
 		policies[p.Name()] = p.Version()
 	}
-	for _, path := range opts.LocalPolicyPackPaths {
-		policies[path] = "(local)"
+	for _, pack := range opts.LocalPolicyPacks {
+		policies[pack.Path] = "(local)"
 	}
 
 	var resourceChanges ResourceChanges
@@ -422,3 +580,8 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
+
+func (acts *updateActions) OnPolicyRemediation(urn resource.URN, r plugin.Remediation,
+	before, after resource.PropertyMap) {
+	acts.Opts.Events.policyRemediationEvent(urn, r, before, after)
+}
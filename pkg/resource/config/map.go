@@ -16,6 +16,7 @@ package config
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/pkg/errors"
 )
@@ -47,6 +48,18 @@ func (m Map) HasSecureValue() bool {
 	return false
 }
 
+// SecureKeys returns the keys of the config map's secure (encrypted) values, in sorted order.
+func (m Map) SecureKeys() []Key {
+	var keys []Key
+	for k, v := range m {
+		if v.Secure() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
 func (m Map) MarshalJSON() ([]byte, error) {
 	rawMap := make(map[string]Value, len(m))
 	for k, v := range m {
@@ -41,6 +41,20 @@ func TestMarshalMapJSON(t *testing.T) {
 
 }
 
+func TestSecureKeys(t *testing.T) {
+	m := Map{
+		Key{namespace: "my", name: "plain"}:   NewValue("value"),
+		Key{namespace: "my", name: "secret"}:  NewSecureValue("c2VjcmV0"),
+		Key{namespace: "my", name: "another"}: NewSecureValue("YW5vdGhlcg=="),
+	}
+	assert.Equal(t, []Key{
+		{namespace: "my", name: "another"},
+		{namespace: "my", name: "secret"},
+	}, m.SecureKeys())
+
+	assert.Empty(t, Map{Key{namespace: "my", name: "plain"}: NewValue("value")}.SecureKeys())
+}
+
 func TestMarshalMapYAML(t *testing.T) {
 	m := Map{
 		Key{namespace: "my", name: "testKey"}:        NewValue("testValue"),
@@ -158,6 +158,16 @@ func (p *builtinProvider) Invoke(tok tokens.ModuleMember,
 	return outs, nil, nil
 }
 
+func (p *builtinProvider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+	return nil, errors.Errorf("unrecognized streaming function name: '%v'", tok)
+}
+
+func (p *builtinProvider) Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	return nil, nil, errors.Errorf("unrecognized method name: '%v'", tok)
+}
+
 func (p *builtinProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	// return an error: this should not be called for the builtin provider
 	return workspace.PluginInfo{}, errors.New("the builtin provider does not report plugin info")
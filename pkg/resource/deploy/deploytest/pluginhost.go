@@ -58,6 +58,7 @@ func NewProviderLoaderWithHost(pkg tokens.Package, version semver.Version,
 type pluginHost struct {
 	providerLoaders []*ProviderLoader
 	languageRuntime plugin.LanguageRuntime
+	analyzers       []plugin.Analyzer
 	sink            diag.Sink
 	statusSink      diag.Sink
 
@@ -78,6 +79,15 @@ func NewPluginHost(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRu
 	}
 }
 
+// NewPluginHostWithAnalyzers creates a plugin host whose analyzers are the given analyzers.
+func NewPluginHostWithAnalyzers(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRuntime,
+	analyzers []plugin.Analyzer, providerLoaders ...*ProviderLoader) plugin.Host {
+
+	host := NewPluginHost(sink, statusSink, languageRuntime, providerLoaders...).(*pluginHost)
+	host.analyzers = analyzers
+	return host
+}
+
 func (host *pluginHost) isClosed() bool {
 	host.m.Lock()
 	defer host.m.Unlock()
@@ -123,6 +133,10 @@ func (host *pluginHost) Provider(pkg tokens.Package, version *semver.Version) (p
 	return prov, nil
 }
 
+func (host *pluginHost) ProviderFromAddress(pkg tokens.Package, addr string) (plugin.Provider, error) {
+	return nil, errors.New("unsupported")
+}
+
 func (host *pluginHost) LanguageRuntime(runtime string) (plugin.LanguageRuntime, error) {
 	return host.languageRuntime, nil
 }
@@ -181,9 +195,14 @@ func (host *pluginHost) GetRequiredPlugins(info plugin.ProgInfo,
 }
 
 func (host *pluginHost) PolicyAnalyzer(name tokens.QName, path string) (plugin.Analyzer, error) {
+	for _, analyzer := range host.analyzers {
+		if analyzer.Name() == name {
+			return analyzer, nil
+		}
+	}
 	return nil, errors.New("unsupported")
 }
 
 func (host *pluginHost) ListAnalyzers() []plugin.Analyzer {
-	return nil
+	return host.analyzers
 }
@@ -52,6 +52,10 @@ type Provider struct {
 		inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error)
 	InvokeF func(tok tokens.ModuleMember,
 		inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error)
+	StreamInvokeF func(tok tokens.ModuleMember, inputs resource.PropertyMap,
+		onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error)
+	CallF func(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+		args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error)
 
 	CancelF func() error
 }
@@ -155,3 +159,19 @@ func (prov *Provider) Invoke(tok tokens.ModuleMember,
 	}
 	return prov.InvokeF(tok, args)
 }
+
+func (prov *Provider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+	if prov.StreamInvokeF == nil {
+		return nil, nil
+	}
+	return prov.StreamInvokeF(tok, args, onNext)
+}
+
+func (prov *Provider) Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	if prov.CallF == nil {
+		return resource.PropertyMap{}, nil, nil
+	}
+	return prov.CallF(tok, urn, id, state, args)
+}
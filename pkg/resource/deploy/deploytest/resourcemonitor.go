@@ -17,6 +17,7 @@ package deploytest
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
@@ -193,6 +194,105 @@ func (rm *ResourceMonitor) Invoke(tok tokens.ModuleMember, inputs resource.Prope
 	return outs, nil, nil
 }
 
+func (rm *ResourceMonitor) Call(tok tokens.ModuleMember, urn resource.URN, inputs resource.PropertyMap,
+	argDependencies map[resource.PropertyKey][]resource.URN) (resource.PropertyMap, []resource.URN,
+	[]*pulumirpc.CheckFailure, error) {
+
+	// marshal inputs
+	ins, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// marshal dependencies
+	deps := make(map[string]*pulumirpc.ResourceCallRequest_ArgumentDependencies)
+	for pk, pd := range argDependencies {
+		var urns []string
+		for _, d := range pd {
+			urns = append(urns, string(d))
+		}
+		deps[string(pk)] = &pulumirpc.ResourceCallRequest_ArgumentDependencies{Urns: urns}
+	}
+
+	// submit request
+	resp, err := rm.resmon.Call(context.Background(), &pulumirpc.ResourceCallRequest{
+		Tok:             string(tok),
+		Urn:             string(urn),
+		Args:            ins,
+		ArgDependencies: deps,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// handle failures
+	if len(resp.Failures) != 0 {
+		return nil, nil, resp.Failures, nil
+	}
+
+	// unmarshal outputs, if they are known
+	var returnDependencies []resource.URN
+	for _, d := range resp.ReturnDependencies {
+		returnDependencies = append(returnDependencies, resource.URN(d))
+	}
+	if resp.Return == nil {
+		return nil, returnDependencies, nil, nil
+	}
+	outs, err := plugin.UnmarshalProperties(resp.Return, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return outs, returnDependencies, nil, nil
+}
+
+func (rm *ResourceMonitor) StreamInvoke(tok tokens.ModuleMember, inputs resource.PropertyMap,
+	provider string, version string, onNext func(resource.PropertyMap) error) ([]*pulumirpc.CheckFailure, error) {
+
+	// marshal inputs
+	ins, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// submit request, cancelling it if we stop reading early
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := rm.resmon.StreamInvoke(ctx, &pulumirpc.InvokeRequest{
+		Tok:      string(tok),
+		Provider: provider,
+		Args:     ins,
+		Version:  version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// handle failures
+		if len(resp.Failures) != 0 {
+			return resp.Failures, nil
+		}
+
+		// unmarshal outputs
+		outs, err := plugin.UnmarshalProperties(resp.Return, plugin.MarshalOptions{KeepUnknowns: true})
+		if err != nil {
+			return nil, err
+		}
+		if err = onNext(outs); err != nil {
+			return nil, err
+		}
+	}
+}
+
 func prepareTestTimeout(timeout float64) string {
 	mins := int(timeout) / 60
 
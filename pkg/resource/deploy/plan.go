@@ -56,6 +56,8 @@ type Options struct {
 	UpdateTargets     []resource.URN // Specific resources to update.
 	TrustDependencies bool           // whether or not to trust the resource dependency graph.
 	UseLegacyDiff     bool           // whether or not to use legacy diffing behavior.
+
+	RestartCrashedProviders bool // whether or not to restart crashed providers and retry their reads.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -79,9 +81,10 @@ type StepExecutorEvents interface {
 	OnResourceOutputs(step Step) error
 }
 
-// PolicyEvents is an interface that can be used to hook policy violation events.
+// PolicyEvents is an interface that can be used to hook policy violation and remediation events.
 type PolicyEvents interface {
 	OnPolicyViolation(resource.URN, plugin.AnalyzeDiagnostic)
+	OnPolicyRemediation(urn resource.URN, r plugin.Remediation, before, after resource.PropertyMap)
 }
 
 // Events is an interface that can be used to hook interesting engine/planning events.
@@ -153,10 +156,7 @@ func addDefaultProviders(target *Target, source Source, prev *Snapshot) error {
 				return errors.Errorf("could not fetch configuration for default provider '%v'", pkg)
 			}
 
-			inputs := make(resource.PropertyMap)
-			for k, v := range cfg {
-				inputs[resource.PropertyKey(k.Name())] = resource.NewStringProperty(v)
-			}
+			inputs := cfg.Copy()
 			if version, ok := defaultProviderVersions[pkg]; ok {
 				inputs["version"] = resource.NewStringProperty(version.String())
 			}
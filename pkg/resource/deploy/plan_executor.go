@@ -268,12 +268,9 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 		resourcesSeen := pe.stepGen.resourceStates
 		resources := make([]plugin.AnalyzerResource, 0, len(resourcesSeen))
 		for _, v := range resourcesSeen {
-			resources = append(resources, plugin.AnalyzerResource{
-				Type: v.Type,
-				// Unlike Analyze, AnalyzeStack is called on the final outputs of each resource,
-				// to verify the final stack is in a compliant state.
-				Properties: v.Outputs,
-			})
+			// Unlike Analyze, AnalyzeStack is called on the final outputs of each resource,
+			// to verify the final stack is in a compliant state.
+			resources = append(resources, pe.stepGen.analyzerResource(v, v.Outputs, nil))
 		}
 
 		analyzers := pe.stepGen.plan.ctx.Host.ListAnalyzers()
@@ -16,6 +16,7 @@ package providers
 
 import (
 	"fmt"
+	"net"
 	"sync"
 
 	"github.com/blang/semver"
@@ -49,6 +50,62 @@ func GetProviderVersion(inputs resource.PropertyMap) (*semver.Version, error) {
 	return &sv, nil
 }
 
+// endpointKey is the name of the reserved provider property that holds the address of a provider that is served by
+// the program being run rather than by a plugin.  This property is not part of the provider's configuration.
+const endpointKey resource.PropertyKey = "__endpoint"
+
+// GetProviderEndpoint fetches the address of a served provider from the given property map. If the endpoint property
+// is not present, this function returns the empty string.
+func GetProviderEndpoint(inputs resource.PropertyMap) (string, error) {
+	endpointProp, ok := inputs[endpointKey]
+	if !ok {
+		return "", nil
+	}
+
+	if !endpointProp.IsString() || endpointProp.StringValue() == "" {
+		return "", errors.Errorf("'%s' must be a non-empty string", endpointKey)
+	}
+	return endpointProp.StringValue(), nil
+}
+
+// servedProviderPackage is the only package whose providers may be served by the program being run: that of the Go
+// SDK's dynamic providers.  This must match the Go SDK's definition.
+const servedProviderPackage tokens.Package = "pulumi-go"
+
+// checkProviderEndpoint returns an error unless a provider for the given package may be served at the given address.
+// Only dynamic providers may be served by the program, and only on a loopback address, so that a provider's inputs
+// cannot direct the engine to some other package's provider or to another host.
+func checkProviderEndpoint(pkg tokens.Package, endpoint string) error {
+	if pkg != servedProviderPackage {
+		return errors.Errorf("'%s' may only be set for %v providers", endpointKey, servedProviderPackage)
+	}
+
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return errors.Errorf("'%s' must be a host and port: %v", endpointKey, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return errors.Errorf("'%s' must be a loopback address", endpointKey)
+	}
+	return nil
+}
+
+// removeEndpoint returns the configuration portion of the given provider properties, which excludes the endpoint
+// property if it is present.
+func removeEndpoint(inputs resource.PropertyMap) resource.PropertyMap {
+	if _, ok := inputs[endpointKey]; !ok {
+		return inputs
+	}
+
+	config := resource.PropertyMap{}
+	for k, v := range inputs {
+		if k != endpointKey {
+			config[k] = v
+		}
+	}
+	return config
+}
+
 // Registry manages the lifecylce of provider resources and their plugins and handles the resolution of provider
 // references to loaded plugins.
 //
@@ -65,18 +122,22 @@ type Registry struct {
 	isPreview bool
 	providers map[Reference]plugin.Provider
 	builtins  plugin.Provider
+	served    map[resource.URN]string // the addresses of the providers served by the program, by URN.
 	m         sync.RWMutex
 }
 
 var _ plugin.Provider = (*Registry)(nil)
 
-func loadProvider(pkg tokens.Package, version *semver.Version, host plugin.Host,
+func loadProvider(pkg tokens.Package, version *semver.Version, endpoint string, host plugin.Host,
 	builtins plugin.Provider) (plugin.Provider, error) {
 
 	if builtins != nil && pkg == builtins.Pkg() {
 		return builtins, nil
 	}
 
+	if endpoint != "" {
+		return host.ProviderFromAddress(pkg, endpoint)
+	}
 	return host.Provider(pkg, version)
 }
 
@@ -91,6 +152,7 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool,
 		isPreview: isPreview,
 		providers: make(map[Reference]plugin.Provider),
 		builtins:  builtins,
+		served:    make(map[resource.URN]string),
 	}
 
 	for _, res := range prev {
@@ -118,7 +180,23 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool,
 		if err != nil {
 			return nil, errors.Errorf("could not parse version for %v provider '%v': %v", providerPkg, urn, err)
 		}
-		provider, err := loadProvider(providerPkg, version, host, builtins)
+
+		// A provider that was served by a program is only reachable once the current program serves it again, so
+		// defer connecting to it until it is actually used.
+		endpoint, err := GetProviderEndpoint(res.Inputs)
+		if err != nil {
+			return nil, errors.Errorf("could not parse endpoint for %v provider '%v': %v", providerPkg, urn, err)
+		}
+		if endpoint != "" {
+			if err = checkProviderEndpoint(providerPkg, endpoint); err != nil {
+				return nil, errors.Errorf("invalid endpoint for %v provider '%v': %v", providerPkg, urn, err)
+			}
+			logging.V(7).Infof("deferred loading of served provider %v", ref)
+			r.providers[ref] = newServedProvider(r, urn, providerPkg, removeEndpoint(res.Inputs))
+			continue
+		}
+
+		provider, err := loadProvider(providerPkg, version, "", host, builtins)
 		if err != nil {
 			return nil, errors.Errorf("could not load plugin for %v provider '%v': %v", providerPkg, urn, err)
 		}
@@ -158,6 +236,40 @@ func (r *Registry) setProvider(ref Reference, provider plugin.Provider) {
 	r.providers[ref] = provider
 }
 
+// setServedEndpoint records the address at which the program serves the provider with the given URN.
+func (r *Registry) setServedEndpoint(urn resource.URN, endpoint string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	logging.V(7).Infof("setServedEndpoint(%v, %v)", urn, endpoint)
+
+	r.served[urn] = endpoint
+}
+
+// servedEndpoint returns the address at which the program serves the provider with the given URN.  If the program does
+// not serve that provider but serves exactly one provider for the same package, the address of that provider is
+// returned instead.  If the program serves no such provider, an error is returned.
+func (r *Registry) servedEndpoint(urn resource.URN, pkg tokens.Package) (string, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	if endpoint, ok := r.served[urn]; ok {
+		return endpoint, nil
+	}
+
+	var endpoints []string
+	for served, endpoint := range r.served {
+		if GetProviderPackage(served.Type()) == pkg {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 1 {
+		return endpoints[0], nil
+	}
+	return "", errors.Errorf("the %v provider '%v' is served by the program that registered it, which is not serving "+
+		"it now; run an update whose program registers this provider to manage its resources", pkg, urn)
+}
+
 func (r *Registry) deleteProvider(ref Reference) (plugin.Provider, bool) {
 	r.m.Lock()
 	defer r.m.Unlock()
@@ -225,7 +337,15 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	if err != nil {
 		return nil, []plugin.CheckFailure{{Property: "version", Reason: err.Error()}}, nil
 	}
-	provider, err := loadProvider(GetProviderPackage(urn.Type()), version, r.host, r.builtins)
+	pkg := GetProviderPackage(urn.Type())
+	endpoint, err := GetProviderEndpoint(news)
+	if err == nil && endpoint != "" {
+		err = checkProviderEndpoint(pkg, endpoint)
+	}
+	if err != nil {
+		return nil, []plugin.CheckFailure{{Property: endpointKey, Reason: err.Error()}}, nil
+	}
+	provider, err := loadProvider(pkg, version, endpoint, r.host, r.builtins)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -234,7 +354,7 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	}
 
 	// Check the provider's config. If the check fails, unload the provider.
-	inputs, failures, err := provider.CheckConfig(urn, olds, news, allowUnknowns)
+	inputs, failures, err := provider.CheckConfig(urn, removeEndpoint(olds), removeEndpoint(news), allowUnknowns)
 	if len(failures) != 0 || err != nil {
 		closeErr := r.host.CloseProvider(provider)
 		contract.IgnoreError(closeErr)
@@ -254,6 +374,18 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	// Create a provider reference using the URN and the unknown ID and register the provider.
 	r.setProvider(mustNewReference(urn, UnknownID), provider)
 
+	// Record the endpoint of a served provider, both so that any provider from the prior state with the same URN can
+	// reach it and alongside its checked configuration.
+	if endpoint != "" {
+		r.setServedEndpoint(urn, endpoint)
+
+		withEndpoint := resource.PropertyMap{endpointKey: news[endpointKey]}
+		for k, v := range inputs {
+			withEndpoint[k] = v
+		}
+		inputs = withEndpoint
+	}
+
 	return inputs, nil, nil
 }
 
@@ -276,7 +408,7 @@ func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.Pr
 		provider, ok = r.GetProvider(mustNewReference(urn, id))
 		contract.Assertf(ok, "Provider must have been registered by NewRegistry for DBR Diff (%v::%v)", urn, id)
 
-		diff, err := provider.DiffConfig(urn, olds, news, allowUnknowns, ignoreChanges)
+		diff, err := provider.DiffConfig(urn, removeEndpoint(olds), removeEndpoint(news), allowUnknowns, ignoreChanges)
 		if err != nil {
 			return plugin.DiffResult{Changes: plugin.DiffUnknown}, err
 		}
@@ -284,7 +416,7 @@ func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.Pr
 	}
 
 	// Diff the properties.
-	diff, err := provider.DiffConfig(urn, olds, news, allowUnknowns, ignoreChanges)
+	diff, err := provider.DiffConfig(urn, removeEndpoint(olds), removeEndpoint(news), allowUnknowns, ignoreChanges)
 	if err != nil {
 		return plugin.DiffResult{Changes: plugin.DiffUnknown}, err
 	}
@@ -293,11 +425,26 @@ func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.Pr
 	//
 	// If the diff does not require replacement and we are running a preview, register it under its current ID so that
 	// references to the provider from other resources will resolve properly.
+	//
+	// A served provider from the prior state may no longer be reachable, so if the diff does not require replacement
+	// we also configure the newly-served provider now and register it in place of the old one, even if its
+	// configuration has not changed.
 	if len(diff.ReplaceKeys) != 0 {
 		closeErr := r.host.CloseProvider(provider)
 		contract.IgnoreError(closeErr)
 	} else if r.isPreview {
 		r.setProvider(mustNewReference(urn, id), provider)
+	} else if _, served := news[endpointKey]; served {
+		if err := provider.Configure(removeEndpoint(news)); err != nil {
+			return plugin.DiffResult{Changes: plugin.DiffUnknown}, err
+		}
+
+		ref := mustNewReference(urn, id)
+		if old, ok := r.GetProvider(ref); ok {
+			closeErr := r.host.CloseProvider(old)
+			contract.IgnoreError(closeErr)
+		}
+		r.setProvider(ref, provider)
 	}
 
 	return diff, nil
@@ -319,7 +466,7 @@ func (r *Registry) Create(urn resource.URN,
 	provider, ok := r.GetProvider(mustNewReference(urn, UnknownID))
 	contract.Assertf(ok, "'Check' must be called before 'Create' (%v)", urn)
 
-	if err := provider.Configure(news); err != nil {
+	if err := provider.Configure(removeEndpoint(news)); err != nil {
 		return "", nil, resource.StatusOK, err
 	}
 
@@ -346,7 +493,7 @@ func (r *Registry) Update(urn resource.URN, id resource.ID, olds, news resource.
 	provider, ok := r.GetProvider(mustNewReference(urn, UnknownID))
 	contract.Assertf(ok, "'Check' and 'Diff' must be called before 'Update' (%v)", urn)
 
-	if err := provider.Configure(news); err != nil {
+	if err := provider.Configure(removeEndpoint(news)); err != nil {
 		return nil, resource.StatusUnknown, err
 	}
 
@@ -384,6 +531,22 @@ func (r *Registry) Invoke(tok tokens.ModuleMember,
 	return nil, nil, errors.New("the provider registry is not invokable")
 }
 
+func (r *Registry) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+
+	// It is the responsibility of the eval source to ensure that we never attempt an invoke using the provider
+	// registry.
+	contract.Fail()
+	return nil, errors.New("the provider registry is not invokable")
+}
+
+func (r *Registry) Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	// Methods are called using the provider of the resource in question, which is never the provider registry.
+	return nil, nil, errors.New("provider resources have no methods")
+}
+
 func (r *Registry) GetPluginInfo() (workspace.PluginInfo, error) {
 	// return an error: this should not be called for the provider registry
 	return workspace.PluginInfo{}, errors.New("the provider registry does not report plugin info")
@@ -29,9 +29,10 @@ import (
 )
 
 type testPluginHost struct {
-	t             *testing.T
-	provider      func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error)
-	closeProvider func(provider plugin.Provider) error
+	t                   *testing.T
+	provider            func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error)
+	providerFromAddress func(pkg tokens.Package, addr string) (plugin.Provider, error)
+	closeProvider       func(provider plugin.Provider) error
 }
 
 func (host *testPluginHost) SignalCancellation() error {
@@ -62,6 +63,12 @@ func (host *testPluginHost) ListAnalyzers() []plugin.Analyzer {
 func (host *testPluginHost) Provider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
 	return host.provider(pkg, version)
 }
+func (host *testPluginHost) ProviderFromAddress(pkg tokens.Package, addr string) (plugin.Provider, error) {
+	if host.providerFromAddress == nil {
+		return nil, errors.New("unsupported")
+	}
+	return host.providerFromAddress(pkg, addr)
+}
 func (host *testPluginHost) CloseProvider(provider plugin.Provider) error {
 	return host.closeProvider(provider)
 }
@@ -142,6 +149,14 @@ func (prov *testProvider) Invoke(tok tokens.ModuleMember,
 	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
 	return nil, nil, errors.New("unsupported")
 }
+func (prov *testProvider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+	return nil, errors.New("unsupported")
+}
+func (prov *testProvider) Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID,
+	state, args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	return nil, nil, errors.New("unsupported")
+}
 func (prov *testProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{
 		Name:    "testProvider",
@@ -748,3 +763,145 @@ func TestCRUDBadVersion(t *testing.T) {
 	assert.Equal(t, "version", string(failures[0].Property))
 	assert.Nil(t, inputs)
 }
+
+func TestServedProvider(t *testing.T) {
+	endpoint := resource.NewStringProperty("127.0.0.1:1234")
+	olds := []*resource.State{
+		newProviderState("pulumi-go", "a", "id1", false, resource.PropertyMap{
+			"__endpoint": resource.NewStringProperty("127.0.0.1:1"),
+		}),
+	}
+
+	var connected []string
+	host := newPluginHost(t, nil).(*testPluginHost)
+	host.providerFromAddress = func(pkg tokens.Package, addr string) (plugin.Provider, error) {
+		connected = append(connected, addr)
+		return &testProvider{
+			pkg: pkg,
+			checkConfig: func(urn resource.URN, olds,
+				news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+				assert.NotContains(t, news, resource.PropertyKey("__endpoint"))
+				return news, nil, nil
+			},
+			diffConfig: func(urn resource.URN, olds, news resource.PropertyMap,
+				allowUnknowns bool, ignoreChanges []string) (plugin.DiffResult, error) {
+				assert.NotContains(t, olds, resource.PropertyKey("__endpoint"))
+				assert.NotContains(t, news, resource.PropertyKey("__endpoint"))
+				return plugin.DiffResult{}, nil
+			},
+			config: func(inputs resource.PropertyMap) error {
+				assert.NotContains(t, inputs, resource.PropertyKey("__endpoint"))
+				return nil
+			},
+		}, nil
+	}
+
+	// The provider from the old state must not be connected to until it is used.
+	r, err := NewRegistry(host, olds, false, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, connected)
+
+	urn, id := olds[0].URN, olds[0].ID
+	old, ok := r.GetProvider(Reference{urn: urn, id: id})
+	assert.True(t, ok)
+	assert.Equal(t, tokens.Package("pulumi-go"), old.Pkg())
+
+	// Check connects to the provider at its new address, and records that address in its inputs.
+	news := resource.PropertyMap{"__endpoint": endpoint}
+	inputs, failures, err := r.Check(urn, olds[0].Inputs, news, false)
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Equal(t, news, inputs)
+	assert.Equal(t, []string{"127.0.0.1:1234"}, connected)
+
+	// Even though the provider's configuration is unchanged, the newly-served provider replaces the old one.
+	diff, err := r.Diff(urn, id, olds[0].Inputs, inputs, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, plugin.DiffResult{}, diff)
+
+	p, ok := r.GetProvider(Reference{urn: urn, id: id})
+	assert.True(t, ok)
+	assert.True(t, p.(*testProvider).configured)
+	assert.Equal(t, []string{"127.0.0.1:1234"}, connected)
+
+	// An old served provider is never connected to at its last known address: until the program serves it, it is
+	// unreachable.
+	r, err = NewRegistry(host, olds, false, nil)
+	assert.NoError(t, err)
+	old, ok = r.GetProvider(Reference{urn: urn, id: id})
+	assert.True(t, ok)
+	_, err = old.GetPluginInfo()
+	assert.Error(t, err)
+	assert.Equal(t, []string{"127.0.0.1:1234"}, connected)
+
+	// Once the program serves the provider, the old provider connects to it at its new address, with its old
+	// configuration.
+	_, _, err = r.Check(urn, olds[0].Inputs, resource.PropertyMap{"__endpoint": endpoint}, false)
+	assert.NoError(t, err)
+	_, err = old.GetPluginInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:1234", "127.0.0.1:1234", "127.0.0.1:1234"}, connected)
+
+	// If the program serves the package's provider under a different name, the old provider connects to that one.
+	r, err = NewRegistry(host, olds, false, nil)
+	assert.NoError(t, err)
+	old, ok = r.GetProvider(Reference{urn: urn, id: id})
+	assert.True(t, ok)
+	renamed := resource.NewURN(urn.Stack(), urn.Project(), "", urn.Type(), "b")
+	_, _, err = r.Check(renamed, nil, resource.PropertyMap{"__endpoint": resource.NewStringProperty("127.0.0.1:5678")},
+		false)
+	assert.NoError(t, err)
+	_, err = old.GetPluginInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:5678", connected[len(connected)-1])
+	assert.NotContains(t, connected, "127.0.0.1:1")
+}
+
+func TestServedProviderWrongPackage(t *testing.T) {
+	host := newPluginHost(t, nil).(*testPluginHost)
+	host.providerFromAddress = func(pkg tokens.Package, addr string) (plugin.Provider, error) {
+		assert.Fail(t, "unexpected connection to %v at %v", pkg, addr)
+		return nil, errors.New("unexpected connection")
+	}
+	endpoint := resource.PropertyMap{"__endpoint": resource.NewStringProperty("127.0.0.1:1234")}
+
+	// Only dynamic providers may be served by the program.
+	r, err := NewRegistry(host, nil, false, nil)
+	assert.NoError(t, err)
+	urn := resource.NewURN("test", "test", "", MakeProviderType("aws"), "a")
+	inputs, failures, err := r.Check(urn, nil, endpoint, false)
+	assert.NoError(t, err)
+	assert.Nil(t, inputs)
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "__endpoint", string(failures[0].Property))
+	}
+
+	_, err = NewRegistry(host, []*resource.State{newProviderState("aws", "a", "id1", false, endpoint)}, false, nil)
+	assert.Error(t, err)
+}
+
+func TestServedProviderNotLoopback(t *testing.T) {
+	host := newPluginHost(t, nil).(*testPluginHost)
+	host.providerFromAddress = func(pkg tokens.Package, addr string) (plugin.Provider, error) {
+		assert.Fail(t, "unexpected connection to %v at %v", pkg, addr)
+		return nil, errors.New("unexpected connection")
+	}
+
+	// Served providers must be reached at a loopback address.
+	r, err := NewRegistry(host, nil, false, nil)
+	assert.NoError(t, err)
+	urn := resource.NewURN("test", "test", "", MakeProviderType("pulumi-go"), "a")
+	for _, addr := range []string{"10.0.0.1:1234", "example.com:1234", "127.0.0.1"} {
+		endpoint := resource.PropertyMap{"__endpoint": resource.NewStringProperty(addr)}
+		inputs, failures, err := r.Check(urn, nil, endpoint, false)
+		assert.NoError(t, err)
+		assert.Nil(t, inputs)
+		if assert.Len(t, failures, 1) {
+			assert.Equal(t, "__endpoint", string(failures[0].Property))
+		}
+	}
+
+	endpoint := resource.PropertyMap{"__endpoint": resource.NewStringProperty("10.0.0.1:1234")}
+	_, err = NewRegistry(host, []*resource.State{newProviderState("pulumi-go", "a", "id1", false, endpoint)}, false, nil)
+	assert.Error(t, err)
+}
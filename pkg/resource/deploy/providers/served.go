@@ -0,0 +1,229 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// servedProvider stands in for a provider from the prior state that was served by the program that registered it,
+// rather than by a plugin.  The address at which the provider was last served is never reused: the program that served
+// it has since exited, and another process may now be listening there.  Instead, the provider is not connected to until
+// one of its methods is called.  At that point, it is connected to at the address at which the current program serves
+// the same provider, and configured with its prior configuration.
+type servedProvider struct {
+	registry *Registry
+	urn      resource.URN
+	pkg      tokens.Package
+	config   resource.PropertyMap
+
+	m        sync.Mutex      // a lock protecting the fields below.
+	provider plugin.Provider // the underlying provider, once connected.
+	err      error           // the error encountered while connecting, if any.
+}
+
+var _ plugin.Provider = (*servedProvider)(nil)
+
+func newServedProvider(registry *Registry, urn resource.URN, pkg tokens.Package,
+	config resource.PropertyMap) *servedProvider {
+
+	return &servedProvider{
+		registry: registry,
+		urn:      urn,
+		pkg:      pkg,
+		config:   config,
+	}
+}
+
+// load connects to and configures the served provider, if that has not already been done.
+func (p *servedProvider) load() (plugin.Provider, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.provider != nil || p.err != nil {
+		return p.provider, p.err
+	}
+
+	// If the current program does not serve the provider, it may yet do so, so this error is not remembered.
+	endpoint, err := p.registry.servedEndpoint(p.urn, p.pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	host := p.registry.host
+	provider, err := host.ProviderFromAddress(p.pkg, endpoint)
+	if err != nil {
+		p.err = errors.Wrapf(err, "could not connect to the %v provider at %v", p.pkg, endpoint)
+		return nil, p.err
+	}
+	if err = provider.Configure(p.config); err != nil {
+		closeErr := host.CloseProvider(provider)
+		contract.IgnoreError(closeErr)
+		p.err = errors.Wrapf(err, "could not configure the %v provider at %v", p.pkg, endpoint)
+		return nil, p.err
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+// loaded returns the underlying provider if it has already been connected to, and nil otherwise.
+func (p *servedProvider) loaded() plugin.Provider {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.provider
+}
+
+// Close closes the underlying provider, if it was connected to.  Note that this is called by the host as part of
+// closing the placeholder itself, so it must not call back into the host.
+func (p *servedProvider) Close() error {
+	if provider := p.loaded(); provider != nil {
+		return provider.Close()
+	}
+	return nil
+}
+
+func (p *servedProvider) Pkg() tokens.Package {
+	return p.pkg
+}
+
+func (p *servedProvider) CheckConfig(urn resource.URN, olds,
+	news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	provider, err := p.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider.CheckConfig(urn, olds, news, allowUnknowns)
+}
+
+func (p *servedProvider) DiffConfig(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool, ignoreChanges []string) (plugin.DiffResult, error) {
+	provider, err := p.load()
+	if err != nil {
+		return plugin.DiffResult{Changes: plugin.DiffUnknown}, err
+	}
+	return provider.DiffConfig(urn, olds, news, allowUnknowns, ignoreChanges)
+}
+
+func (p *servedProvider) Configure(inputs resource.PropertyMap) error {
+	provider, err := p.load()
+	if err != nil {
+		return err
+	}
+	return provider.Configure(inputs)
+}
+
+func (p *servedProvider) Check(urn resource.URN, olds, news resource.PropertyMap,
+	allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	provider, err := p.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider.Check(urn, olds, news, allowUnknowns)
+}
+
+func (p *servedProvider) Diff(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+	news resource.PropertyMap, allowUnknowns bool, ignoreChanges []string) (plugin.DiffResult, error) {
+	provider, err := p.load()
+	if err != nil {
+		return plugin.DiffResult{Changes: plugin.DiffUnknown}, err
+	}
+	return provider.Diff(urn, id, olds, news, allowUnknowns, ignoreChanges)
+}
+
+func (p *servedProvider) Create(urn resource.URN, news resource.PropertyMap,
+	timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+	provider, err := p.load()
+	if err != nil {
+		return "", nil, resource.StatusOK, err
+	}
+	return provider.Create(urn, news, timeout)
+}
+
+func (p *servedProvider) Read(urn resource.URN, id resource.ID,
+	inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+	provider, err := p.load()
+	if err != nil {
+		return plugin.ReadResult{}, resource.StatusUnknown, err
+	}
+	return provider.Read(urn, id, inputs, state)
+}
+
+func (p *servedProvider) Update(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+	news resource.PropertyMap, timeout float64, ignoreChanges []string) (resource.PropertyMap, resource.Status, error) {
+	provider, err := p.load()
+	if err != nil {
+		return nil, resource.StatusUnknown, err
+	}
+	return provider.Update(urn, id, olds, news, timeout, ignoreChanges)
+}
+
+func (p *servedProvider) Delete(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	timeout float64) (resource.Status, error) {
+	provider, err := p.load()
+	if err != nil {
+		return resource.StatusUnknown, err
+	}
+	return provider.Delete(urn, id, props, timeout)
+}
+
+func (p *servedProvider) Invoke(tok tokens.ModuleMember,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	provider, err := p.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider.Invoke(tok, args)
+}
+
+func (p *servedProvider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]plugin.CheckFailure, error) {
+	provider, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	return provider.StreamInvoke(tok, args, onNext)
+}
+
+func (p *servedProvider) Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+	provider, err := p.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider.Call(tok, urn, id, state, args)
+}
+
+func (p *servedProvider) GetPluginInfo() (workspace.PluginInfo, error) {
+	provider, err := p.load()
+	if err != nil {
+		return workspace.PluginInfo{}, err
+	}
+	return provider.GetPluginInfo()
+}
+
+func (p *servedProvider) SignalCancellation() error {
+	if provider := p.loaded(); provider != nil {
+		return provider.SignalCancellation()
+	}
+	return nil
+}
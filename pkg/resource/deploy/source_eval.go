@@ -17,10 +17,13 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -203,6 +206,7 @@ func (iter *evalSourceIterator) forkRun(opts Options) {
 				Program:        iter.src.runinfo.Program,
 				Args:           iter.src.runinfo.Args,
 				Config:         config,
+				ConfigSecrets:  iter.src.runinfo.Target.Config.SecureKeys(),
 				DryRun:         iter.src.dryRun,
 				Parallel:       opts.Parallel,
 			})
@@ -265,10 +269,7 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 	}
 
 	// Create the inputs for the provider resource.
-	inputs := make(resource.PropertyMap)
-	for k, v := range cfg {
-		inputs[resource.PropertyKey(k.Name())] = resource.NewStringProperty(v)
-	}
+	inputs := cfg.Copy()
 
 	// Request that the engine instantiate a specific version of this provider, if one was requested. We'll figure out
 	// what version to request by:
@@ -396,6 +397,8 @@ type resmon struct {
 	regChan          chan *registerResourceEvent        // the channel to send resource registrations to.
 	regOutChan       chan *registerResourceOutputsEvent // the channel to send resource output registrations to.
 	regReadChan      chan *readResourceEvent            // the channel to send resource reads to.
+	resources        map[resource.URN]*resource.State   // the resources registered or read by the program, by URN.
+	resourcesLock    sync.Mutex                         // a lock protecting resources.
 	addr             string                             // the address the host is listening on.
 	cancel           chan bool                          // a channel that can cancel the server.
 	done             chan error                         // a channel that resolves when the server completes.
@@ -428,6 +431,7 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 		regChan:          regChan,
 		regOutChan:       regOutChan,
 		regReadChan:      regReadChan,
+		resources:        make(map[resource.URN]*resource.State),
 		cancel:           cancel,
 	}
 
@@ -579,6 +583,163 @@ func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pu
 	return &pulumirpc.InvokeResponse{Return: mret, Failures: chkfails}, nil
 }
 
+// StreamInvoke invokes a streaming function in a provider, sending each of its results to the program as it arrives.
+func (rm *resmon) StreamInvoke(
+	req *pulumirpc.InvokeRequest, stream pulumirpc.ResourceMonitor_StreamInvokeServer) error {
+
+	tok := tokens.ModuleMember(req.GetTok())
+	label := fmt.Sprintf("ResourceMonitor.StreamInvoke(%s)", tok)
+
+	providerReq, err := parseProviderRequest(tok.Package(), req.GetVersion())
+	if err != nil {
+		return err
+	}
+	prov, err := getProviderFromSource(rm.providers, rm.defaultProviders, providerReq, req.GetProvider())
+	if err != nil {
+		return err
+	}
+
+	args, err := plugin.UnmarshalProperties(
+		req.GetArgs(), plugin.MarshalOptions{
+			Label:        label,
+			KeepUnknowns: true,
+			KeepSecrets:  true,
+		})
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %v args", tok)
+	}
+
+	// Do the invoke, sending each result as it arrives, and then any failures.
+	logging.V(5).Infof("ResourceMonitor.StreamInvoke received: tok=%v #args=%v", tok, len(args))
+	failures, err := prov.StreamInvoke(tok, args, func(ret resource.PropertyMap) error {
+		mret, err := plugin.MarshalProperties(ret, plugin.MarshalOptions{
+			Label:        label,
+			KeepUnknowns: true,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %v return", tok)
+		}
+		return stream.Send(&pulumirpc.InvokeResponse{Return: mret})
+	})
+	if err != nil {
+		return errors.Wrapf(err, "streaming invocation of %v returned an error", tok)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	var chkfails []*pulumirpc.CheckFailure
+	for _, failure := range failures {
+		chkfails = append(chkfails, &pulumirpc.CheckFailure{
+			Property: string(failure.Property),
+			Reason:   failure.Reason,
+		})
+	}
+	return stream.Send(&pulumirpc.InvokeResponse{Failures: chkfails})
+}
+
+// Call calls a method of a resource that the program has registered or read.  The call is routed to the resource's
+// provider, which is passed the resource's ID and current state.  The result depends on the resource itself and on the
+// resources that any of the arguments depend on.
+func (rm *resmon) Call(ctx context.Context,
+	req *pulumirpc.ResourceCallRequest) (*pulumirpc.ResourceCallResponse, error) {
+
+	tok := tokens.ModuleMember(req.GetTok())
+	urn := resource.URN(req.GetUrn())
+	label := fmt.Sprintf("ResourceMonitor.Call(%s, %s)", tok, urn)
+
+	// Look up the resource whose method is being called, and its provider.
+	state, ok := rm.getResource(urn)
+	if !ok {
+		return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("unknown resource %v", urn))
+	}
+	if !state.Custom || state.Provider == "" {
+		return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("resource %v is not managed by a provider", urn))
+	}
+	ref, err := providers.ParseReference(state.Provider)
+	if err != nil {
+		return nil, errors.Errorf("could not parse provider reference: %v", err)
+	}
+	prov, ok := rm.providers.GetProvider(ref)
+	if !ok {
+		return nil, errors.Errorf("unknown provider '%v'", state.Provider)
+	}
+
+	args, err := plugin.UnmarshalProperties(
+		req.GetArgs(), plugin.MarshalOptions{
+			Label:        label,
+			KeepUnknowns: true,
+			KeepSecrets:  true,
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %v args", tok)
+	}
+
+	// The result depends on the resource and on everything its arguments depend on.
+	deps := map[resource.URN]bool{urn: true}
+	for _, argDeps := range req.GetArgDependencies() {
+		for _, dep := range argDeps.GetUrns() {
+			deps[resource.URN(dep)] = true
+		}
+	}
+	var returnDependencies []string
+	for dep := range deps {
+		returnDependencies = append(returnDependencies, string(dep))
+	}
+	sort.Strings(returnDependencies)
+
+	// If the resource has not been created yet or any of the inputs to the call are unknown, as may happen during
+	// previews, so is the result.
+	if state.ID == "" || state.Outputs.ContainsUnknowns() || args.ContainsUnknowns() {
+		logging.V(5).Infof("ResourceMonitor.Call(%s, %s): inputs are unknown; skipping", tok, urn)
+		return &pulumirpc.ResourceCallResponse{ReturnDependencies: returnDependencies}, nil
+	}
+
+	logging.V(5).Infof("ResourceMonitor.Call received: tok=%v urn=%v #args=%v", tok, urn, len(args))
+	ret, failures, err := prov.Call(tok, urn, state.ID, state.Outputs, args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "call of %v returned an error", tok)
+	}
+	var mret *_struct.Struct
+	if ret != nil {
+		if mret, err = plugin.MarshalProperties(ret, plugin.MarshalOptions{
+			Label:        label,
+			KeepUnknowns: true,
+			KeepSecrets:  true,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal %v return", tok)
+		}
+	}
+	var chkfails []*pulumirpc.CheckFailure
+	for _, failure := range failures {
+		chkfails = append(chkfails, &pulumirpc.CheckFailure{
+			Property: string(failure.Property),
+			Reason:   failure.Reason,
+		})
+	}
+	return &pulumirpc.ResourceCallResponse{
+		Return:             mret,
+		ReturnDependencies: returnDependencies,
+		Failures:           chkfails,
+	}, nil
+}
+
+// recordResource records the state of a resource that the program has registered or read, so that its methods may be
+// called.
+func (rm *resmon) recordResource(state *resource.State) {
+	rm.resourcesLock.Lock()
+	defer rm.resourcesLock.Unlock()
+	rm.resources[state.URN] = state
+}
+
+// getResource returns the state of a resource that the program has registered or read.
+func (rm *resmon) getResource(urn resource.URN) (*resource.State, bool) {
+	rm.resourcesLock.Lock()
+	defer rm.resourcesLock.Unlock()
+	state, ok := rm.resources[urn]
+	return state, ok
+}
+
 // ReadResource reads the current state associated with a resource from its provider plugin.
 func (rm *resmon) ReadResource(ctx context.Context,
 	req *pulumirpc.ReadResourceRequest) (*pulumirpc.ReadResourceResponse, error) {
@@ -653,6 +814,7 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	}
 
 	contract.Assert(result != nil)
+	rm.recordResource(result.State)
 	marshaled, err := plugin.MarshalProperties(result.State.Outputs, plugin.MarshalOptions{
 		Label:        label,
 		KeepUnknowns: true,
@@ -814,6 +976,7 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	}
 
 	state := result.State
+	rm.recordResource(state)
 	stable := result.Stable
 	var stables []string
 	for _, sta := range result.Stables {
@@ -154,12 +154,14 @@ func runLangPlugin(src *querySource) result.Result {
 	defer contract.IgnoreClose(langhost)
 
 	// Decrypt the configuration.
+	var configSecrets []config.Key
 	var config map[config.Key]string
 	if src.runinfo.Target != nil {
 		config, err = src.runinfo.Target.Config.Decrypt(src.runinfo.Target.Decrypter)
 		if err != nil {
 			return result.FromError(err)
 		}
+		configSecrets = src.runinfo.Target.Config.SecureKeys()
 	}
 
 	var name string
@@ -176,6 +178,7 @@ func runLangPlugin(src *querySource) result.Result {
 		Program:        src.runinfo.Program,
 		Args:           src.runinfo.Args,
 		Config:         config,
+		ConfigSecrets:  configSecrets,
 		DryRun:         true,
 		QueryMode:      true,
 		Parallel:       math.MaxInt32,
@@ -341,6 +344,61 @@ func (rm *queryResmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest)
 	return &pulumirpc.InvokeResponse{Return: mret, Failures: chkfails}, nil
 }
 
+// StreamInvoke invokes a streaming function in a provider, sending each of its results to the program as it arrives.
+func (rm *queryResmon) StreamInvoke(
+	req *pulumirpc.InvokeRequest, stream pulumirpc.ResourceMonitor_StreamInvokeServer) error {
+
+	tok := tokens.ModuleMember(req.GetTok())
+	label := fmt.Sprintf("QueryResourceMonitor.StreamInvoke(%s)", tok)
+
+	providerReq, err := parseProviderRequest(tok.Package(), req.GetVersion())
+	if err != nil {
+		return err
+	}
+	prov, err := getProviderFromSource(rm.reg, rm.defaultProviders, providerReq, req.GetProvider())
+	if err != nil {
+		return err
+	}
+
+	args, err := plugin.UnmarshalProperties(
+		req.GetArgs(), plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %v args", tok)
+	}
+
+	// Do the invoke, sending each result as it arrives, and then any failures.
+	logging.V(5).Infof("QueryResourceMonitor.StreamInvoke received: tok=%v #args=%v", tok, len(args))
+	failures, err := prov.StreamInvoke(tok, args, func(ret resource.PropertyMap) error {
+		mret, err := plugin.MarshalProperties(ret, plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal return")
+		}
+		return stream.Send(&pulumirpc.InvokeResponse{Return: mret})
+	})
+	if err != nil {
+		return errors.Wrapf(err, "streaming invocation of %v returned an error", tok)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	var chkfails []*pulumirpc.CheckFailure
+	for _, failure := range failures {
+		chkfails = append(chkfails, &pulumirpc.CheckFailure{
+			Property: string(failure.Property),
+			Reason:   failure.Reason,
+		})
+	}
+	return stream.Send(&pulumirpc.InvokeResponse{Failures: chkfails})
+}
+
+// Call calls a method of a resource.  Queries may not register or read resources, so there are none to call.
+func (rm *queryResmon) Call(ctx context.Context,
+	req *pulumirpc.ResourceCallRequest) (*pulumirpc.ResourceCallResponse, error) {
+
+	return nil, fmt.Errorf("Query mode does not support calling resource methods")
+}
+
 // ReadResource reads the current state associated with a resource from its provider plugin.
 func (rm *queryResmon) ReadResource(ctx context.Context,
 	req *pulumirpc.ReadResourceRequest) (*pulumirpc.ReadResourceResponse, error) {
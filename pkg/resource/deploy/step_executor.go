@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 )
@@ -270,6 +271,28 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
 	status, stepComplete, err := step.Apply(se.preview)
 
+	// If the step failed because its provider's plugin exited, restart the provider, if we have been asked to, so that
+	// it can be used by the steps that follow.  Only steps that have no side effects are retried: a step that creates,
+	// updates or deletes a resource may have completed before the plugin exited, so it is failed without ending its
+	// pending operation, which remains in the snapshot until a refresh or import reconciles it.
+	if err != nil && se.opts.RestartCrashedProviders {
+		if prov, exitErr := crashedProvider(step); exitErr != nil {
+			se.log(workerID, "step %v on %v failed because its provider exited: %v", step.Op(), step.URN(), exitErr)
+			if restartErr := prov.Restart(); restartErr != nil {
+				se.log(workerID, "failed to restart provider for %v: %v", step.URN(), restartErr)
+				err = errors.Wrapf(err, "restarting the provider failed: %v", restartErr)
+			} else if !isRetryableStep(step) {
+				return errors.Errorf("%v during the %v operation, which may have completed; restarted the provider, "+
+					"but left the operation pending so that it can be reconciled by a refresh or an import",
+					exitErr, step.Op())
+			} else {
+				se.plan.Diag().Warningf(diag.RawMessage(step.URN(),
+					fmt.Sprintf("%v; restarted the provider and retrying the %v operation", exitErr, step.Op())))
+				status, stepComplete, err = step.Apply(se.preview)
+			}
+		}
+	}
+
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
 		if step.Logical() && step.New() != nil {
@@ -314,6 +337,31 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	return nil
 }
 
+// isRetryableStep returns true if the given step has no side effects, so that it may be safely retried if its
+// provider's plugin exits while it is being applied.
+func isRetryableStep(step Step) bool {
+	switch step.Op() {
+	case OpRead, OpReadReplacement, OpRefresh, OpImport, OpImportReplacement:
+		return true
+	default:
+		return false
+	}
+}
+
+// crashedProvider returns the provider for the given step and an error describing how its plugin exited, if the
+// provider can be restarted and its plugin has exited.
+func crashedProvider(step Step) (plugin.RestartableProvider, error) {
+	prov, err := getProvider(step)
+	if err != nil {
+		return nil, nil
+	}
+	restartable, ok := prov.(plugin.RestartableProvider)
+	if !ok {
+		return nil, nil
+	}
+	return restartable, restartable.Exited()
+}
+
 // log is a simple logging helper for the step executor.
 func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 	if logging.V(stepExecutorLogLevel) {
@@ -163,8 +163,36 @@ func (sg *stepGenerator) GenerateSteps(
 		}
 	}
 
+	// Produce a new state object that we'll build up as operations are performed.  Ultimately, this is what will
+	// get serialized into the checkpoint file.
+	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", goal.Properties, nil, goal.Parent, goal.Protect,
+		false, goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
+		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts)
+
+	// Load all policy packs into the plugin host.
+	for _, path := range sg.plan.localPolicyPackPaths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, result.FromError(err)
+		}
+
+		var analyzer plugin.Analyzer
+		analyzer, err = sg.plan.ctx.Host.PolicyAnalyzer(tokens.QName(abs), path)
+		if err != nil {
+			return nil, result.FromError(err)
+		} else if analyzer == nil {
+			return nil, result.Errorf("analyzer could not be loaded from path %q", path)
+		}
+	}
+
+	// Give any analyzers the opportunity to remediate the goal state's properties before they are checked and diffed.
+	props, res := sg.remediate(new, goal)
+	if res != nil {
+		return nil, res
+	}
+
 	// Create the desired inputs from the goal state
-	inputs := goal.Properties
+	inputs := props
 	if hasOld {
 		// Set inputs back to their old values (if any) for any "ignored" properties
 		processedInputs, res := processIgnoreChanges(inputs, oldInputs, goal.IgnoreChanges)
@@ -173,12 +201,7 @@ func (sg *stepGenerator) GenerateSteps(
 		}
 		inputs = processedInputs
 	}
-
-	// Produce a new state object that we'll build up as operations are performed.  Ultimately, this is what will
-	// get serialized into the checkpoint file.
-	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
-		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
-		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts)
+	new.Inputs = inputs
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -227,7 +250,7 @@ func (sg *stepGenerator) GenerateSteps(
 		// invalid (they got deleted) so don't consider them. Similarly, if the old resource was External,
 		// don't consider those inputs since Pulumi does not own them.
 		if recreating || wasExternal {
-			inputs, failures, err = prov.Check(urn, nil, goal.Properties, allowUnknowns)
+			inputs, failures, err = prov.Check(urn, nil, props, allowUnknowns)
 		} else {
 			inputs, failures, err = prov.Check(urn, oldInputs, inputs, allowUnknowns)
 		}
@@ -240,29 +263,10 @@ func (sg *stepGenerator) GenerateSteps(
 		new.Inputs = inputs
 	}
 
-	// Load all policy packs into the plugin host.
-	for _, path := range sg.plan.localPolicyPackPaths {
-		abs, err := filepath.Abs(path)
-		if err != nil {
-			return nil, result.FromError(err)
-		}
-
-		var analyzer plugin.Analyzer
-		analyzer, err = sg.plan.ctx.Host.PolicyAnalyzer(tokens.QName(abs), path)
-		if err != nil {
-			return nil, result.FromError(err)
-		} else if analyzer == nil {
-			return nil, result.Errorf("analyzer could not be loaded from path %q", path)
-		}
-	}
-
 	// Send the resource off to any Analyzers before being operated on.
 	analyzers := sg.plan.ctx.Host.ListAnalyzers()
 	for _, analyzer := range analyzers {
-		r := plugin.AnalyzerResource{
-			Type:       new.Type,
-			Properties: inputs,
-		}
+		r := sg.analyzerResource(new, inputs, goal)
 		diagnostics, aErr := analyzer.Analyze(r)
 		if aErr != nil {
 			return nil, result.FromError(aErr)
@@ -1018,6 +1022,72 @@ func (sg *stepGenerator) loadResourceProvider(
 	return p, nil
 }
 
+// remediate gives each analyzer, in turn, the opportunity to transform the properties of the resource with the given
+// state and goal, and returns the resulting properties.  Each remediation is reported to the user.
+func (sg *stepGenerator) remediate(new *resource.State, goal *resource.Goal) (resource.PropertyMap, result.Result) {
+	props := goal.Properties
+	for _, analyzer := range sg.plan.ctx.Host.ListAnalyzers() {
+		remediations, err := analyzer.Remediate(sg.analyzerResource(new, props, goal))
+		if err != nil {
+			return nil, result.FromError(err)
+		}
+		for _, r := range remediations {
+			if r.Properties == nil {
+				continue
+			}
+			if props.Diff(r.Properties) != nil {
+				sg.opts.Events.OnPolicyRemediation(new.URN, r, props, r.Properties)
+			}
+			props = r.Properties
+		}
+	}
+	return props, nil
+}
+
+// analyzerResource returns the view of a resource with the given state and properties that is sent to analyzers.  The
+// resource's goal, if any, supplies those options that are only known when the resource is registered.
+func (sg *stepGenerator) analyzerResource(state *resource.State, props resource.PropertyMap,
+	goal *resource.Goal) plugin.AnalyzerResource {
+
+	r := plugin.AnalyzerResource{
+		URN:        state.URN,
+		Type:       state.Type,
+		Name:       state.URN.Name(),
+		Properties: props,
+		Options: plugin.AnalyzerResourceOptions{
+			Protect:                 state.Protect,
+			AdditionalSecretOutputs: state.AdditionalSecretOutputs,
+			Aliases:                 state.Aliases,
+			CustomTimeouts:          state.CustomTimeouts,
+		},
+		Parent:               state.Parent,
+		Dependencies:         state.Dependencies,
+		PropertyDependencies: state.PropertyDependencies,
+	}
+	if goal != nil {
+		r.Options.IgnoreChanges = goal.IgnoreChanges
+		if goal.DeleteBeforeReplace != nil {
+			r.Options.DeleteBeforeReplace = *goal.DeleteBeforeReplace
+			r.Options.DeleteBeforeReplaceDefined = true
+		}
+	}
+
+	// If the resource's provider has been seen, include its inputs.
+	if state.Provider != "" {
+		if ref, err := providers.ParseReference(state.Provider); err == nil {
+			if provider, ok := sg.providers[ref.URN()]; ok {
+				r.Provider = &plugin.AnalyzerProviderResource{
+					URN:        provider.URN,
+					Type:       provider.Type,
+					Name:       provider.URN.Name(),
+					Properties: provider.Inputs,
+				}
+			}
+		}
+	}
+	return r
+}
+
 type dependentReplace struct {
 	res  *resource.State
 	keys []resource.PropertyKey
@@ -15,6 +15,7 @@
 package deploy
 
 import (
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
 )
@@ -27,9 +28,10 @@ type Target struct {
 	Snapshot  *Snapshot        // the last snapshot deployed to the target.
 }
 
-// GetPackageConfig returns the set of configuration parameters for the indicated package, if any.
-func (t *Target) GetPackageConfig(pkg tokens.Package) (map[config.Key]string, error) {
-	var result map[config.Key]string
+// GetPackageConfig returns the set of configuration parameters for the indicated package, if any.  Each parameter
+// is keyed by its name alone; those that are encrypted are decrypted and marked as secrets.
+func (t *Target) GetPackageConfig(pkg tokens.Package) (resource.PropertyMap, error) {
+	var result resource.PropertyMap
 	if t == nil {
 		return result, nil
 	}
@@ -43,9 +45,13 @@ func (t *Target) GetPackageConfig(pkg tokens.Package) (map[config.Key]string, er
 			return nil, err
 		}
 		if result == nil {
-			result = make(map[config.Key]string)
+			result = make(resource.PropertyMap)
 		}
-		result[k] = v
+		value := resource.NewStringProperty(v)
+		if c.Secure() {
+			value = resource.MakeSecret(value)
+		}
+		result[resource.PropertyKey(k.Name())] = value
 	}
 	return result, nil
 }
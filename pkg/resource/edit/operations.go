@@ -15,6 +15,8 @@
 package edit
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/resource"
@@ -113,47 +115,261 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.QName, newProject tokens.
 		return resource.NewURN(newName, project, "", u.QualifiedType(), u.Name())
 	}
 
-	rewriteState := func(res *resource.State) {
-		contract.Assert(res != nil)
+	if err := snap.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "checkpoint is invalid")
+	}
+
+	rewriteURNs(snap, rewriteUrn)
+	return nil
+}
+
+// RenameResource changes the name of the given resource, and of any other resource that shares its URN, to newName.
+// Every reference to the resource's old URN, whether as a parent, a dependency, a property dependency or a provider, is
+// rewritten to refer to its new URN. It is an error to choose a name that is already taken by a resource of the same
+// type and parent type.
+func RenameResource(snap *deploy.Snapshot, res *resource.State, newName tokens.QName) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+
+	if newName == "" || strings.Contains(string(newName), "::") {
+		return errors.Errorf("invalid resource name %q", newName)
+	}
 
-		res.URN = rewriteUrn(res.URN)
+	oldURN := res.URN
+	newURN := resource.NewURN(oldURN.Stack(), oldURN.Project(), "", oldURN.QualifiedType(), newName)
+	if newURN == oldURN {
+		return nil
+	}
+	if len(LocateResource(snap, newURN)) != 0 {
+		return errors.Errorf("a resource with URN %q already exists", newURN)
+	}
 
-		if res.Parent != "" {
-			res.Parent = rewriteUrn(res.Parent)
+	rewriteURNs(snap, func(u resource.URN) resource.URN {
+		if u == oldURN {
+			return newURN
 		}
+		return u
+	})
+	return nil
+}
 
-		for depIdx, dep := range res.Dependencies {
-			res.Dependencies[depIdx] = rewriteUrn(dep)
+// MoveResources moves the given resources from the source snapshot to the end of the destination snapshot, rewriting
+// their URNs to belong to the given stack and project. The providers of the moved resources are copied along with them
+// unless the destination already holds the same provider, and are removed from the source once no remaining resource
+// refers to them.
+//
+// A set of resources can only be moved if it is self-contained: moved resources may not depend on or descend from
+// resources that stay behind, and resources that stay behind may not depend on or descend from moved resources. The
+// one exception is the source stack's root resource; moved resources that are parented to it are parented to the
+// destination stack's root resource instead, if it has one.
+func MoveResources(source, dest *deploy.Snapshot, resources []*resource.State,
+	destStack tokens.QName, destProject tokens.PackageName) error {
+
+	contract.Require(source != nil, "source")
+	contract.Require(dest != nil, "dest")
+
+	if err := source.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "source checkpoint is invalid")
+	}
+	if err := dest.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "destination checkpoint is invalid")
+	}
+
+	moving := make(map[*resource.State]bool)
+	movingURNs := make(map[resource.URN]bool)
+	for _, res := range resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			return errors.New("the root stack resource cannot be moved")
+		}
+		moving[res], movingURNs[res.URN] = true, true
+	}
+	for _, op := range source.PendingOperations {
+		if movingURNs[op.Resource.URN] {
+			return errors.Errorf("resource %s has a pending %s operation", op.Resource.URN, op.Type)
+		}
+	}
+
+	// Find the providers that must be copied along with the moved resources.
+	copying := make(map[resource.URN]bool)
+	for _, res := range resources {
+		if res.Provider == "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+		if !movingURNs[ref.URN()] {
+			copying[ref.URN()] = true
+		}
+	}
+
+	// Ensure that the moved resources are self-contained.
+	var sourceRoot resource.URN
+	for _, res := range source.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			sourceRoot = res.URN
 		}
 
-		for _, propDeps := range res.PropertyDependencies {
-			for depIdx, dep := range propDeps {
-				propDeps[depIdx] = rewriteUrn(dep)
+		if moving[res] {
+			if res.Parent != "" && res.Parent != sourceRoot && !movingURNs[res.Parent] {
+				return errors.Errorf("resource %s cannot be moved without its parent %s", res.URN, res.Parent)
+			}
+			for _, dep := range dependencyURNs(res) {
+				if !movingURNs[dep] && !copying[dep] {
+					return errors.Errorf("resource %s cannot be moved without its dependency %s", res.URN, dep)
+				}
+			}
+			continue
+		}
+		for _, dep := range referencedURNs(res) {
+			if movingURNs[dep] {
+				return errors.Errorf("resource %s cannot be moved because %s depends on it", dep, res.URN)
 			}
 		}
+	}
 
-		if res.Provider != "" {
-			providerRef, err := providers.ParseReference(res.Provider)
-			contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+	var destRoot resource.URN
+	destURNs := make(map[resource.URN]*resource.State)
+	for _, res := range dest.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			destRoot = res.URN
+		}
+		destURNs[res.URN] = res
+	}
+
+	rewriteUrn := func(u resource.URN) resource.URN {
+		if u == sourceRoot {
+			return destRoot
+		}
+		return resource.NewURN(destStack, destProject, "", u.QualifiedType(), u.Name())
+	}
 
-			providerRef, err = providers.NewReference(rewriteUrn(providerRef.URN()), providerRef.ID())
-			contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+	// Copy the providers and move the resources, in the order in which they appear in the source snapshot so that
+	// providers, parents and dependencies precede the resources that refer to them.
+	var moved, remaining []*resource.State
+	for _, res := range source.Resources {
+		switch {
+		case copying[res.URN]:
+			if existing, has := destURNs[rewriteUrn(res.URN)]; has {
+				if existing.ID != res.ID {
+					return errors.Errorf("the destination already has a different provider named %s",
+						rewriteUrn(res.URN))
+				}
+				continue
+			}
+			moved = append(moved, copyState(res))
+		case moving[res]:
+			if _, has := destURNs[rewriteUrn(res.URN)]; has {
+				return errors.Errorf("the destination already has a resource named %s", rewriteUrn(res.URN))
+			}
+			moved = append(moved, res)
+		}
+	}
+	for _, res := range moved {
+		rewriteStateURNs(res, rewriteUrn)
+	}
 
-			res.Provider = providerRef.String()
+	// Drop the moved resources from the source, along with any copied providers that are no longer used there.
+	used := make(map[resource.URN]bool)
+	for _, res := range source.Resources {
+		if !moving[res] {
+			for _, dep := range referencedURNs(res) {
+				used[dep] = true
+			}
+			remaining = append(remaining, res)
 		}
 	}
+	source.Resources = remaining[:0]
+	for _, res := range remaining {
+		if !copying[res.URN] || used[res.URN] {
+			source.Resources = append(source.Resources, res)
+		}
+	}
+	dest.Resources = append(dest.Resources, moved...)
 
-	if err := snap.VerifyIntegrity(); err != nil {
-		return errors.Wrap(err, "checkpoint is invalid")
+	if err := source.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "moving resources would leave the source checkpoint invalid")
+	}
+	if err := dest.VerifyIntegrity(); err != nil {
+		return errors.Wrap(err, "moving resources would leave the destination checkpoint invalid")
+	}
+	return nil
+}
+
+// referencedURNs returns the URNs of the parent, dependencies, property dependencies and provider of a resource.
+func referencedURNs(res *resource.State) []resource.URN {
+	if res.Parent == "" {
+		return dependencyURNs(res)
 	}
+	return append([]resource.URN{res.Parent}, dependencyURNs(res)...)
+}
 
+// dependencyURNs returns the URNs of the dependencies, property dependencies and provider of a resource.
+func dependencyURNs(res *resource.State) []resource.URN {
+	urns := append([]resource.URN(nil), res.Dependencies...)
+	for _, propDeps := range res.PropertyDependencies {
+		urns = append(urns, propDeps...)
+	}
+	if res.Provider != "" {
+		ref, err := providers.ParseReference(res.Provider)
+		contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+		urns = append(urns, ref.URN())
+	}
+	return urns
+}
+
+// copyState returns a copy of a resource whose URN references may be rewritten without affecting the original.
+func copyState(res *resource.State) *resource.State {
+	copied := *res
+	copied.Dependencies = append([]resource.URN(nil), res.Dependencies...)
+	if res.PropertyDependencies != nil {
+		copied.PropertyDependencies = make(map[resource.PropertyKey][]resource.URN)
+		for k, deps := range res.PropertyDependencies {
+			copied.PropertyDependencies[k] = append([]resource.URN(nil), deps...)
+		}
+	}
+	return &copied
+}
+
+// rewriteURNs applies the given rewrite to the URN of every resource in a snapshot and to each of its references to
+// other resources.
+func rewriteURNs(snap *deploy.Snapshot, rewrite func(resource.URN) resource.URN) {
 	for _, res := range snap.Resources {
-		rewriteState(res)
+		rewriteStateURNs(res, rewrite)
 	}
 
 	for _, ops := range snap.PendingOperations {
-		rewriteState(ops.Resource)
+		rewriteStateURNs(ops.Resource, rewrite)
 	}
+}
 
-	return nil
+// rewriteStateURNs applies the given rewrite to the URN of a resource and to each of its references to other
+// resources.
+func rewriteStateURNs(res *resource.State, rewrite func(resource.URN) resource.URN) {
+	contract.Assert(res != nil)
+
+	res.URN = rewrite(res.URN)
+
+	if res.Parent != "" {
+		res.Parent = rewrite(res.Parent)
+	}
+
+	for depIdx, dep := range res.Dependencies {
+		res.Dependencies[depIdx] = rewrite(dep)
+	}
+
+	for _, propDeps := range res.PropertyDependencies {
+		for depIdx, dep := range propDeps {
+			propDeps[depIdx] = rewrite(dep)
+		}
+	}
+
+	if res.Provider != "" {
+		providerRef, err := providers.ParseReference(res.Provider)
+		contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
+
+		providerRef, err = providers.NewReference(rewrite(providerRef.URN()), providerRef.ID())
+		contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+
+		res.Provider = providerRef.String()
+	}
 }
@@ -321,3 +321,94 @@ func TestRenameStack(t *testing.T) {
 		assert.Len(t, LocateResource(snap, updatedResourceURN), 1)
 	})
 }
+
+func TestRenameResource(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"x": {a.URN}}
+	c := NewResource("c", pA)
+	c.Parent = a.URN
+	snap := NewSnapshot([]*resource.State{
+		pA,
+		a,
+		b,
+		c,
+	})
+
+	// Renaming a resource rewrites the references to it.
+	err := RenameResource(snap, a, "renamed")
+	assert.NoError(t, err)
+	renamed := resource.NewURN("test", "test", "", "a:b:c", "renamed")
+	assert.Equal(t, renamed, a.URN)
+	assert.Equal(t, []resource.URN{renamed}, b.Dependencies)
+	assert.Equal(t, []resource.URN{renamed}, b.PropertyDependencies["x"])
+	assert.Equal(t, renamed, c.Parent)
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// Renaming a provider rewrites the references to it.
+	err = RenameResource(snap, pA, "p2")
+	assert.NoError(t, err)
+	for _, res := range []*resource.State{a, b, c} {
+		ref, err := providers.ParseReference(res.Provider)
+		assert.NoError(t, err)
+		assert.Equal(t, pA.URN, ref.URN())
+	}
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	// Names must be unique and valid.
+	assert.Error(t, RenameResource(snap, b, "c"))
+	assert.Error(t, RenameResource(snap, b, "a::b"))
+	assert.Error(t, RenameResource(snap, b, ""))
+}
+
+func TestMoveResources(t *testing.T) {
+	pA := NewProviderResource("a", "p1", "0")
+	root := NewResource("test-test", nil)
+	root.Type = resource.RootStackType
+	root.URN = resource.NewURN("test", "test", "", resource.RootStackType, "test-test")
+	a := NewResource("a", pA)
+	a.Parent = root.URN
+	b := NewResource("b", pA, a.URN)
+	b.Parent = root.URN
+	c := NewResource("c", pA)
+	source := NewSnapshot([]*resource.State{
+		root,
+		pA,
+		a,
+		b,
+		c,
+	})
+	dest := NewSnapshot(nil)
+
+	// Resources can't be moved without the resources they depend upon, or leave behind resources that depend on them.
+	err := MoveResources(source, dest, []*resource.State{b}, "dest", "proj")
+	assert.EqualError(t, err, "resource "+string(b.URN)+" cannot be moved without its dependency "+string(a.URN))
+	err = MoveResources(source, dest, []*resource.State{a}, "dest", "proj")
+	assert.EqualError(t, err, "resource "+string(a.URN)+" cannot be moved because "+string(b.URN)+" depends on it")
+	assert.Equal(t, []*resource.State{root, pA, a, b, c}, source.Resources)
+	assert.Empty(t, dest.Resources)
+
+	// Moving a and b copies their provider, which is still used by c.
+	err = MoveResources(source, dest, []*resource.State{b, a}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{root, pA, c}, source.Resources)
+	if assert.Len(t, dest.Resources, 3) {
+		assert.Equal(t, resource.NewURN("dest", "proj", "", pA.Type, "p1"), dest.Resources[0].URN)
+		assert.Equal(t, resource.NewURN("dest", "proj", "", "a:b:c", "a"), dest.Resources[1].URN)
+		assert.Equal(t, resource.NewURN("dest", "proj", "", "a:b:c", "b"), dest.Resources[2].URN)
+		assert.Equal(t, []resource.URN{dest.Resources[1].URN}, dest.Resources[2].Dependencies)
+		assert.Empty(t, dest.Resources[1].Parent)
+	}
+	assert.Equal(t, resource.NewURN("test", "test", "", pA.Type, "p1"), pA.URN)
+
+	// Moving c reuses the provider that was copied before, and drops it from the source.
+	err = MoveResources(source, dest, []*resource.State{c}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.State{root}, source.Resources)
+	assert.Len(t, dest.Resources, 4)
+
+	// The root stack resource can't be moved.
+	err = MoveResources(source, dest, []*resource.State{root}, "dest", "proj")
+	assert.EqualError(t, err, "the root stack resource cannot be moved")
+}
@@ -0,0 +1,403 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// Repair describes a single change made to a snapshot by RepairSnapshot.
+type Repair struct {
+	URN         resource.URN // the resource that was changed, if the change pertains to a resource.
+	Description string       // a human-readable description of the change.
+}
+
+func (r Repair) String() string {
+	if r.URN == "" {
+		return r.Description
+	}
+	return fmt.Sprintf("%s: %s", r.URN, r.Description)
+}
+
+// ChooseFunc chooses which of several live resources that share a URN but have different IDs is to be kept. The
+// candidates are given in the order in which they appear in the snapshot.
+type ChooseFunc func(urn resource.URN, candidates []*resource.State) (*resource.State, error)
+
+// RepairSnapshot fixes, in place, the problems in a snapshot that cause VerifyIntegrity to reject it, and returns the
+// list of changes that it made. The repairs are made in the following order:
+//
+//  1. A manifest whose magic cookie does not match its version is given the matching cookie.
+//  2. Pending operations, which prevent further updates, are removed.
+//  3. Where several live resources share a URN, all but the last are removed if they have the same ID as the last.
+//     If they have different IDs, the given function chooses the one to keep, and the others are removed from the
+//     snapshot without deleting the physical resources that they refer to.
+//  4. References to missing providers are re-pointed to a provider with the same URN, or, for default providers, to
+//     another default provider for the same package. References to missing default providers that can't be
+//     re-pointed are cleared, so that the default provider is used again.
+//  5. References to missing parents, dependencies and property dependencies are re-pointed to the resource that
+//     carries the missing URN as an alias, if there is one. Otherwise, dangling parents are re-pointed to the root
+//     stack resource and dangling dependencies are dropped.
+//  6. Resources are reordered so that each follows its provider, its parent and its dependencies.
+//
+// Resources that are pending deletion are always kept, since each refers to a physical resource that has yet to be
+// deleted.
+//
+// RepairSnapshot returns an error if the snapshot has a problem that it can't repair, such as a reference to a missing
+// provider that isn't a default provider, a dependency cycle, or, if choose is nil, live resources that share a URN
+// but have different IDs. The snapshot may have been partially repaired.
+func RepairSnapshot(snap *deploy.Snapshot, choose ChooseFunc) ([]Repair, error) {
+	contract.Require(snap != nil, "snap")
+
+	var repairs []Repair
+	report := func(urn resource.URN, format string, args ...interface{}) {
+		repairs = append(repairs, Repair{URN: urn, Description: fmt.Sprintf(format, args...)})
+	}
+
+	if magic := snap.Manifest.NewMagic(); snap.Manifest.Magic != magic {
+		snap.Manifest.Magic = magic
+		report("", "reset the manifest's magic cookie")
+	}
+
+	clearPendingOperations(snap, report)
+	if err := removeDuplicates(snap, choose, report); err != nil {
+		return repairs, err
+	}
+	if err := repairProviders(snap, report); err != nil {
+		return repairs, err
+	}
+	repairReferences(snap, report)
+	if err := reorderResources(snap, report); err != nil {
+		return repairs, err
+	}
+
+	if err := snap.VerifyIntegrity(); err != nil {
+		return repairs, errors.Wrap(err, "the snapshot could not be repaired")
+	}
+	return repairs, nil
+}
+
+type reportFunc func(urn resource.URN, format string, args ...interface{})
+
+// clearPendingOperations removes the snapshot's pending operations. A resource that was being created or imported may
+// exist without being recorded in the snapshot, so its removal is reported as such.
+func clearPendingOperations(snap *deploy.Snapshot, report reportFunc) {
+	for _, op := range snap.PendingOperations {
+		switch op.Type {
+		case resource.OperationTypeCreating, resource.OperationTypeImporting:
+			report(op.Resource.URN, "removed pending operation '%s'; the resource may exist and, if so, must be "+
+				"imported or deleted by hand", op.Type)
+		default:
+			report(op.Resource.URN, "removed pending operation '%s'", op.Type)
+		}
+	}
+	snap.PendingOperations = nil
+}
+
+// removeDuplicates ensures that at most one resource with each URN is live. Where the live copies of a URN all have
+// the same ID, the last is kept. Otherwise, the given function chooses the copy to keep, and the others are removed
+// from the snapshot without being deleted.
+func removeDuplicates(snap *deploy.Snapshot, choose ChooseFunc, report reportFunc) error {
+	var urns []resource.URN
+	copies := make(map[resource.URN][]*resource.State)
+	for _, res := range snap.Resources {
+		if !res.Delete {
+			if len(copies[res.URN]) == 0 {
+				urns = append(urns, res.URN)
+			}
+			copies[res.URN] = append(copies[res.URN], res)
+		}
+	}
+
+	kept := make(map[resource.URN]*resource.State)
+	var unresolved []string
+	for _, urn := range urns {
+		// Find the last copy of each distinct physical resource.
+		last := make(map[resource.ID]*resource.State)
+		for _, res := range copies[urn] {
+			last[res.ID] = res
+		}
+		var candidates []*resource.State
+		for _, res := range copies[urn] {
+			if last[res.ID] == res {
+				candidates = append(candidates, res)
+			}
+		}
+
+		switch {
+		case len(candidates) == 1:
+			kept[urn] = candidates[0]
+		case choose == nil:
+			var ids []string
+			for _, res := range candidates {
+				ids = append(ids, fmt.Sprintf("%q", res.ID))
+			}
+			unresolved = append(unresolved, fmt.Sprintf("%s: %s", urn, strings.Join(ids, ", ")))
+		default:
+			chosen, err := choose(urn, candidates)
+			if err != nil {
+				return err
+			}
+			if last[chosen.ID] != chosen {
+				return errors.Errorf("the resource chosen to keep for %s is not one of its copies", urn)
+			}
+			kept[urn] = chosen
+		}
+	}
+	if len(unresolved) != 0 {
+		return errors.Errorf("the following resources have several live copies with different IDs, and the copy "+
+			"to keep must be chosen:\n  %s", strings.Join(unresolved, "\n  "))
+	}
+
+	var resources []*resource.State
+	for _, res := range snap.Resources {
+		if keep := kept[res.URN]; !res.Delete && keep != res {
+			if res.ID == keep.ID {
+				report(res.URN, "removed duplicate resource %q", res.ID)
+			} else {
+				report(res.URN, "removed duplicate resource %q, keeping resource %q; the physical resource was "+
+					"not deleted", res.ID, keep.ID)
+			}
+			continue
+		}
+		resources = append(resources, res)
+	}
+	snap.Resources = resources
+	return nil
+}
+
+// repairProviders re-points or clears references to missing providers.
+func repairProviders(snap *deploy.Snapshot, report reportFunc) error {
+	var provs []providers.Reference
+	present := make(map[providers.Reference]bool)
+	for _, res := range snap.Resources {
+		if providers.IsProviderType(res.Type) {
+			if ref, err := providers.NewReference(res.URN, res.ID); err == nil {
+				present[ref] = true
+				if !res.Delete {
+					provs = append(provs, ref)
+				}
+			}
+		}
+	}
+
+	for _, res := range snap.Resources {
+		if res.Provider == "" {
+			continue
+		}
+		ref, err := providers.ParseReference(res.Provider)
+		if err == nil && present[ref] {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "resource %s has an invalid provider reference", res.URN)
+		}
+
+		// Prefer a provider with the same URN, which is likely to be the same provider after it was replaced.
+		// Otherwise, look for another default provider for the same package.
+		var replacement *providers.Reference
+		for i, candidate := range provs {
+			if candidate.URN() == ref.URN() {
+				replacement = &provs[i]
+				break
+			}
+		}
+		if replacement == nil && providers.IsDefaultProvider(ref.URN()) {
+			for i, candidate := range provs {
+				if providers.IsDefaultProvider(candidate.URN()) && candidate.URN().Type() == ref.URN().Type() {
+					replacement = &provs[i]
+					break
+				}
+			}
+		}
+
+		switch {
+		case replacement != nil:
+			res.Provider = replacement.String()
+			report(res.URN, "re-pointed reference to missing provider %s to %s", ref, replacement)
+		case providers.IsDefaultProvider(ref.URN()):
+			res.Provider = ""
+			report(res.URN, "cleared reference to missing default provider %s", ref)
+		default:
+			return errors.Errorf("resource %s refers to missing provider %s, which can't be replaced automatically",
+				res.URN, ref)
+		}
+	}
+	return nil
+}
+
+// repairReferences re-points or drops references to missing parents and dependencies.
+func repairReferences(snap *deploy.Snapshot, report reportFunc) {
+	present := make(map[resource.URN]bool)
+	aliases := make(map[resource.URN]resource.URN)
+	var root resource.URN
+	for _, res := range snap.Resources {
+		present[res.URN] = true
+		for _, alias := range res.Aliases {
+			aliases[alias] = res.URN
+		}
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			root = res.URN
+		}
+	}
+
+	// resolve returns the URN that should replace a reference to a missing resource, if any.
+	resolve := func(res *resource.State, urn resource.URN) (resource.URN, bool) {
+		if present[urn] && urn != res.URN {
+			return urn, true
+		}
+		if aliased, has := aliases[urn]; has && aliased != res.URN {
+			return aliased, true
+		}
+		return "", false
+	}
+
+	for _, res := range snap.Resources {
+		if res.Parent != "" {
+			if parent, ok := resolve(res, res.Parent); !ok {
+				newParent := root
+				if res.URN == root {
+					newParent = ""
+				}
+				if res.Parent == res.URN {
+					report(res.URN, "re-pointed parent on itself to %s", describeURN(newParent))
+				} else {
+					report(res.URN, "re-pointed missing parent %s to %s", res.Parent, describeURN(newParent))
+				}
+				res.Parent = newParent
+			} else if parent != res.Parent {
+				report(res.URN, "re-pointed missing parent %s to its alias %s", res.Parent, parent)
+				res.Parent = parent
+			}
+		}
+
+		repairDeps := func(deps []resource.URN, kind string) []resource.URN {
+			var repaired []resource.URN
+			for _, dep := range deps {
+				if dep == res.URN {
+					report(res.URN, "dropped %s on itself", kind)
+				} else if resolved, ok := resolve(res, dep); !ok {
+					report(res.URN, "dropped %s on missing resource %s", kind, dep)
+				} else {
+					if resolved != dep {
+						report(res.URN, "re-pointed %s on missing resource %s to its alias %s", kind, dep, resolved)
+					}
+					repaired = append(repaired, resolved)
+				}
+			}
+			return repaired
+		}
+
+		if len(res.Dependencies) != 0 {
+			res.Dependencies = repairDeps(res.Dependencies, "dependency")
+		}
+
+		// Repair the property dependencies in a deterministic order, so that the report is stable.
+		var keys []string
+		for k := range res.PropertyDependencies {
+			keys = append(keys, string(k))
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := resource.PropertyKey(k)
+			res.PropertyDependencies[key] = repairDeps(res.PropertyDependencies[key],
+				fmt.Sprintf("dependency of property %q", k))
+		}
+	}
+}
+
+// reorderResources sorts the resources in a snapshot so that each follows its provider, its parent, its dependencies
+// and, if it is pending deletion, its live counterpart, while otherwise keeping them in their original order.
+func reorderResources(snap *deploy.Snapshot, report reportFunc) error {
+	placedURNs := make(map[resource.URN]bool)
+	placedProviders := make(map[string]bool)
+	live := make(map[resource.URN]bool)
+	for _, res := range snap.Resources {
+		if !res.Delete {
+			live[res.URN] = true
+		}
+	}
+
+	ready := func(res *resource.State) bool {
+		if res.Provider != "" && !placedProviders[res.Provider] {
+			return false
+		}
+		if res.Parent != "" && !placedURNs[res.Parent] {
+			return false
+		}
+		for _, dep := range res.Dependencies {
+			if !placedURNs[dep] {
+				return false
+			}
+		}
+		return !res.Delete || !live[res.URN] || placedURNs[res.URN]
+	}
+
+	remaining := append([]*resource.State(nil), snap.Resources...)
+	deferred := make(map[*resource.State]bool)
+	var sorted []*resource.State
+	for len(remaining) > 0 {
+		next := -1
+		for i, res := range remaining {
+			if ready(res) {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var urns []string
+			for _, res := range remaining {
+				urns = append(urns, string(res.URN))
+			}
+			return errors.Errorf("the following resources depend on each other in a cycle:\n  %s",
+				strings.Join(urns, "\n  "))
+		}
+
+		// Every resource that was skipped over comes before something that it refers to.
+		for _, res := range remaining[:next] {
+			if !deferred[res] {
+				deferred[res] = true
+				report(res.URN, "moved after the provider, parent and dependencies that it refers to")
+			}
+		}
+
+		res := remaining[next]
+		sorted = append(sorted, res)
+		placedURNs[res.URN] = true
+		if providers.IsProviderType(res.Type) {
+			if ref, err := providers.NewReference(res.URN, res.ID); err == nil {
+				placedProviders[ref.String()] = true
+			}
+		}
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	snap.Resources = sorted
+	return nil
+}
+
+func describeURN(urn resource.URN) string {
+	if urn == "" {
+		return "nothing"
+	}
+	return string(urn)
+}
@@ -0,0 +1,175 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+)
+
+func repairDescriptions(repairs []Repair) []string {
+	var descriptions []string
+	for _, r := range repairs {
+		descriptions = append(descriptions, r.String())
+	}
+	return descriptions
+}
+
+func TestRepairValidSnapshot(t *testing.T) {
+	pA := NewProviderResource("a", "default", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	repairs, err := RepairSnapshot(snap, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, repairs)
+	assert.Equal(t, []*resource.State{pA, a, b}, snap.Resources)
+}
+
+func TestRepairSnapshot(t *testing.T) {
+	root := NewResource("test-test", nil)
+	root.Type = resource.RootStackType
+	root.URN = resource.NewURN("test", "test", "", resource.RootStackType, "test-test")
+	pA := NewProviderResource("a", "default", "1")
+	missing := resource.NewURN("test", "test", "", "a:b:c", "missing")
+
+	// b comes before a, which it depends upon, and refers to a default provider that has since been replaced.
+	a := NewResource("a", pA)
+	b := NewResource("b", NewProviderResource("a", "default", "0"), a.URN, missing)
+	b.Parent = root.URN
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"x": {a.URN, missing}}
+
+	// c's parent is gone, and d depends on a resource that has since been aliased to a.
+	c := NewResource("c", pA)
+	c.Parent = missing
+	old := resource.NewURN("test", "test", "", "a:b:c", "old")
+	a.Aliases = []resource.URN{old}
+	d := NewResource("d", pA, old)
+
+	// e is pending deletion without a replacement, and there are three live copies of f, two of the same resource.
+	e := NewResource("e", pA)
+	e.Delete = true
+	f1, f2 := NewResource("f", pA), NewResource("f", pA)
+	f1.ID, f2.ID = "f1", "f2"
+	f3 := NewResource("f", pA)
+	f3.ID = "f2"
+
+	// g was being created, and a was being updated.
+	g := NewResource("g", pA)
+
+	snap := NewSnapshot([]*resource.State{root, pA, b, a, c, d, e, f1, f2, f3})
+	snap.Manifest.Magic = "tampered"
+	snap.PendingOperations = []resource.Operation{
+		resource.NewOperation(g, resource.OperationTypeCreating),
+		resource.NewOperation(a, resource.OperationTypeUpdating),
+	}
+	assert.Error(t, snap.VerifyIntegrity())
+
+	// Without a way to choose between the copies of f, the snapshot can't be repaired.
+	_, err := RepairSnapshot(snap, nil)
+	assert.EqualError(t, err, "the following resources have several live copies with different IDs, and the copy "+
+		"to keep must be chosen:\n  "+string(f1.URN)+`: "f1", "f2"`)
+
+	var candidates []*resource.State
+	choose := func(urn resource.URN, cs []*resource.State) (*resource.State, error) {
+		assert.Equal(t, f1.URN, urn)
+		candidates = cs
+		return f1, nil
+	}
+	snap.Manifest.Magic = "tampered"
+	snap.PendingOperations = []resource.Operation{
+		resource.NewOperation(g, resource.OperationTypeCreating),
+		resource.NewOperation(a, resource.OperationTypeUpdating),
+	}
+	repairs, err := RepairSnapshot(snap, choose)
+	assert.NoError(t, err)
+	assert.NoError(t, snap.VerifyIntegrity())
+	assert.Equal(t, []*resource.State{f1, f3}, candidates)
+	assert.Equal(t, []string{
+		"reset the manifest's magic cookie",
+		string(g.URN) + ": removed pending operation 'creating'; the resource may exist and, if so, must be " +
+			"imported or deleted by hand",
+		string(a.URN) + ": removed pending operation 'updating'",
+		string(f2.URN) + `: removed duplicate resource "f2", keeping resource "f1"; the physical resource was ` +
+			"not deleted",
+		string(f3.URN) + `: removed duplicate resource "f2", keeping resource "f1"; the physical resource was ` +
+			"not deleted",
+		string(b.URN) + ": re-pointed reference to missing provider " + string(pA.URN) + "::0 to " +
+			string(pA.URN) + "::1",
+		string(b.URN) + ": dropped dependency on missing resource " + string(missing),
+		string(b.URN) + `: dropped dependency of property "x" on missing resource ` + string(missing),
+		string(c.URN) + ": re-pointed missing parent " + string(missing) + " to " + string(root.URN),
+		string(d.URN) + ": re-pointed dependency on missing resource " + string(old) + " to its alias " +
+			string(a.URN),
+		string(b.URN) + ": moved after the provider, parent and dependencies that it refers to",
+	}, repairDescriptions(repairs))
+
+	// The resource that is pending deletion is kept.
+	assert.Equal(t, []*resource.State{root, pA, a, b, c, d, e, f1}, snap.Resources)
+	assert.Empty(t, snap.PendingOperations)
+	assert.Equal(t, []resource.URN{a.URN}, b.Dependencies)
+	assert.Equal(t, []resource.URN{a.URN}, b.PropertyDependencies["x"])
+	assert.Equal(t, []resource.URN{a.URN}, d.Dependencies)
+	assert.False(t, f1.Delete)
+
+	// Repairing the snapshot again has no effect.
+	repairs, err = RepairSnapshot(snap, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, repairs)
+}
+
+func TestRepairMissingDefaultProvider(t *testing.T) {
+	gone := NewProviderResource("a", "default_1_0_0", "0")
+	a := NewResource("a", gone)
+	snap := NewSnapshot([]*resource.State{a})
+
+	// Without another default provider for the package, the reference is cleared.
+	repairs, err := RepairSnapshot(snap, nil)
+	assert.NoError(t, err)
+	assert.Len(t, repairs, 1)
+	assert.Empty(t, a.Provider)
+
+	// With one, the reference is re-pointed.
+	pA := NewProviderResource("a", "default_2_0_0", "1")
+	a.Provider = string(gone.URN) + "::0"
+	snap.Resources = []*resource.State{pA, a}
+	_, err = RepairSnapshot(snap, nil)
+	assert.NoError(t, err)
+	ref, err := providers.ParseReference(a.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, pA.URN, ref.URN())
+}
+
+func TestRepairUnrepairableSnapshot(t *testing.T) {
+	// References to missing explicit providers can't be repaired.
+	explicit := NewProviderResource("a", "explicit", "0")
+	a := NewResource("a", explicit)
+	_, err := RepairSnapshot(NewSnapshot([]*resource.State{a}), nil)
+	assert.EqualError(t, err, "resource "+string(a.URN)+" refers to missing provider "+string(explicit.URN)+
+		"::0, which can't be replaced automatically")
+
+	// Nor can dependency cycles.
+	b := NewResource("b", nil)
+	c := NewResource("c", nil, b.URN)
+	b.Dependencies = []resource.URN{c.URN}
+	_, err = RepairSnapshot(NewSnapshot([]*resource.State{b, c}), nil)
+	assert.EqualError(t, err, "the following resources depend on each other in a cycle:\n  "+
+		string(b.URN)+"\n  "+string(c.URN))
+}
@@ -37,15 +37,47 @@ type Analyzer interface {
 	// AnalyzeStack analyzes all resources after a successful preview or update.
 	// Is called after all resources have been processed, and all changes applied.
 	AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeDiagnostic, error)
+	// Remediate is given the opportunity to transform a single resource's properties, and returns the results of any
+	// policies that did so, in the order they were applied.  Is called before the resource is checked by its provider.
+	Remediate(r AnalyzerResource) ([]Remediation, error)
 	// GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
 	GetAnalyzerInfo() (AnalyzerInfo, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
+	// Configure configures the analyzer, passing configuration properties and enforcement levels for each policy.  Is
+	// called once, before any analysis or remediation.
+	Configure(policyConfig map[string]AnalyzerPolicyConfig) error
 }
 
 // AnalyzerResource mirrors a resource that is sent to the analyzer.
 type AnalyzerResource struct {
+	URN                  resource.URN
+	Type                 tokens.Type
+	Name                 tokens.QName
+	Properties           resource.PropertyMap
+	Options              AnalyzerResourceOptions
+	Provider             *AnalyzerProviderResource
+	Parent               resource.URN
+	Dependencies         []resource.URN
+	PropertyDependencies map[resource.PropertyKey][]resource.URN
+}
+
+// AnalyzerResourceOptions mirrors resource options sent to the analyzer.
+type AnalyzerResourceOptions struct {
+	Protect                    bool                    // true to protect this resource from deletion.
+	IgnoreChanges              []string                // a list of property names to ignore during changes.
+	DeleteBeforeReplace        bool                    // true if this resource should be deleted prior to replacement.
+	DeleteBeforeReplaceDefined bool                    // true if DeleteBeforeReplace was set explicitly.
+	AdditionalSecretOutputs    []resource.PropertyKey  // outputs that should always be treated as secrets.
+	Aliases                    []resource.URN          // additional URNs that should be aliased to this resource.
+	CustomTimeouts             resource.CustomTimeouts // an optional config object for resource options
+}
+
+// AnalyzerProviderResource mirrors a resource's provider sent to the analyzer.
+type AnalyzerProviderResource struct {
+	URN        resource.URN
 	Type       tokens.Type
+	Name       tokens.QName
 	Properties resource.PropertyMap
 }
 
@@ -61,9 +93,26 @@ type AnalyzeDiagnostic struct {
 	EnforcementLevel  apitype.EnforcementLevel
 }
 
+// Remediation is the result of a policy that transformed a resource's properties.
+type Remediation struct {
+	PolicyName        string
+	PolicyPackName    string
+	PolicyPackVersion string
+	Description       string
+	Properties        resource.PropertyMap
+}
+
 // AnalyzerInfo provides metadata about a PolicyPack inside an analyzer.
 type AnalyzerInfo struct {
 	Name        string
 	DisplayName string
 	Policies    []apitype.Policy
 }
+
+// AnalyzerPolicyConfig is the configuration of a single policy in a PolicyPack.
+type AnalyzerPolicyConfig struct {
+	// EnforcementLevel is the enforcement level of the policy.
+	EnforcementLevel apitype.EnforcementLevel
+	// Properties are the configuration properties of the policy.
+	Properties map[string]interface{}
+}
@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
@@ -38,6 +39,7 @@ type analyzer struct {
 	name   tokens.QName
 	plug   *plugin
 	client pulumirpc.AnalyzerClient
+	config map[string]AnalyzerPolicyConfig // the configuration of each policy, if the analyzer has been configured.
 }
 
 var _ Analyzer = (*analyzer)(nil)
@@ -118,18 +120,23 @@ func (a *analyzer) label() string {
 
 // Analyze analyzes a single resource object, and returns any errors that it finds.
 func (a *analyzer) Analyze(r AnalyzerResource) ([]AnalyzeDiagnostic, error) {
-	t, props := r.Type, r.Properties
-
-	label := fmt.Sprintf("%s.Analyze(%s)", a.label(), t)
-	logging.V(7).Infof("%s executing (#props=%d)", label, len(props))
-	mprops, err := MarshalProperties(props, MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	label := fmt.Sprintf("%s.Analyze(%s)", a.label(), r.Type)
+	logging.V(7).Infof("%s executing (#props=%d)", label, len(r.Properties))
+	mr, err := marshalAnalyzerResource(r)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := a.client.Analyze(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
-		Type:       string(t),
-		Properties: mprops,
+		Type:                 mr.Type,
+		Properties:           mr.Properties,
+		Urn:                  mr.Urn,
+		Name:                 mr.Name,
+		Options:              mr.Options,
+		Provider:             mr.Provider,
+		Parent:               mr.Parent,
+		Dependencies:         mr.Dependencies,
+		PropertyDependencies: mr.PropertyDependencies,
 	})
 	if err != nil {
 		rpcError := rpcerror.Convert(err)
@@ -144,7 +151,7 @@ func (a *analyzer) Analyze(r AnalyzerResource) ([]AnalyzeDiagnostic, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "converting analysis results")
 	}
-	return diags, nil
+	return a.applyPolicyConfig(diags), nil
 }
 
 // AnalyzeStack analyzes all resources in a stack at the end of the update operation.
@@ -153,15 +160,11 @@ func (a *analyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeDiagnost
 
 	protoResources := make([]*pulumirpc.AnalyzerResource, len(resources))
 	for idx, resource := range resources {
-		props, err := MarshalProperties(resource.Properties, MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		mr, err := marshalAnalyzerResource(resource)
 		if err != nil {
-			return nil, errors.Wrap(err, "marshalling properties")
-		}
-
-		protoResources[idx] = &pulumirpc.AnalyzerResource{
-			Type:       string(resource.Type),
-			Properties: props,
+			return nil, err
 		}
+		protoResources[idx] = mr
 	}
 
 	resp, err := a.client.AnalyzeStack(a.ctx.Request(), &pulumirpc.AnalyzeStackRequest{
@@ -188,7 +191,69 @@ func (a *analyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeDiagnost
 	if err != nil {
 		return nil, errors.Wrap(err, "converting analysis results")
 	}
-	return diags, nil
+	return a.applyPolicyConfig(diags), nil
+}
+
+// Remediate is given the opportunity to transform a single resource's properties, and returns the results of any
+// policies that did so.
+func (a *analyzer) Remediate(r AnalyzerResource) ([]Remediation, error) {
+	label := fmt.Sprintf("%s.Remediate(%s)", a.label(), r.Type)
+	logging.V(7).Infof("%s executing (#props=%d)", label, len(r.Properties))
+	mr, err := marshalAnalyzerResource(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Remediate(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
+		Type:                 mr.Type,
+		Properties:           mr.Properties,
+		Urn:                  mr.Urn,
+		Name:                 mr.Name,
+		Options:              mr.Options,
+		Provider:             mr.Provider,
+		Parent:               mr.Parent,
+		Dependencies:         mr.Dependencies,
+		PropertyDependencies: mr.PropertyDependencies,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		// Policy packs written against older versions of the AnalyzerService cannot remediate resources, which is
+		// not an error.
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s is unimplemented, skipping: err=%v", label, rpcError)
+			return nil, nil
+		}
+
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return nil, rpcError
+	}
+
+	var remediations []Remediation
+	for _, rem := range resp.GetRemediations() {
+		if !a.remediates(rem.GetPolicyName()) {
+			logging.V(7).Infof("%s ignoring remediation by %s, which is not configured to remediate",
+				label, rem.GetPolicyName())
+			continue
+		}
+		// A remediation without properties, e.g. one that only describes a problem, leaves the resource unchanged.
+		if rem.GetProperties() == nil {
+			logging.V(7).Infof("%s ignoring remediation by %s, which has no properties", label, rem.GetPolicyName())
+			continue
+		}
+		props, err := UnmarshalProperties(rem.GetProperties(), MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unmarshalling the properties remediated by %s", rem.GetPolicyName())
+		}
+		remediations = append(remediations, Remediation{
+			PolicyName:        rem.GetPolicyName(),
+			PolicyPackName:    rem.GetPolicyPackName(),
+			PolicyPackVersion: rem.GetPolicyPackVersion(),
+			Description:       rem.GetDescription(),
+			Properties:        props,
+		})
+	}
+	logging.V(7).Infof("%s success: remediations=#%d", label, len(remediations))
+	return remediations, nil
 }
 
 // GetAnalyzerInfo returns metadata about the policies contained in this analyzer plugin.
@@ -209,12 +274,25 @@ func (a *analyzer) GetAnalyzerInfo() (AnalyzerInfo, error) {
 			return AnalyzerInfo{}, err
 		}
 
+		var configSchema *apitype.PolicyConfigSchema
+		if schema := p.GetConfigSchema(); schema != nil {
+			props, err := UnmarshalProperties(schema.GetProperties(), MarshalOptions{})
+			if err != nil {
+				return AnalyzerInfo{}, errors.Wrapf(err, "unmarshalling the config schema of %s", p.GetName())
+			}
+			configSchema = &apitype.PolicyConfigSchema{
+				Properties: props.Mappable(),
+				Required:   schema.GetRequired(),
+			}
+		}
+
 		policies = append(policies, apitype.Policy{
 			Name:             p.GetName(),
 			DisplayName:      p.GetDisplayName(),
 			Description:      p.GetDescription(),
 			EnforcementLevel: enforcementLevel,
 			Message:          p.GetMessage(),
+			ConfigSchema:     configSchema,
 		})
 	}
 
@@ -253,23 +331,170 @@ func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
 	}, nil
 }
 
+// Configure configures the analyzer, passing configuration properties and enforcement levels for each policy.
+func (a *analyzer) Configure(policyConfig map[string]AnalyzerPolicyConfig) error {
+	label := fmt.Sprintf("%s.Configure(...)", a.label())
+	logging.V(7).Infof("%s executing (#policies=%d)", label, len(policyConfig))
+
+	configurable := false
+	config := make(map[string]*pulumirpc.PolicyConfig)
+	for name, c := range policyConfig {
+		props, err := MarshalProperties(resource.NewPropertyMapFromMap(c.Properties), MarshalOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "marshalling the configuration of %s", name)
+		}
+		config[name] = &pulumirpc.PolicyConfig{
+			EnforcementLevel: marshalEnforcementLevel(c.EnforcementLevel),
+			Properties:       props,
+		}
+		configurable = configurable || len(c.Properties) > 0
+	}
+
+	_, err := a.client.Configure(a.ctx.Request(), &pulumirpc.ConfigureAnalyzerRequest{PolicyConfig: config})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		// Policy packs written against older versions of the AnalyzerService cannot be configured.  Their policies'
+		// enforcement levels are still overridden by the engine, but any configuration properties would be lost.
+		if rpcError.Code() != codes.Unimplemented || configurable {
+			logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+			return rpcError
+		}
+		logging.V(7).Infof("%s is unimplemented, skipping: err=%v", label, rpcError)
+	}
+
+	a.config = policyConfig
+	return nil
+}
+
+// remediates returns true if the policy with the given name may remediate resources: that is, unless the analyzer has
+// been configured with an enforcement level other than remediate for it.
+func (a *analyzer) remediates(policyName string) bool {
+	c, has := a.config[policyName]
+	return !has || c.EnforcementLevel == "" || c.EnforcementLevel == apitype.Remediate
+}
+
+// applyPolicyConfig applies the configured enforcement level of each policy to the given diagnostics, dropping any that
+// were reported by disabled policies.
+func (a *analyzer) applyPolicyConfig(diags []AnalyzeDiagnostic) []AnalyzeDiagnostic {
+	if a.config == nil {
+		return diags
+	}
+
+	var result []AnalyzeDiagnostic
+	for _, d := range diags {
+		if c, has := a.config[d.PolicyName]; has {
+			if c.EnforcementLevel == apitype.Disabled {
+				continue
+			}
+			d.EnforcementLevel = c.EnforcementLevel
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
 // Close tears down the underlying plugin RPC connection and process.
 func (a *analyzer) Close() error {
 	return a.plug.Close()
 }
 
+// marshalAnalyzerResource converts a resource to its RPC representation.
+func marshalAnalyzerResource(r AnalyzerResource) (*pulumirpc.AnalyzerResource, error) {
+	props, err := MarshalProperties(r.Properties, MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling properties")
+	}
+
+	var provider *pulumirpc.AnalyzerProviderResource
+	if r.Provider != nil {
+		providerProps, err := MarshalProperties(r.Provider.Properties,
+			MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling provider properties")
+		}
+		provider = &pulumirpc.AnalyzerProviderResource{
+			Urn:        string(r.Provider.URN),
+			Type:       string(r.Provider.Type),
+			Name:       string(r.Provider.Name),
+			Properties: providerProps,
+		}
+	}
+
+	var additionalSecretOutputs []string
+	for _, k := range r.Options.AdditionalSecretOutputs {
+		additionalSecretOutputs = append(additionalSecretOutputs, string(k))
+	}
+	var aliases []string
+	for _, alias := range r.Options.Aliases {
+		aliases = append(aliases, string(alias))
+	}
+	var dependencies []string
+	for _, dep := range r.Dependencies {
+		dependencies = append(dependencies, string(dep))
+	}
+	propertyDependencies := make(map[string]*pulumirpc.AnalyzerPropertyDependencies)
+	for pk, deps := range r.PropertyDependencies {
+		var urns []string
+		for _, dep := range deps {
+			urns = append(urns, string(dep))
+		}
+		propertyDependencies[string(pk)] = &pulumirpc.AnalyzerPropertyDependencies{Urns: urns}
+	}
+
+	return &pulumirpc.AnalyzerResource{
+		Urn:        string(r.URN),
+		Type:       string(r.Type),
+		Name:       string(r.Name),
+		Properties: props,
+		Options: &pulumirpc.AnalyzerResourceOptions{
+			Protect:                    r.Options.Protect,
+			IgnoreChanges:              r.Options.IgnoreChanges,
+			DeleteBeforeReplace:        r.Options.DeleteBeforeReplace,
+			DeleteBeforeReplaceDefined: r.Options.DeleteBeforeReplaceDefined,
+			AdditionalSecretOutputs:    additionalSecretOutputs,
+			Aliases:                    aliases,
+			CustomTimeouts: &pulumirpc.AnalyzerResourceOptions_CustomTimeouts{
+				Create: r.Options.CustomTimeouts.Create,
+				Update: r.Options.CustomTimeouts.Update,
+				Delete: r.Options.CustomTimeouts.Delete,
+			},
+		},
+		Provider:             provider,
+		Parent:               string(r.Parent),
+		Dependencies:         dependencies,
+		PropertyDependencies: propertyDependencies,
+	}, nil
+}
+
 func convertEnforcementLevel(el pulumirpc.EnforcementLevel) (apitype.EnforcementLevel, error) {
 	switch el {
 	case pulumirpc.EnforcementLevel_ADVISORY:
 		return apitype.Advisory, nil
 	case pulumirpc.EnforcementLevel_MANDATORY:
 		return apitype.Mandatory, nil
+	case pulumirpc.EnforcementLevel_REMEDIATE:
+		return apitype.Remediate, nil
+	case pulumirpc.EnforcementLevel_DISABLED:
+		return apitype.Disabled, nil
 
 	default:
 		return "", fmt.Errorf("Invalid enforcement level %d", el)
 	}
 }
 
+func marshalEnforcementLevel(el apitype.EnforcementLevel) pulumirpc.EnforcementLevel {
+	switch el {
+	case apitype.Mandatory:
+		return pulumirpc.EnforcementLevel_MANDATORY
+	case apitype.Remediate:
+		return pulumirpc.EnforcementLevel_REMEDIATE
+	case apitype.Disabled:
+		return pulumirpc.EnforcementLevel_DISABLED
+	default:
+		return pulumirpc.EnforcementLevel_ADVISORY
+	}
+}
+
 func convertDiagnostics(protoDiagnostics []*pulumirpc.AnalyzeDiagnostic) ([]AnalyzeDiagnostic, error) {
 	diagnostics := make([]AnalyzeDiagnostic, len(protoDiagnostics))
 	for idx := range protoDiagnostics {
@@ -0,0 +1,136 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestMarshalAnalyzerResource(t *testing.T) {
+	mr, err := marshalAnalyzerResource(AnalyzerResource{
+		URN:        "urn:pulumi:stack::proj::my:mod:Comp$aws:s3/bucket:Bucket::b",
+		Type:       "aws:s3/bucket:Bucket",
+		Name:       "b",
+		Properties: resource.PropertyMap{"acl": resource.NewStringProperty("private")},
+		Options: AnalyzerResourceOptions{
+			Protect:                 true,
+			IgnoreChanges:           []string{"tags"},
+			AdditionalSecretOutputs: []resource.PropertyKey{"arn"},
+			CustomTimeouts:          resource.CustomTimeouts{Create: 60},
+		},
+		Provider: &AnalyzerProviderResource{
+			URN:        "urn:pulumi:stack::proj::pulumi:providers:aws::p",
+			Type:       "pulumi:providers:aws",
+			Name:       "p",
+			Properties: resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")},
+		},
+		Parent:               "urn:pulumi:stack::proj::my:mod:Comp::c",
+		Dependencies:         []resource.URN{"urn:pulumi:stack::proj::aws:kms/key:Key::k"},
+		PropertyDependencies: map[resource.PropertyKey][]resource.URN{"key": {"urn:pulumi:stack::proj::aws:kms/key:Key::k"}},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "urn:pulumi:stack::proj::my:mod:Comp$aws:s3/bucket:Bucket::b", mr.GetUrn())
+	assert.Equal(t, "aws:s3/bucket:Bucket", mr.GetType())
+	assert.Equal(t, "b", mr.GetName())
+	assert.Equal(t, "private", mr.GetProperties().GetFields()["acl"].GetStringValue())
+	assert.True(t, mr.GetOptions().GetProtect())
+	assert.Equal(t, []string{"tags"}, mr.GetOptions().GetIgnoreChanges())
+	assert.Equal(t, []string{"arn"}, mr.GetOptions().GetAdditionalSecretOutputs())
+	assert.Equal(t, 60.0, mr.GetOptions().GetCustomTimeouts().GetCreate())
+	assert.Equal(t, "pulumi:providers:aws", mr.GetProvider().GetType())
+	assert.Equal(t, "us-west-2", mr.GetProvider().GetProperties().GetFields()["region"].GetStringValue())
+	assert.Equal(t, "urn:pulumi:stack::proj::my:mod:Comp::c", mr.GetParent())
+	assert.Equal(t, []string{"urn:pulumi:stack::proj::aws:kms/key:Key::k"}, mr.GetDependencies())
+	assert.Equal(t, map[string]*pulumirpc.AnalyzerPropertyDependencies{
+		"key": {Urns: []string{"urn:pulumi:stack::proj::aws:kms/key:Key::k"}},
+	}, mr.GetPropertyDependencies())
+
+	// Resources without a provider have none.
+	mr, err = marshalAnalyzerResource(AnalyzerResource{Type: "my:mod:Comp"})
+	assert.NoError(t, err)
+	assert.Nil(t, mr.GetProvider())
+}
+
+func TestApplyPolicyConfig(t *testing.T) {
+	diags := []AnalyzeDiagnostic{
+		{PolicyName: "policy-a", EnforcementLevel: apitype.Mandatory},
+		{PolicyName: "policy-b", EnforcementLevel: apitype.Mandatory},
+		{PolicyName: "policy-c", EnforcementLevel: apitype.Mandatory},
+	}
+
+	// An analyzer that has not been configured reports its diagnostics as-is.
+	a := &analyzer{}
+	assert.Equal(t, diags, a.applyPolicyConfig(diags))
+
+	a.config = map[string]AnalyzerPolicyConfig{
+		"policy-a": {EnforcementLevel: apitype.Advisory},
+		"policy-b": {EnforcementLevel: apitype.Disabled},
+	}
+	assert.Equal(t, []AnalyzeDiagnostic{
+		{PolicyName: "policy-a", EnforcementLevel: apitype.Advisory},
+		{PolicyName: "policy-c", EnforcementLevel: apitype.Mandatory},
+	}, a.applyPolicyConfig(diags))
+
+	// Only policies whose enforcement level is remediate, or isn't configured, may remediate resources.
+	a.config["policy-d"] = AnalyzerPolicyConfig{EnforcementLevel: apitype.Remediate}
+	assert.False(t, a.remediates("policy-a"))
+	assert.False(t, a.remediates("policy-b"))
+	assert.True(t, a.remediates("policy-c"))
+	assert.True(t, a.remediates("policy-d"))
+}
+
+// remediateClient is an analyzer client whose Remediate method returns a fixed response.
+type remediateClient struct {
+	pulumirpc.AnalyzerClient
+	resp *pulumirpc.RemediateResponse
+}
+
+func (c *remediateClient) Remediate(ctx context.Context, in *pulumirpc.AnalyzeRequest,
+	opts ...grpc.CallOption) (*pulumirpc.RemediateResponse, error) {
+	return c.resp, nil
+}
+
+func TestRemediateWithoutProperties(t *testing.T) {
+	a := &analyzer{ctx: &Context{}, name: "test", client: &remediateClient{resp: &pulumirpc.RemediateResponse{
+		Remediations: []*pulumirpc.Remediation{
+			{PolicyName: "describe-only", Description: "this resource is misconfigured"},
+			{PolicyName: "private-acl", Properties: &_struct.Struct{Fields: map[string]*_struct.Value{
+				"acl": {Kind: &_struct.Value_StringValue{StringValue: "private"}},
+			}}},
+		},
+	}}}
+
+	// Remediations without properties are ignored rather than replacing the resource's properties.
+	remediations, err := a.Remediate(AnalyzerResource{
+		Type:       "aws:s3/bucket:Bucket",
+		Properties: resource.PropertyMap{"acl": resource.NewStringProperty("public-read")},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, remediations, 1) {
+		assert.Equal(t, "private-acl", remediations[0].PolicyName)
+		assert.Equal(t, resource.PropertyMap{"acl": resource.NewStringProperty("private")},
+			remediations[0].Properties)
+	}
+}
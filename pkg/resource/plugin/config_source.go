@@ -15,13 +15,14 @@
 package plugin
 
 import (
-	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
 // ConfigSource is an interface that allows a plugin context to fetch configuration data for a plugin named by
 // package.
 type ConfigSource interface {
-	// GetPackageConfig returns the set of configuration parameters for the indicated package, if any.
-	GetPackageConfig(pkg tokens.Package) (map[config.Key]string, error)
+	// GetPackageConfig returns the set of configuration parameters for the indicated package, if any.  Secret
+	// parameters are marked as such.
+	GetPackageConfig(pkg tokens.Package) (resource.PropertyMap, error)
 }
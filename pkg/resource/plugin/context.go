@@ -20,6 +20,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 
 	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 )
 
@@ -31,6 +32,10 @@ type Context struct {
 	Host       Host      // the host that can be used to fetch providers.
 	Pwd        string    // the working directory to spawn all plugins in.
 
+	// DebugProviders maps the packages of providers that are already running to their addresses.  The default host
+	// attaches to these providers rather than launching their plugins.
+	DebugProviders map[tokens.Package]string
+
 	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
 }
 
@@ -0,0 +1,85 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// DebugProvidersEnvVar is the environment variable that lists providers that are already running, for example under a
+// debugger, and that the engine should attach to rather than launch.  Its value is a comma-separated list of
+// <package>=<address> pairs, such as "aws=localhost:12345,kubernetes=5555".  An address that is only a port refers
+// to the local machine.
+const DebugProvidersEnvVar = "PULUMI_DEBUG_PROVIDERS"
+
+// GetDebugProviders returns the addresses of the providers that the engine should attach to, keyed by package.  These
+// come from the given project's debugProviders setting, if any, and from DebugProvidersEnvVar, which takes precedence.
+func GetDebugProviders(proj *workspace.Project) (map[tokens.Package]string, error) {
+	providers := make(map[tokens.Package]string)
+	if proj != nil {
+		for pkg, addr := range proj.DebugProviders {
+			normalized, err := normalizeProviderAddress(addr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid address for the %s provider in debugProviders", pkg)
+			}
+			providers[tokens.Package(pkg)] = normalized
+		}
+	}
+
+	if env := os.Getenv(DebugProvidersEnvVar); env != "" {
+		for _, entry := range strings.Split(env, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			eq := strings.Index(entry, "=")
+			if eq <= 0 {
+				return nil, errors.Errorf("invalid entry %q in %s; expected <package>=<address>",
+					entry, DebugProvidersEnvVar)
+			}
+			pkg, addr := entry[:eq], entry[eq+1:]
+			normalized, err := normalizeProviderAddress(addr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid address for the %s provider in %s", pkg, DebugProvidersEnvVar)
+			}
+			providers[tokens.Package(pkg)] = normalized
+		}
+	}
+
+	return providers, nil
+}
+
+// normalizeProviderAddress checks that the given address is of the form <host>:<port> or <port>, and returns it in
+// the former form.
+func normalizeProviderAddress(addr string) (string, error) {
+	if port, err := strconv.Atoi(addr); err == nil {
+		if port <= 0 || port > 65535 {
+			return "", errors.Errorf("port %d is out of range", port)
+		}
+		return net.JoinHostPort("127.0.0.1", addr), nil
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return "", errors.Errorf("%q is not of the form <host>:<port> or <port>", addr)
+	}
+	return addr, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestGetDebugProviders(t *testing.T) {
+	proj := &workspace.Project{DebugProviders: map[string]string{
+		"aws":        "12345",
+		"kubernetes": "debughost:5555",
+	}}
+	providers, err := GetDebugProviders(proj)
+	assert.NoError(t, err)
+	assert.Equal(t, map[tokens.Package]string{
+		"aws":        "127.0.0.1:12345",
+		"kubernetes": "debughost:5555",
+	}, providers)
+
+	// The environment takes precedence over the project.
+	assert.NoError(t, os.Setenv(DebugProvidersEnvVar, "aws=localhost:2345, random=3456"))
+	defer os.Unsetenv(DebugProvidersEnvVar)
+	providers, err = GetDebugProviders(proj)
+	assert.NoError(t, err)
+	assert.Equal(t, map[tokens.Package]string{
+		"aws":        "localhost:2345",
+		"kubernetes": "debughost:5555",
+		"random":     "127.0.0.1:3456",
+	}, providers)
+
+	for _, bad := range []string{"aws", "=1234", "aws=", "aws=host", "aws=99999"} {
+		assert.NoError(t, os.Setenv(DebugProvidersEnvVar, bad))
+		_, err = GetDebugProviders(nil)
+		assert.Error(t, err, bad)
+	}
+}
+
+// debugProvider is a provider server that implements only the methods used when attaching to a provider.
+type debugProvider struct {
+	pulumirpc.ResourceProviderServer
+
+	cancelled chan bool
+}
+
+func (p *debugProvider) GetPluginInfo(context.Context, *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: "1.2.3"}, nil
+}
+
+func (p *debugProvider) Cancel(context.Context, *pbempty.Empty) (*pbempty.Empty, error) {
+	close(p.cancelled)
+	return &pbempty.Empty{}, nil
+}
+
+func TestAttachToDebugProvider(t *testing.T) {
+	server := &debugProvider{cancelled: make(chan bool)}
+	stop := make(chan bool)
+	port, done, err := rpcutil.Serve(0, stop, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, server)
+			return nil
+		},
+	}, nil)
+	assert.NoError(t, err)
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	ctx, err := NewContext(sink, sink, nil, nil, "", nil, nil)
+	assert.NoError(t, err)
+	ctx.DebugProviders = map[tokens.Package]string{"debug": fmt.Sprintf("127.0.0.1:%d", port)}
+
+	// The host attaches to the running provider rather than looking for its plugin.
+	prov, err := ctx.Host.Provider("debug", nil)
+	assert.NoError(t, err)
+	info, err := prov.GetPluginInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", info.Version.String())
+
+	// Cancellation is passed along to the provider.
+	assert.NoError(t, ctx.Host.SignalCancellation())
+	<-server.cancelled
+
+	// Closing the host disconnects from the provider, but leaves it running.
+	assert.NoError(t, ctx.Close())
+	again, err := NewProviderFromAddress(ctx, "debug", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.NoError(t, err)
+	_, err = again.GetPluginInfo()
+	assert.NoError(t, err)
+	assert.NoError(t, again.Close())
+}
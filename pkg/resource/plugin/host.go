@@ -61,6 +61,10 @@ type Host interface {
 	// Provider loads a new copy of the provider for a given package.  If a provider for this package could not be
 	// found, or an error occurs while creating it, a non-nil error is returned.
 	Provider(pkg tokens.Package, version *semver.Version) (Provider, error)
+	// ProviderFromAddress connects to a provider for a given package that is already being served at the given
+	// address, e.g. by the program being run, rather than launching its plugin.  If the provider could not be reached,
+	// a non-nil error is returned.
+	ProviderFromAddress(pkg tokens.Package, addr string) (Provider, error)
 	// CloseProvider closes the given provider plugin and deregisters it from this host.
 	CloseProvider(provider Provider) error
 	// LanguageRuntime fetches the language runtime plugin for a given language, lazily allocating if necessary.  If
@@ -70,7 +74,8 @@ type Host interface {
 	// ListPlugins lists all plugins that have been loaded, with version information.
 	ListPlugins() []workspace.PluginInfo
 	// EnsurePlugins ensures all plugins in the given array are loaded and ready to use.  If any plugins are missing,
-	// and/or there are errors loading one or more plugins, a non-nil error is returned.
+	// were not installed from the tarballs with their expected checksums, and/or there are errors loading one or more
+	// plugins, a non-nil error is returned.
 	EnsurePlugins(plugins []workspace.PluginInfo, kinds Flags) error
 	// GetRequiredPlugins lists a full set of plugins that will be required by the given program.
 	GetRequiredPlugins(info ProgInfo, kinds Flags) ([]workspace.PluginInfo, error)
@@ -247,6 +252,16 @@ func (host *defaultHost) ListAnalyzers() []Analyzer {
 }
 
 func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
+	// If the provider is already running, for example under a debugger, attach to it rather than launching its plugin.
+	if addr, has := host.ctx.DebugProviders[pkg]; has {
+		host.ctx.Diag.Infoerrf(diag.Message("" /*urn*/, "attaching to the %v provider at %v"), pkg, addr)
+		plug, err := host.ProviderFromAddress(pkg, addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not attach to the %v provider at %v", pkg, addr)
+		}
+		return plug, nil
+	}
+
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
 		// Try to load and bind to a plugin.
 		plug, err := NewProvider(host, host.ctx, pkg, version)
@@ -293,6 +308,28 @@ func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (
 	return plugin.(Provider), nil
 }
 
+func (host *defaultHost) ProviderFromAddress(pkg tokens.Package, addr string) (Provider, error) {
+	plugin, err := host.loadPlugin(func() (interface{}, error) {
+		plug, err := NewProviderFromAddress(host.ctx, pkg, addr)
+		if err != nil {
+			return nil, err
+		}
+		info, err := plug.GetPluginInfo()
+		if err != nil {
+			return nil, err
+		}
+
+		// Record the provider so that it is signalled and closed along with the others. Note that served providers are
+		// not plugins installed in the workspace, so they are not added to the list of loaded plugins.
+		host.resourcePlugins[plug] = &resourcePlugin{Plugin: plug, Info: info}
+		return plug, nil
+	})
+	if plugin == nil || err != nil {
+		return nil, err
+	}
+	return plugin.(Provider), nil
+}
+
 func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error) {
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
 		// First see if we already loaded this plugin.
@@ -332,6 +369,17 @@ func (host *defaultHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds Fla
 	// Use a multieerror to track failures so we can return one big list of all failures at the end.
 	var result error
 	for _, plugin := range plugins {
+		// Check the checksums of all of the plugins, including those that will be loaded later, so that no plugin is
+		// used unless it came from the tarball the project expects.  Language plugins are not installed from tarballs,
+		// and attached providers are not installed at all.
+		_, attached := host.ctx.DebugProviders[tokens.Package(plugin.Name)]
+		if plugin.Kind != workspace.LanguagePlugin && !(plugin.Kind == workspace.ResourcePlugin && attached) {
+			if err := workspace.VerifyPluginChecksum(plugin); err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+		}
+
 		switch plugin.Kind {
 		case workspace.AnalyzerPlugin:
 			if kinds&AnalyzerPlugins != 0 {
@@ -56,6 +56,7 @@ type RunInfo struct {
 	Program        string                // the path to the program to execute.
 	Args           []string              // any arguments to pass to the program.
 	Config         map[config.Key]string // the configuration variables to apply before running.
+	ConfigSecrets  []config.Key          // the configuration keys that have secret values.
 	DryRun         bool                  // true if we are performing a dry-run (preview).
 	QueryMode      bool                  // true if we're only doing a query.
 	Parallel       int                   // the degree of parallelism for resource operations (<=1 for serial).
@@ -16,11 +16,13 @@ package plugin
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -43,6 +45,12 @@ type plugin struct {
 	stdoutDone <-chan bool
 	stderrDone <-chan bool
 
+	exited     <-chan bool      // closed when the plugin's process has exited.
+	exitState  *os.ProcessState // the state of the plugin's process once it has exited.
+	stderrLock sync.Mutex       // a lock protecting stderrTail.
+	stderrTail []string         // the last lines that the plugin wrote to stderr.
+
+	Prefix string
 	Bin    string
 	Args   []string
 	Conn   *grpc.ClientConn
@@ -58,6 +66,13 @@ var pluginRPCConnectionTimeout = time.Second * 10
 // pluginRPCMaxMessageSize raises the gRPC Max Message size from `4194304` to `419430400`
 var pluginRPCMaxMessageSize = 1024 * 1024 * 400
 
+// pluginExitTimeout dictates how long we wait to observe the exit of a plugin whose RPC connection has failed, and
+// for its stderr to drain afterwards.
+var pluginExitTimeout = time.Second
+
+// pluginStderrTailLines is the number of lines of a plugin's stderr that are retained to report if it exits.
+const pluginStderrTailLines = 10
+
 // A unique ID provided to the output stream of each plugin.  This allows the output of the plugin
 // to be streamed to the display, while still allowing that output to be sent a small piece at a
 // time.
@@ -85,6 +100,7 @@ func newPlugin(ctx *Context, bin string, prefix string, args []string) (*plugin,
 		return nil, errors.Wrapf(err, "failed to load plugin %s", bin)
 	}
 	contract.Assert(plug != nil)
+	plug.Prefix = prefix
 
 	// If we did not successfully launch the plugin, we still need to wait for stderr and stdout to drain.
 	defer func() {
@@ -118,6 +134,7 @@ func newPlugin(ctx *Context, bin string, prefix string, args []string) (*plugin,
 
 			if strings.TrimSpace(msg) != "" {
 				if stderr {
+					plug.recordStderr(msg)
 					ctx.Diag.Infoerrf(diag.StreamMessage("" /*urn*/, msg, errStreamID))
 				} else {
 					ctx.Diag.Infof(diag.StreamMessage("" /*urn*/, msg, outStreamID))
@@ -173,15 +190,36 @@ func newPlugin(ctx *Context, bin string, prefix string, args []string) (*plugin,
 	plug.stdoutDone = stdoutDone
 	go runtrace(plug.Stdout, false, stdoutDone)
 
+	// Now that we have the port, go ahead and create a gRPC client connection to it.
+	conn, err := dialPlugin("127.0.0.1:"+port, prefix, bin, plug)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done; store the connection and return the plugin info.
+	plug.Conn = conn
+	return plug, nil
+}
+
+// dialPlugin creates a gRPC client connection to the plugin listening at the given address and waits for it to begin
+// responding to requests.  prefix and name are used to describe the plugin in errors.  If the engine launched the
+// plugin, plug is its process, and RPCs that fail because the process has exited report how it exited.
+func dialPlugin(addr, prefix, name string, plug *plugin) (*grpc.ClientConn, error) {
 	// We want to increase the default message size as per pulumi/pulumi#2319
 	messageSizeOpts := grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(pluginRPCMaxMessageSize))
 
-	// Now that we have the port, go ahead and create a gRPC client connection to it.
-	conn, err := grpc.Dial("127.0.0.1:"+port, grpc.WithInsecure(), grpc.WithUnaryInterceptor(
-		rpcutil.OpenTracingClientInterceptor(),
-	), messageSizeOpts)
+	interceptor := rpcutil.OpenTracingClientInterceptor()
+	opts := []grpc.DialOption{grpc.WithInsecure(), messageSizeOpts}
+	if plug != nil {
+		interceptor = plug.exitInterceptor(interceptor)
+		opts = append(opts, grpc.WithStreamInterceptor(plug.exitStreamInterceptor()))
+	}
+	opts = append(opts, grpc.WithUnaryInterceptor(interceptor))
+
+	// Create a gRPC client connection to the plugin.
+	conn, err := grpc.Dial(addr, opts...)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not dial plugin [%v] over RPC", bin)
+		return nil, errors.Wrapf(err, "could not dial plugin [%v] over RPC", name)
 	}
 
 	// Now wait for the gRPC connection to the plugin to become ready.
@@ -215,20 +253,18 @@ func newPlugin(ctx *Context, bin string, prefix string, args []string) (*plugin,
 					}
 
 					// Unexpected error; get outta dodge.
-					return nil, errors.Wrapf(err, "%v plugin [%v] did not come alive", prefix, bin)
+					return nil, errors.Wrapf(err, "%v plugin [%v] did not come alive", prefix, name)
 				}
 			}
 			break
 		}
 		// Not ready yet; ask the gRPC client APIs to block until the state transitions again so we can retry.
 		if !conn.WaitForStateChange(timeout, s) {
-			return nil, errors.Errorf("%v plugin [%v] did not begin responding to RPC connections", prefix, bin)
+			return nil, errors.Errorf("%v plugin [%v] did not begin responding to RPC connections", prefix, name)
 		}
 	}
 
-	// Done; store the connection and return the plugin info.
-	plug.Conn = conn
-	return plug, nil
+	return conn, nil
 }
 
 func execPlugin(bin string, pluginArgs []string, pwd string) (*plugin, error) {
@@ -258,14 +294,139 @@ func execPlugin(bin string, pluginArgs []string, pwd string) (*plugin, error) {
 		return nil, err
 	}
 
-	return &plugin{
+	exited := make(chan bool)
+	plug := &plugin{
+		exited: exited,
 		Bin:    bin,
 		Args:   args,
 		Proc:   cmd.Process,
 		Stdin:  in,
 		Stdout: out,
 		Stderr: err,
-	}, nil
+	}
+
+	// Watch for the process to exit, so that we can tell when a plugin has crashed out from underneath us.  Note that
+	// we wait on the process rather than the command, as the latter would close the plugin's pipes.
+	go func() {
+		state, waitErr := plug.Proc.Wait()
+		if waitErr != nil {
+			logging.V(5).Infof("failed to wait for plugin [%v]: %v", bin, waitErr)
+		}
+		plug.exitState = state
+		close(exited)
+	}()
+
+	return plug, nil
+}
+
+// recordStderr adds a line that the plugin wrote to stderr to the tail that is reported if the plugin exits.
+func (p *plugin) recordStderr(line string) {
+	p.stderrLock.Lock()
+	defer p.stderrLock.Unlock()
+
+	p.stderrTail = append(p.stderrTail, strings.TrimRight(line, "\r\n"))
+	if len(p.stderrTail) > pluginStderrTailLines {
+		p.stderrTail = p.stderrTail[len(p.stderrTail)-pluginStderrTailLines:]
+	}
+}
+
+// hasExited returns true if the plugin's process is known to have exited.
+func (p *plugin) hasExited() bool {
+	if p.exited == nil {
+		return false
+	}
+	select {
+	case <-p.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// exitError returns an error describing how the plugin's process exited, including the last lines that it wrote to
+// stderr, or nil if the process is still running.  An RPC may fail before the process's exit has been observed, so
+// this waits up to pluginExitTimeout for the process to exit.
+func (p *plugin) exitError() error {
+	if p.exited == nil {
+		return nil
+	}
+	select {
+	case <-p.exited:
+	case <-time.After(pluginExitTimeout):
+		return nil
+	}
+
+	// Give stderr a chance to drain, so that the tail includes the plugin's last words.
+	if p.stderrDone != nil {
+		select {
+		case <-p.stderrDone:
+		case <-time.After(pluginExitTimeout):
+		}
+	}
+
+	how := "for an unknown reason"
+	if p.exitState != nil {
+		how = fmt.Sprintf("(%v)", p.exitState)
+	}
+	msg := fmt.Sprintf("%v plugin [%v] exited unexpectedly %v", p.Prefix, p.Bin, how)
+
+	p.stderrLock.Lock()
+	defer p.stderrLock.Unlock()
+	if len(p.stderrTail) > 0 {
+		msg += "; the last lines it wrote to stderr were:\n    " + strings.Join(p.stderrTail, "\n    ")
+	}
+	return errors.New(msg)
+}
+
+// exitInterceptor returns a gRPC client interceptor that wraps next.  If an RPC fails because the plugin's process has
+// exited, the interceptor replaces the opaque transport error with one that describes how the process exited.
+func (p *plugin) exitInterceptor(next grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		return p.replaceExitError(next(ctx, method, req, reply, cc, invoker, opts...))
+	}
+}
+
+// exitStreamInterceptor returns a gRPC client stream interceptor that, like exitInterceptor, replaces the errors of
+// streaming RPCs that fail because the plugin's process has exited, both when the stream is opened and when messages
+// are sent or received on it.
+func (p *plugin) exitStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, p.replaceExitError(err)
+		}
+		return &exitClientStream{ClientStream: stream, plug: p}, nil
+	}
+}
+
+// exitClientStream is a client stream whose errors are replaced by replaceExitError.
+type exitClientStream struct {
+	grpc.ClientStream
+	plug *plugin
+}
+
+func (s *exitClientStream) SendMsg(m interface{}) error {
+	return s.plug.replaceExitError(s.ClientStream.SendMsg(m))
+}
+
+func (s *exitClientStream) RecvMsg(m interface{}) error {
+	return s.plug.replaceExitError(s.ClientStream.RecvMsg(m))
+}
+
+// replaceExitError returns an error that describes how the plugin's process exited if err is an RPC failure caused by
+// that exit, and err otherwise.  The replacement's code is Unknown, as the plugin may have exited part way through the
+// operation.
+func (p *plugin) replaceExitError(err error) error {
+	if err != nil && status.Code(err) == codes.Unavailable {
+		if exitErr := p.exitError(); exitErr != nil {
+			return status.Error(codes.Unknown, exitErr.Error())
+		}
+	}
+	return err
 }
 
 func (p *plugin) Close() error {
@@ -273,18 +434,28 @@ func (p *plugin) Close() error {
 		contract.IgnoreClose(p.Conn)
 	}
 
+	// If we connected to a plugin that was already running, we do not own its process, so there is nothing more to do.
+	if p.Proc == nil {
+		return nil
+	}
+
 	var result error
 
 	// On each platform, plugins are not loaded directly, instead a shell launches each plugin as a child process, so
 	// instead we need to kill all the children of the PID we have recorded, as well. Otherwise we will block waiting
-	// for the child processes to close.
-	if err := cmdutil.KillChildren(p.Proc.Pid); err != nil {
+	// for the child processes to close.  If the plugin has already exited, there may be no children left to kill.
+	if err := cmdutil.KillChildren(p.Proc.Pid); err != nil && !p.hasExited() {
 		result = multierror.Append(result, err)
 	}
 
 	// IDEA: consider a more graceful termination than just SIGKILL.
 	if err := p.Proc.Kill(); err != nil {
-		result = multierror.Append(result, err)
+		// Killing the process fails if it has already exited, which is fine.
+		select {
+		case <-p.exited:
+		case <-time.After(pluginExitTimeout):
+			result = multierror.Append(result, err)
+		}
 	}
 
 	// Wait for stdout and stderr to drain.
@@ -0,0 +1,123 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPluginExit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	script := "for i in 1 2 3 4 5 6 7 8 9 10 11 12; do echo line $i >&2; done; exit 3"
+	plug, err := execPlugin("sh", []string{"-c", script}, "")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	plug.Prefix = "test (resource)"
+
+	// Drain stderr as newPlugin would.
+	stderrDone := make(chan bool)
+	plug.stderrDone = stderrDone
+	go func() {
+		scanner := bufio.NewScanner(plug.Stderr)
+		for scanner.Scan() {
+			plug.recordStderr(scanner.Text())
+		}
+		close(stderrDone)
+	}()
+
+	exitErr := plug.exitError()
+	if !assert.Error(t, exitErr) {
+		t.FailNow()
+	}
+	assert.True(t, plug.hasExited())
+	assert.Contains(t, exitErr.Error(), "test (resource) plugin [sh] exited unexpectedly (exit status 3)")
+	assert.Contains(t, exitErr.Error(), "line 12")
+	assert.NotContains(t, exitErr.Error(), "line 2\n")
+
+	// RPCs that fail because the plugin exited report how it exited.
+	unavailable := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "transport is closing")
+	}
+	err = plug.exitInterceptor(unavailable)(context.Background(), "/test", nil, nil, nil, nil)
+	assert.Equal(t, codes.Unknown, status.Code(err))
+	assert.True(t, strings.HasPrefix(status.Convert(err).Message(), "test (resource) plugin [sh] exited"))
+
+	// Other failures are left alone.
+	invalid := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return status.Error(codes.InvalidArgument, "bad request")
+	}
+	err = plug.exitInterceptor(invalid)(context.Background(), "/test", nil, nil, nil, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Streaming RPCs report how the plugin exited whether they fail when opened or when messages are received.
+	unavailableStream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, status.Error(codes.Unavailable, "transport is closing")
+	}
+	_, err = plug.exitStreamInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/test", unavailableStream)
+	assert.Equal(t, codes.Unknown, status.Code(err))
+	assert.True(t, strings.HasPrefix(status.Convert(err).Message(), "test (resource) plugin [sh] exited"))
+
+	closingStream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{err: status.Error(codes.Unavailable, "transport is closing")}, nil
+	}
+	stream, err := plug.exitStreamInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/test", closingStream)
+	if assert.NoError(t, err) {
+		err = stream.RecvMsg(nil)
+		assert.Equal(t, codes.Unknown, status.Code(err))
+		assert.True(t, strings.HasPrefix(status.Convert(err).Message(), "test (resource) plugin [sh] exited"))
+		err = stream.SendMsg(nil)
+		assert.Equal(t, codes.Unknown, status.Code(err))
+	}
+
+	// The end of a stream is left alone.
+	endedStream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{err: io.EOF}, nil
+	}
+	stream, err = plug.exitStreamInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/test", endedStream)
+	if assert.NoError(t, err) {
+		assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+	}
+
+	// Closing a plugin that has already exited is not an error.
+	assert.NoError(t, plug.Close())
+}
+
+// testClientStream is a client stream whose sends and receives fail with a fixed error.
+type testClientStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *testClientStream) SendMsg(m interface{}) error { return s.err }
+func (s *testClientStream) RecvMsg(m interface{}) error { return s.err }
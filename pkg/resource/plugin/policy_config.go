@@ -0,0 +1,320 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+)
+
+// allPoliciesConfigKey is the key in a policy pack's configuration whose enforcement level applies to every policy in
+// the pack, unless overridden by the configuration of a particular policy.
+const allPoliciesConfigKey = "all"
+
+// ParsePolicyPackConfigFromFile parses the policy pack configuration in the JSON file at the given path.
+func ParsePolicyPackConfigFromFile(path string) (map[string]AnalyzerPolicyConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading policy pack config %s", path)
+	}
+	var raw map[string]*json.RawMessage
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, errors.Wrapf(err, "parsing policy pack config %s", path)
+	}
+	config, err := ParsePolicyPackConfig(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing policy pack config %s", path)
+	}
+	return config, nil
+}
+
+// ParsePolicyPackConfig parses a policy pack's configuration, which maps each policy's name to either an enforcement
+// level, or an object containing an optional "enforcementLevel" and the policy's configuration properties.  The
+// enforcement level given for "all", if any, applies to every policy that is not otherwise configured.  Policies may
+// be configured to be advisory, mandatory, or disabled, but not to remediate resources.
+func ParsePolicyPackConfig(raw map[string]*json.RawMessage) (map[string]AnalyzerPolicyConfig, error) {
+	config := make(map[string]AnalyzerPolicyConfig)
+	for name, value := range raw {
+		if value == nil {
+			continue
+		}
+
+		var level apitype.EnforcementLevel
+		var props map[string]interface{}
+		if err := json.Unmarshal(*value, &level); err != nil {
+			if err = json.Unmarshal(*value, &props); err != nil {
+				return nil, errors.Errorf(
+					"the configuration of %q must be an enforcement level or an object", name)
+			}
+			if el, has := props["enforcementLevel"]; has {
+				s, ok := el.(string)
+				if !ok {
+					return nil, errors.Errorf("the enforcement level of %q must be a string", name)
+				}
+				level = apitype.EnforcementLevel(s)
+				delete(props, "enforcementLevel")
+			}
+			if len(props) == 0 {
+				props = nil
+			}
+		}
+		if level != "" && !level.IsValid() {
+			return nil, errors.Errorf("%q has an invalid enforcement level %q", name, level)
+		}
+		if level == apitype.Remediate {
+			return nil, errors.Errorf("%q cannot be configured to %q; only %q, %q, or %q may be configured",
+				name, level, apitype.Advisory, apitype.Mandatory, apitype.Disabled)
+		}
+		if name == allPoliciesConfigKey && len(props) > 0 {
+			return nil, errors.Errorf("the configuration of %q may only contain an enforcement level", name)
+		}
+
+		config[name] = AnalyzerPolicyConfig{EnforcementLevel: level, Properties: props}
+	}
+	return config, nil
+}
+
+// ReconcilePolicyPackConfig combines the given configuration with the defaults of the given policies, returning the
+// complete configuration of every policy.  If the configuration refers to policies that do not exist, or does not
+// satisfy the policies' configuration schemas, the problems are returned as validation errors.
+func ReconcilePolicyPackConfig(policies []apitype.Policy,
+	config map[string]AnalyzerPolicyConfig) (map[string]AnalyzerPolicyConfig, []string) {
+
+	var validationErrors []string
+	known := make(map[string]bool)
+	for _, p := range policies {
+		known[p.Name] = true
+	}
+	for name := range config {
+		if name != allPoliciesConfigKey && !known[name] {
+			validationErrors = append(validationErrors, fmt.Sprintf("%s: no policy with this name exists", name))
+		}
+	}
+
+	result := make(map[string]AnalyzerPolicyConfig)
+	for _, p := range policies {
+		level := p.EnforcementLevel
+		if all := config[allPoliciesConfigKey].EnforcementLevel; all != "" {
+			level = all
+		}
+
+		c := config[p.Name]
+		if c.EnforcementLevel != "" {
+			level = c.EnforcementLevel
+		}
+
+		var props map[string]interface{}
+		if p.ConfigSchema == nil {
+			if len(c.Properties) > 0 {
+				validationErrors = append(validationErrors,
+					fmt.Sprintf("%s: this policy does not accept any configuration", p.Name))
+			}
+		} else {
+			props = make(map[string]interface{})
+			for k, schema := range p.ConfigSchema.Properties {
+				if s, ok := schema.(map[string]interface{}); ok {
+					if def, has := s["default"]; has {
+						props[k] = def
+					}
+				}
+			}
+			for k, v := range c.Properties {
+				props[k] = v
+			}
+
+			schema := map[string]interface{}{"type": "object", "properties": p.ConfigSchema.Properties}
+			if len(p.ConfigSchema.Required) > 0 {
+				required := make([]interface{}, len(p.ConfigSchema.Required))
+				for i, r := range p.ConfigSchema.Required {
+					required[i] = r
+				}
+				schema["required"] = required
+			}
+			validationErrors = append(validationErrors, validateJSONSchema(p.Name, schema, props)...)
+		}
+
+		result[p.Name] = AnalyzerPolicyConfig{EnforcementLevel: level, Properties: props}
+	}
+
+	sort.Strings(validationErrors)
+	return result, validationErrors
+}
+
+// validateJSONSchema validates the given value, which is the result of decoding JSON, against the given JSON schema.
+// Only the commonly used validation keywords are supported; any other keywords are ignored.  Each validation error is
+// prefixed with the path of the offending value.
+func validateJSONSchema(path string, schema interface{}, value interface{}) []string {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		// Boolean schemas either accept or reject everything.
+		if b, isBool := schema.(bool); isBool && !b {
+			return []string{fmt.Sprintf("%s: no value is allowed", path)}
+		}
+		return nil
+	}
+
+	failf := func(format string, args ...interface{}) []string {
+		return []string{fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...))}
+	}
+
+	if t, has := s["type"]; has {
+		var types []string
+		switch t := t.(type) {
+		case string:
+			types = []string{t}
+		case []interface{}:
+			for _, e := range t {
+				if e, ok := e.(string); ok {
+					types = append(types, e)
+				}
+			}
+		}
+		matched := false
+		for _, t := range types {
+			if isJSONType(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return failf("expected %s, but got %s", strings.Join(types, " or "), jsonTypeOf(value))
+		}
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return failf("%v is not one of the allowed values", jsonString(value))
+		}
+	}
+
+	var errs []string
+	switch v := value.(type) {
+	case float64:
+		if min, ok := s["minimum"].(float64); ok && v < min {
+			errs = append(errs, failf("%v is less than the minimum of %v", v, min)...)
+		}
+		if max, ok := s["maximum"].(float64); ok && v > max {
+			errs = append(errs, failf("%v is greater than the maximum of %v", v, max)...)
+		}
+	case string:
+		if min, ok := s["minLength"].(float64); ok && float64(len(v)) < min {
+			errs = append(errs, failf("%q is shorter than the minimum length of %v", v, min)...)
+		}
+		if max, ok := s["maxLength"].(float64); ok && float64(len(v)) > max {
+			errs = append(errs, failf("%q is longer than the maximum length of %v", v, max)...)
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				errs = append(errs, failf("invalid pattern %q: %v", pattern, err)...)
+			} else if !re.MatchString(v) {
+				errs = append(errs, failf("%q does not match the pattern %q", v, pattern)...)
+			}
+		}
+	case []interface{}:
+		if min, ok := s["minItems"].(float64); ok && float64(len(v)) < min {
+			errs = append(errs, failf("expected at least %v items, but got %d", min, len(v))...)
+		}
+		if max, ok := s["maxItems"].(float64); ok && float64(len(v)) > max {
+			errs = append(errs, failf("expected at most %v items, but got %d", max, len(v))...)
+		}
+		if items, has := s["items"]; has {
+			for i, e := range v {
+				errs = append(errs, validateJSONSchema(fmt.Sprintf("%s[%d]", path, i), items, e)...)
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := s["required"].([]interface{}); ok {
+			for _, r := range required {
+				if r, ok := r.(string); ok {
+					if _, has := v[r]; !has {
+						errs = append(errs, fmt.Sprintf("%s.%s: a value is required", path, r))
+					}
+				}
+			}
+		}
+		props, _ := s["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if propSchema, has := props[k]; has {
+				errs = append(errs, validateJSONSchema(path+"."+k, propSchema, v[k])...)
+			} else if additional, has := s["additionalProperties"]; has {
+				errs = append(errs, validateJSONSchema(path+"."+k, additional, v[k])...)
+			}
+		}
+	}
+	return errs
+}
+
+// isJSONType returns true if the given decoded JSON value has the given JSON schema type.
+func isJSONType(t string, value interface{}) bool {
+	switch t {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	default:
+		return jsonTypeOf(value) == t
+	}
+}
+
+// jsonTypeOf returns the JSON schema type of the given decoded JSON value.
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonString returns the JSON representation of the given decoded JSON value, for use in error messages.
+func jsonString(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
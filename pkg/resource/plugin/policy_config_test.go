@@ -0,0 +1,117 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+)
+
+func parseRawConfig(t *testing.T, text string) map[string]*json.RawMessage {
+	var raw map[string]*json.RawMessage
+	err := json.Unmarshal([]byte(text), &raw)
+	assert.NoError(t, err)
+	return raw
+}
+
+func TestParsePolicyPackConfig(t *testing.T) {
+	config, err := ParsePolicyPackConfig(parseRawConfig(t, `{
+		"all": "mandatory",
+		"policy-a": "disabled",
+		"policy-b": {"enforcementLevel": "advisory", "maxSize": 10},
+		"policy-c": {"maxSize": 20}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]AnalyzerPolicyConfig{
+		"all":      {EnforcementLevel: apitype.Mandatory},
+		"policy-a": {EnforcementLevel: apitype.Disabled},
+		"policy-b": {EnforcementLevel: apitype.Advisory, Properties: map[string]interface{}{"maxSize": float64(10)}},
+		"policy-c": {Properties: map[string]interface{}{"maxSize": float64(20)}},
+	}, config)
+
+	_, err = ParsePolicyPackConfig(parseRawConfig(t, `{"policy-a": "sometimes"}`))
+	assert.EqualError(t, err, `"policy-a" has an invalid enforcement level "sometimes"`)
+
+	_, err = ParsePolicyPackConfig(parseRawConfig(t, `{"policy-a": {"enforcementLevel": "remediate"}}`))
+	assert.EqualError(t, err,
+		`"policy-a" cannot be configured to "remediate"; only "advisory", "mandatory", or "disabled" may be configured`)
+
+	_, err = ParsePolicyPackConfig(parseRawConfig(t, `{"policy-a": 42}`))
+	assert.EqualError(t, err, `the configuration of "policy-a" must be an enforcement level or an object`)
+
+	_, err = ParsePolicyPackConfig(parseRawConfig(t, `{"all": {"maxSize": 10}}`))
+	assert.EqualError(t, err, `the configuration of "all" may only contain an enforcement level`)
+}
+
+func TestReconcilePolicyPackConfig(t *testing.T) {
+	policies := []apitype.Policy{
+		{Name: "policy-a", EnforcementLevel: apitype.Advisory},
+		{
+			Name:             "policy-b",
+			EnforcementLevel: apitype.Mandatory,
+			ConfigSchema: &apitype.PolicyConfigSchema{
+				Properties: map[string]interface{}{
+					"maxSize": map[string]interface{}{"type": "integer", "minimum": float64(1), "default": float64(8)},
+					"sizes": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"type": "string", "enum": []interface{}{"small", "large"}},
+					},
+					"owner": map[string]interface{}{"type": "string"},
+				},
+				Required: []string{"owner"},
+			},
+		},
+	}
+
+	// Defaults are filled in, and enforcement levels are overridden by "all" and then by each policy.
+	config, errs := ReconcilePolicyPackConfig(policies, map[string]AnalyzerPolicyConfig{
+		"all":      {EnforcementLevel: apitype.Disabled},
+		"policy-b": {EnforcementLevel: apitype.Advisory, Properties: map[string]interface{}{"owner": "platform"}},
+	})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]AnalyzerPolicyConfig{
+		"policy-a": {EnforcementLevel: apitype.Disabled},
+		"policy-b": {
+			EnforcementLevel: apitype.Advisory,
+			Properties:       map[string]interface{}{"maxSize": float64(8), "owner": "platform"},
+		},
+	}, config)
+
+	// Without any configuration, each policy has its default enforcement level.
+	_, errs = ReconcilePolicyPackConfig(policies, nil)
+	assert.Equal(t, []string{"policy-b.owner: a value is required"}, errs)
+
+	// Every problem with the configuration is reported.
+	_, errs = ReconcilePolicyPackConfig(policies, map[string]AnalyzerPolicyConfig{
+		"policy-a": {Properties: map[string]interface{}{"maxSize": float64(1)}},
+		"policy-b": {Properties: map[string]interface{}{
+			"owner":   true,
+			"maxSize": float64(2.5),
+			"sizes":   []interface{}{"small", "huge"},
+		}},
+		"policy-c": {EnforcementLevel: apitype.Mandatory},
+	})
+	assert.Equal(t, []string{
+		"policy-a: this policy does not accept any configuration",
+		"policy-b.maxSize: expected integer, but got number",
+		"policy-b.owner: expected string, but got boolean",
+		`policy-b.sizes[1]: "huge" is not one of the allowed values`,
+		"policy-c: no policy with this name exists",
+	}, errs)
+}
@@ -71,6 +71,14 @@ type Provider interface {
 	Delete(urn resource.URN, id resource.ID, props resource.PropertyMap, timeout float64) (resource.Status, error)
 	// Invoke dynamically executes a built-in function in the provider.
 	Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
+	// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream of results.  onNext
+	// is called with each result in turn; if it returns an error, the stream is abandoned and that error returned.
+	StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+		onNext func(resource.PropertyMap) error) ([]CheckFailure, error)
+	// Call dynamically executes a method of an existing resource in the provider.  The resource's ID and current state
+	// are passed along with the method's arguments.  A nil result indicates that the result is not yet known.
+	Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+		args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
 
@@ -82,6 +90,17 @@ type Provider interface {
 	SignalCancellation() error
 }
 
+// RestartableProvider is a provider whose plugin can be relaunched if it exits unexpectedly, for instance, because it
+// crashed part way through an update.
+type RestartableProvider interface {
+	Provider
+
+	// Exited returns an error describing how the provider's plugin exited, or nil if it is still running.
+	Exited() error
+	// Restart relaunches the provider's plugin if it has exited, and configures it as it was configured before.
+	Restart() error
+}
+
 // CheckFailure indicates that a call to check failed; it contains the property and reason for the failure.
 type CheckFailure struct {
 	Property resource.PropertyKey // the property that failed checking.
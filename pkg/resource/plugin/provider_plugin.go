@@ -15,8 +15,12 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -48,12 +52,19 @@ const nodejsDynamicProviderType = "pulumi:providers:pulumi-nodejs"
 // This is needed because we have to handle some buggy behavior that previous versions of this provider implemented.
 const kubernetesProviderType = "pulumi:providers:kubernetes"
 
+// attachedCancellationTimeout is how long to wait for a provider that the engine attached to, rather than launched, to
+// acknowledge cancellation.
+const attachedCancellationTimeout = 5 * time.Second
+
 // provider reflects a resource plugin, loaded dynamically for a single package.
 type provider struct {
 	ctx           *Context                         // a plugin context for caching, etc.
 	pkg           tokens.Package                   // the Pulumi package containing this provider's resources.
+	args          []string                         // the arguments the plugin was launched with.
+	plugLock      sync.RWMutex                     // a lock protecting plug and clientRaw, which change on restart.
 	plug          *plugin                          // the actual plugin process wrapper.
 	clientRaw     pulumirpc.ResourceProviderClient // the raw provider client; usually unsafe to use directly.
+	config        map[string]string                // the variables the plugin was configured with, if known.
 	cfgerr        error                            // non-nil if a configure call fails.
 	cfgknown      bool                             // true if all configuration values are known.
 	cfgdone       chan bool                        // closed when configuration has completed.
@@ -76,7 +87,8 @@ func NewProvider(host Host, ctx *Context, pkg tokens.Package, version *semver.Ve
 		})
 	}
 
-	plug, err := newPlugin(ctx, path, fmt.Sprintf("%v (resource)", pkg), []string{host.ServerAddr()})
+	args := []string{host.ServerAddr()}
+	plug, err := newPlugin(ctx, path, fmt.Sprintf("%v (resource)", pkg), args)
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +97,47 @@ func NewProvider(host Host, ctx *Context, pkg tokens.Package, version *semver.Ve
 	return &provider{
 		ctx:       ctx,
 		pkg:       pkg,
+		args:      args,
 		plug:      plug,
 		clientRaw: pulumirpc.NewResourceProviderClient(plug.Conn),
 		cfgdone:   make(chan bool),
 	}, nil
 }
 
+// NewProviderFromAddress creates a provider for the given package that is bound to a server already listening at the
+// given address, rather than to a plugin process launched by the engine.  Closing the provider disconnects from the
+// server but does not otherwise affect it.
+func NewProviderFromAddress(ctx *Context, pkg tokens.Package, addr string) (Provider, error) {
+	conn, err := dialPlugin(addr, fmt.Sprintf("%v (resource)", pkg), addr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider{
+		ctx:       ctx,
+		pkg:       pkg,
+		plug:      &plugin{Bin: addr, Conn: conn},
+		clientRaw: pulumirpc.NewResourceProviderClient(conn),
+		cfgdone:   make(chan bool),
+	}, nil
+}
+
 func (p *provider) Pkg() tokens.Package { return p.pkg }
 
+// currentPlugin returns the provider's plugin, which changes if the plugin is restarted.
+func (p *provider) currentPlugin() *plugin {
+	p.plugLock.RLock()
+	defer p.plugLock.RUnlock()
+	return p.plug
+}
+
+// rawClient returns the raw client for the provider's plugin, which changes if the plugin is restarted.
+func (p *provider) rawClient() pulumirpc.ResourceProviderClient {
+	p.plugLock.RLock()
+	defer p.plugLock.RUnlock()
+	return p.clientRaw
+}
+
 // label returns a base label for tracing functions.
 func (p *provider) label() string {
 	return fmt.Sprintf("Provider[%s, %p]", p.pkg, p)
@@ -165,7 +210,7 @@ func (p *provider) CheckConfig(urn resource.URN, olds,
 		return nil, nil, err
 	}
 
-	resp, err := p.clientRaw.CheckConfig(p.ctx.Request(), &pulumirpc.CheckRequest{
+	resp, err := p.rawClient().CheckConfig(p.ctx.Request(), &pulumirpc.CheckRequest{
 		Urn:  string(urn),
 		Olds: molds,
 		News: mnews,
@@ -257,7 +302,7 @@ func (p *provider) DiffConfig(urn resource.URN, olds, news resource.PropertyMap,
 		return DiffResult{}, err
 	}
 
-	resp, err := p.clientRaw.DiffConfig(p.ctx.Request(), &pulumirpc.DiffRequest{
+	resp, err := p.rawClient().DiffConfig(p.ctx.Request(), &pulumirpc.DiffRequest{
 		Urn:           string(urn),
 		Olds:          molds,
 		News:          mnews,
@@ -322,7 +367,7 @@ func (p *provider) getClient() (pulumirpc.ResourceProviderClient, error) {
 	if err := p.ensureConfigured(); err != nil {
 		return nil, err
 	}
-	return p.clientRaw, nil
+	return p.rawClient(), nil
 }
 
 // ensureConfigured blocks waiting for the plugin to be configured.  To improve parallelism, all Configure RPCs
@@ -397,26 +442,90 @@ func (p *provider) Configure(inputs resource.PropertyMap) error {
 		}
 	}
 
+	// Remember the configuration, so that the plugin can be configured the same way if it has to be restarted.
+	p.config = config
+
 	// Spawn the configure to happen in parallel.  This ensures that we remain responsive elsewhere that might
 	// want to make forward progress, even as the configure call is happening.
 	go func() {
-		resp, err := p.clientRaw.Configure(p.ctx.Request(), &pulumirpc.ConfigureRequest{
-			AcceptSecrets: true,
-			Variables:     config,
-		})
-		if err != nil {
-			rpcError := rpcerror.Convert(err)
-			logging.V(7).Infof("%s failed: err=%v", label, rpcError.Message())
-			err = createConfigureError(rpcError)
-		}
+		acceptSecrets, err := configure(label, p.rawClient(), p.ctx, config)
 		// Acquire the lock, publish the results, and notify any waiters.
-		p.cfgknown, p.acceptSecrets, p.cfgerr = true, resp.GetAcceptSecrets(), err
+		p.cfgknown, p.acceptSecrets, p.cfgerr = true, acceptSecrets, err
 		close(p.cfgdone)
 	}()
 
 	return nil
 }
 
+// configure issues the Configure RPC to a provider's plugin, returning whether or not it accepts secrets.
+func configure(label string, client pulumirpc.ResourceProviderClient, ctx *Context,
+	config map[string]string) (bool, error) {
+
+	resp, err := client.Configure(ctx.Request(), &pulumirpc.ConfigureRequest{
+		AcceptSecrets: true,
+		Variables:     config,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError.Message())
+		return false, createConfigureError(rpcError)
+	}
+	return resp.GetAcceptSecrets(), nil
+}
+
+// Exited returns an error describing how the provider's plugin exited, or nil if it is still running.
+func (p *provider) Exited() error {
+	plug := p.currentPlugin()
+	if !plug.hasExited() {
+		return nil
+	}
+	return plug.exitError()
+}
+
+// Restart relaunches the provider's plugin if it has exited, and configures it as it was configured before.  If
+// several callers observe the same exit, only the first restarts the plugin.
+func (p *provider) Restart() error {
+	label := fmt.Sprintf("%s.Restart()", p.label())
+
+	// A provider that the engine attached to, rather than launched, is not the engine's to restart.
+	if p.args == nil {
+		return errors.Errorf("the %v provider was not launched by the engine, so it cannot be restarted", p.pkg)
+	}
+
+	// The plugin can only be configured as it was before once its original configuration has completed.
+	if err := p.ensureConfigured(); err != nil {
+		return err
+	}
+
+	p.plugLock.Lock()
+	defer p.plugLock.Unlock()
+
+	if !p.plug.hasExited() {
+		logging.V(7).Infof("%s plugin is running; nothing to do", label)
+		return nil
+	}
+
+	logging.V(7).Infof("%s relaunching plugin", label)
+	plug, err := newPlugin(p.ctx, p.plug.Bin, p.plug.Prefix, p.args)
+	if err != nil {
+		return err
+	}
+	client := pulumirpc.NewResourceProviderClient(plug.Conn)
+
+	// If the configuration was known, reconfigure the new plugin with it.
+	if p.cfgknown {
+		if _, err = configure(label, client, p.ctx, p.config); err != nil {
+			contract.IgnoreError(plug.Close())
+			return errors.Wrapf(err, "configuring the restarted %v provider", p.pkg)
+		}
+	}
+
+	contract.IgnoreError(p.plug.Close())
+	p.plug, p.clientRaw = plug, client
+	logging.V(7).Infof("%s success", label)
+	return nil
+}
+
 // Check validates that the given property bag is valid for a resource of the given type.
 func (p *provider) Check(urn resource.URN,
 	olds, news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []CheckFailure, error) {
@@ -954,6 +1063,153 @@ func (p *provider) Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (r
 	return ret, failures, nil
 }
 
+// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream of results.
+func (p *provider) StreamInvoke(tok tokens.ModuleMember, args resource.PropertyMap,
+	onNext func(resource.PropertyMap) error) ([]CheckFailure, error) {
+	contract.Assert(tok != "")
+	contract.Assert(onNext != nil)
+
+	label := fmt.Sprintf("%s.StreamInvoke(%s)", p.label(), tok)
+	logging.V(7).Infof("%s executing (#args=%d)", label, len(args))
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// If the provider is not fully configured, return an empty stream.
+	if !p.cfgknown {
+		return nil, nil
+	}
+
+	margs, err := MarshalProperties(args, MarshalOptions{
+		Label:       fmt.Sprintf("%s.args", label),
+		KeepSecrets: p.acceptSecrets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel the stream if we stop reading it early.
+	ctx, cancel := context.WithCancel(p.ctx.Request())
+	defer cancel()
+
+	stream, err := client.StreamInvoke(ctx, &pulumirpc.InvokeRequest{Tok: string(tok), Args: margs})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+		return nil, rpcError
+	}
+
+	count := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			logging.V(7).Infof("%s success (#results=%d)", label, count)
+			return nil, nil
+		}
+		if err != nil {
+			rpcError := rpcerror.Convert(err)
+			logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+			return nil, rpcError
+		}
+
+		// If any properties failed verification, the stream carries no results.
+		if len(resp.GetFailures()) > 0 {
+			var failures []CheckFailure
+			for _, failure := range resp.GetFailures() {
+				failures = append(failures, CheckFailure{resource.PropertyKey(failure.Property), failure.Reason})
+			}
+			logging.V(7).Infof("%s success (#failures=%d)", label, len(failures))
+			return failures, nil
+		}
+
+		ret, err := UnmarshalProperties(resp.GetReturn(), MarshalOptions{
+			Label:          fmt.Sprintf("%s.returns", label),
+			RejectUnknowns: true,
+			KeepSecrets:    true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = onNext(ret); err != nil {
+			return nil, err
+		}
+		count++
+	}
+}
+
+// Call dynamically executes a method of an existing resource in the provider.
+func (p *provider) Call(tok tokens.ModuleMember, urn resource.URN, id resource.ID, state resource.PropertyMap,
+	args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error) {
+	contract.Assert(tok != "")
+	contract.Assert(urn != "")
+	contract.Assert(id != "")
+
+	label := fmt.Sprintf("%s.Call(%s,%s)", p.label(), tok, urn)
+	logging.V(7).Infof("%s executing (#state=%d,#args=%d)", label, len(state), len(args))
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If the provider is not fully configured, the result is not yet known.
+	if !p.cfgknown {
+		return nil, nil, nil
+	}
+
+	mstate, err := MarshalProperties(state, MarshalOptions{
+		Label:              fmt.Sprintf("%s.state", label),
+		ElideAssetContents: true,
+		KeepSecrets:        p.acceptSecrets,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	margs, err := MarshalProperties(args, MarshalOptions{
+		Label:       fmt.Sprintf("%s.args", label),
+		KeepSecrets: p.acceptSecrets,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.Call(p.ctx.Request(), &pulumirpc.CallRequest{
+		Tok:   string(tok),
+		Urn:   string(urn),
+		Id:    string(id),
+		State: mstate,
+		Args:  margs,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+		return nil, nil, rpcError
+	}
+
+	// Unmarshal any return values.
+	ret, err := UnmarshalProperties(resp.GetReturn(), MarshalOptions{
+		Label:          fmt.Sprintf("%s.returns", label),
+		RejectUnknowns: true,
+		KeepSecrets:    true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// And now any properties that failed verification.
+	var failures []CheckFailure
+	for _, failure := range resp.GetFailures() {
+		failures = append(failures, CheckFailure{resource.PropertyKey(failure.Property), failure.Reason})
+	}
+
+	logging.V(7).Infof("%s success (#ret=%d,#failures=%d) success", label, len(ret), len(failures))
+	return ret, failures, nil
+}
+
 // GetPluginInfo returns this plugin's information.
 func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 	label := fmt.Sprintf("%s.GetPluginInfo()", p.label())
@@ -961,7 +1217,7 @@ func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 
 	// Calling GetPluginInfo happens immediately after loading, and does not require configuration to proceed.
 	// Thus, we access the clientRaw property, rather than calling getClient.
-	resp, err := p.clientRaw.GetPluginInfo(p.ctx.Request(), &pbempty.Empty{})
+	resp, err := p.rawClient().GetPluginInfo(p.ctx.Request(), &pbempty.Empty{})
 	if err != nil {
 		rpcError := rpcerror.Convert(err)
 		logging.V(7).Infof("%s failed: err=%v", label, rpcError.Message())
@@ -979,14 +1235,23 @@ func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 
 	return workspace.PluginInfo{
 		Name:    string(p.pkg),
-		Path:    p.plug.Bin,
+		Path:    p.currentPlugin().Bin,
 		Kind:    workspace.ResourcePlugin,
 		Version: version,
 	}, nil
 }
 
 func (p *provider) SignalCancellation() error {
-	_, err := p.clientRaw.Cancel(p.ctx.Request(), &pbempty.Empty{})
+	ctx, attached := p.ctx.Request(), p.currentPlugin().Proc == nil
+	if attached {
+		// A provider that the engine attached to, rather than launched, may be stopped in a debugger.  Don't let it
+		// hold up cancellation indefinitely.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, attachedCancellationTimeout)
+		defer cancel()
+	}
+
+	_, err := p.rawClient().Cancel(ctx, &pbempty.Empty{})
 	if err != nil {
 		rpcError := rpcerror.Convert(err)
 		logging.V(8).Infof("provider received rpc error `%s`: `%s`", rpcError.Code(),
@@ -995,6 +1260,11 @@ func (p *provider) SignalCancellation() error {
 		case codes.Unimplemented:
 			// For backwards compatibility, do nothing if it's not implemented.
 			return nil
+		case codes.DeadlineExceeded:
+			if attached {
+				logging.V(5).Infof("%s did not respond to cancellation; continuing", p.label())
+				return nil
+			}
 		}
 	}
 
@@ -1003,7 +1273,7 @@ func (p *provider) SignalCancellation() error {
 
 // Close tears down the underlying plugin RPC connection and process.
 func (p *provider) Close() error {
-	return p.plug.Close()
+	return p.currentPlugin().Close()
 }
 
 // createConfigureError creates a nice error message from an RPC error that
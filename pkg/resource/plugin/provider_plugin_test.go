@@ -1,12 +1,21 @@
 package plugin
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
 	"reflect"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
 func TestAnnotateSecrets(t *testing.T) {
@@ -217,3 +226,170 @@ func TestNestedSecret(t *testing.T) {
 
 	assert.Truef(t, reflect.DeepEqual(to, expected), "did not match expected after annotation")
 }
+
+// streamingProvider is a provider server whose streaming function returns the numbers from zero to its "count"
+// argument.
+type streamingProvider struct {
+	pulumirpc.ResourceProviderServer
+}
+
+func (p *streamingProvider) Configure(context.Context,
+	*pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+	return &pulumirpc.ConfigureResponse{}, nil
+}
+
+func (p *streamingProvider) StreamInvoke(req *pulumirpc.InvokeRequest,
+	server pulumirpc.ResourceProvider_StreamInvokeServer) error {
+
+	args, err := UnmarshalProperties(req.GetArgs(), MarshalOptions{})
+	if err != nil {
+		return err
+	}
+	count, ok := args["count"]
+	if !ok {
+		return server.Send(&pulumirpc.InvokeResponse{Failures: []*pulumirpc.CheckFailure{
+			{Property: "count", Reason: "missing"},
+		}})
+	}
+	for i := 0; i < int(count.NumberValue()); i++ {
+		ret, err := MarshalProperties(resource.PropertyMap{
+			"n": resource.NewNumberProperty(float64(i)),
+		}, MarshalOptions{})
+		if err != nil {
+			return err
+		}
+		if err = server.Send(&pulumirpc.InvokeResponse{Return: ret}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamInvoke(t *testing.T) {
+	stop := make(chan bool)
+	port, done, err := rpcutil.Serve(0, stop, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, &streamingProvider{})
+			return nil
+		},
+	}, nil)
+	assert.NoError(t, err)
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	ctx, err := NewContext(sink, sink, nil, nil, "", nil, nil)
+	assert.NoError(t, err)
+	prov, err := NewProviderFromAddress(ctx, "stream", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, prov.Close()) }()
+	assert.NoError(t, prov.Configure(resource.PropertyMap{}))
+
+	// Each result is passed along as it arrives.
+	var results []float64
+	failures, err := prov.StreamInvoke("stream:index:count", resource.PropertyMap{
+		"count": resource.NewNumberProperty(3),
+	}, func(ret resource.PropertyMap) error {
+		results = append(results, ret["n"].NumberValue())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Equal(t, []float64{0, 1, 2}, results)
+
+	// An error from the callback abandons the stream.
+	stopErr := errors.New("stop")
+	results = nil
+	_, err = prov.StreamInvoke("stream:index:count", resource.PropertyMap{
+		"count": resource.NewNumberProperty(100),
+	}, func(ret resource.PropertyMap) error {
+		results = append(results, ret["n"].NumberValue())
+		return stopErr
+	})
+	assert.Equal(t, stopErr, err)
+	assert.Equal(t, []float64{0}, results)
+
+	// Failures are returned rather than results.
+	failures, err = prov.StreamInvoke("stream:index:count", resource.PropertyMap{},
+		func(ret resource.PropertyMap) error {
+			assert.Fail(t, "unexpected result")
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []CheckFailure{{Property: "count", Reason: "missing"}}, failures)
+}
+
+// methodProvider is a provider server whose resources have a method that returns the resource's ID, a property of its
+// state, and an argument.
+type methodProvider struct {
+	pulumirpc.ResourceProviderServer
+}
+
+func (p *methodProvider) Configure(context.Context,
+	*pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+	return &pulumirpc.ConfigureResponse{}, nil
+}
+
+func (p *methodProvider) Call(ctx context.Context, req *pulumirpc.CallRequest) (*pulumirpc.CallResponse, error) {
+	state, err := UnmarshalProperties(req.GetState(), MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	args, err := UnmarshalProperties(req.GetArgs(), MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := args["path"]; !ok {
+		return &pulumirpc.CallResponse{Failures: []*pulumirpc.CheckFailure{{Property: "path", Reason: "missing"}}}, nil
+	}
+	ret, err := MarshalProperties(resource.PropertyMap{
+		"url": resource.NewStringProperty(fmt.Sprintf("%s://%s/%s",
+			state["scheme"].StringValue(), req.GetId(), args["path"].StringValue())),
+	}, MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CallResponse{Return: ret}, nil
+}
+
+func TestCall(t *testing.T) {
+	stop := make(chan bool)
+	port, done, err := rpcutil.Serve(0, stop, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, &methodProvider{})
+			return nil
+		},
+	}, nil)
+	assert.NoError(t, err)
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	ctx, err := NewContext(sink, sink, nil, nil, "", nil, nil)
+	assert.NoError(t, err)
+	prov, err := NewProviderFromAddress(ctx, "method", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, prov.Close()) }()
+	assert.NoError(t, prov.Configure(resource.PropertyMap{}))
+
+	urn := resource.URN("urn:pulumi:test::test::method:index:Bucket::b")
+	state := resource.PropertyMap{"scheme": resource.NewStringProperty("s3")}
+
+	// The resource's ID and state are passed along with the arguments.
+	ret, failures, err := prov.Call("method:index:Bucket/signedURL", urn, "bucket-1234", state, resource.PropertyMap{
+		"path": resource.NewStringProperty("key"),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Equal(t, resource.PropertyMap{"url": resource.NewStringProperty("s3://bucket-1234/key")}, ret)
+
+	// Failures are returned rather than results.
+	ret, failures, err = prov.Call("method:index:Bucket/signedURL", urn, "bucket-1234", state, resource.PropertyMap{})
+	assert.NoError(t, err)
+	assert.Equal(t, []CheckFailure{{Property: "path", Reason: "missing"}}, failures)
+	assert.Empty(t, ret)
+}
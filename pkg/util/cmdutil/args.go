@@ -38,6 +38,12 @@ func ArgsFunc(argsValidator cobra.PositionalArgs) cobra.PositionalArgs {
 // Pulumi error handling.
 var NoArgs = ArgsFunc(cobra.NoArgs)
 
+// MinimumNArgs is the same as cobra.MinimumNArgs, except it is wrapped with ArgsFunc to provide standard
+// Pulumi error handling.
+func MinimumNArgs(n int) cobra.PositionalArgs {
+	return ArgsFunc(cobra.MinimumNArgs(n))
+}
+
 // MaximumNArgs is the same as cobra.MaximumNArgs, except it is wrapped with ArgsFunc to provide standard
 // Pulumi error handling.
 func MaximumNArgs(n int) cobra.PositionalArgs {
@@ -0,0 +1,229 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/encoding"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// pluginBundleManifest is the name of the file, within a plugin bundle, that lists the plugins it contains.  It is a
+// lock file, so each plugin's checksum is recorded alongside it.
+const pluginBundleManifest = LockFileName
+
+// PluginBundleWriter writes a plugin bundle: a gzipped tarball that holds the tarballs of a set of plugins, for
+// installing them where plugin servers can't be reached.
+type PluginBundleWriter struct {
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest LockFile
+}
+
+// NewPluginBundleWriter returns a writer for a new plugin bundle.  The bundle is complete once the writer is closed.
+func NewPluginBundleWriter(w io.Writer) *PluginBundleWriter {
+	gz := gzip.NewWriter(w)
+	return &PluginBundleWriter{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+// Add adds the given plugin's tarball, for the plugin's platform, to the bundle.  A plugin that the bundle already
+// contains is not added again.
+func (w *PluginBundleWriter) Add(info PluginInfo, tarball []byte) error {
+	if w.manifest.Apply(info).Checksum != "" {
+		return nil
+	}
+	name, err := info.TarballName()
+	if err != nil {
+		return err
+	}
+	if err = w.writeFile(name, tarball); err != nil {
+		return err
+	}
+	w.manifest.Record(PluginInfo{Kind: info.Kind, Name: info.Name, Version: info.Version, Platform: info.Platform},
+		TarballChecksum(tarball), "")
+	return nil
+}
+
+// Close writes the bundle's manifest and completes the bundle.  It does not close the underlying writer.
+func (w *PluginBundleWriter) Close() error {
+	manifest, err := encoding.YAML.Marshal(w.manifest)
+	if err != nil {
+		return err
+	}
+	if err = w.writeFile(pluginBundleManifest, manifest); err != nil {
+		return err
+	}
+	if err = w.tw.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+func (w *PluginBundleWriter) writeFile(name string, contents []byte) error {
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(contents)),
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Wrapf(err, "writing %s to plugin bundle", name)
+	}
+	_, err := w.tw.Write(contents)
+	return errors.Wrapf(err, "writing %s to plugin bundle", name)
+}
+
+// PluginBundle is a plugin bundle that has been unpacked, from which plugins may be installed.
+type PluginBundle struct {
+	dir      string
+	manifest *LockFile
+}
+
+// OpenPluginBundle unpacks the plugin bundle at the given path into a temporary directory.  The bundle must be closed
+// to remove it.
+func OpenPluginBundle(path string) (*PluginBundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(f)
+
+	dir, err := ioutil.TempDir("", "pulumi-plugin-bundle")
+	if err != nil {
+		return nil, err
+	}
+	bundle := &PluginBundle{dir: dir}
+	if err = bundle.unpack(f); err != nil {
+		contract.IgnoreClose(bundle)
+		return nil, errors.Wrapf(err, "reading plugin bundle %s", path)
+	}
+	return bundle, nil
+}
+
+func (b *PluginBundle) unpack(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		// Bundles are flat, so anything else is not something that we wrote.
+		if header.Typeflag != tar.TypeReg || strings.ContainsAny(header.Name, `/\`) || header.Name == ".." {
+			return errors.Errorf("unexpected entry %s", header.Name)
+		}
+		if err = writeBundleFile(filepath.Join(b.dir, header.Name), tr); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := ioutil.ReadFile(filepath.Join(b.dir, pluginBundleManifest))
+	if os.IsNotExist(err) {
+		return errors.Errorf("it has no %s manifest", pluginBundleManifest)
+	} else if err != nil {
+		return err
+	}
+	b.manifest = &LockFile{}
+	if err = encoding.YAML.Unmarshal(manifest, b.manifest); err != nil {
+		return errors.Wrapf(err, "reading its %s manifest", pluginBundleManifest)
+	}
+	return nil
+}
+
+func writeBundleFile(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Plugins returns the plugins in the bundle for the given platform, with the checksums of their tarballs.
+func (b *PluginBundle) Plugins(platform Platform) ([]PluginInfo, error) {
+	var plugins []PluginInfo
+	for _, plug := range b.manifest.Plugins {
+		checksum, has := plug.Checksums[platform.String()]
+		if !has {
+			continue
+		}
+		version, err := semver.ParseTolerant(plug.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version for %s plugin %s in plugin bundle", plug.Kind, plug.Name)
+		}
+		plugins = append(plugins, PluginInfo{
+			Kind:     plug.Kind,
+			Name:     plug.Name,
+			Version:  &version,
+			Checksum: checksum,
+			Platform: platform,
+		})
+	}
+	return plugins, nil
+}
+
+// Checksum returns the checksum of the given plugin's tarball, for the plugin's platform, in the bundle, or "" if the
+// bundle doesn't contain it.
+func (b *PluginBundle) Checksum(info PluginInfo) string {
+	return b.manifest.Apply(info).Checksum
+}
+
+// Open returns the given plugin's tarball from the bundle, and its size.
+func (b *PluginBundle) Open(info PluginInfo) (io.ReadCloser, int64, error) {
+	name, err := info.TarballName()
+	if err != nil {
+		return nil, -1, err
+	}
+	if b.Checksum(info) == "" {
+		return nil, -1, errors.Errorf("the plugin bundle does not contain %s plugin %s for %s",
+			info.Kind, info, info.GetPlatform())
+	}
+
+	f, err := os.Open(filepath.Join(b.dir, name))
+	if err != nil {
+		return nil, -1, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		contract.IgnoreClose(f)
+		return nil, -1, err
+	}
+	return f, stat.Size(), nil
+}
+
+// Close removes the unpacked bundle.
+func (b *PluginBundle) Close() error {
+	return os.RemoveAll(b.dir)
+}
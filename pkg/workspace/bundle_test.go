@@ -0,0 +1,118 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginBundleRoundTrip(t *testing.T) {
+	old := currentPlatform
+	currentPlatform = Platform{OS: "linux", Arch: "amd64"}
+	defer func() { currentPlatform = old }()
+
+	dir, err := ioutil.TempDir("", "pulumi-bundle")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	v1 := semver.MustParse("1.0.0")
+	v2 := semver.MustParse("2.0.0-alpha.1")
+	aws := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1, ServerURL: "https://example.com"}
+	policy := PluginInfo{Kind: AnalyzerPlugin, Name: "policy", Version: &v2}
+
+	var buf bytes.Buffer
+	w := NewPluginBundleWriter(&buf)
+	assert.NoError(t, w.Add(aws, []byte("aws tarball")))
+	assert.NoError(t, w.Add(policy, []byte("policy tarball")))
+	assert.NoError(t, w.Add(aws, []byte("aws tarball")))
+	arm := Platform{OS: "linux", Arch: "arm64"}
+	awsArm := aws
+	awsArm.Platform = arm
+	assert.NoError(t, w.Add(awsArm, []byte("aws arm tarball")))
+	assert.NoError(t, w.Close())
+
+	path := filepath.Join(dir, "plugins.tar.gz")
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+
+	bundle, err := OpenPluginBundle(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { assert.NoError(t, bundle.Close()) }()
+
+	plugins, err := bundle.Plugins(CurrentPlatform())
+	assert.NoError(t, err)
+	if assert.Len(t, plugins, 2) {
+		assert.Equal(t, "aws", plugins[0].Name)
+		assert.Equal(t, "", plugins[0].ServerURL)
+		assert.Equal(t, TarballChecksum([]byte("aws tarball")), plugins[0].Checksum)
+		assert.Equal(t, "policy", plugins[1].Name)
+		assert.Equal(t, "2.0.0-alpha.1", plugins[1].Version.String())
+	}
+	assert.Equal(t, TarballChecksum([]byte("policy tarball")), bundle.Checksum(policy))
+
+	tarball, size, err := bundle.Open(policy)
+	if assert.NoError(t, err) {
+		b, err := ioutil.ReadAll(tarball)
+		assert.NoError(t, err)
+		assert.NoError(t, tarball.Close())
+		assert.Equal(t, "policy tarball", string(b))
+		assert.Equal(t, int64(len(b)), size)
+	}
+
+	// Plugins for other platforms are listed separately.
+	plugins, err = bundle.Plugins(arm)
+	assert.NoError(t, err)
+	if assert.Len(t, plugins, 1) {
+		assert.Equal(t, arm, plugins[0].Platform)
+		assert.Equal(t, TarballChecksum([]byte("aws arm tarball")), plugins[0].Checksum)
+	}
+	tarball, _, err = bundle.Open(awsArm)
+	if assert.NoError(t, err) {
+		b, err := ioutil.ReadAll(tarball)
+		assert.NoError(t, err)
+		assert.NoError(t, tarball.Close())
+		assert.Equal(t, "aws arm tarball", string(b))
+	}
+
+	missing := PluginInfo{Kind: ResourcePlugin, Name: "gcp", Version: &v1}
+	assert.Equal(t, "", bundle.Checksum(missing))
+	_, _, err = bundle.Open(missing)
+	assert.Error(t, err)
+}
+
+func TestOpenInvalidPluginBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-bundle")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Plugin tarballs are not bundles.
+	path := filepath.Join(dir, "plugin.tar.gz")
+	assert.NoError(t, ioutil.WriteFile(path, newPluginTarball(t, "pulumi-resource-foo", "#!/bin/sh\n"), 0600))
+	_, err = OpenPluginBundle(path)
+	assert.Error(t, err)
+
+	// Nor are archives with nested paths.
+	assert.NoError(t, ioutil.WriteFile(path, newPluginTarball(t, "../pulumi-resource-foo", "#!/bin/sh\n"), 0600))
+	_, err = OpenPluginBundle(path)
+	assert.Error(t, err)
+}
@@ -94,6 +94,8 @@ type Account struct {
 	AccessToken     string    `json:"accessToken,omitempty"`     // The access token for this account.
 	Username        string    `json:"username,omitempty"`        // The username for this account.
 	LastValidatedAt time.Time `json:"lastValidatedAt,omitempty"` // The last time this token was validated.
+	ReadOnly        bool      `json:"readOnly,omitempty"`        // True if this account may not modify stacks.
+	ShowSecrets     bool      `json:"showSecrets,omitempty"`     // True if a read-only account may show secrets.
 }
 
 // Credentials hold the information necessary for authenticating Pulumi Cloud API requests.  It contains
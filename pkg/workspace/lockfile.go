@@ -0,0 +1,159 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/encoding"
+)
+
+// PluginLock records the exact plugin that a project uses, so that the same plugin is installed wherever the project
+// is deployed.
+type PluginLock struct {
+	Kind      PluginKind `json:"kind" yaml:"kind"`                         // the kind of the plugin.
+	Name      string     `json:"name" yaml:"name"`                         // the name of the plugin.
+	Version   string     `json:"version" yaml:"version"`                   // the exact version of the plugin.
+	ServerURL string     `json:"server,omitempty" yaml:"server,omitempty"` // the server it is downloaded from.
+	// Checksums records the checksums of the plugin's tarballs, by platform.
+	Checksums map[string]string `json:"checksums,omitempty" yaml:"checksums,omitempty"`
+	// Executables records the checksums of the plugin's executables, by platform.
+	Executables map[string]string `json:"executables,omitempty" yaml:"executables,omitempty"`
+}
+
+// LockFile is the content of a project's Pulumi.lock file, which records the plugins the project uses.
+type LockFile struct {
+	Plugins []PluginLock `json:"plugins" yaml:"plugins"`
+}
+
+// LockFilePath returns the path of the lock file that belongs to the project file at the given path.
+func LockFilePath(projectPath string) string {
+	return filepath.Join(filepath.Dir(projectPath), LockFileName)
+}
+
+// LoadLockFile reads the lock file at the given path.  If there is no such file, an empty lock file is returned.
+func LoadLockFile(path string) (*LockFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &LockFile{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var lock LockFile
+	if err = encoding.YAML.Unmarshal(b, &lock); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	for i, plug := range lock.Plugins {
+		version, err := semver.ParseTolerant(plug.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s: invalid version for %s plugin %s", path, plug.Kind, plug.Name)
+		}
+		lock.Plugins[i].Version = version.String()
+	}
+	return &lock, nil
+}
+
+// Save writes the lock file to the given path, with its plugins in a stable order.
+func (lock *LockFile) Save(path string) error {
+	sort.Slice(lock.Plugins, func(i, j int) bool {
+		a, b := lock.Plugins[i], lock.Plugins[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return semver.MustParse(a.Version).LT(semver.MustParse(b.Version))
+	})
+
+	b, err := encoding.YAML.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Lookup returns the lock recorded for the given plugin, or nil if there is none.  Only plugins with versions may be
+// locked.
+func (lock *LockFile) Lookup(info PluginInfo) *PluginLock {
+	if info.Version == nil {
+		return nil
+	}
+	for i, plug := range lock.Plugins {
+		if plug.Kind == info.Kind && plug.Name == info.Name && semver.MustParse(plug.Version).EQ(*info.Version) {
+			return &lock.Plugins[i]
+		}
+	}
+	return nil
+}
+
+// Record records that the project uses the given plugin, whose tarball and executable for the plugin's platform have
+// the given checksums, and that it is downloaded from the plugin's server.  Checksums that are not known are given as
+// "", and are left as they were.  The checksums recorded for other platforms are kept.  Record returns true if the lock
+// file changed.
+func (lock *LockFile) Record(info PluginInfo, checksum, executable string) bool {
+	entry, changed := lock.Lookup(info), false
+	if entry == nil {
+		changed = true
+		lock.Plugins = append(lock.Plugins, PluginLock{
+			Kind:    info.Kind,
+			Name:    info.Name,
+			Version: info.Version.String(),
+		})
+		entry = &lock.Plugins[len(lock.Plugins)-1]
+	}
+
+	platform := info.GetPlatform().String()
+	if entry.ServerURL != info.ServerURL {
+		changed = true
+	}
+	entry.ServerURL = info.ServerURL
+	if checksum != "" && entry.Checksums[platform] != checksum {
+		if entry.Checksums == nil {
+			entry.Checksums = make(map[string]string)
+		}
+		entry.Checksums[platform] = checksum
+		changed = true
+	}
+	if executable != "" && entry.Executables[platform] != executable {
+		if entry.Executables == nil {
+			entry.Executables = make(map[string]string)
+		}
+		entry.Executables[platform] = executable
+		changed = true
+	}
+	return changed
+}
+
+// Apply fills in the checksums for the given plugin's platform, and the server if none is given, from the plugin's
+// lock, if it has one.
+func (lock *LockFile) Apply(info PluginInfo) PluginInfo {
+	if plug := lock.Lookup(info); plug != nil {
+		platform := info.GetPlatform().String()
+		info.Checksum = plug.Checksums[platform]
+		info.ExecutableChecksum = plug.Executables[platform]
+		if info.ServerURL == "" {
+			info.ServerURL = plug.ServerURL
+		}
+	}
+	return info
+}
@@ -0,0 +1,183 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFileRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A missing lock file is just an empty one.
+	path := LockFilePath(filepath.Join(dir, "Pulumi.yaml"))
+	assert.Equal(t, filepath.Join(dir, LockFileName), path)
+	lock, err := LoadLockFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, lock.Plugins)
+
+	v1 := semver.MustParse("1.0.0")
+	v2 := semver.MustParse("2.0.0")
+	linux := Platform{OS: "linux", Arch: "amd64"}
+	arm := Platform{OS: "linux", Arch: "arm64"}
+	aws := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2, ServerURL: "https://example.com", Platform: linux}
+	assert.True(t, lock.Record(aws, "sha256:b", "sha256:bx"))
+	assert.True(t, lock.Record(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1}, "sha256:a", ""))
+	assert.True(t, lock.Record(PluginInfo{Kind: AnalyzerPlugin, Name: "policy", Version: &v1}, "sha256:c", "sha256:cx"))
+
+	// Recording the same plugin again replaces its checksums for the same platform, and keeps those for others.
+	// Checksums that aren't known are left as they were.
+	assert.True(t, lock.Record(aws, "sha256:d", "sha256:dx"))
+	assert.False(t, lock.Record(aws, "sha256:d", "sha256:dx"))
+	assert.False(t, lock.Record(aws, "", ""))
+	awsArm := aws
+	awsArm.Platform = arm
+	assert.True(t, lock.Record(awsArm, "sha256:e", ""))
+	assert.NoError(t, lock.Save(path))
+
+	loaded, err := LoadLockFile(path)
+	assert.NoError(t, err)
+	if assert.Len(t, loaded.Plugins, 3) {
+		assert.Equal(t, AnalyzerPlugin, loaded.Plugins[0].Kind)
+		assert.Equal(t, "1.0.0", loaded.Plugins[1].Version)
+		assert.Equal(t, "2.0.0", loaded.Plugins[2].Version)
+	}
+
+	entry := loaded.Lookup(aws)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, map[string]string{"linux-amd64": "sha256:d", "linux-arm64": "sha256:e"}, entry.Checksums)
+		assert.Equal(t, map[string]string{"linux-amd64": "sha256:dx"}, entry.Executables)
+		assert.Equal(t, "https://example.com", entry.ServerURL)
+	}
+	assert.Nil(t, loaded.Lookup(PluginInfo{Kind: ResourcePlugin, Name: "aws"}))
+	assert.Nil(t, loaded.Lookup(PluginInfo{Kind: ResourcePlugin, Name: "gcp", Version: &v1}))
+
+	// Applying the lock fills in the checksum and the server, but doesn't override a server that was given.
+	applied := loaded.Apply(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2, Platform: linux})
+	assert.Equal(t, "sha256:d", applied.Checksum)
+	assert.Equal(t, "sha256:dx", applied.ExecutableChecksum)
+	assert.Equal(t, "https://example.com", applied.ServerURL)
+	applied = loaded.Apply(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2, ServerURL: "https://other.com",
+		Platform: arm})
+	assert.Equal(t, "sha256:e", applied.Checksum)
+	assert.Equal(t, "", applied.ExecutableChecksum)
+	assert.Equal(t, "https://other.com", applied.ServerURL)
+
+	// Platforms without a recorded checksum have none.
+	applied = loaded.Apply(PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2,
+		Platform: Platform{OS: "darwin", Arch: "amd64"}})
+	assert.Equal(t, "", applied.Checksum)
+}
+
+func newPluginTarball(t *testing.T, name, contents string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0755,
+		Size:     int64(len(contents)),
+		Typeflag: tar.TypeReg,
+	}))
+	_, err := tw.Write([]byte(contents))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestInstallVerifiesChecksum(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	old := os.Getenv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(PulumiHomeEnvVar, old)) }()
+
+	version := semver.MustParse("1.2.3")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "checksumtest", Version: &version}
+	good := newPluginTarball(t, "pulumi-resource-checksumtest", "#!/bin/sh\n")
+	bad := newPluginTarball(t, "pulumi-resource-checksumtest", "#!/bin/sh\nexit 1\n")
+	goodChecksum, badChecksum := TarballChecksum(good), TarballChecksum(bad)
+	assert.NotEqual(t, goodChecksum, badChecksum)
+
+	// A tarball that doesn't match the expected checksum is refused.
+	info.Checksum = goodChecksum
+	err = info.Install(ioutil.NopCloser(bytes.NewReader(bad)))
+	assert.Error(t, err)
+	assert.False(t, HasPlugin(info))
+
+	// One that does is installed, and its checksum is recorded.
+	assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(good))))
+	assert.True(t, HasPlugin(info))
+	checksum, err := GetPluginChecksum(info)
+	assert.NoError(t, err)
+	assert.Equal(t, goodChecksum, checksum)
+
+	// The plugin is verified against the checksum of its executable.
+	executable, err := GetPluginExecutableChecksum(info)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("#!/bin/sh\n"))), executable)
+	info.ExecutableChecksum = executable
+	assert.NoError(t, VerifyPluginChecksum(info))
+
+	// An executable that has changed since it was installed doesn't match.
+	dir, err := info.PlatformDirPath()
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "pulumi-resource-checksumtest")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\nexit 2\n"), 0700))
+	assert.Error(t, VerifyPluginChecksum(info))
+
+	// A tarball whose executable doesn't match the expected checksum is refused, and the plugin is left as it was.
+	mismatched := info
+	mismatched.Checksum = ""
+	assert.Error(t, mismatched.Install(ioutil.NopCloser(bytes.NewReader(bad))))
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nexit 2\n", string(contents))
+
+	// Reinstalling the plugin from its tarball restores its executable.
+	assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(good))))
+	assert.NoError(t, VerifyPluginChecksum(info))
+
+	// Installing a different tarball replaces the plugin.
+	mismatched.Checksum, mismatched.ExecutableChecksum = badChecksum, ""
+	assert.NoError(t, mismatched.Install(ioutil.NopCloser(bytes.NewReader(bad))))
+	contents, err = ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nexit 1\n", string(contents))
+	assert.Error(t, VerifyPluginChecksum(info))
+
+	// Unless checksums are required, plugins without one aren't checked.
+	info.ExecutableChecksum = ""
+	assert.NoError(t, VerifyPluginChecksum(info))
+	assert.NoError(t, os.Setenv(RequirePluginChecksumsEnvVar, "true"))
+	defer func() { assert.NoError(t, os.Unsetenv(RequirePluginChecksumsEnvVar)) }()
+	assert.Error(t, VerifyPluginChecksum(info))
+}
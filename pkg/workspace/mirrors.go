@@ -0,0 +1,193 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/azureblob" // driver for azblob://
+	_ "gocloud.dev/blob/fileblob"  // driver for file://
+	_ "gocloud.dev/blob/gcsblob"   // driver for gs://
+	_ "gocloud.dev/blob/s3blob"    // driver for s3://
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/httputil"
+	"github.com/pulumi/pulumi/pkg/version"
+)
+
+// PluginMirrorsEnvVar is the environment variable that lists, separated by commas, the mirrors from which plugins are
+// downloaded, in the order in which they are tried.  The plugin's own server is tried after all of them.  A mirror is
+// an HTTP(S) URL, a file:// URL or path of a local directory, or a gocloud blob URL such as s3://bucket/plugins.  Each
+// mirror holds plugin tarballs, and optionally their ".sha256" checksums, under the same names as the server does.
+const PluginMirrorsEnvVar = "PULUMI_PLUGIN_MIRRORS"
+
+// GetPluginMirrors returns the plugin mirrors listed in PULUMI_PLUGIN_MIRRORS, in order.
+func GetPluginMirrors() []string {
+	var mirrors []string
+	for _, mirror := range strings.Split(os.Getenv(PluginMirrorsEnvVar), ",") {
+		if mirror = strings.TrimSpace(mirror); mirror != "" {
+			mirrors = append(mirrors, mirror)
+		}
+	}
+	return mirrors
+}
+
+// sourceNotFoundError is returned when a plugin source does not have the requested file.
+type sourceNotFoundError struct {
+	source string
+	name   string
+}
+
+func (e sourceNotFoundError) Error() string {
+	return fmt.Sprintf("%s was not found in %s", e.name, e.source)
+}
+
+// isSourceNotFound returns true if the given error reports that a plugin source does not have the requested file.
+func isSourceNotFound(err error) bool {
+	_, ok := errors.Cause(err).(sourceNotFoundError)
+	return ok
+}
+
+// unwrapSingleError returns the only error in the given multierror, if there is exactly one.
+func unwrapSingleError(err error) error {
+	if merr, ok := err.(*multierror.Error); ok && len(merr.Errors) == 1 {
+		return merr.Errors[0]
+	}
+	return err
+}
+
+// fetchFromSource fetches the file with the given name from a plugin source, and also returns its size (if known).
+func fetchFromSource(source, name string) (io.ReadCloser, int64, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return fetchFromServer(source, name)
+	}
+	return fetchFromBucket(source, name)
+}
+
+// get issues a GET request for the given endpoint, retrying as necessary.
+func get(endpoint string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
+	req.Header.Set("User-Agent", userAgent)
+
+	return httputil.DoWithRetry(req, http.DefaultClient)
+}
+
+// fetchFromServer fetches a file from a plugin server, or a mirror of one, over HTTP(S).
+func fetchFromServer(serverURL, name string) (io.ReadCloser, int64, error) {
+	endpoint := strings.TrimSuffix(serverURL, "/") + "/" + name
+	resp, err := get(endpoint)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		contract.IgnoreClose(resp.Body)
+		return nil, -1, sourceNotFoundError{source: serverURL, name: name}
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		contract.IgnoreClose(resp.Body)
+		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+	}
+
+	return resp.Body, resp.ContentLength, nil
+}
+
+// bucketReader reads a file from a bucket, and closes the bucket when it is closed.
+type bucketReader struct {
+	*blob.Reader
+	bucket *blob.Bucket
+}
+
+func (r *bucketReader) Close() error {
+	err := r.Reader.Close()
+	contract.IgnoreClose(r.bucket)
+	return err
+}
+
+// fetchFromBucket fetches a file from a mirror that is a local directory or a gocloud blob bucket.
+func fetchFromBucket(mirror, name string) (io.ReadCloser, int64, error) {
+	bucketURL, prefix, err := parseBucketMirror(mirror)
+	if err != nil {
+		return nil, -1, errors.Wrapf(err, "invalid plugin mirror %s", mirror)
+	}
+
+	ctx := context.Background()
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, -1, errors.Wrapf(err, "opening plugin mirror %s", mirror)
+	}
+	if prefix != "" {
+		bucket = blob.PrefixedBucket(bucket, prefix)
+	}
+
+	reader, err := bucket.NewReader(ctx, name, nil)
+	if err != nil {
+		contract.IgnoreClose(bucket)
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, -1, sourceNotFoundError{source: mirror, name: name}
+		}
+		return nil, -1, errors.Wrapf(err, "fetching plugin from %s", mirror)
+	}
+	return &bucketReader{Reader: reader, bucket: bucket}, reader.Size(), nil
+}
+
+// parseBucketMirror returns the URL of the bucket that holds a mirror's files, and the prefix of their keys within it.
+// Local directories, given either by path or by file:// URL, are the buckets themselves.
+func parseBucketMirror(mirror string) (string, string, error) {
+	if !strings.Contains(mirror, "://") {
+		path, err := filepath.Abs(mirror)
+		if err != nil {
+			return "", "", err
+		}
+
+		// On Windows, convert "\" to "/" and add a leading "/". (See https://gocloud.dev/howto/blob/#local)
+		path = filepath.ToSlash(path)
+		if os.PathSeparator != '/' && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		return "file://" + path, "", nil
+	}
+
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme == "file" {
+		return mirror, "", nil
+	}
+
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	u.Path = ""
+	return u.String(), prefix, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTarball(t *testing.T, info PluginInfo) string {
+	tarball, _, err := info.Download()
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer tarball.Close()
+	b, err := ioutil.ReadAll(tarball)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestDownloadFromMirrors(t *testing.T) {
+	v := semver.MustParse("1.0.0")
+	local := PluginInfo{Kind: ResourcePlugin, Name: "local", Version: &v}
+	remote := PluginInfo{Kind: ResourcePlugin, Name: "remote", Version: &v}
+	server := PluginInfo{Kind: ResourcePlugin, Name: "server", Version: &v}
+	missing := PluginInfo{Kind: ResourcePlugin, Name: "missing", Version: &v}
+
+	// The first mirror is a local directory.
+	dir, err := ioutil.TempDir("", "pulumi-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	localName, err := local.TarballName()
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, localName), []byte("local tarball"), 0600))
+	checksum := TarballChecksum([]byte("local tarball"))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, localName+".sha256"),
+		[]byte(checksum[len(checksumPrefix):]+"  "+localName+"\n"), 0600))
+
+	// The second is served over HTTP, as is the plugins' own server.
+	serve := func(plug PluginInfo, contents string) *httptest.Server {
+		name, err := plug.TarballName()
+		assert.NoError(t, err)
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/plugins/"+name {
+				http.NotFound(w, r)
+				return
+			}
+			_, err := w.Write([]byte(contents))
+			assert.NoError(t, err)
+		}))
+	}
+	mirror := serve(remote, "remote tarball")
+	defer mirror.Close()
+	origin := serve(server, "server tarball")
+	defer origin.Close()
+
+	old := os.Getenv(PluginMirrorsEnvVar)
+	assert.NoError(t, os.Setenv(PluginMirrorsEnvVar, dir+", "+mirror.URL+"/plugins/"))
+	defer func() { assert.NoError(t, os.Setenv(PluginMirrorsEnvVar, old)) }()
+
+	for _, plug := range []*PluginInfo{&local, &remote, &server, &missing} {
+		plug.ServerURL = origin.URL + "/plugins"
+		assert.Equal(t, []string{dir, mirror.URL + "/plugins/", origin.URL + "/plugins"}, plug.Sources())
+	}
+
+	assert.Equal(t, "local tarball", readTarball(t, local))
+	assert.Equal(t, "remote tarball", readTarball(t, remote))
+	assert.Equal(t, "server tarball", readTarball(t, server))
+	_, _, err = missing.Download()
+	assert.Error(t, err)
+
+	// Checksums are found wherever they are published.
+	sum, err := local.DownloadChecksum()
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, sum)
+	sum, err = remote.DownloadChecksum()
+	assert.NoError(t, err)
+	assert.Equal(t, "", sum)
+}
+
+func TestParseBucketMirror(t *testing.T) {
+	bucket, prefix, err := parseBucketMirror("s3://bucket/releases/plugins/?region=us-west-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket?region=us-west-2", bucket)
+	assert.Equal(t, "releases/plugins/", prefix)
+
+	bucket, prefix, err = parseBucketMirror("gs://bucket")
+	assert.NoError(t, err)
+	assert.Equal(t, "gs://bucket", bucket)
+	assert.Equal(t, "", prefix)
+
+	bucket, prefix, err = parseBucketMirror("file:///var/plugins")
+	assert.NoError(t, err)
+	assert.Equal(t, "file:///var/plugins", bucket)
+	assert.Equal(t, "", prefix)
+}
@@ -39,12 +39,17 @@ const (
 	GitDir = ".git"
 	// HistoryDir is the name of the directory that holds historical information for projects.
 	HistoryDir = "history"
+	// JournalDir is the name of the directory that holds the journals of changes made to stacks since they were last
+	// checkpointed.
+	JournalDir = "journals"
 	// PluginDir is the name of the directory containing plugins.
 	PluginDir = "plugins"
 	// PolicyDir is the name of the directory that holds policy packs.
 	PolicyDir = "policies"
 	// StackDir is the name of the directory that holds stack information for projects.
 	StackDir = "stacks"
+	// TagDir is the name of the directory that holds the tags of stacks managed by a local or cloud storage backend.
+	TagDir = "tags"
 	// TemplateDir is the name of the directory containing templates.
 	TemplateDir = "templates"
 	// WorkspaceDir is the name of the directory that holds workspace information for projects.
@@ -55,6 +60,8 @@ const (
 
 	// ProjectFile is the base name of a project file.
 	ProjectFile = "Pulumi"
+	// LockFile is the name of the file, next to a project file, that records the plugins the project uses.
+	LockFileName = "Pulumi.lock"
 	// RepoFile is the name of the file that holds information specific to the entire repository.
 	RepoFile = "settings.json"
 	// WorkspaceFile is the name of the file that holds workspace information.
@@ -0,0 +1,74 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Platform is an operating system and architecture pair for which plugins are built, such as linux-arm64.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+func (p Platform) String() string {
+	return p.OS + "-" + p.Arch
+}
+
+// Validate returns an error if plugins are not published for the platform.
+func (p Platform) Validate() error {
+	switch p.OS {
+	case "darwin", "linux", "windows":
+	default:
+		return errors.Errorf("unsupported plugin OS: %s", p.OS)
+	}
+	switch p.Arch {
+	case "amd64", "arm64":
+	default:
+		return errors.Errorf("unsupported plugin architecture: %s", p.Arch)
+	}
+	return nil
+}
+
+// ParsePlatform parses a platform of the form <os>-<arch>, such as linux-arm64.
+func ParsePlatform(s string) (Platform, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return Platform{}, errors.Errorf("invalid platform %q: expected <os>-<arch>, such as linux-arm64", s)
+	}
+	p := Platform{OS: parts[0], Arch: parts[1]}
+	if err := p.Validate(); err != nil {
+		return Platform{}, err
+	}
+	return p, nil
+}
+
+// currentPlatform is the platform that this process runs on.  Tests replace it to exercise other platforms.
+var currentPlatform = Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+
+// CurrentPlatform returns the platform that this process runs on, and so the platform of the plugins it loads.
+func CurrentPlatform() Platform {
+	return currentPlatform
+}
+
+// legacyPlatform returns the platform of plugins installed before plugin directories held more than one platform.
+// Plugins were only published for amd64 then, so that is what their files are built for.
+func legacyPlatform() Platform {
+	return Platform{OS: currentPlatform.OS, Arch: "amd64"}
+}
@@ -0,0 +1,181 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+// withPlatform runs the given function as if this process ran on the given platform.
+func withPlatform(platform Platform, f func()) {
+	old := currentPlatform
+	currentPlatform = platform
+	defer func() { currentPlatform = old }()
+	f()
+}
+
+func TestParsePlatform(t *testing.T) {
+	platform, err := ParsePlatform("linux-arm64")
+	assert.NoError(t, err)
+	assert.Equal(t, Platform{OS: "linux", Arch: "arm64"}, platform)
+	assert.Equal(t, "linux-arm64", platform.String())
+
+	for _, invalid := range []string{"linux", "linux-arm64-v8", "linux-386", "plan9-amd64"} {
+		_, err = ParsePlatform(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestTarballNamePlatforms(t *testing.T) {
+	v := semver.MustParse("1.2.3")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v}
+
+	withPlatform(Platform{OS: "linux", Arch: "arm64"}, func() {
+		name, err := info.TarballName()
+		assert.NoError(t, err)
+		assert.Equal(t, "pulumi-resource-aws-v1.2.3-linux-arm64.tar.gz", name)
+
+		// An explicit platform wins over the current one.
+		info.Platform = Platform{OS: "darwin", Arch: "amd64"}
+		name, err = info.TarballName()
+		assert.NoError(t, err)
+		assert.Equal(t, "pulumi-resource-aws-v1.2.3-darwin-amd64.tar.gz", name)
+		info.Platform = Platform{}
+	})
+
+	withPlatform(Platform{OS: "linux", Arch: "386"}, func() {
+		_, err := info.TarballName()
+		assert.EqualError(t, err, "unsupported plugin architecture: 386")
+	})
+}
+
+// withPulumiHome runs the given function with an empty Pulumi home directory.
+func withPulumiHome(t *testing.T, f func()) {
+	home, err := ioutil.TempDir("", "pulumi-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	old := os.Getenv(PulumiHomeEnvVar)
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+	defer func() { assert.NoError(t, os.Setenv(PulumiHomeEnvVar, old)) }()
+	f()
+}
+
+func TestMultiPlatformPlugins(t *testing.T) {
+	amd64 := Platform{OS: "linux", Arch: "amd64"}
+	arm64 := Platform{OS: "linux", Arch: "arm64"}
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "multiarch", Version: &v}
+	amd64Tarball := newPluginTarball(t, "pulumi-resource-multiarch", "amd64")
+	arm64Tarball := newPluginTarball(t, "pulumi-resource-multiarch", "arm64")
+
+	withPulumiHome(t, func() {
+		// Install the plugin for each architecture into the same directory, as agents sharing a cache would.
+		withPlatform(amd64, func() {
+			assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(amd64Tarball))))
+			assert.True(t, HasPlugin(info))
+		})
+		withPlatform(arm64, func() {
+			assert.False(t, HasPlugin(info))
+			_, path, err := GetPluginPath(info.Kind, info.Name, info.Version)
+			assert.Error(t, err)
+			assert.Equal(t, "", path)
+
+			assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(arm64Tarball))))
+			assert.True(t, HasPlugin(info))
+		})
+
+		plugins, err := GetPlugins()
+		assert.NoError(t, err)
+		if assert.Len(t, plugins, 1) {
+			assert.Equal(t, []Platform{amd64, arm64}, plugins[0].Platforms)
+		}
+
+		// Each platform loads its own files.
+		for _, platform := range []Platform{amd64, arm64} {
+			withPlatform(platform, func() {
+				dir, path, err := GetPluginPath(info.Kind, info.Name, info.Version)
+				assert.NoError(t, err)
+				assert.Equal(t, platform.String(), filepath.Base(dir))
+				contents, err := ioutil.ReadFile(path)
+				assert.NoError(t, err)
+				assert.Equal(t, platform.Arch, string(contents))
+
+				checksum, err := GetPluginChecksum(info)
+				assert.NoError(t, err)
+				if platform == amd64 {
+					assert.Equal(t, TarballChecksum(amd64Tarball), checksum)
+				} else {
+					assert.Equal(t, TarballChecksum(arm64Tarball), checksum)
+				}
+			})
+		}
+	})
+}
+
+func TestLegacyPluginLayout(t *testing.T) {
+	amd64 := Platform{OS: "linux", Arch: "amd64"}
+	arm64 := Platform{OS: "linux", Arch: "arm64"}
+	v := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "legacy", Version: &v}
+
+	withPulumiHome(t, func() {
+		// Plugins used to be installed directly into their directories, and were always built for amd64.
+		dir, err := info.DirPath()
+		assert.NoError(t, err)
+		assert.NoError(t, os.MkdirAll(dir, 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pulumi-resource-legacy"), []byte("legacy"), 0700))
+
+		withPlatform(amd64, func() {
+			assert.True(t, HasPlugin(info))
+			installed, path, err := GetPluginPath(info.Kind, info.Name, info.Version)
+			assert.NoError(t, err)
+			assert.Equal(t, dir, installed)
+			assert.Equal(t, filepath.Join(dir, "pulumi-resource-legacy"), path)
+
+			plugins, err := GetPlugins()
+			assert.NoError(t, err)
+			if assert.Len(t, plugins, 1) {
+				assert.Equal(t, []Platform{amd64}, plugins[0].Platforms)
+			}
+		})
+		withPlatform(arm64, func() {
+			assert.False(t, HasPlugin(info))
+
+			// Installing another architecture leaves the legacy files in place.
+			tarball := newPluginTarball(t, "pulumi-resource-legacy", "arm64")
+			assert.NoError(t, info.Install(ioutil.NopCloser(bytes.NewReader(tarball))))
+			assert.True(t, HasPlugin(info))
+
+			plugins, err := GetPlugins()
+			assert.NoError(t, err)
+			if assert.Len(t, plugins, 1) {
+				assert.Equal(t, []Platform{amd64, arm64}, plugins[0].Platforms)
+			}
+		})
+		withPlatform(amd64, func() {
+			path, err := info.FilePath()
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, "pulumi-resource-legacy"), path)
+		})
+	})
+}
@@ -15,10 +15,10 @@
 package workspace
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"os/exec"
 	"path"
@@ -26,6 +26,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/util/archive"
@@ -33,17 +34,20 @@ import (
 	"github.com/blang/semver"
 	"github.com/cheggaaa/pb"
 	"github.com/djherbis/times"
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/util/contract"
-	"github.com/pulumi/pulumi/pkg/util/httputil"
 	"github.com/pulumi/pulumi/pkg/util/logging"
-	"github.com/pulumi/pulumi/pkg/version"
 )
 
 const (
 	windowsGOOS = "windows"
+
+	// RequirePluginChecksumsEnvVar is the environment variable that, when set to any value, refuses to load any
+	// plugin whose checksum is not recorded in the project's lock file.
+	RequirePluginChecksumsEnvVar = "PULUMI_REQUIRE_PLUGIN_CHECKSUMS"
 )
 
 var (
@@ -75,17 +79,34 @@ func (err *MissingError) Error() string {
 }
 
 // PluginInfo provides basic information about a plugin.  Each plugin gets installed into a system-wide
-// location, by default `~/.pulumi/plugins/<kind>-<name>-<version>/`.  A plugin may contain multiple files,
-// however the primary loadable executable must be named `pulumi-<kind>-<name>`.
+// location, by default `~/.pulumi/plugins/<kind>-<name>-<version>/`, with its files for each platform in a directory
+// named after that platform, such as `linux-arm64/`.  A plugin may contain multiple files, however the primary
+// loadable executable must be named `pulumi-<kind>-<name>`.
 type PluginInfo struct {
-	Name         string          // the simple name of the plugin.
-	Path         string          // the path that a plugin was loaded from.
-	Kind         PluginKind      // the kind of the plugin (language, resource, etc).
-	Version      *semver.Version // the plugin's semantic version, if present.
-	Size         int64           // the size of the plugin, in bytes.
-	InstallTime  time.Time       // the time the plugin was installed.
-	LastUsedTime time.Time       // the last time the plugin was used.
-	ServerURL    string          // an optional server to use when downloading this plugin.
+	Name               string          // the simple name of the plugin.
+	Path               string          // the path that a plugin was loaded from.
+	Kind               PluginKind      // the kind of the plugin (language, resource, etc).
+	Version            *semver.Version // the plugin's semantic version, if present.
+	Size               int64           // the size of the plugin, in bytes.
+	InstallTime        time.Time       // the time the plugin was installed.
+	LastUsedTime       time.Time       // the last time the plugin was used.
+	ServerURL          string          // an optional server to use when downloading this plugin.
+	Checksum           string          // the expected checksum of this plugin's tarball, if known.
+	ExecutableChecksum string          // the expected checksum of this plugin's executable, if known.
+	Platform           Platform        // the platform of this plugin's files, or the current platform if empty.
+	Platforms          []Platform      // the platforms for which an installed plugin's files are present.
+}
+
+// GetPlatform returns the platform of this plugin's files.
+func (info PluginInfo) GetPlatform() Platform {
+	platform := info.Platform
+	if platform.OS == "" {
+		platform.OS = currentPlatform.OS
+	}
+	if platform.Arch == "" {
+		platform.Arch = currentPlatform.Arch
+	}
+	return platform
 }
 
 // Dir gets the expected plugin directory for this plugin.
@@ -109,7 +130,7 @@ func (info PluginInfo) FilePrefix() string {
 
 // FileSuffix returns the suffix for the plugin (if any).
 func (info PluginInfo) FileSuffix() string {
-	if runtime.GOOS == windowsGOOS {
+	if info.GetPlatform().OS == windowsGOOS {
 		return ".exe"
 	}
 	return ""
@@ -124,17 +145,61 @@ func (info PluginInfo) DirPath() (string, error) {
 	return filepath.Join(dir, info.Dir()), nil
 }
 
-// FilePath returns the full path where this plugin's primary executable should be installed.
-func (info PluginInfo) FilePath() (string, error) {
+// PlatformDirPath returns the directory where this plugin's files for its platform should be installed.
+func (info PluginInfo) PlatformDirPath() (string, error) {
 	dir, err := info.DirPath()
 	if err != nil {
 		return "", err
 	}
+	return filepath.Join(dir, info.GetPlatform().String()), nil
+}
+
+// InstalledDirPath returns the directory where this plugin's files for its platform are installed, or "" if they are
+// not installed.  Plugins installed before plugin directories held more than one platform keep their files directly
+// in the plugin's directory.
+func (info PluginInfo) InstalledDirPath() (string, error) {
+	platformDir, err := info.PlatformDirPath()
+	if err != nil {
+		return "", err
+	}
+	if stat, err := os.Stat(platformDir); err == nil && stat.IsDir() {
+		return platformDir, nil
+	}
+
+	if info.GetPlatform() == legacyPlatform() {
+		dir, err := info.DirPath()
+		if err != nil {
+			return "", err
+		}
+		if info.hasLegacyFiles(dir) {
+			return dir, nil
+		}
+	}
+	return "", nil
+}
+
+// hasLegacyFiles returns true if the given plugin directory holds the plugin's primary executable directly.
+func (info PluginInfo) hasLegacyFiles(dir string) bool {
+	stat, err := os.Stat(filepath.Join(dir, info.File()))
+	return err == nil && !stat.IsDir()
+}
+
+// FilePath returns the full path where this plugin's primary executable for its platform is, or should be, installed.
+func (info PluginInfo) FilePath() (string, error) {
+	dir, err := info.InstalledDirPath()
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		if dir, err = info.PlatformDirPath(); err != nil {
+			return "", err
+		}
+	}
 	return filepath.Join(dir, info.File()), nil
 }
 
-// Delete removes the plugin from the cache.  It also deletes any supporting files in the cache, which includes
-// any files that contain the same prefix as the plugin itself.
+// Delete removes the plugin, for all platforms, from the cache.  It also deletes any supporting files in the cache,
+// which includes any files that contain the same prefix as the plugin itself.
 func (info PluginInfo) Delete() error {
 	dir, err := info.DirPath()
 	if err != nil {
@@ -169,24 +234,21 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 	return nil
 }
 
-// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
-func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
-	// Figure out the OS/ARCH pair for the download URL.
-	var os string
-	switch runtime.GOOS {
-	case "darwin", "linux", "windows":
-		os = runtime.GOOS
-	default:
-		return nil, -1, errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
-	}
-	var arch string
-	switch runtime.GOARCH {
-	case "amd64":
-		arch = runtime.GOARCH
-	default:
-		return nil, -1, errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+// TarballName returns the name of this plugin's tarball for its platform, as it is published by plugin servers and
+// mirrors.
+func (info PluginInfo) TarballName() (string, error) {
+	platform := info.GetPlatform()
+	if err := platform.Validate(); err != nil {
+		return "", err
 	}
 
+	return fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz",
+		info.Kind, info.Name, info.Version, platform.OS, platform.Arch), nil
+}
+
+// Sources returns the URLs from which this plugin's tarball is downloaded, in the order in which they are tried: any
+// mirrors listed in PULUMI_PLUGIN_MIRRORS, and then the plugin's server.
+func (info PluginInfo) Sources() []string {
 	// If the plugin has a server, associated with it, download from there.  Otherwise use the "default" location, which
 	// is hosted by Pulumi.
 	serverURL := info.ServerURL
@@ -194,39 +256,179 @@ func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 		serverURL = "https://api.pulumi.com/releases/plugins"
 	}
 
-	endpoint := fmt.Sprintf("%s/pulumi-%s-%s-v%s-%s-%s.tar.gz", serverURL, info.Kind, info.Name, info.Version, os, arch)
-	req, err := http.NewRequest("GET", endpoint, nil)
+	return append(GetPluginMirrors(), serverURL)
+}
+
+// Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).  Each of the
+// plugin's sources is tried in turn, until one of them has the plugin's tarball.
+func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
+	name, err := info.TarballName()
 	if err != nil {
 		return nil, -1, err
 	}
 
-	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
-	req.Header.Set("User-Agent", userAgent)
+	var result error
+	for _, source := range info.Sources() {
+		tarball, size, err := fetchFromSource(source, name)
+		if err == nil {
+			return tarball, size, nil
+		}
+		logging.V(5).Infof("Download(%s %s): %v", info.Kind, info, err)
+		result = multierror.Append(result, err)
+	}
+	return nil, -1, unwrapSingleError(result)
+}
 
-	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+// DownloadChecksum fetches the checksum that the plugin's sources publish for this plugin's tarball, in a file named
+// after the tarball with a ".sha256" extension.  The first such checksum found is returned.  If no source publishes a
+// checksum, "" is returned.
+func (info PluginInfo) DownloadChecksum() (string, error) {
+	name, err := info.TarballName()
 	if err != nil {
-		return nil, -1, err
+		return "", err
+	}
+	name += ".sha256"
+
+	// Sources that can't be reached are skipped, so that a mirror without checksums may stand in for an unreachable
+	// server.  If none of the sources can be reached, though, we can't say that there is no checksum.
+	sources := info.Sources()
+	var result error
+	for _, source := range sources {
+		body, _, err := fetchFromSource(source, name)
+		if isSourceNotFound(err) {
+			continue
+		} else if err != nil {
+			logging.V(5).Infof("DownloadChecksum(%s %s): %v", info.Kind, info, err)
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		// The file holds the checksum in hexadecimal, optionally followed by the name of the tarball.
+		b, err := ioutil.ReadAll(body)
+		contract.IgnoreClose(body)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading plugin checksum from %s", source)
+		}
+		fields := strings.Fields(string(b))
+		if len(fields) == 0 || !sha256Regexp.MatchString(fields[0]) {
+			return "", errors.Errorf("%s in %s does not contain a SHA-256 checksum", name, source)
+		}
+		return checksumPrefix + strings.ToLower(fields[0]), nil
+	}
+
+	if merr, ok := result.(*multierror.Error); ok && len(merr.Errors) == len(sources) {
+		return "", unwrapSingleError(result)
+	}
+	return "", nil
+}
+
+// checksumPrefix prefixes the hexadecimal SHA-256 digest of a plugin's tarball to form its checksum.
+const checksumPrefix = "sha256:"
+
+// sha256Regexp matches a SHA-256 digest in hexadecimal.
+var sha256Regexp = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// pluginChecksumFile is the name of the file, within an installed plugin's directory, that records the checksum of the
+// tarball it was installed from.
+const pluginChecksumFile = ".checksum"
+
+// TarballChecksum returns the checksum of the given plugin tarball.
+func TarballChecksum(tarball []byte) string {
+	return fmt.Sprintf("%s%x", checksumPrefix, sha256.Sum256(tarball))
+}
+
+// GetPluginChecksum returns the checksum of the tarball from which the given plugin was installed for its platform, or
+// "" if the plugin is not installed or its checksum was not recorded.
+func GetPluginChecksum(info PluginInfo) (string, error) {
+	dir, err := info.InstalledDirPath()
+	if err != nil || dir == "" {
+		return "", err
+	}
+	return readPluginChecksum(dir)
+}
+
+// readPluginChecksum returns the checksum recorded in the given plugin directory, or "" if there is none.
+func readPluginChecksum(dir string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, pluginChecksumFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
 	}
+	return strings.TrimSpace(string(b)), nil
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+// executableChecksum returns the checksum of the plugin executable at the given path.
+func executableChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
 	}
+	defer contract.IgnoreClose(f)
 
-	return resp.Body, resp.ContentLength, nil
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%x", checksumPrefix, hash.Sum(nil)), nil
+}
+
+// GetPluginExecutableChecksum returns the checksum of the executable that will be loaded for the given plugin, or "" if
+// there is no such plugin.
+func GetPluginExecutableChecksum(info PluginInfo) (string, error) {
+	_, path, err := GetPluginPath(info.Kind, info.Name, info.Version)
+	if err != nil || path == "" {
+		return "", err
+	}
+	return executableChecksum(path)
 }
 
-// Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
+// VerifyPluginChecksum checks that the executable that will be loaded for the given plugin has the plugin's expected
+// checksum.  Plugins without an expected checksum are not checked, unless RequirePluginChecksumsEnvVar is set, in
+// which case they are refused.
+func VerifyPluginChecksum(info PluginInfo) error {
+	if info.ExecutableChecksum == "" {
+		if os.Getenv(RequirePluginChecksumsEnvVar) != "" {
+			return errors.Errorf("no checksum is recorded for %s plugin %s in %s, and %s is set; "+
+				"run `pulumi plugin install` to record one", info.Kind, info, LockFileName, RequirePluginChecksumsEnvVar)
+		}
+		return nil
+	}
+
+	_, path, err := GetPluginPath(info.Kind, info.Name, info.Version)
+	if err != nil {
+		return err
+	} else if path == "" {
+		// Missing plugins are reported when they are loaded.
+		return nil
+	}
+
+	checksum, err := executableChecksum(path)
+	if err != nil {
+		return errors.Wrapf(err, "computing the checksum of %s plugin %s", info.Kind, info)
+	}
+	if checksum != info.ExecutableChecksum {
+		return errors.Errorf("%s plugin %s at %s has checksum %s, but %s is recorded in %s; "+
+			"run `pulumi plugin install --reinstall` to reinstall it", info.Kind, info, path, checksum,
+			info.ExecutableChecksum, LockFileName)
+	}
+	return nil
+}
+
+// Install installs a plugin's tarball, for the plugin's platform, into the cache.  It validates that plugin names are
+// in the expected format.  If the plugin has a checksum, the tarball must match it, and if it has an executable
+// checksum, the executable it contains must match that.  The tarball's checksum is recorded alongside the plugin.
 func (info PluginInfo) Install(tarball io.ReadCloser) error {
 	// Fetch the directory into which we will expand this tarball, and create it.
-	finalDir, err := info.DirPath()
+	finalDir, err := info.PlatformDirPath()
 	if err != nil {
 		return err
 	}
 
 	// If part of the directory tree is missing, ioutil.TempDir will return an error, so make sure the path we're going
-	// to create the temporary folder in actually exists.
+	// to create the temporary folder in actually exists.  The plugin's directory holds its files for every platform.
 	if err := os.MkdirAll(filepath.Dir(finalDir), 0700); err != nil {
-		return errors.Wrap(err, "creating plugin root")
+		return errors.Wrap(err, "creating plugin directory")
 	}
 
 	tempDir, err := ioutil.TempDir(filepath.Dir(finalDir), fmt.Sprintf("%s.tmp", filepath.Base(finalDir)))
@@ -249,12 +451,47 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 			return err
 		}
 
-		return archive.Untgz(tarballBytes, tempDir)
+		// If we know what the tarball should be, make sure that it is exactly that before unpacking anything.
+		checksum := TarballChecksum(tarballBytes)
+		if info.Checksum != "" && checksum != info.Checksum {
+			return errors.Errorf("the tarball for %s plugin %s has checksum %s, but %s was expected",
+				info.Kind, info, checksum, info.Checksum)
+		}
+
+		if err = archive.Untgz(tarballBytes, tempDir); err != nil {
+			return err
+		}
+		if info.ExecutableChecksum != "" {
+			executable, err := executableChecksum(filepath.Join(tempDir, info.File()))
+			if err != nil {
+				return errors.Wrapf(err, "computing the checksum of %s plugin %s", info.Kind, info)
+			}
+			if executable != info.ExecutableChecksum {
+				return errors.Errorf("the executable of %s plugin %s has checksum %s, but %s was expected",
+					info.Kind, info, executable, info.ExecutableChecksum)
+			}
+		}
+		return ioutil.WriteFile(filepath.Join(tempDir, pluginChecksumFile), []byte(checksum+"\n"), 0600)
 	})()
 	if err != nil {
 		return err
 	}
 
+	// If the plugin is already installed, but from a different tarball, or its executable has changed since, replace it.
+	checksum, err := readPluginChecksum(tempDir)
+	if err != nil {
+		return err
+	}
+	if existing, err := readPluginChecksum(finalDir); err == nil && isDir(finalDir) {
+		executable, _ := executableChecksum(filepath.Join(tempDir, info.File()))
+		existingExecutable, _ := executableChecksum(filepath.Join(finalDir, info.File()))
+		if existing != checksum || existingExecutable != executable {
+			if err = os.RemoveAll(finalDir); err != nil {
+				return errors.Wrap(err, "removing the existing plugin")
+			}
+		}
+	}
+
 	// If two calls to `plugin install` for the same plugin are racing, the second one will be unable to rename
 	// the directory. That's OK, just ignore the error. The temp directory created as part of the install will be
 	// cleaned up when we exit by the defer above.
@@ -306,16 +543,16 @@ func IsPluginKind(k string) bool {
 	}
 }
 
-// HasPlugin returns true if the given plugin exists.
+// HasPlugin returns true if the given plugin exists for its platform.
 func HasPlugin(plug PluginInfo) bool {
-	dir, err := plug.DirPath()
-	if err == nil {
-		_, err := os.Stat(dir)
-		if err == nil {
-			return true
-		}
-	}
-	return false
+	dir, err := plug.InstalledDirPath()
+	return err == nil && dir != ""
+}
+
+// isDir returns true if the given path is a directory.
+func isDir(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && stat.IsDir()
 }
 
 // HasPluginGTE returns true if the given plugin exists at the given version number or greater.
@@ -330,6 +567,7 @@ func HasPluginGTE(plug PluginInfo) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	plugs = pluginsForPlatform(plugs, plug.GetPlatform())
 
 	// If we're not doing the legacy plugin behavior and we've been asked for a specific version, do the same plugin
 	// search that we'd do at runtime. This ensures that `pulumi plugin install` works the same way that the runtime
@@ -408,7 +646,11 @@ func GetPlugins() ([]PluginInfo, error) {
 				Kind:    kind,
 				Version: &version,
 			}
-			if err = plugin.SetFileMetadata(filepath.Join(dir, file.Name())); err != nil {
+			path := filepath.Join(dir, file.Name())
+			if err = plugin.SetFileMetadata(path); err != nil {
+				return nil, err
+			}
+			if plugin.Platforms, err = plugin.installedPlatforms(path); err != nil {
 				return nil, err
 			}
 			plugins = append(plugins, plugin)
@@ -417,6 +659,54 @@ func GetPlugins() ([]PluginInfo, error) {
 	return plugins, nil
 }
 
+// installedPlatforms returns the platforms for which the plugin in the given directory has files.
+func (info PluginInfo) installedPlatforms(dir string) ([]Platform, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var platforms []Platform
+	hasLegacy := info.hasLegacyFiles(dir)
+	for _, file := range files {
+		// Skip anything that isn't a platform's directory, including those that are being installed.
+		if !file.IsDir() {
+			continue
+		}
+		platform, err := ParsePlatform(file.Name())
+		if err != nil {
+			continue
+		}
+		if platform == legacyPlatform() {
+			hasLegacy = false
+		}
+		platforms = append(platforms, platform)
+	}
+	if hasLegacy {
+		platforms = append(platforms, legacyPlatform())
+	}
+
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].String() < platforms[j].String()
+	})
+	return platforms, nil
+}
+
+// pluginsForPlatform returns the plugins that are installed for the given platform.
+func pluginsForPlatform(plugins []PluginInfo, platform Platform) []PluginInfo {
+	var result []PluginInfo
+	for _, plugin := range plugins {
+		for _, p := range plugin.Platforms {
+			if p == platform {
+				plugin.Platform = platform
+				result = append(result, plugin)
+				break
+			}
+		}
+	}
+	return result
+}
+
 // GetPluginPath finds a plugin's path by its kind, name, and optional version.  It will match the latest version that
 // is >= the version specified.  If no version is supplied, the latest plugin for that given kind/name pair is loaded,
 // using standard semver sorting rules.  A plugin may be overridden entirely by placing it on your $PATH.
@@ -455,11 +745,12 @@ func GetPluginPath(kind PluginKind, name string, version *semver.Version) (strin
 		}
 	}
 
-	// Otherwise, check the plugin cache.
+	// Otherwise, check the plugin cache for plugins that have been installed for this platform.
 	plugins, err := GetPlugins()
 	if err != nil {
 		return "", "", errors.Wrapf(err, "loading plugin list")
 	}
+	plugins = pluginsForPlatform(plugins, currentPlatform)
 
 	var match *PluginInfo
 	if !enableLegacyPluginBehavior && version != nil {
@@ -501,7 +792,7 @@ func GetPluginPath(kind PluginKind, name string, version *semver.Version) (strin
 	}
 
 	if match != nil {
-		matchDir, err := match.DirPath()
+		matchDir, err := match.InstalledDirPath()
 		if err != nil {
 			return "", "", err
 		}
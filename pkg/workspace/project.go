@@ -92,6 +92,10 @@ type Project struct {
 
 	// Backend is an optional backend configuration
 	Backend *ProjectBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// DebugProviders optionally maps provider package names to the addresses of providers that are already running,
+	// for example under a debugger.  The engine attaches to these providers rather than launching their plugins.
+	DebugProviders map[string]string `json:"debugProviders,omitempty" yaml:"debugProviders,omitempty"`
 }
 
 func (proj *Project) Validate() error {
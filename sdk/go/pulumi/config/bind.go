@@ -0,0 +1,312 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/mapper"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+// Validator may be implemented by a struct that configuration is bound to in order to check the values it was given.
+// Validate is called only if every value was loaded successfully.
+type Validator interface {
+	Validate() error
+}
+
+// Object loads a structured configuration value by its key into the struct pointed to by target.  The value must be a
+// JSON object, whose properties are mapped to the struct's fields using the same tags as Bind.  Properties that do not
+// correspond to any field are rejected.  If any properties are missing or invalid, the returned error lists all of
+// them.
+func Object(ctx *pulumi.Context, key string, target interface{}) error {
+	t, err := bindTarget(target)
+	if err != nil {
+		return err
+	}
+
+	v, ok := ctx.GetConfig(key)
+	if !ok {
+		return errors.Errorf("missing required configuration variable '%s'; run `pulumi config` to set", key)
+	}
+	var obj map[string]interface{}
+	if err = json.Unmarshal([]byte(v), &obj); err != nil || obj == nil {
+		return errors.Errorf("configuration variable '%s' must be a JSON object", key)
+	}
+
+	errs := applyDefaults(obj, t, key, ctx.IsConfigSecret(key))
+	if err := decoder().Decode(obj, target); err != nil {
+		for _, failure := range err.Failures() {
+			errs = append(errs, invalidValueError(key, failure))
+		}
+	}
+	return finishBind(target, errs)
+}
+
+// Bind loads the configuration for the current project into the struct pointed to by target.  See Config.Bind.
+func Bind(ctx *pulumi.Context, target interface{}) error {
+	return New(ctx, "").Bind(target)
+}
+
+// Object loads a structured configuration value by its key into the struct pointed to by target.  See Object.
+func (c *Config) Object(key string, target interface{}) error {
+	return Object(c.ctx, c.fullKey(key), target)
+}
+
+// Bind loads the configuration in this bag's namespace into the struct pointed to by target.  Each field tagged with
+// `pulumi:"name"` is loaded from the configuration variable "namespace:name".  Fields may be further annotated:
+//
+//	`pulumi:"name,optional"` permits the variable to be missing, in which case the field is left unchanged;
+//	`default:"value"` supplies the value to use if the variable is missing, and implies optional;
+//	`secret:"true"` marks the value as sensitive: it must be set with `pulumi config set --secret`, and may not be
+//	given a default in the program's source.
+//
+// Values for fields of string, bool, integer, and floating point types are parsed from their textual form; values for
+// fields of any other type, including structs, slices, and maps, must be JSON.  Rather than stopping at the first
+// problem, Bind checks every field and returns an error that lists all missing and invalid variables.  If every value
+// was loaded and the target implements Validator, its Validate method is called as a final check.
+func (c *Config) Bind(target interface{}) error {
+	t, err := bindTarget(target)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	obj := make(map[string]interface{})
+	for _, fld := range bindFields(t) {
+		key := c.fullKey(fld.name)
+		if fld.secret && fld.hasDefault {
+			errs = append(errs, errors.Errorf("secret configuration variable '%s' may not have a default", key))
+			continue
+		}
+
+		raw, ok := c.ctx.GetConfig(key)
+		if !ok {
+			if !fld.hasDefault {
+				if !fld.optional {
+					errs = append(errs, errors.Errorf(
+						"missing required configuration variable '%s'; run `pulumi config` to set", key))
+				}
+				continue
+			}
+			raw = fld.def
+		} else if fld.secret && !c.ctx.IsConfigSecret(key) {
+			errs = append(errs, notSecretError(key))
+			continue
+		}
+
+		v, err := parseValue(raw, fld.typ)
+		if err != nil {
+			if !ok {
+				err = errors.Wrap(err, "invalid default")
+			}
+			errs = append(errs, errors.Errorf("invalid value for configuration variable '%s': %v", key, err))
+			continue
+		}
+		if nested, ok := v.(map[string]interface{}); ok && structType(fld.typ) != nil {
+			errs = append(errs, applyDefaults(nested, structType(fld.typ), key, c.ctx.IsConfigSecret(key))...)
+		}
+		obj[fld.name] = v
+	}
+
+	// Missing variables were reported above, so skip the decoder's reports of them.
+	if err := decoder().Decode(obj, target); err != nil {
+		for _, failure := range err.Failures() {
+			if missing, ok := failure.(*mapper.MissingError); ok && missing.Type == t {
+				continue
+			}
+			key := c.namespace
+			if ferr, ok := failure.(mapper.FieldError); ok {
+				key = c.fullKey(ferr.Field())
+			}
+			errs = append(errs, invalidValueError(key, failure))
+		}
+	}
+	return finishBind(target, errs)
+}
+
+// bindField describes a struct field that configuration is bound to.
+type bindField struct {
+	name       string       // the configuration key, relative to its namespace or enclosing object.
+	typ        reflect.Type // the type of the field.
+	optional   bool         // true if the value may be missing.
+	secret     bool         // true if the value is sensitive.
+	hasDefault bool         // true if the field has a default value.
+	def        string       // the textual default value, if any.
+}
+
+// bindFields returns the fields of the given struct type that configuration may be bound to.  This follows the same
+// rules as the mapper uses to decode the fields' values.
+func bindFields(t reflect.Type) []bindField {
+	var flds []bindField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("pulumi")
+		if tag == "" || f.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		if parts[0] == "-" {
+			continue
+		}
+		fld := bindField{name: parts[0], typ: f.Type}
+		skip := false
+		for _, part := range parts[1:] {
+			switch part {
+			case "optional":
+				fld.optional = true
+			case "skip":
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+		fld.def, fld.hasDefault = f.Tag.Lookup("default")
+		fld.optional = fld.optional || fld.hasDefault
+		fld.secret = f.Tag.Get("secret") == "true"
+		flds = append(flds, fld)
+	}
+	return flds
+}
+
+// bindTarget checks that target is a non-nil pointer to a struct and returns the struct's type.
+func bindTarget(target interface{}) (reflect.Type, error) {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("configuration must be bound to a non-nil pointer to a struct; got %T", target)
+	}
+	return v.Elem().Type(), nil
+}
+
+// decoder returns a mapper that decodes configuration values using `pulumi` tags.
+func decoder() mapper.Mapper {
+	return mapper.New(&mapper.Opts{Tags: []string{"pulumi"}})
+}
+
+// applyDefaults fills in the default values for any missing properties of the given object, and of any objects nested
+// within it, according to the fields of the given struct type.  It returns an error for each invalid default, and for
+// each secret property that is present when the configuration variable itself is not stored as a secret.
+func applyDefaults(obj map[string]interface{}, t reflect.Type, key string, secret bool) []error {
+	var errs []error
+	for _, fld := range bindFields(t) {
+		if fld.secret && fld.hasDefault {
+			errs = append(errs, errors.Errorf(
+				"secret property '%s' of configuration variable '%s' may not have a default", fld.name, key))
+			continue
+		}
+
+		v, has := obj[fld.name]
+		if has && fld.secret && !secret {
+			errs = append(errs, errors.Errorf(
+				"secret property '%s' of configuration variable '%s' requires the variable to be a secret; "+
+					"run `pulumi config set --secret` to set", fld.name, key))
+			continue
+		}
+		if !has && fld.hasDefault {
+			dv, err := parseValue(fld.def, fld.typ)
+			if err != nil {
+				errs = append(errs, errors.Errorf("invalid default for property '%s' of configuration variable '%s': %v",
+					fld.name, key, err))
+				continue
+			}
+			obj[fld.name], v = dv, dv
+		}
+
+		if nested, ok := v.(map[string]interface{}); ok && structType(fld.typ) != nil {
+			errs = append(errs, applyDefaults(nested, structType(fld.typ), key, secret)...)
+		}
+	}
+	return errs
+}
+
+// structType returns the struct type that the given field type refers to, if any, and nil otherwise.
+func structType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// parseValue parses the textual form of a configuration value for a field of the given type.  Scalars are parsed
+// strictly, so that, for example, "3.5" is rejected for an integer field; all other values must be JSON.  Errors never
+// include the value itself, which may be a secret.
+func parseValue(raw string, t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(raw, 10, t.Bits()); err == nil {
+			return i, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(raw, 10, t.Bits()); err == nil {
+			return u, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(raw, t.Bits()); err == nil {
+			return f, nil
+		}
+	default:
+		var v interface{}
+		if err := json.Unmarshal([]byte(raw), &v); err == nil {
+			return v, nil
+		}
+		return nil, errors.Errorf("expected a JSON value of type %v", t)
+	}
+	return nil, errors.Errorf("expected a value of type %v", t)
+}
+
+// notSecretError reports that a secret configuration variable was not stored as a secret.
+func notSecretError(key string) error {
+	return errors.Errorf("configuration variable '%s' must be a secret; run `pulumi config set --secret` to set", key)
+}
+
+// invalidValueError turns a failure reported by the mapper into an error that names the configuration variable.
+func invalidValueError(key string, failure error) error {
+	reason := failure.Error()
+	if ferr, ok := failure.(mapper.FieldError); ok {
+		reason = ferr.Reason()
+	}
+	return errors.Errorf("invalid value for configuration variable '%s': %v", key, reason)
+}
+
+// finishBind returns an error listing the given failures, if there are any, and otherwise validates the target.
+func finishBind(target interface{}, errs []error) error {
+	if len(errs) > 0 {
+		return mapper.NewMappingError(errs)
+	}
+	if v, ok := target.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/util/mapper"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+type database struct {
+	Host string `pulumi:"host"`
+	Port int    `pulumi:"port" default:"5432"`
+}
+
+type settings struct {
+	Region   string    `pulumi:"region"`
+	Replicas int       `pulumi:"replicas" default:"3"`
+	Debug    bool      `pulumi:"debug,optional"`
+	Ratio    *float64  `pulumi:"ratio,optional"`
+	Password string    `pulumi:"password" secret:"true"`
+	Zones    []string  `pulumi:"zones,optional"`
+	Database *database `pulumi:"database"`
+	Ignored  string
+}
+
+func (s *settings) Validate() error {
+	if s.Replicas < 1 {
+		return errors.New("replicas must be positive")
+	}
+	return nil
+}
+
+func newBindContext(t *testing.T, config map[string]string, secretKeys ...string) *pulumi.Context {
+	ctx, err := pulumi.NewContext(context.Background(),
+		pulumi.RunInfo{Project: "proj", Config: config, ConfigSecretKeys: secretKeys})
+	assert.Nil(t, err)
+	return ctx
+}
+
+// TestBind tests binding a project's configuration to a struct.
+func TestBind(t *testing.T) {
+	ctx := newBindContext(t, map[string]string{
+		"proj:region":   "us-west-2",
+		"proj:ratio":    "0.5",
+		"proj:password": "hunter2",
+		"proj:zones":    `["a","b"]`,
+		"proj:database": `{"host":"db.local"}`,
+		"other:region":  "eu-west-1",
+	}, "proj:password")
+
+	var s settings
+	err := Bind(ctx, &s)
+	assert.Nil(t, err)
+	assert.Equal(t, "us-west-2", s.Region)
+	assert.Equal(t, 3, s.Replicas)
+	assert.False(t, s.Debug)
+	if assert.NotNil(t, s.Ratio) {
+		assert.Equal(t, 0.5, *s.Ratio)
+	}
+	assert.Equal(t, "hunter2", s.Password)
+	assert.Equal(t, []string{"a", "b"}, s.Zones)
+	if assert.NotNil(t, s.Database) {
+		assert.Equal(t, database{Host: "db.local", Port: 5432}, *s.Database)
+	}
+
+	// A bag binds the variables in its own namespace.
+	var o struct {
+		Region string `pulumi:"region"`
+	}
+	err = New(ctx, "other").Bind(&o)
+	assert.Nil(t, err)
+	assert.Equal(t, "eu-west-1", o.Region)
+
+	// Targets must be pointers to structs.
+	assert.NotNil(t, Bind(ctx, s))
+	assert.NotNil(t, Bind(ctx, (*settings)(nil)))
+}
+
+// TestBindErrors tests that binding reports every missing and invalid variable at once.
+func TestBindErrors(t *testing.T) {
+	ctx := newBindContext(t, map[string]string{
+		"proj:replicas": "2.5",
+		"proj:debug":    "maybe",
+		"proj:database": `{"port":"high","user":"admin"}`,
+	})
+
+	var s settings
+	err := Bind(ctx, &s)
+	merr, ok := err.(mapper.MappingError)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Len(t, merr.Failures(), 5)
+	for _, key := range []string{"region", "replicas", "debug", "password", "database"} {
+		assert.Contains(t, err.Error(), "'proj:"+key+"'")
+	}
+	assert.Contains(t, err.Error(), "Missing required field 'host'")
+	assert.Contains(t, err.Error(), "Unrecognized field 'user'")
+
+	// Validation runs only once every value has been loaded.
+	ctx = newBindContext(t, map[string]string{
+		"proj:region":   "us-west-2",
+		"proj:replicas": "0",
+		"proj:password": "hunter2",
+		"proj:database": `{"host":"db.local"}`,
+	}, "proj:password")
+	err = Bind(ctx, &s)
+	assert.EqualError(t, err, "replicas must be positive")
+
+	// Secrets may not have defaults.
+	var bad struct {
+		Token string `pulumi:"token" secret:"true" default:"abc"`
+	}
+	err = Bind(ctx, &bad)
+	assert.Contains(t, err.Error(), "secret configuration variable 'proj:token' may not have a default")
+}
+
+// TestBindSecrets tests that secret fields may only be bound to configuration variables that are stored as secrets.
+func TestBindSecrets(t *testing.T) {
+	type credentials struct {
+		User     string `pulumi:"user"`
+		Password string `pulumi:"password" secret:"true"`
+	}
+	var s struct {
+		Password    string       `pulumi:"password" secret:"true"`
+		Token       string       `pulumi:"token,optional" secret:"true"`
+		Credentials *credentials `pulumi:"credentials"`
+	}
+
+	config := map[string]string{
+		"proj:password":    "hunter2",
+		"proj:credentials": `{"user":"admin","password":"hunter2"}`,
+	}
+	ctx := newBindContext(t, config)
+	err := Bind(ctx, &s)
+	merr, ok := err.(mapper.MappingError)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Len(t, merr.Failures(), 2)
+	assert.Contains(t, err.Error(),
+		"configuration variable 'proj:password' must be a secret; run `pulumi config set --secret` to set")
+	assert.Contains(t, err.Error(),
+		"secret property 'password' of configuration variable 'proj:credentials' requires the variable to be a secret")
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	var c credentials
+	err = Object(ctx, "proj:credentials", &c)
+	assert.Contains(t, err.Error(),
+		"secret property 'password' of configuration variable 'proj:credentials' requires the variable to be a secret")
+
+	// Once the variables are stored as secrets, they may be bound.
+	ctx = newBindContext(t, config, "proj:password", "proj:credentials")
+	err = Bind(ctx, &s)
+	assert.Nil(t, err)
+	assert.Equal(t, "hunter2", s.Password)
+	assert.Equal(t, "", s.Token)
+	if assert.NotNil(t, s.Credentials) {
+		assert.Equal(t, credentials{User: "admin", Password: "hunter2"}, *s.Credentials)
+	}
+	err = Object(ctx, "proj:credentials", &c)
+	assert.Nil(t, err)
+	assert.Equal(t, "hunter2", c.Password)
+}
+
+// TestObject tests loading a single structured configuration value.
+func TestObject(t *testing.T) {
+	ctx := newBindContext(t, map[string]string{
+		"proj:database": `{"host":"db.local","port":6543}`,
+		"proj:partial":  `{"port":1}`,
+		"proj:invalid":  `not json`,
+	})
+
+	var db database
+	err := Object(ctx, "proj:database", &db)
+	assert.Nil(t, err)
+	assert.Equal(t, database{Host: "db.local", Port: 6543}, db)
+
+	db = database{}
+	err = New(ctx, "proj").Object("database", &db)
+	assert.Nil(t, err)
+	assert.Equal(t, "db.local", db.Host)
+
+	err = Object(ctx, "proj:partial", &db)
+	assert.Contains(t, err.Error(), "invalid value for configuration variable 'proj:partial'")
+	assert.Contains(t, err.Error(), "host")
+
+	err = Object(ctx, "proj:invalid", &db)
+	assert.EqualError(t, err, "configuration variable 'proj:invalid' must be a JSON object")
+
+	err = Object(ctx, "proj:missing", &db)
+	assert.Contains(t, err.Error(), "missing required configuration variable 'proj:missing'")
+}
@@ -15,6 +15,8 @@
 package pulumi
 
 import (
+	"io"
+	"reflect"
 	"sort"
 	"sync"
 
@@ -25,6 +27,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/mapper"
 	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
 
@@ -48,7 +51,9 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 	// Connect to the gRPC endpoints if we have addresses for them.
 	var monitorConn *grpc.ClientConn
 	var monitor pulumirpc.ResourceMonitorClient
-	if addr := info.MonitorAddr; addr != "" {
+	if info.Mocks != nil {
+		monitor = info.Mocks
+	} else if addr := info.MonitorAddr; addr != "" {
 		conn, err := grpc.Dial(info.MonitorAddr, grpc.WithInsecure())
 		if err != nil {
 			return nil, errors.Wrap(err, "connecting to resource monitor over RPC")
@@ -116,6 +121,16 @@ func (ctx *Context) GetConfig(key string) (string, bool) {
 	return v, ok
 }
 
+// IsConfigSecret returns true if the config value with the given key is stored as a secret.
+func (ctx *Context) IsConfigSecret(key string) bool {
+	for _, k := range ctx.info.ConfigSecretKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // Invoke will invoke a provider's function, identified by its token tok.  This function call is synchronous.
 func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...InvokeOpt) (map[string]interface{}, error) {
 	if tok == "" {
@@ -123,20 +138,12 @@ func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...Invo
 	}
 
 	// Check for a provider option.
-	var provider string
-	for _, opt := range opts {
-		if opt.Provider != nil {
-			pr, err := ctx.resolveProviderReference(opt.Provider)
-			if err != nil {
-				return nil, err
-			}
-			provider = pr
-			break
-		}
+	provider, err := ctx.invokeProvider(opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Serialize arguments, first by awaiting them, and then marshaling them to the requisite gRPC values.
-	// TODO[pulumi/pulumi#1483]: feels like we should be propagating dependencies to the outputs, instead of ignoring.
 	rpcArgs, _, _, err := marshalInputs(args)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshaling arguments")
@@ -148,8 +155,95 @@ func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...Invo
 	}
 	defer ctx.endRPC()
 
+	return ctx.invoke(tok, rpcArgs, provider)
+}
+
+// InvokeOutput invokes a provider's function, identified by its token tok, without blocking.  The arguments may contain
+// outputs, which are awaited before the function is invoked.  The returned output resolves once the function returns,
+// and depends on every resource that the arguments depend on.
+//
+// If result is nil, the output's value is the function's result as a map[string]interface{}.  Otherwise, result must
+// be a pointer to a struct with `pulumi:"name"` tags; the function's result is decoded into it, and the output's value
+// is result itself.  Properties of the function's result that the struct does not have are ignored, as are fields of
+// the struct that the result does not have.  Result must not be read until the output has resolved.
+//
+// If any of the arguments are unknown, as may happen during previews, the function is not invoked and the output is
+// unknown.
+func (ctx *Context) InvokeOutput(tok string, args map[string]interface{}, result interface{},
+	opts ...InvokeOpt) *Output {
+
+	out, resolve, reject := NewOutput(inputDeps(args))
+	if tok == "" {
+		reject(errors.New("invoke token must not be empty"))
+		return out
+	}
+	if result != nil {
+		if rv := reflect.ValueOf(result); rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+			reject(errors.Errorf("invoke result must be a non-nil pointer to a struct; got %T", result))
+			return out
+		}
+	}
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		reject(err)
+		return out
+	}
+
+	// Await the provider and arguments and invoke the function asynchronously, resolving the output once it returns.
+	go func() {
+		defer ctx.endRPC()
+
+		provider, err := ctx.invokeProvider(opts)
+		if err != nil {
+			reject(err)
+			return
+		}
+		rpcArgs, _, _, err := marshalInputs(args)
+		if err != nil {
+			reject(errors.Wrap(err, "marshaling arguments"))
+			return
+		}
+		if containsUnknowns(rpcArgs) {
+			logging.V(9).Infof("InvokeOutput(%s, ...): arguments are unknown; skipping", tok)
+			resolve(nil, false)
+			return
+		}
+
+		outs, err := ctx.invoke(tok, rpcArgs, provider)
+		if err != nil {
+			reject(err)
+			return
+		}
+		if result == nil {
+			resolve(outs, true)
+			return
+		}
+		md := mapper.New(&mapper.Opts{IgnoreMissing: true, IgnoreUnrecognized: true})
+		if err = md.Decode(outs, result); err != nil {
+			reject(errors.Wrapf(err, "decoding the result of invoking %s", tok))
+			return
+		}
+		resolve(result, true)
+	}()
+	return out
+}
+
+// invokeProvider returns the reference to the provider named by the given invoke options, if any.
+func (ctx *Context) invokeProvider(opts []InvokeOpt) (string, error) {
+	for _, opt := range opts {
+		if opt.Provider != nil {
+			return ctx.resolveProviderReference(opt.Provider)
+		}
+	}
+	return "", nil
+}
+
+// invoke invokes a provider's function with the given marshaled arguments and returns its unmarshaled result.  The
+// caller is responsible for tracking the outstanding RPC.
+func (ctx *Context) invoke(tok string, rpcArgs *structpb.Struct, provider string) (map[string]interface{}, error) {
 	// Now, invoke the RPC to the provider synchronously.
-	logging.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(args))
+	logging.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(rpcArgs.GetFields()))
 	resp, err := ctx.monitor.Invoke(ctx.ctx, &pulumirpc.InvokeRequest{
 		Tok:      tok,
 		Args:     rpcArgs,
@@ -177,6 +271,257 @@ func (ctx *Context) Invoke(tok string, args map[string]interface{}, opts ...Invo
 	return outs, err
 }
 
+// Call calls a method, identified by its token tok, of a custom resource without blocking.  The method is serviced by
+// the resource's provider, which is passed the resource's ID and current state along with the arguments.  The
+// arguments may contain outputs, which are awaited before the method is called.  The returned output resolves once the
+// method returns, and depends on the resource and on every resource that the arguments depend on.
+//
+// As with InvokeOutput, if result is nil, the output's value is the method's result as a map[string]interface{};
+// otherwise, result must be a pointer to a struct with `pulumi:"name"` tags, into which the method's result is
+// decoded.
+//
+// If the resource has not been created yet or any of the arguments are unknown, as may happen during previews, the
+// method is not called and the output is unknown.
+func (ctx *Context) Call(tok string, res CustomResource, args map[string]interface{}, result interface{}) *Output {
+	out, resolve, reject := NewOutput(append([]Resource{res}, inputDeps(args)...))
+	if tok == "" {
+		reject(errors.New("call token must not be empty"))
+		return out
+	}
+	if result != nil {
+		if rv := reflect.ValueOf(result); rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+			reject(errors.Errorf("call result must be a non-nil pointer to a struct; got %T", result))
+			return out
+		}
+	}
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		reject(err)
+		return out
+	}
+
+	// Await the resource and arguments and call the method asynchronously, resolving the output once it returns.
+	go func() {
+		defer ctx.endRPC()
+
+		urn, err := res.URN().Value()
+		if err != nil {
+			reject(err)
+			return
+		}
+		rpcArgs, argDeps, _, err := marshalInputs(args)
+		if err != nil {
+			reject(errors.Wrap(err, "marshaling arguments"))
+			return
+		}
+		if containsUnknowns(rpcArgs) {
+			logging.V(9).Infof("Call(%s, %s, ...): arguments are unknown; skipping", tok, urn)
+			resolve(nil, false)
+			return
+		}
+		rpcArgDeps := make(map[string]*pulumirpc.ResourceCallRequest_ArgumentDependencies)
+		for key, deps := range argDeps {
+			var urns []string
+			for _, d := range deps {
+				urns = append(urns, string(d))
+			}
+			rpcArgDeps[key] = &pulumirpc.ResourceCallRequest_ArgumentDependencies{Urns: urns}
+		}
+
+		logging.V(9).Infof("Call(%s, %s, #args=%d): RPC call being made", tok, urn, len(rpcArgs.GetFields()))
+		resp, err := ctx.monitor.Call(ctx.ctx, &pulumirpc.ResourceCallRequest{
+			Tok:             tok,
+			Urn:             string(urn),
+			Args:            rpcArgs,
+			ArgDependencies: rpcArgDeps,
+		})
+		if err != nil {
+			logging.V(9).Infof("Call(%s, %s, ...): error: %v", tok, urn, err)
+			reject(err)
+			return
+		}
+
+		// If there were any failures from the provider, reject the output with them.
+		if len(resp.Failures) > 0 {
+			var ferr error
+			for _, failure := range resp.Failures {
+				ferr = multierror.Append(ferr,
+					errors.Errorf("%s call failed: %s (%s)", tok, failure.Reason, failure.Property))
+			}
+			reject(ferr)
+			return
+		}
+
+		// If the engine did not return a result, it is not yet known.
+		if resp.Return == nil {
+			logging.V(9).Infof("Call(%s, %s, ...): result is unknown", tok, urn)
+			resolve(nil, false)
+			return
+		}
+		outs, err := unmarshalOutputs(resp.Return)
+		if err != nil {
+			reject(err)
+			return
+		}
+		if result == nil {
+			resolve(outs, true)
+			return
+		}
+		md := mapper.New(&mapper.Opts{IgnoreMissing: true, IgnoreUnrecognized: true})
+		if err = md.Decode(outs, result); err != nil {
+			reject(errors.Wrapf(err, "decoding the result of calling %s", tok))
+			return
+		}
+		resolve(result, true)
+	}()
+	return out
+}
+
+// StreamInvoke invokes a provider's streaming function, identified by its token tok, and returns a stream of its
+// results.  Streaming functions suit results that are unbounded, such as a tail of a log, or too large to return at
+// once, such as a long listing.  The stream is read in the manner of a bufio.Scanner:
+//
+//     stream, err := ctx.StreamInvoke("aws:cloudwatch/tailLogs:tailLogs", args)
+//     if err != nil {
+//         return err
+//     }
+//     defer stream.Close()
+//     for stream.Next() {
+//         event := stream.Result()
+//         ...
+//     }
+//     if err := stream.Err(); err != nil {
+//         return err
+//     }
+//
+// The stream counts as an outstanding RPC until it has been read to completion or closed, so the program does not
+// complete until then.
+func (ctx *Context) StreamInvoke(tok string, args map[string]interface{},
+	opts ...InvokeOpt) (*InvokeStream, error) {
+
+	if tok == "" {
+		return nil, errors.New("invoke token must not be empty")
+	}
+
+	// Check for a provider option.
+	provider, err := ctx.invokeProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize arguments, first by awaiting them, and then marshaling them to the requisite gRPC values.
+	rpcArgs, _, _, err := marshalInputs(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling arguments")
+	}
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.  The RPC is
+	// outstanding until the stream ends or is closed.
+	if err = ctx.beginRPC(); err != nil {
+		return nil, err
+	}
+
+	logging.V(9).Infof("StreamInvoke(%s, #args=%d): RPC stream being opened", tok, len(rpcArgs.GetFields()))
+	streamCtx, cancel := context.WithCancel(ctx.ctx)
+	stream, err := ctx.monitor.StreamInvoke(streamCtx, &pulumirpc.InvokeRequest{
+		Tok:      tok,
+		Args:     rpcArgs,
+		Provider: provider,
+	})
+	if err != nil {
+		logging.V(9).Infof("StreamInvoke(%s, ...): error: %v", tok, err)
+		cancel()
+		ctx.endRPC()
+		return nil, err
+	}
+
+	return &InvokeStream{
+		tok:    tok,
+		stream: stream,
+		cancel: cancel,
+		endRPC: ctx.endRPC,
+	}, nil
+}
+
+// InvokeStream is the stream of results returned by a provider's streaming function.  See Context.StreamInvoke.
+type InvokeStream struct {
+	tok    string                                       // the function being invoked.
+	stream pulumirpc.ResourceMonitor_StreamInvokeClient // the underlying RPC stream.
+	cancel context.CancelFunc                           // cancels the underlying RPC stream.
+	endRPC func()                                       // signals the completion of the outstanding RPC.
+	once   sync.Once                                    // ensures that the stream is finished only once.
+	done   bool                                         // true once the stream has ended.
+	result map[string]interface{}                       // the current result.
+	err    error                                        // the error that ended the stream, if any.
+}
+
+// Next advances the stream to its next result, which is then available through Result.  It returns false when the
+// stream ends, either because the function has returned all of its results or because of an error, which is then
+// available through Err.
+func (s *InvokeStream) Next() bool {
+	if s.done {
+		return false
+	}
+
+	resp, err := s.stream.Recv()
+	switch {
+	case err == io.EOF:
+		logging.V(9).Infof("StreamInvoke(%s, ...): success: end of stream", s.tok)
+		s.finish(nil)
+		return false
+	case err != nil:
+		logging.V(9).Infof("StreamInvoke(%s, ...): error: %v", s.tok, err)
+		s.finish(err)
+		return false
+	}
+
+	// If there were any failures from the provider, end the stream with them.
+	if len(resp.Failures) > 0 {
+		logging.V(9).Infof("StreamInvoke(%s, ...): success: w/ %d failures", s.tok, len(resp.Failures))
+		var ferr error
+		for _, failure := range resp.Failures {
+			ferr = multierror.Append(ferr,
+				errors.Errorf("%s invoke failed: %s (%s)", s.tok, failure.Reason, failure.Property))
+		}
+		s.finish(ferr)
+		return false
+	}
+
+	result, err := unmarshalOutputs(resp.Return)
+	if err != nil {
+		s.finish(err)
+		return false
+	}
+	s.result = result
+	return true
+}
+
+// Result returns the result that the most recent call to Next advanced the stream to.
+func (s *InvokeStream) Result() map[string]interface{} {
+	return s.result
+}
+
+// Err returns the error that ended the stream, if any.  Closing a stream does not cause an error.
+func (s *InvokeStream) Err() error {
+	return s.err
+}
+
+// Close abandons the rest of the stream.  It is safe to close a stream that has ended.
+func (s *InvokeStream) Close() error {
+	s.finish(nil)
+	return nil
+}
+
+// finish ends the stream with the given error, if it has not already ended, and cancels the underlying RPC.
+func (s *InvokeStream) finish(err error) {
+	s.once.Do(func() {
+		s.done, s.result, s.err = true, nil, err
+		s.cancel()
+		s.endRPC()
+	})
+}
+
 // ReadResource reads an existing custom resource's state from the resource monitor.  Note that resources read in this
 // way will not be part of the resulting stack's state, as they are presumed to belong to another.
 func (ctx *Context) ReadResource(
@@ -218,6 +563,7 @@ func (ctx *Context) ReadResource(
 		resp, err := ctx.monitor.ReadResource(ctx.ctx, &pulumirpc.ReadResourceRequest{
 			Type:       t,
 			Name:       name,
+			Id:         string(id),
 			Parent:     inputs.parent,
 			Properties: inputs.rpcProps,
 			Provider:   inputs.provider,
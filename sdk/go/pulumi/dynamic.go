@@ -0,0 +1,315 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"fmt"
+	"sync"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// DynamicProviderPackage is the package to which the types of all resources managed by dynamic providers belong, e.g.
+// "pulumi-go:dynamic:Widget".  The engine only accepts providers served by the program for this package, so this must
+// match the engine's definition.
+const DynamicProviderPackage = "pulumi-go"
+
+// dynamicEndpointKey is the reserved provider property through which the engine learns the address of a provider that
+// is served by the program.  This must match the engine's definition.
+const dynamicEndpointKey = "__endpoint"
+
+// DynamicProvider implements the CRUD operations for resources that are managed by the program itself rather than by
+// a provider plugin.  The inputs and state of these resources are passed to and from the provider as property maps.
+type DynamicProvider interface {
+	// Create creates a new resource from the given inputs and returns its ID and output state.
+	Create(inputs resource.PropertyMap) (ID, resource.PropertyMap, error)
+	// Read returns the current state of the resource with the given ID and last known state.  If the resource no
+	// longer exists, Read returns a nil state.
+	Read(id ID, state resource.PropertyMap) (resource.PropertyMap, error)
+	// Update updates the resource with the given ID and current state to match the new inputs, and returns its new
+	// output state.
+	Update(id ID, olds, news resource.PropertyMap) (resource.PropertyMap, error)
+	// Delete deletes the resource with the given ID and current state.
+	Delete(id ID, state resource.PropertyMap) error
+}
+
+// DynamicDiffer may be implemented by a DynamicProvider to control how changes to its resources are detected.  If a
+// provider does not implement it, the engine compares a resource's old and new inputs, and updates it if any differ.
+type DynamicDiffer interface {
+	// Diff compares the current state of the resource with the given ID to its new inputs.
+	Diff(id ID, olds, news resource.PropertyMap) (DynamicDiffResult, error)
+}
+
+// DynamicDiffResult describes the changes that updating a resource managed by a dynamic provider would make.
+type DynamicDiffResult struct {
+	Changes             bool     // true if the resource must be updated or replaced.
+	ReplaceKeys         []string // the properties whose changes require the resource to be replaced, if any.
+	DeleteBeforeReplace bool     // true if the resource must be deleted before its replacement is created.
+}
+
+// dynamicServers holds the servers for every dynamic provider registered by this process, keyed by project, stack, and
+// provider name, so that a program that is run repeatedly within a process reuses the same servers.
+var dynamicServers = make(map[string]*dynamicProviderServer)
+
+// dynamicServersLock protects the dynamicServers map.
+var dynamicServersLock sync.Mutex
+
+// NewDynamicProvider serves the given dynamic provider from this program and registers a provider resource with the
+// given name that refers to it.  Resources are managed by the provider by passing this provider resource as the
+// Provider option to RegisterResource, using a type token in the DynamicProviderPackage.
+//
+// The provider is served for the remainder of the process's lifetime, as the engine may need it after the program has
+// completed, e.g. to delete resources that the program no longer registers.  The engine only reaches the provider
+// while the program is running and serving it, so operations that do not run the program, such as destroy and
+// refresh, fail if any resources are managed by it.  To delete such resources, run an update whose program still calls
+// NewDynamicProvider but no longer registers them.
+func (ctx *Context) NewDynamicProvider(name string, provider DynamicProvider,
+	opts ...ResourceOpt) (*ResourceState, error) {
+
+	if provider == nil {
+		return nil, errors.New("dynamic provider cannot be nil")
+	}
+
+	server, err := serveDynamicProvider(fmt.Sprintf("%s::%s::%s", ctx.Project(), ctx.Stack(), name), provider)
+	if err != nil {
+		return nil, errors.Wrapf(err, "serving dynamic provider '%s'", name)
+	}
+
+	return ctx.RegisterResource("pulumi:providers:"+DynamicProviderPackage, name, true, map[string]interface{}{
+		dynamicEndpointKey: server.endpoint,
+	}, opts...)
+}
+
+// serveDynamicProvider returns a server for the given provider, starting one if this process has not served a provider
+// with the same key before.
+func serveDynamicProvider(key string, provider DynamicProvider) (*dynamicProviderServer, error) {
+	dynamicServersLock.Lock()
+	defer dynamicServersLock.Unlock()
+
+	if server, has := dynamicServers[key]; has {
+		server.setProvider(provider)
+		return server, nil
+	}
+
+	server := &dynamicProviderServer{provider: provider}
+	port, _, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, server)
+			return nil
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	server.endpoint = fmt.Sprintf("127.0.0.1:%d", port)
+
+	dynamicServers[key] = server
+	return server, nil
+}
+
+// dynamicProviderServer adapts a DynamicProvider to the resource provider RPC interface.
+type dynamicProviderServer struct {
+	endpoint string          // the address at which this server is listening.
+	provider DynamicProvider // the provider implementation.
+	m        sync.RWMutex    // a lock protecting the provider implementation.
+}
+
+func (s *dynamicProviderServer) getProvider() DynamicProvider {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.provider
+}
+
+func (s *dynamicProviderServer) setProvider(provider DynamicProvider) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.provider = provider
+}
+
+func (s *dynamicProviderServer) CheckConfig(ctx context.Context,
+	req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+}
+
+func (s *dynamicProviderServer) DiffConfig(ctx context.Context,
+	req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+
+	olds, err := plugin.UnmarshalProperties(req.GetOlds(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	news, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// Dynamic providers have no configuration of their own, so changes to their properties never require replacement.
+	changes := pulumirpc.DiffResponse_DIFF_NONE
+	if !olds.DeepEquals(news) {
+		changes = pulumirpc.DiffResponse_DIFF_SOME
+	}
+	return &pulumirpc.DiffResponse{Changes: changes}, nil
+}
+
+func (s *dynamicProviderServer) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+	return &pulumirpc.ConfigureResponse{}, nil
+}
+
+func (s *dynamicProviderServer) Invoke(ctx context.Context,
+	req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
+	return nil, errors.Errorf("dynamic providers do not support invoking '%s'", req.GetTok())
+}
+
+func (s *dynamicProviderServer) StreamInvoke(req *pulumirpc.InvokeRequest,
+	server pulumirpc.ResourceProvider_StreamInvokeServer) error {
+	return errors.Errorf("dynamic providers do not support invoking '%s'", req.GetTok())
+}
+
+func (s *dynamicProviderServer) Call(ctx context.Context,
+	req *pulumirpc.CallRequest) (*pulumirpc.CallResponse, error) {
+	return nil, errors.Errorf("dynamic providers do not support calling '%s'", req.GetTok())
+}
+
+func (s *dynamicProviderServer) Check(ctx context.Context,
+	req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+}
+
+func (s *dynamicProviderServer) Diff(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	differ, ok := s.getProvider().(DynamicDiffer)
+	if !ok {
+		return &pulumirpc.DiffResponse{Changes: pulumirpc.DiffResponse_DIFF_UNKNOWN}, nil
+	}
+
+	olds, err := plugin.UnmarshalProperties(req.GetOlds(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	news, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := differ.Diff(ID(req.GetId()), olds, news)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := pulumirpc.DiffResponse_DIFF_NONE
+	if diff.Changes {
+		changes = pulumirpc.DiffResponse_DIFF_SOME
+	}
+	return &pulumirpc.DiffResponse{
+		Changes:             changes,
+		Replaces:            diff.ReplaceKeys,
+		DeleteBeforeReplace: diff.DeleteBeforeReplace,
+	}, nil
+}
+
+func (s *dynamicProviderServer) Create(ctx context.Context,
+	req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+
+	inputs, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	id, state, err := s.getProvider().Create(inputs)
+	if err != nil {
+		return nil, err
+	} else if id == "" {
+		return nil, errors.New("dynamic provider returned an empty ID from Create")
+	}
+
+	props, err := plugin.MarshalProperties(state, plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: string(id), Properties: props}, nil
+}
+
+func (s *dynamicProviderServer) Read(ctx context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+	state, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	newState, err := s.getProvider().Read(ID(req.GetId()), state)
+	if err != nil {
+		return nil, err
+	} else if newState == nil {
+		// The resource no longer exists.
+		return &pulumirpc.ReadResponse{}, nil
+	}
+
+	props, err := plugin.MarshalProperties(newState, plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: props, Inputs: req.GetInputs()}, nil
+}
+
+func (s *dynamicProviderServer) Update(ctx context.Context,
+	req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+
+	olds, err := plugin.UnmarshalProperties(req.GetOlds(), plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	news, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getProvider().Update(ID(req.GetId()), olds, news)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := plugin.MarshalProperties(state, plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.UpdateResponse{Properties: props}, nil
+}
+
+func (s *dynamicProviderServer) Delete(ctx context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	state, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.getProvider().Delete(ID(req.GetId()), state); err != nil {
+		return nil, err
+	}
+	return &pbempty.Empty{}, nil
+}
+
+func (s *dynamicProviderServer) Cancel(ctx context.Context, req *pbempty.Empty) (*pbempty.Empty, error) {
+	return &pbempty.Empty{}, nil
+}
+
+func (s *dynamicProviderServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{}, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+type echoMocks struct {
+	calls int
+}
+
+func (m *echoMocks) Call(tok string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+	m.calls++
+	outs := args.Copy()
+	outs["tok"] = resource.NewStringProperty(tok)
+	return outs, nil
+}
+
+func (m *echoMocks) NewResource(typeToken, name string, inputs resource.PropertyMap,
+	provider, id string) (string, resource.PropertyMap, error) {
+
+	return name + "_id", inputs, nil
+}
+
+type echoResult struct {
+	Tok    string   `pulumi:"tok"`
+	Bucket string   `pulumi:"bucket"`
+	Tags   []string `pulumi:"tags"`
+}
+
+func TestInvokeOutput(t *testing.T) {
+	mocks := &echoMocks{}
+	err := RunErr(func(ctx *Context) error {
+		bucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "bucket", true, nil)
+		if err != nil {
+			return err
+		}
+
+		// Outputs are awaited, results are decoded, and dependencies are tracked.
+		arn, resolveARN, _ := NewOutput([]Resource{bucket})
+		go resolveARN("arn:bucket", true)
+
+		var result echoResult
+		out := ctx.InvokeOutput("aws:s3/getBucket:getBucket", map[string]interface{}{
+			"bucket": arn,
+			"tags":   []interface{}{"a", "b"},
+			"extra":  42,
+		}, &result)
+		assert.Equal(t, []Resource{bucket}, out.Deps())
+		v, known, err := out.Value()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, &result, v)
+		assert.Equal(t, echoResult{Tok: "aws:s3/getBucket:getBucket", Bucket: "arn:bucket", Tags: []string{"a", "b"}},
+			result)
+
+		// Without a result struct, the output's value is the result's map.
+		v, known, err = ctx.InvokeOutput("aws:index:getZones", nil, nil).Value()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, map[string]interface{}{"tok": "aws:index:getZones"}, v)
+
+		// Invalid results are rejected.
+		_, _, err = ctx.InvokeOutput("aws:index:getZones", nil, result).Value()
+		assert.Error(t, err)
+		_, _, err = ctx.InvokeOutput("", nil, nil).Value()
+		assert.Error(t, err)
+		return nil
+	}, WithMocks(NewMockMonitor("project", "stack", mocks)))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, mocks.calls)
+}
+
+func TestInvokeOutputUnknownDuringPreview(t *testing.T) {
+	mocks := &echoMocks{}
+	err := RunErr(func(ctx *Context) error {
+		bucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "bucket", true, nil)
+		if err != nil {
+			return err
+		}
+
+		// The mocks resolve resources' state even during previews, so use an explicitly unknown output.
+		arn, resolveARN, _ := NewOutput([]Resource{bucket})
+		resolveARN(nil, false)
+
+		var result echoResult
+		out := ctx.InvokeOutput("aws:s3/getBucket:getBucket", map[string]interface{}{
+			"bucket": map[string]interface{}{"arn": arn},
+		}, &result)
+		assert.Equal(t, []Resource{bucket}, out.Deps())
+		v, known, err := out.Value()
+		assert.NoError(t, err)
+		assert.False(t, known)
+		assert.Nil(t, v)
+		assert.Equal(t, echoResult{}, result)
+
+		// Functions whose arguments are known are still invoked.
+		_, known, err = ctx.InvokeOutput("aws:index:getZones", nil, nil).Value()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		return nil
+	}, WithMocks(NewMockMonitor("project", "stack", mocks)), func(info *RunInfo) { info.DryRun = true })
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mocks.calls)
+}
+
+type streamMocks struct {
+	echoMocks
+}
+
+func (m *streamMocks) StreamCall(tok string, args resource.PropertyMap,
+	provider string) ([]resource.PropertyMap, error) {
+
+	var results []resource.PropertyMap
+	for i := 0; i < int(args["count"].NumberValue()); i++ {
+		results = append(results, resource.PropertyMap{"n": resource.NewNumberProperty(float64(i))})
+	}
+	return results, nil
+}
+
+func TestStreamInvoke(t *testing.T) {
+	err := RunErr(func(ctx *Context) error {
+		// Each result is yielded in turn.
+		stream, err := ctx.StreamInvoke("test:index:count", map[string]interface{}{"count": 3})
+		assert.NoError(t, err)
+		var results []interface{}
+		for stream.Next() {
+			results = append(results, stream.Result()["n"])
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []interface{}{0.0, 1.0, 2.0}, results)
+		assert.NoError(t, stream.Close())
+
+		// A stream may be closed before it ends.
+		stream, err = ctx.StreamInvoke("test:index:count", map[string]interface{}{"count": 100})
+		assert.NoError(t, err)
+		assert.True(t, stream.Next())
+		assert.NoError(t, stream.Close())
+		assert.False(t, stream.Next())
+		assert.NoError(t, stream.Err())
+		return nil
+	}, WithMocks(NewMockMonitor("project", "stack", &streamMocks{})))
+	assert.NoError(t, err)
+
+	// Mocks that don't support streaming yield the single result of Call.
+	mocks := &echoMocks{}
+	err = RunErr(func(ctx *Context) error {
+		stream, err := ctx.StreamInvoke("test:index:echo", map[string]interface{}{"a": "b"})
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, stream.Close()) }()
+		var results []map[string]interface{}
+		for stream.Next() {
+			results = append(results, stream.Result())
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []map[string]interface{}{{"a": "b", "tok": "test:index:echo"}}, results)
+		return nil
+	}, WithMocks(NewMockMonitor("project", "stack", mocks)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mocks.calls)
+}
+
+type methodMocks struct {
+	echoMocks
+}
+
+func (m *methodMocks) MethodCall(tok string, urn URN, id ID, state,
+	args resource.PropertyMap) (resource.PropertyMap, error) {
+
+	outs := args.Copy()
+	outs["tok"] = resource.NewStringProperty(tok)
+	outs["id"] = resource.NewStringProperty(string(id))
+	outs["name"] = state["name"]
+	return outs, nil
+}
+
+type callResult struct {
+	Tok  string `pulumi:"tok"`
+	ID   string `pulumi:"id"`
+	Name string `pulumi:"name"`
+	Path string `pulumi:"path"`
+}
+
+func TestCall(t *testing.T) {
+	err := RunErr(func(ctx *Context) error {
+		bucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "bucket", true, map[string]interface{}{
+			"name": "my-bucket",
+		})
+		if err != nil {
+			return err
+		}
+		object, err := ctx.RegisterResource("aws:s3/bucketObject:BucketObject", "object", true, nil)
+		if err != nil {
+			return err
+		}
+
+		// The resource's ID and state are passed to the method, and the result depends on the resource and on
+		// everything the arguments depend on.
+		path, resolvePath, _ := NewOutput([]Resource{object})
+		go resolvePath("key", true)
+		var result callResult
+		out := ctx.Call("aws:s3/bucket:Bucket/signedURL", bucket, map[string]interface{}{"path": path}, &result)
+		assert.Equal(t, []Resource{bucket, object}, out.Deps())
+		v, known, err := out.Value()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, &result, v)
+		assert.Equal(t, callResult{
+			Tok:  "aws:s3/bucket:Bucket/signedURL",
+			ID:   "bucket_id",
+			Name: "my-bucket",
+			Path: "key",
+		}, result)
+		return nil
+	}, WithMocks(NewMockMonitor("project", "stack", &methodMocks{})))
+	assert.NoError(t, err)
+
+	// Mocks that don't support methods return the result of Call.
+	mocks := &echoMocks{}
+	err = RunErr(func(ctx *Context) error {
+		bucket, err := ctx.RegisterResource("aws:s3/bucket:Bucket", "bucket", true, nil)
+		if err != nil {
+			return err
+		}
+		v, known, err := ctx.Call("aws:s3/bucket:Bucket/signedURL", bucket, nil, nil).Value()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, map[string]interface{}{"tok": "aws:s3/bucket:Bucket/signedURL"}, v)
+		return nil
+	}, WithMocks(NewMockMonitor("project", "stack", mocks)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mocks.calls)
+}
@@ -0,0 +1,379 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// MockResourceMonitor supplies the behavior of resource providers to a program that is run against a MockMonitor.
+// This allows programs to be unit tested without an engine or any provider plugins.
+type MockResourceMonitor interface {
+	// Call is invoked for every provider function invocation, and returns the function's result.  tok is the function
+	// token, args its arguments, and provider the provider reference (if any) that was passed to the invoke.
+	Call(tok string, args resource.PropertyMap, provider string) (resource.PropertyMap, error)
+	// NewResource is invoked for every custom resource that is registered or read, and returns the resource's ID and
+	// output state.  id is the ID of the resource being read or imported, and is empty otherwise.
+	NewResource(typeToken, name string, inputs resource.PropertyMap,
+		provider, id string) (string, resource.PropertyMap, error)
+}
+
+// MockStreamResourceMonitor may be implemented by a MockResourceMonitor to supply the results of streaming provider
+// function invocations.  If it is not implemented, a streaming invocation yields the single result returned by Call.
+type MockStreamResourceMonitor interface {
+	// StreamCall is invoked for every streaming provider function invocation, and returns the function's results.
+	StreamCall(tok string, args resource.PropertyMap, provider string) ([]resource.PropertyMap, error)
+}
+
+// MockMethodResourceMonitor may be implemented by a MockResourceMonitor to supply the results of calls to resource
+// methods.  If it is not implemented, a method call returns the result of Call, passed the method's token and
+// arguments and the resource's provider.
+type MockMethodResourceMonitor interface {
+	// MethodCall is invoked for every call to a resource's method, and returns the method's result.  tok is the
+	// method token, urn, id, and state identify the resource whose method is being called, and args are the method's
+	// arguments.
+	MethodCall(tok string, urn URN, id ID, state, args resource.PropertyMap) (resource.PropertyMap, error)
+}
+
+// MockResource records a single resource registration observed by a MockMonitor.
+type MockResource struct {
+	URN          URN                  // the resource's URN.
+	ID           ID                   // the resource's ID, if it is a custom resource.
+	Type         string               // the resource's type token.
+	Name         string               // the resource's name.
+	Parent       URN                  // the resource's parent URN, if any.
+	Custom       bool                 // true if this is a custom resource.
+	Read         bool                 // true if this resource was read rather than registered.
+	Protect      bool                 // true if the resource was marked as protected.
+	Provider     string               // the provider reference for this resource, if any.
+	Dependencies []URN                // the resource's explicit and implicit dependencies.
+	Inputs       resource.PropertyMap // the input properties supplied by the program.
+	State        resource.PropertyMap // the output state returned by the mocks.
+	Outputs      resource.PropertyMap // any outputs registered for the resource after its construction.
+}
+
+// MockMonitor is an in-process resource monitor that answers all requests from a program using a MockResourceMonitor
+// rather than an engine.  It records every resource it sees so that tests may inspect them after the program runs.
+type MockMonitor struct {
+	project   string
+	stack     string
+	mocks     MockResourceMonitor
+	resources []*MockResource       // the resources observed, in registration order.
+	byURN     map[URN]*MockResource // a map from URN to the resources observed.
+	lock      sync.Mutex            // a lock protecting the resource list and map.
+}
+
+var _ pulumirpc.ResourceMonitorClient = (*MockMonitor)(nil)
+
+// NewMockMonitor creates a new mock resource monitor for the given project and stack that delegates provider
+// operations to the given mocks.
+func NewMockMonitor(project, stack string, mocks MockResourceMonitor) *MockMonitor {
+	return &MockMonitor{
+		project: project,
+		stack:   stack,
+		mocks:   mocks,
+		byURN:   make(map[URN]*MockResource),
+	}
+}
+
+// WithMocks runs a program against the given mock monitor instead of connecting to an engine over gRPC.
+func WithMocks(monitor *MockMonitor) RunOpt {
+	return func(info *RunInfo) {
+		info.Project, info.Stack, info.Mocks = monitor.project, monitor.stack, monitor
+	}
+}
+
+// Resources returns all resources observed by this monitor, in the order they were registered.
+func (m *MockMonitor) Resources() []MockResource {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	result := make([]MockResource, len(m.resources))
+	for i, r := range m.resources {
+		result[i] = *r
+	}
+	return result
+}
+
+// Resource returns the resource observed by this monitor with the given URN, if any.
+func (m *MockMonitor) Resource(urn URN) (MockResource, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	r, has := m.byURN[urn]
+	if !has {
+		return MockResource{}, false
+	}
+	return *r, true
+}
+
+// newURN creates a URN for a resource with the given parent, type, and name, exactly as the engine would.
+func (m *MockMonitor) newURN(parent, typ, name string) URN {
+	var parentType tokens.Type
+	if p := resource.URN(parent); p != "" && p.Type() != resource.RootStackType {
+		parentType = p.QualifiedType()
+	}
+	return URN(resource.NewURN(tokens.QName(m.stack), tokens.PackageName(m.project), parentType,
+		tokens.Type(typ), tokens.QName(name)))
+}
+
+// record saves the given resource, failing if a resource with the same URN has already been observed.
+func (m *MockMonitor) record(r *MockResource) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, has := m.byURN[r.URN]; has {
+		return errors.Errorf("duplicate resource URN '%s'", r.URN)
+	}
+	m.resources = append(m.resources, r)
+	m.byURN[r.URN] = r
+	return nil
+}
+
+func (m *MockMonitor) SupportsFeature(ctx context.Context, in *pulumirpc.SupportsFeatureRequest,
+	opts ...grpc.CallOption) (*pulumirpc.SupportsFeatureResponse, error) {
+	return &pulumirpc.SupportsFeatureResponse{HasSupport: false}, nil
+}
+
+func (m *MockMonitor) Invoke(ctx context.Context, in *pulumirpc.InvokeRequest,
+	opts ...grpc.CallOption) (*pulumirpc.InvokeResponse, error) {
+
+	args, err := plugin.UnmarshalProperties(in.GetArgs(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.mocks.Call(in.GetTok(), args, in.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := plugin.MarshalProperties(result, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.InvokeResponse{Return: ret}, nil
+}
+
+func (m *MockMonitor) Call(ctx context.Context, in *pulumirpc.ResourceCallRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ResourceCallResponse, error) {
+
+	r, has := m.Resource(URN(in.GetUrn()))
+	if !has {
+		return nil, errors.Errorf("unknown resource '%s'", in.GetUrn())
+	}
+	args, err := plugin.UnmarshalProperties(in.GetArgs(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var result resource.PropertyMap
+	if methods, ok := m.mocks.(MockMethodResourceMonitor); ok {
+		result, err = methods.MethodCall(in.GetTok(), r.URN, r.ID, r.State, args)
+	} else {
+		result, err = m.mocks.Call(in.GetTok(), args, r.Provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := plugin.MarshalProperties(result, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// As with the engine, the result depends on the resource and everything its arguments depend on.
+	deps := map[string]bool{in.GetUrn(): true}
+	for _, argDeps := range in.GetArgDependencies() {
+		for _, d := range argDeps.GetUrns() {
+			deps[d] = true
+		}
+	}
+	var returnDeps []string
+	for d := range deps {
+		returnDeps = append(returnDeps, d)
+	}
+	sort.Strings(returnDeps)
+	return &pulumirpc.ResourceCallResponse{Return: ret, ReturnDependencies: returnDeps}, nil
+}
+
+func (m *MockMonitor) StreamInvoke(ctx context.Context, in *pulumirpc.InvokeRequest,
+	opts ...grpc.CallOption) (pulumirpc.ResourceMonitor_StreamInvokeClient, error) {
+
+	args, err := plugin.UnmarshalProperties(in.GetArgs(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []resource.PropertyMap
+	if streamer, ok := m.mocks.(MockStreamResourceMonitor); ok {
+		results, err = streamer.StreamCall(in.GetTok(), args, in.GetProvider())
+	} else {
+		var result resource.PropertyMap
+		result, err = m.mocks.Call(in.GetTok(), args, in.GetProvider())
+		results = []resource.PropertyMap{result}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &mockInvokeStream{ctx: ctx}
+	for _, result := range results {
+		ret, err := plugin.MarshalProperties(result, plugin.MarshalOptions{KeepUnknowns: true})
+		if err != nil {
+			return nil, err
+		}
+		stream.responses = append(stream.responses, &pulumirpc.InvokeResponse{Return: ret})
+	}
+	return stream, nil
+}
+
+func (m *MockMonitor) ReadResource(ctx context.Context, in *pulumirpc.ReadResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ReadResourceResponse, error) {
+
+	inputs, err := plugin.UnmarshalProperties(in.GetProperties(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	_, state, err := m.mocks.NewResource(in.GetType(), in.GetName(), inputs, in.GetProvider(), in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	urn := m.newURN(in.GetParent(), in.GetType(), in.GetName())
+	if err = m.record(&MockResource{
+		URN:      urn,
+		ID:       ID(in.GetId()),
+		Type:     in.GetType(),
+		Name:     in.GetName(),
+		Parent:   URN(in.GetParent()),
+		Custom:   true,
+		Read:     true,
+		Provider: in.GetProvider(),
+		Inputs:   inputs,
+		State:    state,
+	}); err != nil {
+		return nil, err
+	}
+
+	props, err := plugin.MarshalProperties(state, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResourceResponse{Urn: string(urn), Properties: props}, nil
+}
+
+func (m *MockMonitor) RegisterResource(ctx context.Context, in *pulumirpc.RegisterResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.RegisterResourceResponse, error) {
+
+	inputs, err := plugin.UnmarshalProperties(in.GetObject(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only custom resources are backed by a provider; components (including the root stack) simply echo their inputs.
+	var id string
+	state := inputs
+	if in.GetCustom() {
+		id, state, err = m.mocks.NewResource(in.GetType(), in.GetName(), inputs, in.GetProvider(), in.GetImportId())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deps := make([]URN, len(in.GetDependencies()))
+	for i, d := range in.GetDependencies() {
+		deps[i] = URN(d)
+	}
+
+	urn := m.newURN(in.GetParent(), in.GetType(), in.GetName())
+	if err = m.record(&MockResource{
+		URN:          urn,
+		ID:           ID(id),
+		Type:         in.GetType(),
+		Name:         in.GetName(),
+		Parent:       URN(in.GetParent()),
+		Custom:       in.GetCustom(),
+		Protect:      in.GetProtect(),
+		Provider:     in.GetProvider(),
+		Dependencies: deps,
+		Inputs:       inputs,
+		State:        state,
+	}); err != nil {
+		return nil, err
+	}
+
+	obj, err := plugin.MarshalProperties(state, plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.RegisterResourceResponse{Urn: string(urn), Id: id, Object: obj}, nil
+}
+
+func (m *MockMonitor) RegisterResourceOutputs(ctx context.Context, in *pulumirpc.RegisterResourceOutputsRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+
+	outs, err := plugin.UnmarshalProperties(in.GetOutputs(), plugin.MarshalOptions{KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	r, has := m.byURN[URN(in.GetUrn())]
+	if !has {
+		return nil, errors.Errorf("cannot register outputs for unknown resource '%s'", in.GetUrn())
+	}
+	r.Outputs = outs
+	return &empty.Empty{}, nil
+}
+
+// mockInvokeStream is a client stream that replays the results of a mocked streaming invocation.
+type mockInvokeStream struct {
+	grpc.ClientStream
+
+	ctx       context.Context
+	responses []*pulumirpc.InvokeResponse
+}
+
+func (s *mockInvokeStream) Recv() (*pulumirpc.InvokeResponse, error) {
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func (s *mockInvokeStream) Context() context.Context {
+	return s.ctx
+}
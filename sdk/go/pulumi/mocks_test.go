@@ -0,0 +1,134 @@
+// Copyright 2016-2019, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+type testMocks struct {
+	calls int
+}
+
+func (m *testMocks) Call(tok string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+	m.calls++
+	return resource.PropertyMap{
+		"zones": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("us-west-2a")}),
+	}, nil
+}
+
+func (m *testMocks) NewResource(typeToken, name string, inputs resource.PropertyMap,
+	provider, id string) (string, resource.PropertyMap, error) {
+
+	if id == "" {
+		id = name + "_id"
+	}
+	state := inputs.Copy()
+	state["arn"] = resource.NewStringProperty("arn:" + name)
+	return id, state, nil
+}
+
+func TestRunWithMocks(t *testing.T) {
+	mocks := &testMocks{}
+	monitor := NewMockMonitor("project", "stack", mocks)
+
+	var bucket, object *ResourceState
+	err := RunErr(func(ctx *Context) error {
+		zones, err := ctx.Invoke("aws:index:getAvailabilityZones", nil)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []interface{}{"us-west-2a"}, zones["zones"])
+
+		comp, err := ctx.RegisterResource("pkg:index:Component", "comp", false, nil)
+		if err != nil {
+			return err
+		}
+		bucket, err = ctx.RegisterResource("aws:s3/bucket:Bucket", "bucket", true, map[string]interface{}{
+			"acl": "private",
+			"arn": nil,
+		}, ResourceOpt{Parent: comp})
+		if err != nil {
+			return err
+		}
+		object, err = ctx.RegisterResource("aws:s3/bucketObject:BucketObject", "object", true,
+			map[string]interface{}{"bucket": bucket}, ResourceOpt{Protect: true})
+		if err != nil {
+			return err
+		}
+
+		ctx.Export("bucketArn", bucket.State["arn"])
+		return nil
+	}, WithMocks(monitor))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mocks.calls)
+
+	// Outputs resolve synchronously once the program has completed.
+	id, known, err := bucket.ID().Value()
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, ID("bucket_id"), id)
+	arn, known, err := bucket.State["arn"].String()
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "arn:bucket", arn)
+
+	// Inspect the registered resources.
+	resources := monitor.Resources()
+	if assert.Len(t, resources, 4) {
+		stack, comp, b, o := resources[0], resources[1], resources[2], resources[3]
+		assert.Equal(t, URN("urn:pulumi:stack::project::pulumi:pulumi:Stack::project-stack"), stack.URN)
+		assert.Equal(t, URN("urn:pulumi:stack::project::pkg:index:Component::comp"), comp.URN)
+		assert.Equal(t, stack.URN, comp.Parent)
+		assert.False(t, comp.Custom)
+
+		assert.Equal(t, URN("urn:pulumi:stack::project::pkg:index:Component$aws:s3/bucket:Bucket::bucket"), b.URN)
+		assert.Equal(t, comp.URN, b.Parent)
+		assert.Equal(t, "private", b.Inputs["acl"].StringValue())
+
+		assert.True(t, o.Protect)
+		assert.Equal(t, []URN{b.URN}, o.Dependencies)
+		assert.Equal(t, "bucket_id", o.Inputs["bucket"].StringValue())
+
+		assert.Equal(t, "arn:bucket", stack.Outputs["bucketArn"].StringValue())
+	}
+
+	objectURN, err := object.URN().Value()
+	assert.NoError(t, err)
+	r, ok := monitor.Resource(objectURN)
+	assert.True(t, ok)
+	assert.Equal(t, ID("object_id"), r.ID)
+}
+
+func TestReadResourceWithMocks(t *testing.T) {
+	monitor := NewMockMonitor("project", "stack", &testMocks{})
+
+	err := RunErr(func(ctx *Context) error {
+		_, err := ctx.ReadResource("aws:s3/bucket:Bucket", "existing", ID("bucket-1234"), nil)
+		return err
+	}, WithMocks(monitor))
+	assert.NoError(t, err)
+
+	resources := monitor.Resources()
+	if assert.Len(t, resources, 2) {
+		assert.True(t, resources[1].Read)
+		assert.Equal(t, ID("bucket-1234"), resources[1].ID)
+		assert.Equal(t, "arn:existing", resources[1].State["arn"].StringValue())
+	}
+}
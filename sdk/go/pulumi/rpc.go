@@ -61,6 +61,75 @@ func marshalInputs(props map[string]interface{}) (*structpb.Struct, map[string][
 	return m, pdeps, depURNs, err
 }
 
+// inputDeps returns the resources that the given input value depends on, without awaiting any outputs it contains.
+func inputDeps(v interface{}) []Resource {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case Output:
+		return t.Deps()
+	case *Output:
+		if t == nil {
+			return nil
+		}
+		return t.Deps()
+	case CustomResource:
+		return []Resource{t}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		var deps []Resource
+		for i := 0; i < rv.Len(); i++ {
+			deps = append(deps, inputDeps(rv.Index(i).Interface())...)
+		}
+		return deps
+	case reflect.Map:
+		var deps []Resource
+		for _, key := range rv.MapKeys() {
+			deps = append(deps, inputDeps(rv.MapIndex(key).Interface())...)
+		}
+		return deps
+	case reflect.Ptr:
+		// See if this is an alias for *Output.  If so, convert to an *Output, and recurse.
+		if ot := reflect.TypeOf(&Output{}); rv.Type().ConvertibleTo(ot) {
+			return inputDeps(rv.Convert(ot).Interface())
+		}
+		if rv.IsNil() {
+			return nil
+		}
+		return inputDeps(rv.Elem().Interface())
+	}
+	return nil
+}
+
+// containsUnknowns returns true if any of the given marshaled properties are unknown.
+func containsUnknowns(props *structpb.Struct) bool {
+	for _, v := range props.GetFields() {
+		if valueContainsUnknowns(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueContainsUnknowns(v *structpb.Value) bool {
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return k.StringValue == rpcTokenUnknownValue
+	case *structpb.Value_ListValue:
+		for _, e := range k.ListValue.GetValues() {
+			if valueContainsUnknowns(e) {
+				return true
+			}
+		}
+	case *structpb.Value_StructValue:
+		return containsUnknowns(k.StructValue)
+	}
+	return false
+}
+
 // `gosec` thinks these are credentials, but they are not.
 // nolint: gosec
 const (
@@ -30,29 +30,32 @@ import (
 // Run executes the body of a Pulumi program, granting it access to a deployment context that it may use
 // to register resources and orchestrate deployment activities.  This connects back to the Pulumi engine using gRPC.
 // If the program fails, the process will be terminated and the function will not return.
-func Run(body RunFunc) {
-	if err := RunErr(body); err != nil {
+func Run(body RunFunc, opts ...RunOpt) {
+	if err := RunErr(body, opts...); err != nil {
 		fmt.Fprintf(os.Stderr, "error: program failed: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 // RunErr executes the body of a Pulumi program, granting it access to a deployment context that it may use
-// to register resources and orchestrate deployment activities.  This connects back to the Pulumi engine using gRPC.
-func RunErr(body RunFunc) error {
+// to register resources and orchestrate deployment activities.  This connects back to the Pulumi engine using gRPC,
+// unless the options supply a mock resource monitor to use instead.
+func RunErr(body RunFunc, opts ...RunOpt) error {
 	// Parse the info out of environment variables.  This is a lame contract with the caller, but helps to keep
-	// boilerplate to a minimum in the average Pulumi Go program.
-	// TODO(joe): this is a fine default, but consider `...RunOpt`s to control how we get the various addresses, etc.
+	// boilerplate to a minimum in the average Pulumi Go program.  Any options may then override these defaults.
 	info := getEnvInfo()
+	for _, opt := range opts {
+		opt(&info)
+	}
 
 	// Validate some properties.
 	if info.Project == "" {
 		return errors.Errorf("missing project name")
 	} else if info.Stack == "" {
 		return errors.New("missing stack name")
-	} else if info.MonitorAddr == "" {
+	} else if info.Mocks == nil && info.MonitorAddr == "" {
 		return errors.New("missing resource monitor RPC address")
-	} else if info.EngineAddr == "" {
+	} else if info.Mocks == nil && info.EngineAddr == "" {
 		return errors.New("missing engine RPC address")
 	}
 
@@ -105,15 +108,42 @@ func RunWithContext(ctx *Context, body RunFunc) error {
 // supplied as an arguent and any non-nil return value is interpreted as a program error by the Pulumi runtime.
 type RunFunc func(ctx *Context) error
 
+// RunOpt customizes the metadata used to run a Pulumi program.
+type RunOpt func(info *RunInfo)
+
 // RunInfo contains all the metadata about a run request.
 type RunInfo struct {
-	Project     string
-	Stack       string
-	Config      map[string]string
-	Parallel    int
-	DryRun      bool
-	MonitorAddr string
-	EngineAddr  string
+	Project          string
+	Stack            string
+	Config           map[string]string
+	ConfigSecretKeys []string // the keys of the configuration variables that are stored as secrets.
+	Parallel         int
+	DryRun           bool
+	MonitorAddr      string
+	EngineAddr       string
+	Mocks            *MockMonitor // if non-nil, the program runs against these mocks instead of an engine.
+}
+
+// WithConfig supplies the configuration for a Pulumi program, replacing any read from the environment.
+func WithConfig(config map[string]string) RunOpt {
+	return func(info *RunInfo) {
+		info.Config = config
+	}
+}
+
+// WithConfigSecretKeys supplies the keys of a Pulumi program's configuration variables that are stored as secrets,
+// replacing any read from the environment.
+func WithConfigSecretKeys(keys []string) RunOpt {
+	return func(info *RunInfo) {
+		info.ConfigSecretKeys = keys
+	}
+}
+
+// WithDryRun sets whether a Pulumi program is being run as part of a preview.
+func WithDryRun(dryRun bool) RunOpt {
+	return func(info *RunInfo) {
+		info.DryRun = dryRun
+	}
 }
 
 // getEnvInfo reads various program information from the process environment.
@@ -126,15 +156,20 @@ func getEnvInfo() RunInfo {
 	if cfg := os.Getenv(EnvConfig); cfg != "" {
 		_ = json.Unmarshal([]byte(cfg), &config)
 	}
+	var configSecretKeys []string
+	if keys := os.Getenv(EnvConfigSecretKeys); keys != "" {
+		_ = json.Unmarshal([]byte(keys), &configSecretKeys)
+	}
 
 	return RunInfo{
-		Project:     os.Getenv(EnvProject),
-		Stack:       os.Getenv(EnvStack),
-		Config:      config,
-		Parallel:    parallel,
-		DryRun:      dryRun,
-		MonitorAddr: os.Getenv(EnvMonitor),
-		EngineAddr:  os.Getenv(EnvEngine),
+		Project:          os.Getenv(EnvProject),
+		Stack:            os.Getenv(EnvStack),
+		Config:           config,
+		ConfigSecretKeys: configSecretKeys,
+		Parallel:         parallel,
+		DryRun:           dryRun,
+		MonitorAddr:      os.Getenv(EnvMonitor),
+		EngineAddr:       os.Getenv(EnvEngine),
 	}
 }
 
@@ -145,6 +180,8 @@ const (
 	EnvStack = "PULUMI_STACK"
 	// EnvConfig is the envvar used to read the current Pulumi configuration variables.
 	EnvConfig = "PULUMI_CONFIG"
+	// EnvConfigSecretKeys is the envvar used to read the keys of the current Pulumi configuration's secret variables.
+	EnvConfigSecretKeys = "PULUMI_CONFIG_SECRET_KEYS"
 	// EnvParallel is the envvar used to read the current Pulumi degree of parallelism.
 	EnvParallel = "PULUMI_PARALLEL"
 	// EnvDryRun is the envvar used to read the current Pulumi dry-run setting.
@@ -191,6 +191,32 @@ func (p *monitorProxy) Invoke(
 	return p.target.Invoke(ctx, req)
 }
 
+func (p *monitorProxy) StreamInvoke(
+	req *pulumirpc.InvokeRequest, server pulumirpc.ResourceMonitor_StreamInvokeServer) error {
+
+	client, err := p.target.StreamInvoke(server.Context(), req)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = server.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *monitorProxy) Call(
+	ctx context.Context, req *pulumirpc.ResourceCallRequest) (*pulumirpc.ResourceCallResponse, error) {
+	return p.target.Call(ctx, req)
+}
+
 func (p *monitorProxy) ReadResource(
 	ctx context.Context, req *pulumirpc.ReadResourceRequest) (*pulumirpc.ReadResourceResponse, error) {
 	return p.target.ReadResource(ctx, req)
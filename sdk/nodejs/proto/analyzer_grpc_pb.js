@@ -77,6 +77,17 @@ function deserialize_pulumirpc_AnalyzerInfo(buffer_arg) {
   return analyzer_pb.AnalyzerInfo.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_ConfigureAnalyzerRequest(arg) {
+  if (!(arg instanceof analyzer_pb.ConfigureAnalyzerRequest)) {
+    throw new Error('Expected argument of type pulumirpc.ConfigureAnalyzerRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ConfigureAnalyzerRequest(buffer_arg) {
+  return analyzer_pb.ConfigureAnalyzerRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_PluginInfo(arg) {
   if (!(arg instanceof plugin_pb.PluginInfo)) {
     throw new Error('Expected argument of type pulumirpc.PluginInfo');
@@ -88,6 +99,17 @@ function deserialize_pulumirpc_PluginInfo(buffer_arg) {
   return plugin_pb.PluginInfo.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_RemediateResponse(arg) {
+  if (!(arg instanceof analyzer_pb.RemediateResponse)) {
+    throw new Error('Expected argument of type pulumirpc.RemediateResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_RemediateResponse(buffer_arg) {
+  return analyzer_pb.RemediateResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 
 // Analyzer provides a pluggable interface for checking resource definitions against some number of
 // resource policies. It is intentionally open-ended, allowing for implementations that check
@@ -121,6 +143,19 @@ var AnalyzerService = exports.AnalyzerService = {
     responseSerialize: serialize_pulumirpc_AnalyzeResponse,
     responseDeserialize: deserialize_pulumirpc_AnalyzeResponse,
   },
+  // Remediate is given the opportunity to transform a single resource's properties, and returns the results of any
+  // policies that did so.  Called with the "inputs" to the resource, before they are checked by its provider.
+  remediate: {
+    path: '/pulumirpc.Analyzer/Remediate',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.AnalyzeRequest,
+    responseType: analyzer_pb.RemediateResponse,
+    requestSerialize: serialize_pulumirpc_AnalyzeRequest,
+    requestDeserialize: deserialize_pulumirpc_AnalyzeRequest,
+    responseSerialize: serialize_pulumirpc_RemediateResponse,
+    responseDeserialize: deserialize_pulumirpc_RemediateResponse,
+  },
   // GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
   getAnalyzerInfo: {
     path: '/pulumirpc.Analyzer/GetAnalyzerInfo',
@@ -145,6 +180,19 @@ var AnalyzerService = exports.AnalyzerService = {
     responseSerialize: serialize_pulumirpc_PluginInfo,
     responseDeserialize: deserialize_pulumirpc_PluginInfo,
   },
+  // Configure configures the analyzer, passing configuration properties and enforcement levels for each policy.
+  // Called once, before any analysis or remediation, with a configuration for every policy in the policy pack.
+  configure: {
+    path: '/pulumirpc.Analyzer/Configure',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.ConfigureAnalyzerRequest,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_pulumirpc_ConfigureAnalyzerRequest,
+    requestDeserialize: deserialize_pulumirpc_ConfigureAnalyzerRequest,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
 };
 
 exports.AnalyzerClient = grpc.makeGenericClientConstructor(AnalyzerService);
@@ -19,9 +19,18 @@ goog.exportSymbol('proto.pulumirpc.AnalyzeRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzeResponse', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzeStackRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzerInfo', null, global);
+goog.exportSymbol('proto.pulumirpc.AnalyzerPropertyDependencies', null, global);
+goog.exportSymbol('proto.pulumirpc.AnalyzerProviderResource', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzerResource', null, global);
+goog.exportSymbol('proto.pulumirpc.AnalyzerResourceOptions', null, global);
+goog.exportSymbol('proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts', null, global);
+goog.exportSymbol('proto.pulumirpc.ConfigureAnalyzerRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.EnforcementLevel', null, global);
+goog.exportSymbol('proto.pulumirpc.PolicyConfig', null, global);
+goog.exportSymbol('proto.pulumirpc.PolicyConfigSchema', null, global);
 goog.exportSymbol('proto.pulumirpc.PolicyInfo', null, global);
+goog.exportSymbol('proto.pulumirpc.RemediateResponse', null, global);
+goog.exportSymbol('proto.pulumirpc.Remediation', null, global);
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -34,12 +43,19 @@ goog.exportSymbol('proto.pulumirpc.PolicyInfo', null, global);
  * @constructor
  */
 proto.pulumirpc.AnalyzeRequest = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeRequest.repeatedFields_, null);
 };
 goog.inherits(proto.pulumirpc.AnalyzeRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
   proto.pulumirpc.AnalyzeRequest.displayName = 'proto.pulumirpc.AnalyzeRequest';
 }
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzeRequest.repeatedFields_ = [8];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -70,7 +86,14 @@ proto.pulumirpc.AnalyzeRequest.prototype.toObject = function(opt_includeInstance
 proto.pulumirpc.AnalyzeRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     type: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    urn: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    name: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    options: (f = msg.getOptions()) && proto.pulumirpc.AnalyzerResourceOptions.toObject(includeInstance, f),
+    provider: (f = msg.getProvider()) && proto.pulumirpc.AnalyzerProviderResource.toObject(includeInstance, f),
+    parent: jspb.Message.getFieldWithDefault(msg, 7, ""),
+    dependenciesList: jspb.Message.getRepeatedField(msg, 8),
+    propertydependenciesMap: (f = msg.getPropertydependenciesMap()) ? f.toObject(includeInstance, proto.pulumirpc.AnalyzerPropertyDependencies.toObject) : []
   };
 
   if (includeInstance) {
@@ -116,6 +139,38 @@ proto.pulumirpc.AnalyzeRequest.deserializeBinaryFromReader = function(msg, reade
       reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
       msg.setProperties(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 5:
+      var value = new proto.pulumirpc.AnalyzerResourceOptions;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResourceOptions.deserializeBinaryFromReader);
+      msg.setOptions(value);
+      break;
+    case 6:
+      var value = new proto.pulumirpc.AnalyzerProviderResource;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerProviderResource.deserializeBinaryFromReader);
+      msg.setProvider(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setParent(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addDependencies(value);
+      break;
+    case 9:
+      var value = msg.getPropertydependenciesMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readMessage, proto.pulumirpc.AnalyzerPropertyDependencies.deserializeBinaryFromReader);
+         });
+      break;
     default:
       reader.skipField();
       break;
@@ -160,6 +215,54 @@ proto.pulumirpc.AnalyzeRequest.serializeBinaryToWriter = function(message, write
       google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getOptions();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      proto.pulumirpc.AnalyzerResourceOptions.serializeBinaryToWriter
+    );
+  }
+  f = message.getProvider();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      proto.pulumirpc.AnalyzerProviderResource.serializeBinaryToWriter
+    );
+  }
+  f = message.getParent();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
+  f = message.getDependenciesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      8,
+      f
+    );
+  }
+  f = message.getPropertydependenciesMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(9, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeMessage, proto.pulumirpc.AnalyzerPropertyDependencies.serializeBinaryToWriter);
+  }
 };
 
 
@@ -208,6 +311,158 @@ proto.pulumirpc.AnalyzeRequest.prototype.hasProperties = function() {
 };
 
 
+/**
+ * optional string urn = 3;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeRequest.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string name = 4;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeRequest.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional AnalyzerResourceOptions options = 5;
+ * @return {?proto.pulumirpc.AnalyzerResourceOptions}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getOptions = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerResourceOptions} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerResourceOptions, 5));
+};
+
+
+/** @param {?proto.pulumirpc.AnalyzerResourceOptions|undefined} value */
+proto.pulumirpc.AnalyzeRequest.prototype.setOptions = function(value) {
+  jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+proto.pulumirpc.AnalyzeRequest.prototype.clearOptions = function() {
+  this.setOptions(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.hasOptions = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
+
+
+/**
+ * optional AnalyzerProviderResource provider = 6;
+ * @return {?proto.pulumirpc.AnalyzerProviderResource}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getProvider = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerProviderResource} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerProviderResource, 6));
+};
+
+
+/** @param {?proto.pulumirpc.AnalyzerProviderResource|undefined} value */
+proto.pulumirpc.AnalyzeRequest.prototype.setProvider = function(value) {
+  jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+proto.pulumirpc.AnalyzeRequest.prototype.clearProvider = function() {
+  this.setProvider(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.hasProvider = function() {
+  return jspb.Message.getField(this, 6) != null;
+};
+
+
+/**
+ * optional string parent = 7;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getParent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeRequest.prototype.setParent = function(value) {
+  jspb.Message.setProto3StringField(this, 7, value);
+};
+
+
+/**
+ * repeated string dependencies = 8;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getDependenciesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 8));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzeRequest.prototype.setDependenciesList = function(value) {
+  jspb.Message.setField(this, 8, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.addDependencies = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 8, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzeRequest.prototype.clearDependenciesList = function() {
+  this.setDependenciesList([]);
+};
+
+
+/**
+ * map<string, AnalyzerPropertyDependencies> propertyDependencies = 9;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!proto.pulumirpc.AnalyzerPropertyDependencies>}
+ */
+proto.pulumirpc.AnalyzeRequest.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!proto.pulumirpc.AnalyzerPropertyDependencies>} */ (
+      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
+      proto.pulumirpc.AnalyzerPropertyDependencies));
+};
+
+
+proto.pulumirpc.AnalyzeRequest.prototype.clearPropertydependenciesMap = function() {
+  this.getPropertydependenciesMap().clear();
+};
+
+
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -220,12 +475,19 @@ proto.pulumirpc.AnalyzeRequest.prototype.hasProperties = function() {
  * @constructor
  */
 proto.pulumirpc.AnalyzerResource = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzerResource.repeatedFields_, null);
 };
 goog.inherits(proto.pulumirpc.AnalyzerResource, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
   proto.pulumirpc.AnalyzerResource.displayName = 'proto.pulumirpc.AnalyzerResource';
 }
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzerResource.repeatedFields_ = [8];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -256,7 +518,14 @@ proto.pulumirpc.AnalyzerResource.prototype.toObject = function(opt_includeInstan
 proto.pulumirpc.AnalyzerResource.toObject = function(includeInstance, msg) {
   var f, obj = {
     type: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    urn: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    name: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    options: (f = msg.getOptions()) && proto.pulumirpc.AnalyzerResourceOptions.toObject(includeInstance, f),
+    provider: (f = msg.getProvider()) && proto.pulumirpc.AnalyzerProviderResource.toObject(includeInstance, f),
+    parent: jspb.Message.getFieldWithDefault(msg, 7, ""),
+    dependenciesList: jspb.Message.getRepeatedField(msg, 8),
+    propertydependenciesMap: (f = msg.getPropertydependenciesMap()) ? f.toObject(includeInstance, proto.pulumirpc.AnalyzerPropertyDependencies.toObject) : []
   };
 
   if (includeInstance) {
@@ -302,6 +571,38 @@ proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader = function(msg, rea
       reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
       msg.setProperties(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 5:
+      var value = new proto.pulumirpc.AnalyzerResourceOptions;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResourceOptions.deserializeBinaryFromReader);
+      msg.setOptions(value);
+      break;
+    case 6:
+      var value = new proto.pulumirpc.AnalyzerProviderResource;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerProviderResource.deserializeBinaryFromReader);
+      msg.setProvider(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setParent(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addDependencies(value);
+      break;
+    case 9:
+      var value = msg.getPropertydependenciesMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readMessage, proto.pulumirpc.AnalyzerPropertyDependencies.deserializeBinaryFromReader);
+         });
+      break;
     default:
       reader.skipField();
       break;
@@ -346,6 +647,54 @@ proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter = function(message, wri
       google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getOptions();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      proto.pulumirpc.AnalyzerResourceOptions.serializeBinaryToWriter
+    );
+  }
+  f = message.getProvider();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      proto.pulumirpc.AnalyzerProviderResource.serializeBinaryToWriter
+    );
+  }
+  f = message.getParent();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
+  f = message.getDependenciesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      8,
+      f
+    );
+  }
+  f = message.getPropertydependenciesMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(9, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeMessage, proto.pulumirpc.AnalyzerPropertyDependencies.serializeBinaryToWriter);
+  }
 };
 
 
@@ -394,171 +743,155 @@ proto.pulumirpc.AnalyzerResource.prototype.hasProperties = function() {
 };
 
 
-
 /**
- * Generated by JsPbCodeGenerator.
- * @param {Array=} opt_data Optional initial data array, typically from a
- * server response, or constructed directly in Javascript. The array is used
- * in place and becomes part of the constructed object. It is not cloned.
- * If no data is provided, the constructed object will be empty, but still
- * valid.
- * @extends {jspb.Message}
- * @constructor
+ * optional string urn = 3;
+ * @return {string}
  */
-proto.pulumirpc.AnalyzeStackRequest = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeStackRequest.repeatedFields_, null);
+proto.pulumirpc.AnalyzerResource.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
 };
-goog.inherits(proto.pulumirpc.AnalyzeStackRequest, jspb.Message);
-if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.AnalyzeStackRequest.displayName = 'proto.pulumirpc.AnalyzeStackRequest';
-}
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerResource.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
 /**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
+ * optional string name = 4;
+ * @return {string}
  */
-proto.pulumirpc.AnalyzeStackRequest.repeatedFields_ = [1];
+proto.pulumirpc.AnalyzerResource.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
 
 
+/** @param {string} value */
+proto.pulumirpc.AnalyzerResource.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
 
-if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * Creates an object representation of this proto suitable for use in Soy templates.
- * Field names that are reserved in JavaScript and will be renamed to pb_name.
- * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
- * For the list of reserved names please see:
- *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
- * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
- *     for transitional soy proto support: http://goto/soy-param-migration
- * @return {!Object}
+ * optional AnalyzerResourceOptions options = 5;
+ * @return {?proto.pulumirpc.AnalyzerResourceOptions}
  */
-proto.pulumirpc.AnalyzeStackRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.AnalyzeStackRequest.toObject(opt_includeInstance, this);
+proto.pulumirpc.AnalyzerResource.prototype.getOptions = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerResourceOptions} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerResourceOptions, 5));
 };
 
 
-/**
- * Static version of the {@see toObject} method.
- * @param {boolean|undefined} includeInstance Whether to include the JSPB
- *     instance for transitional soy proto support:
- *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.AnalyzeStackRequest} msg The msg instance to transform.
- * @return {!Object}
- * @suppress {unusedLocalVariables} f is only used for nested messages
- */
-proto.pulumirpc.AnalyzeStackRequest.toObject = function(includeInstance, msg) {
-  var f, obj = {
-    resourcesList: jspb.Message.toObjectList(msg.getResourcesList(),
-    proto.pulumirpc.AnalyzerResource.toObject, includeInstance)
-  };
+/** @param {?proto.pulumirpc.AnalyzerResourceOptions|undefined} value */
+proto.pulumirpc.AnalyzerResource.prototype.setOptions = function(value) {
+  jspb.Message.setWrapperField(this, 5, value);
+};
 
-  if (includeInstance) {
-    obj.$jspbMessageInstance = msg;
-  }
-  return obj;
+
+proto.pulumirpc.AnalyzerResource.prototype.clearOptions = function() {
+  this.setOptions(undefined);
 };
-}
 
 
 /**
- * Deserializes binary data (in protobuf wire format).
- * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.AnalyzeStackRequest}
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.pulumirpc.AnalyzeStackRequest.deserializeBinary = function(bytes) {
-  var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.AnalyzeStackRequest;
-  return proto.pulumirpc.AnalyzeStackRequest.deserializeBinaryFromReader(msg, reader);
+proto.pulumirpc.AnalyzerResource.prototype.hasOptions = function() {
+  return jspb.Message.getField(this, 5) != null;
 };
 
 
 /**
- * Deserializes binary data (in protobuf wire format) from the
- * given reader into the given message object.
- * @param {!proto.pulumirpc.AnalyzeStackRequest} msg The message object to deserialize into.
- * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.AnalyzeStackRequest}
+ * optional AnalyzerProviderResource provider = 6;
+ * @return {?proto.pulumirpc.AnalyzerProviderResource}
  */
-proto.pulumirpc.AnalyzeStackRequest.deserializeBinaryFromReader = function(msg, reader) {
-  while (reader.nextField()) {
-    if (reader.isEndGroup()) {
-      break;
-    }
-    var field = reader.getFieldNumber();
-    switch (field) {
-    case 1:
-      var value = new proto.pulumirpc.AnalyzerResource;
-      reader.readMessage(value,proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader);
-      msg.addResources(value);
-      break;
-    default:
-      reader.skipField();
-      break;
-    }
-  }
-  return msg;
+proto.pulumirpc.AnalyzerResource.prototype.getProvider = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerProviderResource} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerProviderResource, 6));
+};
+
+
+/** @param {?proto.pulumirpc.AnalyzerProviderResource|undefined} value */
+proto.pulumirpc.AnalyzerResource.prototype.setProvider = function(value) {
+  jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+proto.pulumirpc.AnalyzerResource.prototype.clearProvider = function() {
+  this.setProvider(undefined);
 };
 
 
 /**
- * Serializes the message to binary data (in protobuf wire format).
- * @return {!Uint8Array}
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.pulumirpc.AnalyzeStackRequest.prototype.serializeBinary = function() {
-  var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.AnalyzeStackRequest.serializeBinaryToWriter(this, writer);
-  return writer.getResultBuffer();
+proto.pulumirpc.AnalyzerResource.prototype.hasProvider = function() {
+  return jspb.Message.getField(this, 6) != null;
 };
 
 
 /**
- * Serializes the given message to binary data (in protobuf wire
- * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.AnalyzeStackRequest} message
- * @param {!jspb.BinaryWriter} writer
- * @suppress {unusedLocalVariables} f is only used for nested messages
+ * optional string parent = 7;
+ * @return {string}
  */
-proto.pulumirpc.AnalyzeStackRequest.serializeBinaryToWriter = function(message, writer) {
-  var f = undefined;
-  f = message.getResourcesList();
-  if (f.length > 0) {
-    writer.writeRepeatedMessage(
-      1,
-      f,
-      proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter
-    );
-  }
+proto.pulumirpc.AnalyzerResource.prototype.getParent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerResource.prototype.setParent = function(value) {
+  jspb.Message.setProto3StringField(this, 7, value);
 };
 
 
 /**
- * repeated AnalyzerResource resources = 1;
- * @return {!Array.<!proto.pulumirpc.AnalyzerResource>}
+ * repeated string dependencies = 8;
+ * @return {!Array.<string>}
  */
-proto.pulumirpc.AnalyzeStackRequest.prototype.getResourcesList = function() {
-  return /** @type{!Array.<!proto.pulumirpc.AnalyzerResource>} */ (
-    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.AnalyzerResource, 1));
+proto.pulumirpc.AnalyzerResource.prototype.getDependenciesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 8));
 };
 
 
-/** @param {!Array.<!proto.pulumirpc.AnalyzerResource>} value */
-proto.pulumirpc.AnalyzeStackRequest.prototype.setResourcesList = function(value) {
-  jspb.Message.setRepeatedWrapperField(this, 1, value);
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzerResource.prototype.setDependenciesList = function(value) {
+  jspb.Message.setField(this, 8, value || []);
 };
 
 
 /**
- * @param {!proto.pulumirpc.AnalyzerResource=} opt_value
+ * @param {!string} value
  * @param {number=} opt_index
- * @return {!proto.pulumirpc.AnalyzerResource}
  */
-proto.pulumirpc.AnalyzeStackRequest.prototype.addResources = function(opt_value, opt_index) {
-  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.pulumirpc.AnalyzerResource, opt_index);
+proto.pulumirpc.AnalyzerResource.prototype.addDependencies = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 8, value, opt_index);
 };
 
 
-proto.pulumirpc.AnalyzeStackRequest.prototype.clearResourcesList = function() {
-  this.setResourcesList([]);
+proto.pulumirpc.AnalyzerResource.prototype.clearDependenciesList = function() {
+  this.setDependenciesList([]);
+};
+
+
+/**
+ * map<string, AnalyzerPropertyDependencies> propertyDependencies = 9;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!proto.pulumirpc.AnalyzerPropertyDependencies>}
+ */
+proto.pulumirpc.AnalyzerResource.prototype.getPropertydependenciesMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!proto.pulumirpc.AnalyzerPropertyDependencies>} */ (
+      jspb.Message.getMapField(this, 9, opt_noLazyCreate,
+      proto.pulumirpc.AnalyzerPropertyDependencies));
+};
+
+
+proto.pulumirpc.AnalyzerResource.prototype.clearPropertydependenciesMap = function() {
+  this.getPropertydependenciesMap().clear();
 };
 
 
@@ -573,19 +906,19 @@ proto.pulumirpc.AnalyzeStackRequest.prototype.clearResourcesList = function() {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.pulumirpc.AnalyzeResponse = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeResponse.repeatedFields_, null);
+proto.pulumirpc.AnalyzerResourceOptions = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzerResourceOptions.repeatedFields_, null);
 };
-goog.inherits(proto.pulumirpc.AnalyzeResponse, jspb.Message);
+goog.inherits(proto.pulumirpc.AnalyzerResourceOptions, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.AnalyzeResponse.displayName = 'proto.pulumirpc.AnalyzeResponse';
+  proto.pulumirpc.AnalyzerResourceOptions.displayName = 'proto.pulumirpc.AnalyzerResourceOptions';
 }
 /**
  * List of repeated fields within this message type.
  * @private {!Array<number>}
  * @const
  */
-proto.pulumirpc.AnalyzeResponse.repeatedFields_ = [2];
+proto.pulumirpc.AnalyzerResourceOptions.repeatedFields_ = [2,5,6];
 
 
 
@@ -600,8 +933,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.AnalyzeResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.AnalyzeResponse.toObject(opt_includeInstance, this);
+proto.pulumirpc.AnalyzerResourceOptions.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzerResourceOptions.toObject(opt_includeInstance, this);
 };
 
 
@@ -610,14 +943,19 @@ proto.pulumirpc.AnalyzeResponse.prototype.toObject = function(opt_includeInstanc
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.AnalyzeResponse} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.AnalyzerResourceOptions} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.AnalyzeResponse.toObject = function(includeInstance, msg) {
+proto.pulumirpc.AnalyzerResourceOptions.toObject = function(includeInstance, msg) {
   var f, obj = {
-    diagnosticsList: jspb.Message.toObjectList(msg.getDiagnosticsList(),
-    proto.pulumirpc.AnalyzeDiagnostic.toObject, includeInstance)
+    protect: jspb.Message.getFieldWithDefault(msg, 1, false),
+    ignorechangesList: jspb.Message.getRepeatedField(msg, 2),
+    deletebeforereplace: jspb.Message.getFieldWithDefault(msg, 3, false),
+    deletebeforereplacedefined: jspb.Message.getFieldWithDefault(msg, 4, false),
+    additionalsecretoutputsList: jspb.Message.getRepeatedField(msg, 5),
+    aliasesList: jspb.Message.getRepeatedField(msg, 6),
+    customtimeouts: (f = msg.getCustomtimeouts()) && proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -631,33 +969,57 @@ proto.pulumirpc.AnalyzeResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.AnalyzeResponse}
+ * @return {!proto.pulumirpc.AnalyzerResourceOptions}
  */
-proto.pulumirpc.AnalyzeResponse.deserializeBinary = function(bytes) {
+proto.pulumirpc.AnalyzerResourceOptions.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.AnalyzeResponse;
-  return proto.pulumirpc.AnalyzeResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.AnalyzerResourceOptions;
+  return proto.pulumirpc.AnalyzerResourceOptions.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.AnalyzeResponse} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.AnalyzerResourceOptions} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.AnalyzeResponse}
+ * @return {!proto.pulumirpc.AnalyzerResourceOptions}
  */
-proto.pulumirpc.AnalyzeResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.AnalyzerResourceOptions.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
     }
     var field = reader.getFieldNumber();
     switch (field) {
+    case 1:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setProtect(value);
+      break;
     case 2:
-      var value = new proto.pulumirpc.AnalyzeDiagnostic;
-      reader.readMessage(value,proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader);
-      msg.addDiagnostics(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.addIgnorechanges(value);
+      break;
+    case 3:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setDeletebeforereplace(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setDeletebeforereplacedefined(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAdditionalsecretoutputs(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAliases(value);
+      break;
+    case 7:
+      var value = new proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.deserializeBinaryFromReader);
+      msg.setCustomtimeouts(value);
       break;
     default:
       reader.skipField();
@@ -672,9 +1034,9 @@ proto.pulumirpc.AnalyzeResponse.deserializeBinaryFromReader = function(msg, read
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.AnalyzeResponse.prototype.serializeBinary = function() {
+proto.pulumirpc.AnalyzerResourceOptions.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.AnalyzeResponse.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.AnalyzerResourceOptions.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -682,54 +1044,65 @@ proto.pulumirpc.AnalyzeResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.AnalyzeResponse} message
+ * @param {!proto.pulumirpc.AnalyzerResourceOptions} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.AnalyzeResponse.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.AnalyzerResourceOptions.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getDiagnosticsList();
+  f = message.getProtect();
+  if (f) {
+    writer.writeBool(
+      1,
+      f
+    );
+  }
+  f = message.getIgnorechangesList();
   if (f.length > 0) {
-    writer.writeRepeatedMessage(
+    writer.writeRepeatedString(
       2,
+      f
+    );
+  }
+  f = message.getDeletebeforereplace();
+  if (f) {
+    writer.writeBool(
+      3,
+      f
+    );
+  }
+  f = message.getDeletebeforereplacedefined();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+  f = message.getAdditionalsecretoutputsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getAliasesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      6,
+      f
+    );
+  }
+  f = message.getCustomtimeouts();
+  if (f != null) {
+    writer.writeMessage(
+      7,
       f,
-      proto.pulumirpc.AnalyzeDiagnostic.serializeBinaryToWriter
+      proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.serializeBinaryToWriter
     );
   }
 };
 
 
-/**
- * repeated AnalyzeDiagnostic diagnostics = 2;
- * @return {!Array.<!proto.pulumirpc.AnalyzeDiagnostic>}
- */
-proto.pulumirpc.AnalyzeResponse.prototype.getDiagnosticsList = function() {
-  return /** @type{!Array.<!proto.pulumirpc.AnalyzeDiagnostic>} */ (
-    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.AnalyzeDiagnostic, 2));
-};
-
-
-/** @param {!Array.<!proto.pulumirpc.AnalyzeDiagnostic>} value */
-proto.pulumirpc.AnalyzeResponse.prototype.setDiagnosticsList = function(value) {
-  jspb.Message.setRepeatedWrapperField(this, 2, value);
-};
-
-
-/**
- * @param {!proto.pulumirpc.AnalyzeDiagnostic=} opt_value
- * @param {number=} opt_index
- * @return {!proto.pulumirpc.AnalyzeDiagnostic}
- */
-proto.pulumirpc.AnalyzeResponse.prototype.addDiagnostics = function(opt_value, opt_index) {
-  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.pulumirpc.AnalyzeDiagnostic, opt_index);
-};
-
-
-proto.pulumirpc.AnalyzeResponse.prototype.clearDiagnosticsList = function() {
-  this.setDiagnosticsList([]);
-};
-
-
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -741,20 +1114,13 @@ proto.pulumirpc.AnalyzeResponse.prototype.clearDiagnosticsList = function() {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.pulumirpc.AnalyzeDiagnostic = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeDiagnostic.repeatedFields_, null);
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.pulumirpc.AnalyzeDiagnostic, jspb.Message);
+goog.inherits(proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.AnalyzeDiagnostic.displayName = 'proto.pulumirpc.AnalyzeDiagnostic';
+  proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.displayName = 'proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts';
 }
-/**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
- */
-proto.pulumirpc.AnalyzeDiagnostic.repeatedFields_ = [6];
-
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -768,8 +1134,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.AnalyzeDiagnostic.toObject(opt_includeInstance, this);
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.toObject(opt_includeInstance, this);
 };
 
 
@@ -778,19 +1144,15 @@ proto.pulumirpc.AnalyzeDiagnostic.prototype.toObject = function(opt_includeInsta
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.AnalyzeDiagnostic} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.AnalyzeDiagnostic.toObject = function(includeInstance, msg) {
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.toObject = function(includeInstance, msg) {
   var f, obj = {
-    policyname: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    policypackname: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    policypackversion: jspb.Message.getFieldWithDefault(msg, 3, ""),
-    description: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    message: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    tagsList: jspb.Message.getRepeatedField(msg, 6),
-    enforcementlevel: jspb.Message.getFieldWithDefault(msg, 7, 0)
+    create: +jspb.Message.getFieldWithDefault(msg, 1, 0.0),
+    update: +jspb.Message.getFieldWithDefault(msg, 2, 0.0),
+    pb_delete: +jspb.Message.getFieldWithDefault(msg, 3, 0.0)
   };
 
   if (includeInstance) {
@@ -804,12 +1166,1126 @@ proto.pulumirpc.AnalyzeDiagnostic.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.AnalyzeDiagnostic}
+ * @return {!proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts}
  */
-proto.pulumirpc.AnalyzeDiagnostic.deserializeBinary = function(bytes) {
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.AnalyzeDiagnostic;
-  return proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts;
+  return proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setCreate(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setUpdate(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setDelete(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getCreate();
+  if (f !== 0.0) {
+    writer.writeDouble(
+      1,
+      f
+    );
+  }
+  f = message.getUpdate();
+  if (f !== 0.0) {
+    writer.writeDouble(
+      2,
+      f
+    );
+  }
+  f = message.getDelete();
+  if (f !== 0.0) {
+    writer.writeDouble(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional double create = 1;
+ * @return {number}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.getCreate = function() {
+  return /** @type {number} */ (+jspb.Message.getFieldWithDefault(this, 1, 0.0));
+};
+
+
+/** @param {number} value */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.setCreate = function(value) {
+  jspb.Message.setProto3FloatField(this, 1, value);
+};
+
+
+/**
+ * optional double update = 2;
+ * @return {number}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.getUpdate = function() {
+  return /** @type {number} */ (+jspb.Message.getFieldWithDefault(this, 2, 0.0));
+};
+
+
+/** @param {number} value */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.setUpdate = function(value) {
+  jspb.Message.setProto3FloatField(this, 2, value);
+};
+
+
+/**
+ * optional double delete = 3;
+ * @return {number}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.getDelete = function() {
+  return /** @type {number} */ (+jspb.Message.getFieldWithDefault(this, 3, 0.0));
+};
+
+
+/** @param {number} value */
+proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts.prototype.setDelete = function(value) {
+  jspb.Message.setProto3FloatField(this, 3, value);
+};
+
+
+/**
+ * optional bool protect = 1;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getProtect = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setProtect = function(value) {
+  jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+/**
+ * repeated string ignoreChanges = 2;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getIgnorechangesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setIgnorechangesList = function(value) {
+  jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.addIgnorechanges = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzerResourceOptions.prototype.clearIgnorechangesList = function() {
+  this.setIgnorechangesList([]);
+};
+
+
+/**
+ * optional bool deleteBeforeReplace = 3;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getDeletebeforereplace = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 3, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setDeletebeforereplace = function(value) {
+  jspb.Message.setProto3BooleanField(this, 3, value);
+};
+
+
+/**
+ * optional bool deleteBeforeReplaceDefined = 4;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getDeletebeforereplacedefined = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+};
+
+
+/** @param {boolean} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setDeletebeforereplacedefined = function(value) {
+  jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
+/**
+ * repeated string additionalSecretOutputs = 5;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getAdditionalsecretoutputsList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 5));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setAdditionalsecretoutputsList = function(value) {
+  jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.addAdditionalsecretoutputs = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzerResourceOptions.prototype.clearAdditionalsecretoutputsList = function() {
+  this.setAdditionalsecretoutputsList([]);
+};
+
+
+/**
+ * repeated string aliases = 6;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getAliasesList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 6));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setAliasesList = function(value) {
+  jspb.Message.setField(this, 6, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.addAliases = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 6, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzerResourceOptions.prototype.clearAliasesList = function() {
+  this.setAliasesList([]);
+};
+
+
+/**
+ * optional CustomTimeouts customTimeouts = 7;
+ * @return {?proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.getCustomtimeouts = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts, 7));
+};
+
+
+/** @param {?proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts|undefined} value */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.setCustomtimeouts = function(value) {
+  jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+proto.pulumirpc.AnalyzerResourceOptions.prototype.clearCustomtimeouts = function() {
+  this.setCustomtimeouts(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.AnalyzerResourceOptions.prototype.hasCustomtimeouts = function() {
+  return jspb.Message.getField(this, 7) != null;
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzerProviderResource = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzerProviderResource, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzerProviderResource.displayName = 'proto.pulumirpc.AnalyzerProviderResource';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzerProviderResource.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzerProviderResource} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerProviderResource.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    type: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    urn: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    name: jspb.Message.getFieldWithDefault(msg, 4, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzerProviderResource}
+ */
+proto.pulumirpc.AnalyzerProviderResource.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzerProviderResource;
+  return proto.pulumirpc.AnalyzerProviderResource.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzerProviderResource} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzerProviderResource}
+ */
+proto.pulumirpc.AnalyzerProviderResource.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setType(value);
+      break;
+    case 2:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setProperties(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzerProviderResource.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzerProviderResource} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerProviderResource.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getType();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getProperties();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string type = 1;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerProviderResource.prototype.setType = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct properties = 2;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.getProperties = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.AnalyzerProviderResource.prototype.setProperties = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+proto.pulumirpc.AnalyzerProviderResource.prototype.clearProperties = function() {
+  this.setProperties(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.hasProperties = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional string urn = 3;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerProviderResource.prototype.setUrn = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string name = 4;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerProviderResource.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerProviderResource.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzerPropertyDependencies.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzerPropertyDependencies, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzerPropertyDependencies.displayName = 'proto.pulumirpc.AnalyzerPropertyDependencies';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzerPropertyDependencies.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzerPropertyDependencies} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    urnsList: jspb.Message.getRepeatedField(msg, 1)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzerPropertyDependencies}
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzerPropertyDependencies;
+  return proto.pulumirpc.AnalyzerPropertyDependencies.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzerPropertyDependencies} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzerPropertyDependencies}
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addUrns(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzerPropertyDependencies.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzerPropertyDependencies} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUrnsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * repeated string urns = 1;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.prototype.getUrnsList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 1));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzerPropertyDependencies.prototype.setUrnsList = function(value) {
+  jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzerPropertyDependencies.prototype.addUrns = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzerPropertyDependencies.prototype.clearUrnsList = function() {
+  this.setUrnsList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzeStackRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeStackRequest.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzeStackRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzeStackRequest.displayName = 'proto.pulumirpc.AnalyzeStackRequest';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzeStackRequest.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzeStackRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzeStackRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeStackRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    resourcesList: jspb.Message.toObjectList(msg.getResourcesList(),
+    proto.pulumirpc.AnalyzerResource.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzeStackRequest}
+ */
+proto.pulumirpc.AnalyzeStackRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzeStackRequest;
+  return proto.pulumirpc.AnalyzeStackRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzeStackRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzeStackRequest}
+ */
+proto.pulumirpc.AnalyzeStackRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.pulumirpc.AnalyzerResource;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader);
+      msg.addResources(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzeStackRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzeStackRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeStackRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResourcesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated AnalyzerResource resources = 1;
+ * @return {!Array.<!proto.pulumirpc.AnalyzerResource>}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.getResourcesList = function() {
+  return /** @type{!Array.<!proto.pulumirpc.AnalyzerResource>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.AnalyzerResource, 1));
+};
+
+
+/** @param {!Array.<!proto.pulumirpc.AnalyzerResource>} value */
+proto.pulumirpc.AnalyzeStackRequest.prototype.setResourcesList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.AnalyzerResource=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.AnalyzerResource}
+ */
+proto.pulumirpc.AnalyzeStackRequest.prototype.addResources = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.pulumirpc.AnalyzerResource, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzeStackRequest.prototype.clearResourcesList = function() {
+  this.setResourcesList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzeResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeResponse.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzeResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzeResponse.displayName = 'proto.pulumirpc.AnalyzeResponse';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzeResponse.repeatedFields_ = [2];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzeResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzeResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzeResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    diagnosticsList: jspb.Message.toObjectList(msg.getDiagnosticsList(),
+    proto.pulumirpc.AnalyzeDiagnostic.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzeResponse}
+ */
+proto.pulumirpc.AnalyzeResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzeResponse;
+  return proto.pulumirpc.AnalyzeResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzeResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzeResponse}
+ */
+proto.pulumirpc.AnalyzeResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 2:
+      var value = new proto.pulumirpc.AnalyzeDiagnostic;
+      reader.readMessage(value,proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader);
+      msg.addDiagnostics(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzeResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzeResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzeResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getDiagnosticsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      2,
+      f,
+      proto.pulumirpc.AnalyzeDiagnostic.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated AnalyzeDiagnostic diagnostics = 2;
+ * @return {!Array.<!proto.pulumirpc.AnalyzeDiagnostic>}
+ */
+proto.pulumirpc.AnalyzeResponse.prototype.getDiagnosticsList = function() {
+  return /** @type{!Array.<!proto.pulumirpc.AnalyzeDiagnostic>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.AnalyzeDiagnostic, 2));
+};
+
+
+/** @param {!Array.<!proto.pulumirpc.AnalyzeDiagnostic>} value */
+proto.pulumirpc.AnalyzeResponse.prototype.setDiagnosticsList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.AnalyzeDiagnostic=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.AnalyzeDiagnostic}
+ */
+proto.pulumirpc.AnalyzeResponse.prototype.addDiagnostics = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.pulumirpc.AnalyzeDiagnostic, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzeResponse.prototype.clearDiagnosticsList = function() {
+  this.setDiagnosticsList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzeDiagnostic = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzeDiagnostic.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzeDiagnostic, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzeDiagnostic.displayName = 'proto.pulumirpc.AnalyzeDiagnostic';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzeDiagnostic.repeatedFields_ = [6];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzeDiagnostic.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzeDiagnostic} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeDiagnostic.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    policyname: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    policypackname: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    policypackversion: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    description: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    message: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    tagsList: jspb.Message.getRepeatedField(msg, 6),
+    enforcementlevel: jspb.Message.getFieldWithDefault(msg, 7, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzeDiagnostic}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzeDiagnostic;
+  return proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader(msg, reader);
 };
 
 
@@ -818,9 +2294,766 @@ proto.pulumirpc.AnalyzeDiagnostic.deserializeBinary = function(bytes) {
  * given reader into the given message object.
  * @param {!proto.pulumirpc.AnalyzeDiagnostic} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.AnalyzeDiagnostic}
+ * @return {!proto.pulumirpc.AnalyzeDiagnostic}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicyname(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicypackname(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicypackversion(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDescription(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMessage(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addTags(value);
+      break;
+    case 7:
+      var value = /** @type {!proto.pulumirpc.EnforcementLevel} */ (reader.readEnum());
+      msg.setEnforcementlevel(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzeDiagnostic.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzeDiagnostic} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzeDiagnostic.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPolicyname();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getPolicypackname();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getPolicypackversion();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getDescription();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getMessage();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getTagsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      6,
+      f
+    );
+  }
+  f = message.getEnforcementlevel();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      7,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string policyName = 1;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getPolicyname = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setPolicyname = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string policyPackName = 2;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getPolicypackname = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setPolicypackname = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string policyPackVersion = 3;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getPolicypackversion = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setPolicypackversion = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string description = 4;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getDescription = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setDescription = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional string message = 5;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getMessage = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setMessage = function(value) {
+  jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+/**
+ * repeated string tags = 6;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getTagsList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 6));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setTagsList = function(value) {
+  jspb.Message.setField(this, 6, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.addTags = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 6, value, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzeDiagnostic.prototype.clearTagsList = function() {
+  this.setTagsList([]);
+};
+
+
+/**
+ * optional EnforcementLevel enforcementLevel = 7;
+ * @return {!proto.pulumirpc.EnforcementLevel}
+ */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.getEnforcementlevel = function() {
+  return /** @type {!proto.pulumirpc.EnforcementLevel} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/** @param {!proto.pulumirpc.EnforcementLevel} value */
+proto.pulumirpc.AnalyzeDiagnostic.prototype.setEnforcementlevel = function(value) {
+  jspb.Message.setProto3EnumField(this, 7, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.Remediation = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.Remediation, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.Remediation.displayName = 'proto.pulumirpc.Remediation';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.Remediation.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.Remediation.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.Remediation} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.Remediation.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    policyname: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    policypackname: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    policypackversion: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    description: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.Remediation}
+ */
+proto.pulumirpc.Remediation.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.Remediation;
+  return proto.pulumirpc.Remediation.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.Remediation} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.Remediation}
+ */
+proto.pulumirpc.Remediation.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicyname(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicypackname(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicypackversion(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDescription(value);
+      break;
+    case 5:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setProperties(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.Remediation.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.Remediation.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.Remediation} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.Remediation.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPolicyname();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getPolicypackname();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getPolicypackversion();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getDescription();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getProperties();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string policyName = 1;
+ * @return {string}
+ */
+proto.pulumirpc.Remediation.prototype.getPolicyname = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.Remediation.prototype.setPolicyname = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string policyPackName = 2;
+ * @return {string}
+ */
+proto.pulumirpc.Remediation.prototype.getPolicypackname = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.Remediation.prototype.setPolicypackname = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string policyPackVersion = 3;
+ * @return {string}
+ */
+proto.pulumirpc.Remediation.prototype.getPolicypackversion = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.Remediation.prototype.setPolicypackversion = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string description = 4;
+ * @return {string}
+ */
+proto.pulumirpc.Remediation.prototype.getDescription = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.Remediation.prototype.setDescription = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct properties = 5;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.Remediation.prototype.getProperties = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 5));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.Remediation.prototype.setProperties = function(value) {
+  jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+proto.pulumirpc.Remediation.prototype.clearProperties = function() {
+  this.setProperties(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.Remediation.prototype.hasProperties = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RemediateResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RemediateResponse.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.RemediateResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.RemediateResponse.displayName = 'proto.pulumirpc.RemediateResponse';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.RemediateResponse.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RemediateResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RemediateResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RemediateResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RemediateResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    remediationsList: jspb.Message.toObjectList(msg.getRemediationsList(),
+    proto.pulumirpc.Remediation.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RemediateResponse}
+ */
+proto.pulumirpc.RemediateResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RemediateResponse;
+  return proto.pulumirpc.RemediateResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RemediateResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RemediateResponse}
+ */
+proto.pulumirpc.RemediateResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.pulumirpc.Remediation;
+      reader.readMessage(value,proto.pulumirpc.Remediation.deserializeBinaryFromReader);
+      msg.addRemediations(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RemediateResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RemediateResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RemediateResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RemediateResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getRemediationsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.pulumirpc.Remediation.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated Remediation remediations = 1;
+ * @return {!Array.<!proto.pulumirpc.Remediation>}
+ */
+proto.pulumirpc.RemediateResponse.prototype.getRemediationsList = function() {
+  return /** @type{!Array.<!proto.pulumirpc.Remediation>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.Remediation, 1));
+};
+
+
+/** @param {!Array.<!proto.pulumirpc.Remediation>} value */
+proto.pulumirpc.RemediateResponse.prototype.setRemediationsList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.Remediation=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.Remediation}
+ */
+proto.pulumirpc.RemediateResponse.prototype.addRemediations = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.pulumirpc.Remediation, opt_index);
+};
+
+
+proto.pulumirpc.RemediateResponse.prototype.clearRemediationsList = function() {
+  this.setRemediationsList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnalyzerInfo = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzerInfo.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnalyzerInfo, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.AnalyzerInfo.displayName = 'proto.pulumirpc.AnalyzerInfo';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnalyzerInfo.repeatedFields_ = [3];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnalyzerInfo.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnalyzerInfo.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnalyzerInfo} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerInfo.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    displayname: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    policiesList: jspb.Message.toObjectList(msg.getPoliciesList(),
+    proto.pulumirpc.PolicyInfo.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnalyzerInfo}
+ */
+proto.pulumirpc.AnalyzerInfo.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnalyzerInfo;
+  return proto.pulumirpc.AnalyzerInfo.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnalyzerInfo} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnalyzerInfo}
  */
-proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.AnalyzerInfo.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -829,32 +3062,246 @@ proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader = function(msg, re
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setPolicyname(value);
+      msg.setName(value);
       break;
     case 2:
       var value = /** @type {string} */ (reader.readString());
-      msg.setPolicypackname(value);
+      msg.setDisplayname(value);
       break;
     case 3:
+      var value = new proto.pulumirpc.PolicyInfo;
+      reader.readMessage(value,proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader);
+      msg.addPolicies(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnalyzerInfo.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnalyzerInfo.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnalyzerInfo} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnalyzerInfo.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getDisplayname();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getPoliciesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      3,
+      f,
+      proto.pulumirpc.PolicyInfo.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerInfo.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerInfo.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string displayName = 2;
+ * @return {string}
+ */
+proto.pulumirpc.AnalyzerInfo.prototype.getDisplayname = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.pulumirpc.AnalyzerInfo.prototype.setDisplayname = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * repeated PolicyInfo policies = 3;
+ * @return {!Array.<!proto.pulumirpc.PolicyInfo>}
+ */
+proto.pulumirpc.AnalyzerInfo.prototype.getPoliciesList = function() {
+  return /** @type{!Array.<!proto.pulumirpc.PolicyInfo>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.PolicyInfo, 3));
+};
+
+
+/** @param {!Array.<!proto.pulumirpc.PolicyInfo>} value */
+proto.pulumirpc.AnalyzerInfo.prototype.setPoliciesList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 3, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.PolicyInfo=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.PolicyInfo}
+ */
+proto.pulumirpc.AnalyzerInfo.prototype.addPolicies = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 3, opt_value, proto.pulumirpc.PolicyInfo, opt_index);
+};
+
+
+proto.pulumirpc.AnalyzerInfo.prototype.clearPoliciesList = function() {
+  this.setPoliciesList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.PolicyInfo = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.PolicyInfo, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.PolicyInfo.displayName = 'proto.pulumirpc.PolicyInfo';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.PolicyInfo.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.PolicyInfo.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.PolicyInfo} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.PolicyInfo.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    displayname: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    description: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    message: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    enforcementlevel: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    configschema: (f = msg.getConfigschema()) && proto.pulumirpc.PolicyConfigSchema.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.PolicyInfo}
+ */
+proto.pulumirpc.PolicyInfo.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.PolicyInfo;
+  return proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.PolicyInfo} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.PolicyInfo}
+ */
+proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setPolicypackversion(value);
+      msg.setName(value);
       break;
-    case 4:
+    case 2:
       var value = /** @type {string} */ (reader.readString());
-      msg.setDescription(value);
+      msg.setDisplayname(value);
       break;
-    case 5:
+    case 3:
       var value = /** @type {string} */ (reader.readString());
-      msg.setMessage(value);
+      msg.setDescription(value);
       break;
-    case 6:
+    case 4:
       var value = /** @type {string} */ (reader.readString());
-      msg.addTags(value);
+      msg.setMessage(value);
       break;
-    case 7:
+    case 5:
       var value = /** @type {!proto.pulumirpc.EnforcementLevel} */ (reader.readEnum());
       msg.setEnforcementlevel(value);
       break;
+    case 6:
+      var value = new proto.pulumirpc.PolicyConfigSchema;
+      reader.readMessage(value,proto.pulumirpc.PolicyConfigSchema.deserializeBinaryFromReader);
+      msg.setConfigschema(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -868,9 +3315,9 @@ proto.pulumirpc.AnalyzeDiagnostic.deserializeBinaryFromReader = function(msg, re
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.serializeBinary = function() {
+proto.pulumirpc.PolicyInfo.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.AnalyzeDiagnostic.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.PolicyInfo.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -878,180 +3325,367 @@ proto.pulumirpc.AnalyzeDiagnostic.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.AnalyzeDiagnostic} message
+ * @param {!proto.pulumirpc.PolicyInfo} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.AnalyzeDiagnostic.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.PolicyInfo.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPolicyname();
+  f = message.getName();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getPolicypackname();
+  f = message.getDisplayname();
   if (f.length > 0) {
     writer.writeString(
       2,
       f
     );
   }
-  f = message.getPolicypackversion();
+  f = message.getDescription();
   if (f.length > 0) {
     writer.writeString(
       3,
       f
     );
   }
-  f = message.getDescription();
+  f = message.getMessage();
   if (f.length > 0) {
     writer.writeString(
       4,
       f
     );
   }
-  f = message.getMessage();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getEnforcementlevel();
+  if (f !== 0.0) {
+    writer.writeEnum(
       5,
       f
     );
   }
-  f = message.getTagsList();
-  if (f.length > 0) {
-    writer.writeRepeatedString(
+  f = message.getConfigschema();
+  if (f != null) {
+    writer.writeMessage(
       6,
-      f
-    );
-  }
-  f = message.getEnforcementlevel();
-  if (f !== 0.0) {
-    writer.writeEnum(
-      7,
-      f
+      f,
+      proto.pulumirpc.PolicyConfigSchema.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional string policyName = 1;
+ * optional string name = 1;
  * @return {string}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getPolicyname = function() {
+proto.pulumirpc.PolicyInfo.prototype.getName = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setPolicyname = function(value) {
+proto.pulumirpc.PolicyInfo.prototype.setName = function(value) {
   jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * optional string policyPackName = 2;
+ * optional string displayName = 2;
  * @return {string}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getPolicypackname = function() {
+proto.pulumirpc.PolicyInfo.prototype.getDisplayname = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setPolicypackname = function(value) {
+proto.pulumirpc.PolicyInfo.prototype.setDisplayname = function(value) {
   jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
 /**
- * optional string policyPackVersion = 3;
+ * optional string description = 3;
  * @return {string}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getPolicypackversion = function() {
+proto.pulumirpc.PolicyInfo.prototype.getDescription = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setPolicypackversion = function(value) {
+proto.pulumirpc.PolicyInfo.prototype.setDescription = function(value) {
   jspb.Message.setProto3StringField(this, 3, value);
 };
 
 
 /**
- * optional string description = 4;
+ * optional string message = 4;
  * @return {string}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getDescription = function() {
+proto.pulumirpc.PolicyInfo.prototype.getMessage = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
 
 /** @param {string} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setDescription = function(value) {
+proto.pulumirpc.PolicyInfo.prototype.setMessage = function(value) {
   jspb.Message.setProto3StringField(this, 4, value);
 };
 
 
 /**
- * optional string message = 5;
- * @return {string}
+ * optional EnforcementLevel enforcementLevel = 5;
+ * @return {!proto.pulumirpc.EnforcementLevel}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getMessage = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+proto.pulumirpc.PolicyInfo.prototype.getEnforcementlevel = function() {
+  return /** @type {!proto.pulumirpc.EnforcementLevel} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
 };
 
 
-/** @param {string} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setMessage = function(value) {
-  jspb.Message.setProto3StringField(this, 5, value);
+/** @param {!proto.pulumirpc.EnforcementLevel} value */
+proto.pulumirpc.PolicyInfo.prototype.setEnforcementlevel = function(value) {
+  jspb.Message.setProto3EnumField(this, 5, value);
 };
 
 
 /**
- * repeated string tags = 6;
- * @return {!Array.<string>}
+ * optional PolicyConfigSchema configSchema = 6;
+ * @return {?proto.pulumirpc.PolicyConfigSchema}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getTagsList = function() {
-  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 6));
+proto.pulumirpc.PolicyInfo.prototype.getConfigschema = function() {
+  return /** @type{?proto.pulumirpc.PolicyConfigSchema} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.PolicyConfigSchema, 6));
 };
 
 
-/** @param {!Array.<string>} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setTagsList = function(value) {
-  jspb.Message.setField(this, 6, value || []);
+/** @param {?proto.pulumirpc.PolicyConfigSchema|undefined} value */
+proto.pulumirpc.PolicyInfo.prototype.setConfigschema = function(value) {
+  jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+proto.pulumirpc.PolicyInfo.prototype.clearConfigschema = function() {
+  this.setConfigschema(undefined);
 };
 
 
 /**
- * @param {!string} value
- * @param {number=} opt_index
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.addTags = function(value, opt_index) {
-  jspb.Message.addToRepeatedField(this, 6, value, opt_index);
+proto.pulumirpc.PolicyInfo.prototype.hasConfigschema = function() {
+  return jspb.Message.getField(this, 6) != null;
 };
 
 
-proto.pulumirpc.AnalyzeDiagnostic.prototype.clearTagsList = function() {
-  this.setTagsList([]);
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.PolicyConfigSchema = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.PolicyConfigSchema.repeatedFields_, null);
 };
+goog.inherits(proto.pulumirpc.PolicyConfigSchema, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.pulumirpc.PolicyConfigSchema.displayName = 'proto.pulumirpc.PolicyConfigSchema';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.PolicyConfigSchema.repeatedFields_ = [2];
+
 
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * optional EnforcementLevel enforcementLevel = 7;
- * @return {!proto.pulumirpc.EnforcementLevel}
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
  */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.getEnforcementlevel = function() {
-  return /** @type {!proto.pulumirpc.EnforcementLevel} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+proto.pulumirpc.PolicyConfigSchema.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.PolicyConfigSchema.toObject(opt_includeInstance, this);
 };
 
 
-/** @param {!proto.pulumirpc.EnforcementLevel} value */
-proto.pulumirpc.AnalyzeDiagnostic.prototype.setEnforcementlevel = function(value) {
-  jspb.Message.setProto3EnumField(this, 7, value);
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.PolicyConfigSchema} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.PolicyConfigSchema.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f),
+    requiredList: jspb.Message.getRepeatedField(msg, 2)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.PolicyConfigSchema}
+ */
+proto.pulumirpc.PolicyConfigSchema.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.PolicyConfigSchema;
+  return proto.pulumirpc.PolicyConfigSchema.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.PolicyConfigSchema} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.PolicyConfigSchema}
+ */
+proto.pulumirpc.PolicyConfigSchema.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setProperties(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addRequired(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.PolicyConfigSchema.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.PolicyConfigSchema.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.PolicyConfigSchema} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.PolicyConfigSchema.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getProperties();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+  f = message.getRequiredList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional google.protobuf.Struct properties = 1;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.pulumirpc.PolicyConfigSchema.prototype.getProperties = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 1));
+};
+
+
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.PolicyConfigSchema.prototype.setProperties = function(value) {
+  jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+proto.pulumirpc.PolicyConfigSchema.prototype.clearProperties = function() {
+  this.setProperties(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.pulumirpc.PolicyConfigSchema.prototype.hasProperties = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * repeated string required = 2;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.PolicyConfigSchema.prototype.getRequiredList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.PolicyConfigSchema.prototype.setRequiredList = function(value) {
+  jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.PolicyConfigSchema.prototype.addRequired = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+proto.pulumirpc.PolicyConfigSchema.prototype.clearRequiredList = function() {
+  this.setRequiredList([]);
 };
 
 
@@ -1065,21 +3699,14 @@ proto.pulumirpc.AnalyzeDiagnostic.prototype.setEnforcementlevel = function(value
  * valid.
  * @extends {jspb.Message}
  * @constructor
- */
-proto.pulumirpc.AnalyzerInfo = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnalyzerInfo.repeatedFields_, null);
+ */
+proto.pulumirpc.PolicyConfig = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.pulumirpc.AnalyzerInfo, jspb.Message);
+goog.inherits(proto.pulumirpc.PolicyConfig, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.AnalyzerInfo.displayName = 'proto.pulumirpc.AnalyzerInfo';
+  proto.pulumirpc.PolicyConfig.displayName = 'proto.pulumirpc.PolicyConfig';
 }
-/**
- * List of repeated fields within this message type.
- * @private {!Array<number>}
- * @const
- */
-proto.pulumirpc.AnalyzerInfo.repeatedFields_ = [3];
-
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1093,8 +3720,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.AnalyzerInfo.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.AnalyzerInfo.toObject(opt_includeInstance, this);
+proto.pulumirpc.PolicyConfig.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.PolicyConfig.toObject(opt_includeInstance, this);
 };
 
 
@@ -1103,16 +3730,14 @@ proto.pulumirpc.AnalyzerInfo.prototype.toObject = function(opt_includeInstance)
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.AnalyzerInfo} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.PolicyConfig} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.AnalyzerInfo.toObject = function(includeInstance, msg) {
+proto.pulumirpc.PolicyConfig.toObject = function(includeInstance, msg) {
   var f, obj = {
-    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    displayname: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    policiesList: jspb.Message.toObjectList(msg.getPoliciesList(),
-    proto.pulumirpc.PolicyInfo.toObject, includeInstance)
+    enforcementlevel: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    properties: (f = msg.getProperties()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1126,23 +3751,23 @@ proto.pulumirpc.AnalyzerInfo.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.AnalyzerInfo}
+ * @return {!proto.pulumirpc.PolicyConfig}
  */
-proto.pulumirpc.AnalyzerInfo.deserializeBinary = function(bytes) {
+proto.pulumirpc.PolicyConfig.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.AnalyzerInfo;
-  return proto.pulumirpc.AnalyzerInfo.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.PolicyConfig;
+  return proto.pulumirpc.PolicyConfig.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.AnalyzerInfo} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.PolicyConfig} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.AnalyzerInfo}
+ * @return {!proto.pulumirpc.PolicyConfig}
  */
-proto.pulumirpc.AnalyzerInfo.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.PolicyConfig.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1150,17 +3775,13 @@ proto.pulumirpc.AnalyzerInfo.deserializeBinaryFromReader = function(msg, reader)
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setName(value);
+      var value = /** @type {!proto.pulumirpc.EnforcementLevel} */ (reader.readEnum());
+      msg.setEnforcementlevel(value);
       break;
     case 2:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setDisplayname(value);
-      break;
-    case 3:
-      var value = new proto.pulumirpc.PolicyInfo;
-      reader.readMessage(value,proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader);
-      msg.addPolicies(value);
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setProperties(value);
       break;
     default:
       reader.skipField();
@@ -1175,9 +3796,9 @@ proto.pulumirpc.AnalyzerInfo.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.AnalyzerInfo.prototype.serializeBinary = function() {
+proto.pulumirpc.PolicyConfig.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.AnalyzerInfo.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.PolicyConfig.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1185,95 +3806,72 @@ proto.pulumirpc.AnalyzerInfo.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.AnalyzerInfo} message
+ * @param {!proto.pulumirpc.PolicyConfig} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.AnalyzerInfo.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.PolicyConfig.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getName();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getEnforcementlevel();
+  if (f !== 0.0) {
+    writer.writeEnum(
       1,
       f
     );
   }
-  f = message.getDisplayname();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getProperties();
+  if (f != null) {
+    writer.writeMessage(
       2,
-      f
-    );
-  }
-  f = message.getPoliciesList();
-  if (f.length > 0) {
-    writer.writeRepeatedMessage(
-      3,
       f,
-      proto.pulumirpc.PolicyInfo.serializeBinaryToWriter
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional string name = 1;
- * @return {string}
+ * optional EnforcementLevel enforcementLevel = 1;
+ * @return {!proto.pulumirpc.EnforcementLevel}
  */
-proto.pulumirpc.AnalyzerInfo.prototype.getName = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.pulumirpc.PolicyConfig.prototype.getEnforcementlevel = function() {
+  return /** @type {!proto.pulumirpc.EnforcementLevel} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
 };
 
 
-/** @param {string} value */
-proto.pulumirpc.AnalyzerInfo.prototype.setName = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
+/** @param {!proto.pulumirpc.EnforcementLevel} value */
+proto.pulumirpc.PolicyConfig.prototype.setEnforcementlevel = function(value) {
+  jspb.Message.setProto3EnumField(this, 1, value);
 };
 
 
 /**
- * optional string displayName = 2;
- * @return {string}
+ * optional google.protobuf.Struct properties = 2;
+ * @return {?proto.google.protobuf.Struct}
  */
-proto.pulumirpc.AnalyzerInfo.prototype.getDisplayname = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
-};
-
-
-/** @param {string} value */
-proto.pulumirpc.AnalyzerInfo.prototype.setDisplayname = function(value) {
-  jspb.Message.setProto3StringField(this, 2, value);
+proto.pulumirpc.PolicyConfig.prototype.getProperties = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 2));
 };
 
 
-/**
- * repeated PolicyInfo policies = 3;
- * @return {!Array.<!proto.pulumirpc.PolicyInfo>}
- */
-proto.pulumirpc.AnalyzerInfo.prototype.getPoliciesList = function() {
-  return /** @type{!Array.<!proto.pulumirpc.PolicyInfo>} */ (
-    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.PolicyInfo, 3));
+/** @param {?proto.google.protobuf.Struct|undefined} value */
+proto.pulumirpc.PolicyConfig.prototype.setProperties = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
 };
 
 
-/** @param {!Array.<!proto.pulumirpc.PolicyInfo>} value */
-proto.pulumirpc.AnalyzerInfo.prototype.setPoliciesList = function(value) {
-  jspb.Message.setRepeatedWrapperField(this, 3, value);
+proto.pulumirpc.PolicyConfig.prototype.clearProperties = function() {
+  this.setProperties(undefined);
 };
 
 
 /**
- * @param {!proto.pulumirpc.PolicyInfo=} opt_value
- * @param {number=} opt_index
- * @return {!proto.pulumirpc.PolicyInfo}
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.pulumirpc.AnalyzerInfo.prototype.addPolicies = function(opt_value, opt_index) {
-  return jspb.Message.addToRepeatedWrapperField(this, 3, opt_value, proto.pulumirpc.PolicyInfo, opt_index);
-};
-
-
-proto.pulumirpc.AnalyzerInfo.prototype.clearPoliciesList = function() {
-  this.setPoliciesList([]);
+proto.pulumirpc.PolicyConfig.prototype.hasProperties = function() {
+  return jspb.Message.getField(this, 2) != null;
 };
 
 
@@ -1288,12 +3886,12 @@ proto.pulumirpc.AnalyzerInfo.prototype.clearPoliciesList = function() {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.pulumirpc.PolicyInfo = function(opt_data) {
+proto.pulumirpc.ConfigureAnalyzerRequest = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.pulumirpc.PolicyInfo, jspb.Message);
+goog.inherits(proto.pulumirpc.ConfigureAnalyzerRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.pulumirpc.PolicyInfo.displayName = 'proto.pulumirpc.PolicyInfo';
+  proto.pulumirpc.ConfigureAnalyzerRequest.displayName = 'proto.pulumirpc.ConfigureAnalyzerRequest';
 }
 
 
@@ -1308,8 +3906,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.pulumirpc.PolicyInfo.prototype.toObject = function(opt_includeInstance) {
-  return proto.pulumirpc.PolicyInfo.toObject(opt_includeInstance, this);
+proto.pulumirpc.ConfigureAnalyzerRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.ConfigureAnalyzerRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -1318,17 +3916,13 @@ proto.pulumirpc.PolicyInfo.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.pulumirpc.PolicyInfo} msg The msg instance to transform.
+ * @param {!proto.pulumirpc.ConfigureAnalyzerRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.PolicyInfo.toObject = function(includeInstance, msg) {
+proto.pulumirpc.ConfigureAnalyzerRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    displayname: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    description: jspb.Message.getFieldWithDefault(msg, 3, ""),
-    message: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    enforcementlevel: jspb.Message.getFieldWithDefault(msg, 5, 0)
+    policyconfigMap: (f = msg.getPolicyconfigMap()) ? f.toObject(includeInstance, proto.pulumirpc.PolicyConfig.toObject) : []
   };
 
   if (includeInstance) {
@@ -1342,23 +3936,23 @@ proto.pulumirpc.PolicyInfo.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.pulumirpc.PolicyInfo}
+ * @return {!proto.pulumirpc.ConfigureAnalyzerRequest}
  */
-proto.pulumirpc.PolicyInfo.deserializeBinary = function(bytes) {
+proto.pulumirpc.ConfigureAnalyzerRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.pulumirpc.PolicyInfo;
-  return proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.pulumirpc.ConfigureAnalyzerRequest;
+  return proto.pulumirpc.ConfigureAnalyzerRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.pulumirpc.PolicyInfo} msg The message object to deserialize into.
+ * @param {!proto.pulumirpc.ConfigureAnalyzerRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.pulumirpc.PolicyInfo}
+ * @return {!proto.pulumirpc.ConfigureAnalyzerRequest}
  */
-proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader = function(msg, reader) {
+proto.pulumirpc.ConfigureAnalyzerRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1366,24 +3960,10 @@ proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setName(value);
-      break;
-    case 2:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setDisplayname(value);
-      break;
-    case 3:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setDescription(value);
-      break;
-    case 4:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setMessage(value);
-      break;
-    case 5:
-      var value = /** @type {!proto.pulumirpc.EnforcementLevel} */ (reader.readEnum());
-      msg.setEnforcementlevel(value);
+      var value = msg.getPolicyconfigMap();
+      reader.readMessage(value, function(message, reader) {
+        jspb.Map.deserializeBinary(message, reader, jspb.BinaryReader.prototype.readString, jspb.BinaryReader.prototype.readMessage, proto.pulumirpc.PolicyConfig.deserializeBinaryFromReader);
+         });
       break;
     default:
       reader.skipField();
@@ -1398,9 +3978,9 @@ proto.pulumirpc.PolicyInfo.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.pulumirpc.PolicyInfo.prototype.serializeBinary = function() {
+proto.pulumirpc.ConfigureAnalyzerRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.pulumirpc.PolicyInfo.serializeBinaryToWriter(this, writer);
+  proto.pulumirpc.ConfigureAnalyzerRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1408,122 +3988,34 @@ proto.pulumirpc.PolicyInfo.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.pulumirpc.PolicyInfo} message
+ * @param {!proto.pulumirpc.ConfigureAnalyzerRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.pulumirpc.PolicyInfo.serializeBinaryToWriter = function(message, writer) {
+proto.pulumirpc.ConfigureAnalyzerRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getName();
-  if (f.length > 0) {
-    writer.writeString(
-      1,
-      f
-    );
-  }
-  f = message.getDisplayname();
-  if (f.length > 0) {
-    writer.writeString(
-      2,
-      f
-    );
-  }
-  f = message.getDescription();
-  if (f.length > 0) {
-    writer.writeString(
-      3,
-      f
-    );
-  }
-  f = message.getMessage();
-  if (f.length > 0) {
-    writer.writeString(
-      4,
-      f
-    );
-  }
-  f = message.getEnforcementlevel();
-  if (f !== 0.0) {
-    writer.writeEnum(
-      5,
-      f
-    );
+  f = message.getPolicyconfigMap(true);
+  if (f && f.getLength() > 0) {
+    f.serializeBinary(1, writer, jspb.BinaryWriter.prototype.writeString, jspb.BinaryWriter.prototype.writeMessage, proto.pulumirpc.PolicyConfig.serializeBinaryToWriter);
   }
 };
 
 
 /**
- * optional string name = 1;
- * @return {string}
- */
-proto.pulumirpc.PolicyInfo.prototype.getName = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
-};
-
-
-/** @param {string} value */
-proto.pulumirpc.PolicyInfo.prototype.setName = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
-};
-
-
-/**
- * optional string displayName = 2;
- * @return {string}
- */
-proto.pulumirpc.PolicyInfo.prototype.getDisplayname = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
-};
-
-
-/** @param {string} value */
-proto.pulumirpc.PolicyInfo.prototype.setDisplayname = function(value) {
-  jspb.Message.setProto3StringField(this, 2, value);
-};
-
-
-/**
- * optional string description = 3;
- * @return {string}
- */
-proto.pulumirpc.PolicyInfo.prototype.getDescription = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
-};
-
-
-/** @param {string} value */
-proto.pulumirpc.PolicyInfo.prototype.setDescription = function(value) {
-  jspb.Message.setProto3StringField(this, 3, value);
-};
-
-
-/**
- * optional string message = 4;
- * @return {string}
- */
-proto.pulumirpc.PolicyInfo.prototype.getMessage = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
-};
-
-
-/** @param {string} value */
-proto.pulumirpc.PolicyInfo.prototype.setMessage = function(value) {
-  jspb.Message.setProto3StringField(this, 4, value);
-};
-
-
-/**
- * optional EnforcementLevel enforcementLevel = 5;
- * @return {!proto.pulumirpc.EnforcementLevel}
+ * map<string, PolicyConfig> policyConfig = 1;
+ * @param {boolean=} opt_noLazyCreate Do not create the map if
+ * empty, instead returning `undefined`
+ * @return {!jspb.Map<string,!proto.pulumirpc.PolicyConfig>}
  */
-proto.pulumirpc.PolicyInfo.prototype.getEnforcementlevel = function() {
-  return /** @type {!proto.pulumirpc.EnforcementLevel} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+proto.pulumirpc.ConfigureAnalyzerRequest.prototype.getPolicyconfigMap = function(opt_noLazyCreate) {
+  return /** @type {!jspb.Map<string,!proto.pulumirpc.PolicyConfig>} */ (
+      jspb.Message.getMapField(this, 1, opt_noLazyCreate,
+      proto.pulumirpc.PolicyConfig));
 };
 
 
-/** @param {!proto.pulumirpc.EnforcementLevel} value */
-proto.pulumirpc.PolicyInfo.prototype.setEnforcementlevel = function(value) {
-  jspb.Message.setProto3EnumField(this, 5, value);
+proto.pulumirpc.ConfigureAnalyzerRequest.prototype.clearPolicyconfigMap = function() {
+  this.getPolicyconfigMap().clear();
 };
 
 
@@ -1532,7 +4024,9 @@ proto.pulumirpc.PolicyInfo.prototype.setEnforcementlevel = function(value) {
  */
 proto.pulumirpc.EnforcementLevel = {
   ADVISORY: 0,
-  MANDATORY: 1
+  MANDATORY: 1,
+  REMEDIATE: 2,
+  DISABLED: 3
 };
 
 goog.object.extend(exports, proto.pulumirpc);
@@ -404,7 +404,7 @@ if (goog.DEBUG && !COMPILED) {
  * @private {!Array<number>}
  * @const
  */
-proto.pulumirpc.RunRequest.repeatedFields_ = [5];
+proto.pulumirpc.RunRequest.repeatedFields_ = [5,11];
 
 
 
@@ -444,7 +444,8 @@ proto.pulumirpc.RunRequest.toObject = function(includeInstance, msg) {
     dryrun: jspb.Message.getFieldWithDefault(msg, 7, false),
     parallel: jspb.Message.getFieldWithDefault(msg, 8, 0),
     monitorAddress: jspb.Message.getFieldWithDefault(msg, 9, ""),
-    querymode: jspb.Message.getFieldWithDefault(msg, 10, false)
+    querymode: jspb.Message.getFieldWithDefault(msg, 10, false),
+    configsecretkeysList: jspb.Message.getRepeatedField(msg, 11)
   };
 
   if (includeInstance) {
@@ -523,6 +524,10 @@ proto.pulumirpc.RunRequest.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setQuerymode(value);
       break;
+    case 11:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addConfigsecretkeys(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -619,6 +624,13 @@ proto.pulumirpc.RunRequest.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getConfigsecretkeysList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      11,
+      f
+    );
+  }
 };
 
 
@@ -793,6 +805,35 @@ proto.pulumirpc.RunRequest.prototype.setQuerymode = function(value) {
 };
 
 
+/**
+ * repeated string configSecretKeys = 11;
+ * @return {!Array.<string>}
+ */
+proto.pulumirpc.RunRequest.prototype.getConfigsecretkeysList = function() {
+  return /** @type {!Array.<string>} */ (jspb.Message.getRepeatedField(this, 11));
+};
+
+
+/** @param {!Array.<string>} value */
+proto.pulumirpc.RunRequest.prototype.setConfigsecretkeysList = function(value) {
+  jspb.Message.setField(this, 11, value || []);
+};
+
+
+/**
+ * @param {!string} value
+ * @param {number=} opt_index
+ */
+proto.pulumirpc.RunRequest.prototype.addConfigsecretkeys = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 11, value, opt_index);
+};
+
+
+proto.pulumirpc.RunRequest.prototype.clearConfigsecretkeysList = function() {
+  this.setConfigsecretkeysList([]);
+};
+
+
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -110,6 +110,28 @@ function deserialize_pulumirpc_RegisterResourceResponse(buffer_arg) {
   return resource_pb.RegisterResourceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_ResourceCallRequest(arg) {
+  if (!(arg instanceof resource_pb.ResourceCallRequest)) {
+    throw new Error('Expected argument of type pulumirpc.ResourceCallRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ResourceCallRequest(buffer_arg) {
+  return resource_pb.ResourceCallRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_ResourceCallResponse(arg) {
+  if (!(arg instanceof resource_pb.ResourceCallResponse)) {
+    throw new Error('Expected argument of type pulumirpc.ResourceCallResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ResourceCallResponse(buffer_arg) {
+  return resource_pb.ResourceCallResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_SupportsFeatureRequest(arg) {
   if (!(arg instanceof resource_pb.SupportsFeatureRequest)) {
     throw new Error('Expected argument of type pulumirpc.SupportsFeatureRequest');
@@ -157,6 +179,28 @@ var ResourceMonitorService = exports.ResourceMonitorService = {
     responseSerialize: serialize_pulumirpc_InvokeResponse,
     responseDeserialize: deserialize_pulumirpc_InvokeResponse,
   },
+  streamInvoke: {
+    path: '/pulumirpc.ResourceMonitor/StreamInvoke',
+    requestStream: false,
+    responseStream: true,
+    requestType: provider_pb.InvokeRequest,
+    responseType: provider_pb.InvokeResponse,
+    requestSerialize: serialize_pulumirpc_InvokeRequest,
+    requestDeserialize: deserialize_pulumirpc_InvokeRequest,
+    responseSerialize: serialize_pulumirpc_InvokeResponse,
+    responseDeserialize: deserialize_pulumirpc_InvokeResponse,
+  },
+  call: {
+    path: '/pulumirpc.ResourceMonitor/Call',
+    requestStream: false,
+    responseStream: false,
+    requestType: resource_pb.ResourceCallRequest,
+    responseType: resource_pb.ResourceCallResponse,
+    requestSerialize: serialize_pulumirpc_ResourceCallRequest,
+    requestDeserialize: deserialize_pulumirpc_ResourceCallRequest,
+    responseSerialize: serialize_pulumirpc_ResourceCallResponse,
+    responseDeserialize: deserialize_pulumirpc_ResourceCallResponse,
+  },
   readResource: {
     path: '/pulumirpc.ResourceMonitor/ReadResource',
     requestStream: false,
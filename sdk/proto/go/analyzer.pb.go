@@ -31,37 +31,50 @@ type EnforcementLevel int32
 const (
 	EnforcementLevel_ADVISORY  EnforcementLevel = 0
 	EnforcementLevel_MANDATORY EnforcementLevel = 1
+	EnforcementLevel_REMEDIATE EnforcementLevel = 2
+	EnforcementLevel_DISABLED  EnforcementLevel = 3
 )
 
 var EnforcementLevel_name = map[int32]string{
 	0: "ADVISORY",
 	1: "MANDATORY",
+	2: "REMEDIATE",
+	3: "DISABLED",
 }
 var EnforcementLevel_value = map[string]int32{
 	"ADVISORY":  0,
 	"MANDATORY": 1,
+	"REMEDIATE": 2,
+	"DISABLED":  3,
 }
 
 func (x EnforcementLevel) String() string {
 	return proto.EnumName(EnforcementLevel_name, int32(x))
 }
 func (EnforcementLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{0}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{0}
 }
 
 type AnalyzeRequest struct {
-	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Type                 string                                   `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Properties           *_struct.Struct                          `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn                  string                                   `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Name                 string                                   `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Options              *AnalyzerResourceOptions                 `protobuf:"bytes,5,opt,name=options" json:"options,omitempty"`
+	Provider             *AnalyzerProviderResource                `protobuf:"bytes,6,opt,name=provider" json:"provider,omitempty"`
+	Parent               string                                   `protobuf:"bytes,7,opt,name=parent" json:"parent,omitempty"`
+	Dependencies         []string                                 `protobuf:"bytes,8,rep,name=dependencies" json:"dependencies,omitempty"`
+	PropertyDependencies map[string]*AnalyzerPropertyDependencies `protobuf:"bytes,9,rep,name=propertyDependencies" json:"propertyDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
 }
 
 func (m *AnalyzeRequest) Reset()         { *m = AnalyzeRequest{} }
 func (m *AnalyzeRequest) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeRequest) ProtoMessage()    {}
 func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{0}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{0}
 }
 func (m *AnalyzeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeRequest.Unmarshal(m, b)
@@ -95,22 +108,78 @@ func (m *AnalyzeRequest) GetProperties() *_struct.Struct {
 	return nil
 }
 
+func (m *AnalyzeRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetOptions() *AnalyzerResourceOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *AnalyzeRequest) GetProvider() *AnalyzerProviderResource {
+	if m != nil {
+		return m.Provider
+	}
+	return nil
+}
+
+func (m *AnalyzeRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *AnalyzeRequest) GetPropertyDependencies() map[string]*AnalyzerPropertyDependencies {
+	if m != nil {
+		return m.PropertyDependencies
+	}
+	return nil
+}
+
 // Resource defines the view of a Pulumi-managed resource as sent to Analyzers. The properties
 // of the resource are specific to the type of analysis being performed. See the Analyzer
 // service definition for more information.
 type AnalyzerResource struct {
-	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Type                 string                                   `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Properties           *_struct.Struct                          `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn                  string                                   `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Name                 string                                   `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Options              *AnalyzerResourceOptions                 `protobuf:"bytes,5,opt,name=options" json:"options,omitempty"`
+	Provider             *AnalyzerProviderResource                `protobuf:"bytes,6,opt,name=provider" json:"provider,omitempty"`
+	Parent               string                                   `protobuf:"bytes,7,opt,name=parent" json:"parent,omitempty"`
+	Dependencies         []string                                 `protobuf:"bytes,8,rep,name=dependencies" json:"dependencies,omitempty"`
+	PropertyDependencies map[string]*AnalyzerPropertyDependencies `protobuf:"bytes,9,rep,name=propertyDependencies" json:"propertyDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
 }
 
 func (m *AnalyzerResource) Reset()         { *m = AnalyzerResource{} }
 func (m *AnalyzerResource) String() string { return proto.CompactTextString(m) }
 func (*AnalyzerResource) ProtoMessage()    {}
 func (*AnalyzerResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{1}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{1}
 }
 func (m *AnalyzerResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzerResource.Unmarshal(m, b)
@@ -144,6 +213,302 @@ func (m *AnalyzerResource) GetProperties() *_struct.Struct {
 	return nil
 }
 
+func (m *AnalyzerResource) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetOptions() *AnalyzerResourceOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *AnalyzerResource) GetProvider() *AnalyzerProviderResource {
+	if m != nil {
+		return m.Provider
+	}
+	return nil
+}
+
+func (m *AnalyzerResource) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *AnalyzerResource) GetPropertyDependencies() map[string]*AnalyzerPropertyDependencies {
+	if m != nil {
+		return m.PropertyDependencies
+	}
+	return nil
+}
+
+// AnalyzerResourceOptions defines the options associated with a resource.  Some options are only known when a
+// resource is registered, and are not sent to AnalyzeStack.
+type AnalyzerResourceOptions struct {
+	Protect                    bool                                    `protobuf:"varint,1,opt,name=protect" json:"protect,omitempty"`
+	IgnoreChanges              []string                                `protobuf:"bytes,2,rep,name=ignoreChanges" json:"ignoreChanges,omitempty"`
+	DeleteBeforeReplace        bool                                    `protobuf:"varint,3,opt,name=deleteBeforeReplace" json:"deleteBeforeReplace,omitempty"`
+	DeleteBeforeReplaceDefined bool                                    `protobuf:"varint,4,opt,name=deleteBeforeReplaceDefined" json:"deleteBeforeReplaceDefined,omitempty"`
+	AdditionalSecretOutputs    []string                                `protobuf:"bytes,5,rep,name=additionalSecretOutputs" json:"additionalSecretOutputs,omitempty"`
+	Aliases                    []string                                `protobuf:"bytes,6,rep,name=aliases" json:"aliases,omitempty"`
+	CustomTimeouts             *AnalyzerResourceOptions_CustomTimeouts `protobuf:"bytes,7,opt,name=customTimeouts" json:"customTimeouts,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{}                                `json:"-"`
+	XXX_unrecognized           []byte                                  `json:"-"`
+	XXX_sizecache              int32                                   `json:"-"`
+}
+
+func (m *AnalyzerResourceOptions) Reset()         { *m = AnalyzerResourceOptions{} }
+func (m *AnalyzerResourceOptions) String() string { return proto.CompactTextString(m) }
+func (*AnalyzerResourceOptions) ProtoMessage()    {}
+func (*AnalyzerResourceOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{2}
+}
+func (m *AnalyzerResourceOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzerResourceOptions.Unmarshal(m, b)
+}
+func (m *AnalyzerResourceOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzerResourceOptions.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzerResourceOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzerResourceOptions.Merge(dst, src)
+}
+func (m *AnalyzerResourceOptions) XXX_Size() int {
+	return xxx_messageInfo_AnalyzerResourceOptions.Size(m)
+}
+func (m *AnalyzerResourceOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzerResourceOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzerResourceOptions proto.InternalMessageInfo
+
+func (m *AnalyzerResourceOptions) GetProtect() bool {
+	if m != nil {
+		return m.Protect
+	}
+	return false
+}
+
+func (m *AnalyzerResourceOptions) GetIgnoreChanges() []string {
+	if m != nil {
+		return m.IgnoreChanges
+	}
+	return nil
+}
+
+func (m *AnalyzerResourceOptions) GetDeleteBeforeReplace() bool {
+	if m != nil {
+		return m.DeleteBeforeReplace
+	}
+	return false
+}
+
+func (m *AnalyzerResourceOptions) GetDeleteBeforeReplaceDefined() bool {
+	if m != nil {
+		return m.DeleteBeforeReplaceDefined
+	}
+	return false
+}
+
+func (m *AnalyzerResourceOptions) GetAdditionalSecretOutputs() []string {
+	if m != nil {
+		return m.AdditionalSecretOutputs
+	}
+	return nil
+}
+
+func (m *AnalyzerResourceOptions) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *AnalyzerResourceOptions) GetCustomTimeouts() *AnalyzerResourceOptions_CustomTimeouts {
+	if m != nil {
+		return m.CustomTimeouts
+	}
+	return nil
+}
+
+// CustomTimeouts allows a user to be able to create a set of custom timeout parameters.
+type AnalyzerResourceOptions_CustomTimeouts struct {
+	Create               float64  `protobuf:"fixed64,1,opt,name=create" json:"create,omitempty"`
+	Update               float64  `protobuf:"fixed64,2,opt,name=update" json:"update,omitempty"`
+	Delete               float64  `protobuf:"fixed64,3,opt,name=delete" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnalyzerResourceOptions_CustomTimeouts) Reset() {
+	*m = AnalyzerResourceOptions_CustomTimeouts{}
+}
+func (m *AnalyzerResourceOptions_CustomTimeouts) String() string { return proto.CompactTextString(m) }
+func (*AnalyzerResourceOptions_CustomTimeouts) ProtoMessage()    {}
+func (*AnalyzerResourceOptions_CustomTimeouts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{2, 0}
+}
+func (m *AnalyzerResourceOptions_CustomTimeouts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzerResourceOptions_CustomTimeouts.Unmarshal(m, b)
+}
+func (m *AnalyzerResourceOptions_CustomTimeouts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzerResourceOptions_CustomTimeouts.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzerResourceOptions_CustomTimeouts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzerResourceOptions_CustomTimeouts.Merge(dst, src)
+}
+func (m *AnalyzerResourceOptions_CustomTimeouts) XXX_Size() int {
+	return xxx_messageInfo_AnalyzerResourceOptions_CustomTimeouts.Size(m)
+}
+func (m *AnalyzerResourceOptions_CustomTimeouts) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzerResourceOptions_CustomTimeouts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzerResourceOptions_CustomTimeouts proto.InternalMessageInfo
+
+func (m *AnalyzerResourceOptions_CustomTimeouts) GetCreate() float64 {
+	if m != nil {
+		return m.Create
+	}
+	return 0
+}
+
+func (m *AnalyzerResourceOptions_CustomTimeouts) GetUpdate() float64 {
+	if m != nil {
+		return m.Update
+	}
+	return 0
+}
+
+func (m *AnalyzerResourceOptions_CustomTimeouts) GetDelete() float64 {
+	if m != nil {
+		return m.Delete
+	}
+	return 0
+}
+
+// AnalyzerProviderResource provides information about a resource's provider.
+type AnalyzerProviderResource struct {
+	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn                  string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Name                 string          `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AnalyzerProviderResource) Reset()         { *m = AnalyzerProviderResource{} }
+func (m *AnalyzerProviderResource) String() string { return proto.CompactTextString(m) }
+func (*AnalyzerProviderResource) ProtoMessage()    {}
+func (*AnalyzerProviderResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{3}
+}
+func (m *AnalyzerProviderResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzerProviderResource.Unmarshal(m, b)
+}
+func (m *AnalyzerProviderResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzerProviderResource.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzerProviderResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzerProviderResource.Merge(dst, src)
+}
+func (m *AnalyzerProviderResource) XXX_Size() int {
+	return xxx_messageInfo_AnalyzerProviderResource.Size(m)
+}
+func (m *AnalyzerProviderResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzerProviderResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzerProviderResource proto.InternalMessageInfo
+
+func (m *AnalyzerProviderResource) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *AnalyzerProviderResource) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *AnalyzerProviderResource) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *AnalyzerProviderResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// AnalyzerPropertyDependencies describes the resources that a particular property depends on.
+type AnalyzerPropertyDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnalyzerPropertyDependencies) Reset()         { *m = AnalyzerPropertyDependencies{} }
+func (m *AnalyzerPropertyDependencies) String() string { return proto.CompactTextString(m) }
+func (*AnalyzerPropertyDependencies) ProtoMessage()    {}
+func (*AnalyzerPropertyDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{4}
+}
+func (m *AnalyzerPropertyDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzerPropertyDependencies.Unmarshal(m, b)
+}
+func (m *AnalyzerPropertyDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzerPropertyDependencies.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzerPropertyDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzerPropertyDependencies.Merge(dst, src)
+}
+func (m *AnalyzerPropertyDependencies) XXX_Size() int {
+	return xxx_messageInfo_AnalyzerPropertyDependencies.Size(m)
+}
+func (m *AnalyzerPropertyDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzerPropertyDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzerPropertyDependencies proto.InternalMessageInfo
+
+func (m *AnalyzerPropertyDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
 type AnalyzeStackRequest struct {
 	Resources            []*AnalyzerResource `protobuf:"bytes,1,rep,name=resources" json:"resources,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
@@ -155,7 +520,7 @@ func (m *AnalyzeStackRequest) Reset()         { *m = AnalyzeStackRequest{} }
 func (m *AnalyzeStackRequest) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeStackRequest) ProtoMessage()    {}
 func (*AnalyzeStackRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{2}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{5}
 }
 func (m *AnalyzeStackRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeStackRequest.Unmarshal(m, b)
@@ -193,7 +558,7 @@ func (m *AnalyzeResponse) Reset()         { *m = AnalyzeResponse{} }
 func (m *AnalyzeResponse) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeResponse) ProtoMessage()    {}
 func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{3}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{6}
 }
 func (m *AnalyzeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeResponse.Unmarshal(m, b)
@@ -237,7 +602,7 @@ func (m *AnalyzeDiagnostic) Reset()         { *m = AnalyzeDiagnostic{} }
 func (m *AnalyzeDiagnostic) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeDiagnostic) ProtoMessage()    {}
 func (*AnalyzeDiagnostic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{4}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{7}
 }
 func (m *AnalyzeDiagnostic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeDiagnostic.Unmarshal(m, b)
@@ -306,6 +671,117 @@ func (m *AnalyzeDiagnostic) GetEnforcementLevel() EnforcementLevel {
 	return EnforcementLevel_ADVISORY
 }
 
+// Remediation is the result of a policy that transformed a resource's properties.
+type Remediation struct {
+	PolicyName           string          `protobuf:"bytes,1,opt,name=policyName" json:"policyName,omitempty"`
+	PolicyPackName       string          `protobuf:"bytes,2,opt,name=policyPackName" json:"policyPackName,omitempty"`
+	PolicyPackVersion    string          `protobuf:"bytes,3,opt,name=policyPackVersion" json:"policyPackVersion,omitempty"`
+	Description          string          `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,5,opt,name=properties" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Remediation) Reset()         { *m = Remediation{} }
+func (m *Remediation) String() string { return proto.CompactTextString(m) }
+func (*Remediation) ProtoMessage()    {}
+func (*Remediation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{8}
+}
+func (m *Remediation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Remediation.Unmarshal(m, b)
+}
+func (m *Remediation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Remediation.Marshal(b, m, deterministic)
+}
+func (dst *Remediation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Remediation.Merge(dst, src)
+}
+func (m *Remediation) XXX_Size() int {
+	return xxx_messageInfo_Remediation.Size(m)
+}
+func (m *Remediation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Remediation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Remediation proto.InternalMessageInfo
+
+func (m *Remediation) GetPolicyName() string {
+	if m != nil {
+		return m.PolicyName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackName() string {
+	if m != nil {
+		return m.PolicyPackName
+	}
+	return ""
+}
+
+func (m *Remediation) GetPolicyPackVersion() string {
+	if m != nil {
+		return m.PolicyPackVersion
+	}
+	return ""
+}
+
+func (m *Remediation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Remediation) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// RemediateResponse contains the results of the policies that transformed a resource's properties, in the order that
+// they were applied.
+type RemediateResponse struct {
+	Remediations         []*Remediation `protobuf:"bytes,1,rep,name=remediations" json:"remediations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RemediateResponse) Reset()         { *m = RemediateResponse{} }
+func (m *RemediateResponse) String() string { return proto.CompactTextString(m) }
+func (*RemediateResponse) ProtoMessage()    {}
+func (*RemediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{9}
+}
+func (m *RemediateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemediateResponse.Unmarshal(m, b)
+}
+func (m *RemediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemediateResponse.Marshal(b, m, deterministic)
+}
+func (dst *RemediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemediateResponse.Merge(dst, src)
+}
+func (m *RemediateResponse) XXX_Size() int {
+	return xxx_messageInfo_RemediateResponse.Size(m)
+}
+func (m *RemediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemediateResponse proto.InternalMessageInfo
+
+func (m *RemediateResponse) GetRemediations() []*Remediation {
+	if m != nil {
+		return m.Remediations
+	}
+	return nil
+}
+
 // AnalyzerInfo provides metadata about a PolicyPack inside an analyzer.
 type AnalyzerInfo struct {
 	Name                 string        `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
@@ -320,7 +796,7 @@ func (m *AnalyzerInfo) Reset()         { *m = AnalyzerInfo{} }
 func (m *AnalyzerInfo) String() string { return proto.CompactTextString(m) }
 func (*AnalyzerInfo) ProtoMessage()    {}
 func (*AnalyzerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{5}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{10}
 }
 func (m *AnalyzerInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzerInfo.Unmarshal(m, b)
@@ -363,21 +839,22 @@ func (m *AnalyzerInfo) GetPolicies() []*PolicyInfo {
 
 // PolicyInfo provides metadata about an individual Policy within a Policy Pack.
 type PolicyInfo struct {
-	Name                 string           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	DisplayName          string           `protobuf:"bytes,2,opt,name=displayName" json:"displayName,omitempty"`
-	Description          string           `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	Message              string           `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
-	EnforcementLevel     EnforcementLevel `protobuf:"varint,5,opt,name=enforcementLevel,enum=pulumirpc.EnforcementLevel" json:"enforcementLevel,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Name                 string              `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	DisplayName          string              `protobuf:"bytes,2,opt,name=displayName" json:"displayName,omitempty"`
+	Description          string              `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	Message              string              `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+	EnforcementLevel     EnforcementLevel    `protobuf:"varint,5,opt,name=enforcementLevel,enum=pulumirpc.EnforcementLevel" json:"enforcementLevel,omitempty"`
+	ConfigSchema         *PolicyConfigSchema `protobuf:"bytes,6,opt,name=configSchema" json:"configSchema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *PolicyInfo) Reset()         { *m = PolicyInfo{} }
 func (m *PolicyInfo) String() string { return proto.CompactTextString(m) }
 func (*PolicyInfo) ProtoMessage()    {}
 func (*PolicyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_cc9701a1adb279ab, []int{6}
+	return fileDescriptor_analyzer_2c85622870cec030, []int{11}
 }
 func (m *PolicyInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PolicyInfo.Unmarshal(m, b)
@@ -432,14 +909,166 @@ func (m *PolicyInfo) GetEnforcementLevel() EnforcementLevel {
 	return EnforcementLevel_ADVISORY
 }
 
+func (m *PolicyInfo) GetConfigSchema() *PolicyConfigSchema {
+	if m != nil {
+		return m.ConfigSchema
+	}
+	return nil
+}
+
+// PolicyConfigSchema provides the JSON schema of a policy's configuration.
+type PolicyConfigSchema struct {
+	Properties           *_struct.Struct `protobuf:"bytes,1,opt,name=properties" json:"properties,omitempty"`
+	Required             []string        `protobuf:"bytes,2,rep,name=required" json:"required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PolicyConfigSchema) Reset()         { *m = PolicyConfigSchema{} }
+func (m *PolicyConfigSchema) String() string { return proto.CompactTextString(m) }
+func (*PolicyConfigSchema) ProtoMessage()    {}
+func (*PolicyConfigSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{12}
+}
+func (m *PolicyConfigSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyConfigSchema.Unmarshal(m, b)
+}
+func (m *PolicyConfigSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyConfigSchema.Marshal(b, m, deterministic)
+}
+func (dst *PolicyConfigSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyConfigSchema.Merge(dst, src)
+}
+func (m *PolicyConfigSchema) XXX_Size() int {
+	return xxx_messageInfo_PolicyConfigSchema.Size(m)
+}
+func (m *PolicyConfigSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyConfigSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyConfigSchema proto.InternalMessageInfo
+
+func (m *PolicyConfigSchema) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *PolicyConfigSchema) GetRequired() []string {
+	if m != nil {
+		return m.Required
+	}
+	return nil
+}
+
+// PolicyConfig provides the configuration of a policy.
+type PolicyConfig struct {
+	EnforcementLevel     EnforcementLevel `protobuf:"varint,1,opt,name=enforcementLevel,enum=pulumirpc.EnforcementLevel" json:"enforcementLevel,omitempty"`
+	Properties           *_struct.Struct  `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PolicyConfig) Reset()         { *m = PolicyConfig{} }
+func (m *PolicyConfig) String() string { return proto.CompactTextString(m) }
+func (*PolicyConfig) ProtoMessage()    {}
+func (*PolicyConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{13}
+}
+func (m *PolicyConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyConfig.Unmarshal(m, b)
+}
+func (m *PolicyConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyConfig.Marshal(b, m, deterministic)
+}
+func (dst *PolicyConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyConfig.Merge(dst, src)
+}
+func (m *PolicyConfig) XXX_Size() int {
+	return xxx_messageInfo_PolicyConfig.Size(m)
+}
+func (m *PolicyConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyConfig proto.InternalMessageInfo
+
+func (m *PolicyConfig) GetEnforcementLevel() EnforcementLevel {
+	if m != nil {
+		return m.EnforcementLevel
+	}
+	return EnforcementLevel_ADVISORY
+}
+
+func (m *PolicyConfig) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// ConfigureAnalyzerRequest provides the configuration of each policy in a policy pack.
+type ConfigureAnalyzerRequest struct {
+	PolicyConfig         map[string]*PolicyConfig `protobuf:"bytes,1,rep,name=policyConfig" json:"policyConfig,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ConfigureAnalyzerRequest) Reset()         { *m = ConfigureAnalyzerRequest{} }
+func (m *ConfigureAnalyzerRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfigureAnalyzerRequest) ProtoMessage()    {}
+func (*ConfigureAnalyzerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_2c85622870cec030, []int{14}
+}
+func (m *ConfigureAnalyzerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigureAnalyzerRequest.Unmarshal(m, b)
+}
+func (m *ConfigureAnalyzerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigureAnalyzerRequest.Marshal(b, m, deterministic)
+}
+func (dst *ConfigureAnalyzerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigureAnalyzerRequest.Merge(dst, src)
+}
+func (m *ConfigureAnalyzerRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfigureAnalyzerRequest.Size(m)
+}
+func (m *ConfigureAnalyzerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigureAnalyzerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigureAnalyzerRequest proto.InternalMessageInfo
+
+func (m *ConfigureAnalyzerRequest) GetPolicyConfig() map[string]*PolicyConfig {
+	if m != nil {
+		return m.PolicyConfig
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AnalyzeRequest)(nil), "pulumirpc.AnalyzeRequest")
+	proto.RegisterMapType((map[string]*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzeRequest.PropertyDependenciesEntry")
 	proto.RegisterType((*AnalyzerResource)(nil), "pulumirpc.AnalyzerResource")
+	proto.RegisterMapType((map[string]*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzerResource.PropertyDependenciesEntry")
+	proto.RegisterType((*AnalyzerResourceOptions)(nil), "pulumirpc.AnalyzerResourceOptions")
+	proto.RegisterType((*AnalyzerResourceOptions_CustomTimeouts)(nil), "pulumirpc.AnalyzerResourceOptions.CustomTimeouts")
+	proto.RegisterType((*AnalyzerProviderResource)(nil), "pulumirpc.AnalyzerProviderResource")
+	proto.RegisterType((*AnalyzerPropertyDependencies)(nil), "pulumirpc.AnalyzerPropertyDependencies")
 	proto.RegisterType((*AnalyzeStackRequest)(nil), "pulumirpc.AnalyzeStackRequest")
 	proto.RegisterType((*AnalyzeResponse)(nil), "pulumirpc.AnalyzeResponse")
 	proto.RegisterType((*AnalyzeDiagnostic)(nil), "pulumirpc.AnalyzeDiagnostic")
+	proto.RegisterType((*Remediation)(nil), "pulumirpc.Remediation")
+	proto.RegisterType((*RemediateResponse)(nil), "pulumirpc.RemediateResponse")
 	proto.RegisterType((*AnalyzerInfo)(nil), "pulumirpc.AnalyzerInfo")
 	proto.RegisterType((*PolicyInfo)(nil), "pulumirpc.PolicyInfo")
+	proto.RegisterType((*PolicyConfigSchema)(nil), "pulumirpc.PolicyConfigSchema")
+	proto.RegisterType((*PolicyConfig)(nil), "pulumirpc.PolicyConfig")
+	proto.RegisterType((*ConfigureAnalyzerRequest)(nil), "pulumirpc.ConfigureAnalyzerRequest")
+	proto.RegisterMapType((map[string]*PolicyConfig)(nil), "pulumirpc.ConfigureAnalyzerRequest.PolicyConfigEntry")
 	proto.RegisterEnum("pulumirpc.EnforcementLevel", EnforcementLevel_name, EnforcementLevel_value)
 }
 
@@ -461,10 +1090,16 @@ type AnalyzerClient interface {
 	// preview or update. The provided resources are the "outputs", after any mutations
 	// have taken place.
 	AnalyzeStack(ctx context.Context, in *AnalyzeStackRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+	// Remediate is given the opportunity to transform a single resource's properties, and returns the results of any
+	// policies that did so.  Called with the "inputs" to the resource, before they are checked by its provider.
+	Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error)
 	// GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
 	GetAnalyzerInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*AnalyzerInfo, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	// Configure configures the analyzer, passing configuration properties and enforcement levels for each policy.
+	// Called once, before any analysis or remediation, with a configuration for every policy in the policy pack.
+	Configure(ctx context.Context, in *ConfigureAnalyzerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type analyzerClient struct {
@@ -493,6 +1128,15 @@ func (c *analyzerClient) AnalyzeStack(ctx context.Context, in *AnalyzeStackReque
 	return out, nil
 }
 
+func (c *analyzerClient) Remediate(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*RemediateResponse, error) {
+	out := new(RemediateResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.Analyzer/Remediate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analyzerClient) GetAnalyzerInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*AnalyzerInfo, error) {
 	out := new(AnalyzerInfo)
 	err := grpc.Invoke(ctx, "/pulumirpc.Analyzer/GetAnalyzerInfo", in, out, c.cc, opts...)
@@ -511,6 +1155,15 @@ func (c *analyzerClient) GetPluginInfo(ctx context.Context, in *empty.Empty, opt
 	return out, nil
 }
 
+func (c *analyzerClient) Configure(ctx context.Context, in *ConfigureAnalyzerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := grpc.Invoke(ctx, "/pulumirpc.Analyzer/Configure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Analyzer service
 
 type AnalyzerServer interface {
@@ -521,10 +1174,16 @@ type AnalyzerServer interface {
 	// preview or update. The provided resources are the "outputs", after any mutations
 	// have taken place.
 	AnalyzeStack(context.Context, *AnalyzeStackRequest) (*AnalyzeResponse, error)
+	// Remediate is given the opportunity to transform a single resource's properties, and returns the results of any
+	// policies that did so.  Called with the "inputs" to the resource, before they are checked by its provider.
+	Remediate(context.Context, *AnalyzeRequest) (*RemediateResponse, error)
 	// GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
 	GetAnalyzerInfo(context.Context, *empty.Empty) (*AnalyzerInfo, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(context.Context, *empty.Empty) (*PluginInfo, error)
+	// Configure configures the analyzer, passing configuration properties and enforcement levels for each policy.
+	// Called once, before any analysis or remediation, with a configuration for every policy in the policy pack.
+	Configure(context.Context, *ConfigureAnalyzerRequest) (*empty.Empty, error)
 }
 
 func RegisterAnalyzerServer(s *grpc.Server, srv AnalyzerServer) {
@@ -567,6 +1226,24 @@ func _Analyzer_AnalyzeStack_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Remediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Remediate(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Analyzer_GetAnalyzerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -603,6 +1280,24 @@ func _Analyzer_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureAnalyzerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Configure(ctx, req.(*ConfigureAnalyzerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Analyzer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pulumirpc.Analyzer",
 	HandlerType: (*AnalyzerServer)(nil),
@@ -615,6 +1310,10 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AnalyzeStack",
 			Handler:    _Analyzer_AnalyzeStack_Handler,
 		},
+		{
+			MethodName: "Remediate",
+			Handler:    _Analyzer_Remediate_Handler,
+		},
 		{
 			MethodName: "GetAnalyzerInfo",
 			Handler:    _Analyzer_GetAnalyzerInfo_Handler,
@@ -623,49 +1322,89 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPluginInfo",
 			Handler:    _Analyzer_GetPluginInfo_Handler,
 		},
+		{
+			MethodName: "Configure",
+			Handler:    _Analyzer_Configure_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "analyzer.proto",
 }
 
-func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_analyzer_cc9701a1adb279ab) }
-
-var fileDescriptor_analyzer_cc9701a1adb279ab = []byte{
-	// 567 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x54, 0xdd, 0x6a, 0xdb, 0x4c,
-	0x10, 0xb5, 0xec, 0xfc, 0xd8, 0x63, 0xc7, 0x71, 0xf6, 0xe3, 0x6b, 0x54, 0x27, 0x04, 0xa1, 0x8b,
-	0x62, 0x4a, 0x91, 0xa9, 0x7b, 0x51, 0x7a, 0x53, 0xea, 0xe2, 0x60, 0x02, 0x69, 0xea, 0xca, 0x25,
-	0xd0, 0x8b, 0x52, 0x14, 0x65, 0x2c, 0x44, 0x64, 0xed, 0x76, 0x77, 0xd5, 0xe2, 0x3e, 0x4e, 0xe9,
-	0xbb, 0xf4, 0xb5, 0x8a, 0x56, 0x3f, 0x96, 0x25, 0x93, 0x42, 0xe8, 0xdd, 0xee, 0x9c, 0xb3, 0x67,
-	0x76, 0xcf, 0xcc, 0x2c, 0x74, 0x9d, 0xd0, 0x09, 0x56, 0x3f, 0x90, 0x5b, 0x8c, 0x53, 0x49, 0x49,
-	0x8b, 0x45, 0x41, 0xb4, 0xf4, 0x39, 0x73, 0xfb, 0x1d, 0x16, 0x44, 0x9e, 0x1f, 0x26, 0x40, 0xff,
-	0xc4, 0xa3, 0xd4, 0x0b, 0x70, 0xa8, 0x76, 0x37, 0xd1, 0x62, 0x88, 0x4b, 0x26, 0x57, 0x29, 0x78,
-	0x5a, 0x06, 0x85, 0xe4, 0x91, 0x2b, 0x13, 0xd4, 0xfc, 0x0c, 0xdd, 0x71, 0x92, 0xc5, 0xc6, 0xaf,
-	0x11, 0x0a, 0x49, 0x08, 0xec, 0xc8, 0x15, 0x43, 0x5d, 0x33, 0xb4, 0x41, 0xcb, 0x56, 0x6b, 0xf2,
-	0x12, 0x80, 0x71, 0xca, 0x90, 0x4b, 0x1f, 0x85, 0x5e, 0x37, 0xb4, 0x41, 0x7b, 0x74, 0x6c, 0x25,
-	0xc2, 0x56, 0x26, 0x6c, 0xcd, 0x95, 0xb0, 0x5d, 0xa0, 0x9a, 0x5f, 0xa0, 0x97, 0xca, 0x73, 0x1b,
-	0x05, 0x8d, 0xb8, 0x8b, 0xff, 0x36, 0xc1, 0x0c, 0xfe, 0x4b, 0x13, 0xcc, 0xa5, 0xe3, 0xde, 0x65,
-	0x8f, 0x78, 0x05, 0x2d, 0x9e, 0xe6, 0x13, 0xba, 0x66, 0x34, 0x06, 0xed, 0xd1, 0x89, 0x95, 0xdb,
-	0x67, 0x95, 0xef, 0x64, 0xaf, 0xd9, 0xe6, 0x07, 0x38, 0xcc, 0x1d, 0x11, 0x8c, 0x86, 0x02, 0xc9,
-	0x6b, 0x68, 0xdf, 0xfa, 0x8e, 0x17, 0x52, 0x21, 0x7d, 0x37, 0xbe, 0x5e, 0xac, 0x77, 0x5a, 0xd5,
-	0x9b, 0xe4, 0x24, 0xbb, 0x78, 0xc0, 0xfc, 0x59, 0x87, 0xa3, 0x0a, 0x85, 0x9c, 0x01, 0x30, 0x1a,
-	0xf8, 0xee, 0xea, 0xca, 0x59, 0x66, 0x6e, 0x14, 0x22, 0xe4, 0x09, 0x74, 0x93, 0xdd, 0xcc, 0x71,
-	0xef, 0x14, 0xa7, 0xae, 0x38, 0xa5, 0x28, 0x79, 0x06, 0x47, 0xeb, 0xc8, 0x35, 0x72, 0xe1, 0xd3,
-	0x50, 0x6f, 0x28, 0x6a, 0x15, 0x20, 0x06, 0xb4, 0x6f, 0x51, 0xb8, 0xdc, 0x67, 0x32, 0xe6, 0xed,
-	0x28, 0x5e, 0x31, 0x44, 0x74, 0xd8, 0x5f, 0xa2, 0x10, 0x8e, 0x87, 0xfa, 0xae, 0x42, 0xb3, 0xad,
-	0xaa, 0x9c, 0xe3, 0x09, 0x7d, 0xcf, 0x68, 0xa8, 0xca, 0x39, 0x9e, 0x20, 0x53, 0xe8, 0x61, 0xb8,
-	0xa0, 0xdc, 0xc5, 0x25, 0x86, 0xf2, 0x12, 0xbf, 0x61, 0xa0, 0xef, 0x1b, 0xda, 0xa0, 0xbb, 0x61,
-	0xf8, 0x79, 0x89, 0x62, 0x57, 0x0e, 0x99, 0xdf, 0xa1, 0x93, 0x95, 0xe5, 0x22, 0x5c, 0xd0, 0x38,
-	0x59, 0xb8, 0x36, 0x46, 0xad, 0xd5, 0xe5, 0x7d, 0xc1, 0x02, 0x67, 0x55, 0xf0, 0xa3, 0x18, 0x22,
-	0xcf, 0xa1, 0xa9, 0xde, 0x1c, 0xb7, 0x51, 0x43, 0xd5, 0xe9, 0xff, 0xc2, 0x35, 0x66, 0xca, 0x8e,
-	0x58, 0xde, 0xce, 0x69, 0xe6, 0x6f, 0x0d, 0x60, 0x0d, 0x3c, 0x30, 0x6f, 0xc9, 0xd6, 0xc6, 0xbd,
-	0xb6, 0xee, 0x6c, 0xda, 0xba, 0xcd, 0xc2, 0xdd, 0x07, 0x58, 0xf8, 0x74, 0x08, 0xbd, 0x32, 0x8b,
-	0x74, 0xa0, 0x39, 0x9e, 0x5c, 0x5f, 0xcc, 0xdf, 0xdb, 0x9f, 0x7a, 0x35, 0x72, 0x00, 0xad, 0x77,
-	0xe3, 0xab, 0xc9, 0xf8, 0x63, 0xbc, 0xd5, 0x46, 0xbf, 0xea, 0xd0, 0xcc, 0x4c, 0x27, 0x6f, 0x61,
-	0x3f, 0x5d, 0x93, 0xc7, 0xd5, 0xde, 0x4e, 0x27, 0xab, 0xdf, 0xdf, 0x06, 0x25, 0x73, 0x62, 0xd6,
-	0xc8, 0x65, 0x5e, 0x44, 0x35, 0x8e, 0xe4, 0xac, 0xca, 0x2e, 0xce, 0xe9, 0x5f, 0xd4, 0x26, 0x70,
-	0x38, 0x45, 0xb9, 0xd1, 0x15, 0x8f, 0x2a, 0x9f, 0xc2, 0x79, 0xfc, 0xd7, 0xf5, 0x8f, 0xb7, 0x4c,
-	0x77, 0x7c, 0xc0, 0xac, 0x91, 0x37, 0x70, 0x30, 0x45, 0x39, 0x53, 0x1f, 0xe6, 0xbd, 0x1a, 0x1b,
-	0x9d, 0x92, 0xd3, 0xcd, 0xda, 0xcd, 0x9e, 0x22, 0xbe, 0xf8, 0x13, 0x00, 0x00, 0xff, 0xff, 0x21,
-	0x09, 0x03, 0x9e, 0x91, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_analyzer_2c85622870cec030) }
+
+var fileDescriptor_analyzer_2c85622870cec030 = []byte{
+	// 1146 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x36, 0x25, 0xff, 0x91, 0x46, 0x8a, 0x22, 0x6f, 0x7e, 0xbf, 0x98, 0x51, 0xdc, 0xc0, 0x60,
+	0x8a, 0xd6, 0x28, 0x5a, 0xa5, 0x51, 0x50, 0x34, 0x0d, 0xfa, 0x4f, 0xb6, 0x54, 0xc3, 0x85, 0x13,
+	0xab, 0x2b, 0x23, 0x88, 0x8f, 0x1b, 0x72, 0xa4, 0x10, 0xa6, 0x48, 0x66, 0xb9, 0x74, 0xa1, 0x3e,
+	0x42, 0x81, 0x02, 0xbd, 0xf6, 0xd0, 0x4b, 0x9f, 0xa2, 0x6f, 0xd1, 0x4b, 0xcf, 0x7d, 0x96, 0x62,
+	0x97, 0xa4, 0x44, 0x8a, 0x94, 0xe2, 0xfa, 0xd2, 0x16, 0xe8, 0x6d, 0x67, 0xf6, 0x9b, 0xd9, 0x9d,
+	0x6f, 0xbf, 0x1d, 0x2e, 0xa1, 0xc1, 0x5c, 0xe6, 0x4c, 0xbf, 0x43, 0xde, 0xf6, 0xb9, 0x27, 0x3c,
+	0x52, 0xf5, 0x43, 0x27, 0x9c, 0xd8, 0xdc, 0x37, 0x5b, 0x75, 0xdf, 0x09, 0xc7, 0xb6, 0x1b, 0x4d,
+	0xb4, 0xee, 0x8e, 0x3d, 0x6f, 0xec, 0xe0, 0x03, 0x65, 0xbd, 0x0c, 0x47, 0x0f, 0x70, 0xe2, 0x8b,
+	0x69, 0x3c, 0xb9, 0xbb, 0x38, 0x19, 0x08, 0x1e, 0x9a, 0x22, 0x9a, 0x35, 0x7e, 0x5a, 0x87, 0x46,
+	0x37, 0x5a, 0x86, 0xe2, 0xeb, 0x10, 0x03, 0x41, 0x08, 0xac, 0x8b, 0xa9, 0x8f, 0xba, 0xb6, 0xa7,
+	0xed, 0x57, 0xa9, 0x1a, 0x93, 0x8f, 0x01, 0x7c, 0xee, 0xf9, 0xc8, 0x85, 0x8d, 0x81, 0x5e, 0xda,
+	0xd3, 0xf6, 0x6b, 0x9d, 0x9d, 0x76, 0x94, 0xb9, 0x9d, 0x64, 0x6e, 0x0f, 0x55, 0x66, 0x9a, 0x82,
+	0x92, 0x26, 0x94, 0x43, 0xee, 0xea, 0x65, 0x95, 0x4b, 0x0e, 0x65, 0x7a, 0x97, 0x4d, 0x50, 0x5f,
+	0x8f, 0xd2, 0xcb, 0x31, 0xf9, 0x14, 0xb6, 0x3c, 0x5f, 0xd8, 0x9e, 0x1b, 0xe8, 0x1b, 0x2a, 0xb7,
+	0xd1, 0x9e, 0xd5, 0xda, 0x8e, 0xb7, 0xc7, 0x29, 0x06, 0x5e, 0xc8, 0x4d, 0x3c, 0x8d, 0x90, 0x34,
+	0x09, 0x21, 0x5f, 0x40, 0xc5, 0xe7, 0xde, 0xa5, 0x6d, 0x21, 0xd7, 0x37, 0x55, 0xf8, 0xfd, 0x82,
+	0xf0, 0x41, 0x0c, 0x49, 0xd2, 0xd0, 0x59, 0x10, 0xb9, 0x0d, 0x9b, 0x3e, 0xe3, 0xe8, 0x0a, 0x7d,
+	0x4b, 0x6d, 0x2a, 0xb6, 0x88, 0x01, 0x75, 0x0b, 0x7d, 0x74, 0x2d, 0x74, 0x4d, 0x59, 0x77, 0x65,
+	0xaf, 0xbc, 0x5f, 0xa5, 0x19, 0x1f, 0x19, 0xc3, 0xff, 0xe2, 0x72, 0xa7, 0xbd, 0x34, 0xb6, 0xba,
+	0x57, 0xde, 0xaf, 0x75, 0x1e, 0xe5, 0x37, 0x12, 0xd3, 0xdc, 0x1e, 0x14, 0x44, 0xf5, 0x5d, 0xc1,
+	0xa7, 0xb4, 0x30, 0x61, 0xcb, 0x87, 0x3b, 0x4b, 0x43, 0x24, 0xcd, 0x17, 0x38, 0x8d, 0x8f, 0x4c,
+	0x0e, 0xc9, 0x67, 0xb0, 0x71, 0xc9, 0x9c, 0x10, 0xe3, 0xc3, 0x7a, 0xb7, 0x98, 0x91, 0x5c, 0x3a,
+	0x1a, 0x45, 0x3d, 0x29, 0x3d, 0xd6, 0x8c, 0x9f, 0xd7, 0xa1, 0xb9, 0x48, 0xfe, 0x7f, 0xea, 0xf8,
+	0x4b, 0xea, 0xb0, 0x57, 0xaa, 0xe3, 0xa3, 0x15, 0x75, 0xfc, 0x0b, 0xf4, 0xf1, 0x7b, 0x19, 0x76,
+	0x96, 0xd0, 0x4f, 0x74, 0xd8, 0x92, 0x07, 0x8f, 0xa6, 0x50, 0x8b, 0x56, 0x68, 0x62, 0x92, 0xb7,
+	0xe1, 0x86, 0x3d, 0x76, 0x3d, 0x8e, 0x87, 0xaf, 0x98, 0x3b, 0x56, 0x7a, 0x91, 0xbc, 0x65, 0x9d,
+	0xe4, 0x43, 0xb8, 0x65, 0xa1, 0x83, 0x02, 0x0f, 0x70, 0xe4, 0x71, 0xa4, 0xe8, 0x3b, 0xcc, 0x44,
+	0xa5, 0x94, 0x0a, 0x2d, 0x9a, 0x22, 0x9f, 0x43, 0xab, 0xc0, 0xdd, 0xc3, 0x91, 0xed, 0xa2, 0xa5,
+	0xf4, 0x54, 0xa1, 0x2b, 0x10, 0xe4, 0x31, 0xec, 0x30, 0xcb, 0xb2, 0xe5, 0xf6, 0x99, 0x33, 0x44,
+	0x93, 0xa3, 0x38, 0x0d, 0x85, 0x1f, 0x0a, 0xa9, 0x3a, 0xb9, 0xc3, 0x65, 0xd3, 0xb2, 0x56, 0xe6,
+	0xd8, 0x2c, 0xc0, 0x40, 0xdf, 0x54, 0xc8, 0xc4, 0x24, 0xe7, 0xd0, 0x30, 0xc3, 0x40, 0x78, 0x93,
+	0x33, 0x7b, 0x82, 0x9e, 0x4c, 0xb5, 0xa5, 0xd8, 0x7e, 0xf8, 0x66, 0x01, 0xb7, 0x0f, 0x33, 0x81,
+	0x74, 0x21, 0x51, 0xeb, 0x05, 0x34, 0xb2, 0x08, 0xa9, 0x53, 0x93, 0x23, 0x13, 0xd1, 0xdd, 0xd4,
+	0x68, 0x6c, 0x49, 0x7f, 0xe8, 0x5b, 0x4c, 0x44, 0x47, 0xad, 0xd1, 0xd8, 0x92, 0xfe, 0x88, 0x0e,
+	0xc5, 0xaa, 0x46, 0x63, 0xcb, 0xf8, 0x41, 0x03, 0x7d, 0xd9, 0xb5, 0xf8, 0x1b, 0xae, 0xbf, 0xd1,
+	0x81, 0xdd, 0x55, 0x8a, 0x94, 0x31, 0x21, 0x77, 0x03, 0x5d, 0x53, 0xdc, 0xab, 0xb1, 0x31, 0x80,
+	0x5b, 0x71, 0xcc, 0x50, 0x30, 0xf3, 0x22, 0xf9, 0xb4, 0x7d, 0x02, 0x55, 0x1e, 0x57, 0x12, 0xe1,
+	0x6b, 0x9d, 0xbb, 0x2b, 0x8e, 0x82, 0xce, 0xd1, 0xc6, 0x37, 0x70, 0x73, 0xd6, 0xc0, 0x03, 0xdf,
+	0x73, 0x03, 0xa9, 0xb8, 0x9a, 0x65, 0xb3, 0xb1, 0xeb, 0x05, 0xc2, 0x36, 0x23, 0x1d, 0xd7, 0x3a,
+	0xbb, 0xf9, 0x7c, 0xbd, 0x19, 0x88, 0xa6, 0x03, 0x8c, 0x5f, 0x4a, 0xb0, 0x9d, 0x83, 0x90, 0x7b,
+	0x00, 0xbe, 0xe7, 0xd8, 0xe6, 0xf4, 0x19, 0x9b, 0x24, 0x3c, 0xa7, 0x3c, 0xe4, 0x1d, 0x68, 0x44,
+	0xd6, 0x80, 0x99, 0x17, 0x0a, 0x53, 0x52, 0x98, 0x05, 0x2f, 0x79, 0x1f, 0xb6, 0xe7, 0x9e, 0xe7,
+	0xc8, 0x03, 0xdb, 0x4b, 0xa8, 0xce, 0x4f, 0x90, 0x3d, 0xa8, 0x59, 0x18, 0x98, 0xdc, 0x56, 0xea,
+	0x8b, 0xf9, 0x4f, 0xbb, 0xa4, 0xca, 0x27, 0x18, 0x04, 0x6c, 0x8c, 0xaa, 0x0b, 0x57, 0x69, 0x62,
+	0x2a, 0x4d, 0xb0, 0x71, 0x22, 0x7e, 0x35, 0x26, 0x47, 0xd0, 0x44, 0x77, 0xe4, 0x71, 0x13, 0x27,
+	0xe8, 0x8a, 0x13, 0xbc, 0x44, 0x47, 0x69, 0xbf, 0x91, 0x21, 0xbc, 0xbf, 0x00, 0xa1, 0xb9, 0x20,
+	0xe3, 0x0f, 0x0d, 0x6a, 0x14, 0x27, 0x68, 0xd9, 0x4c, 0x6d, 0xe3, 0x9f, 0x4a, 0x4f, 0xf6, 0x12,
+	0x6c, 0x5c, 0xf9, 0x12, 0x18, 0xa7, 0xb0, 0x9d, 0xd4, 0x37, 0x97, 0xd6, 0x13, 0xa8, 0xf3, 0x79,
+	0xd1, 0x89, 0x56, 0x6f, 0xa7, 0xa8, 0x4b, 0x71, 0x42, 0x33, 0x58, 0xe3, 0x5b, 0xa8, 0x27, 0x42,
+	0x3e, 0x76, 0x47, 0xde, 0xec, 0x4e, 0x69, 0xa9, 0x4f, 0xaa, 0xac, 0xc7, 0x0e, 0x7c, 0x87, 0x4d,
+	0x53, 0x14, 0xa5, 0x5d, 0xe4, 0x21, 0x54, 0x14, 0x0d, 0xb2, 0x9a, 0xb2, 0x5a, 0xfd, 0xff, 0xa9,
+	0xd5, 0x07, 0x8a, 0x21, 0x99, 0x9e, 0xce, 0x60, 0xc6, 0xf7, 0x25, 0x80, 0xf9, 0xc4, 0x35, 0xd7,
+	0x5d, 0x60, 0xba, 0xbc, 0x52, 0x88, 0xeb, 0x59, 0x21, 0x16, 0x89, 0x6e, 0xe3, 0x1a, 0xa2, 0x23,
+	0x5d, 0xa8, 0x9b, 0x9e, 0x3b, 0xb2, 0xc7, 0x43, 0xf3, 0x15, 0x4e, 0x58, 0xfc, 0x6e, 0x78, 0x2b,
+	0x47, 0xc0, 0x61, 0x0a, 0x44, 0x33, 0x21, 0x86, 0x0d, 0x24, 0x8f, 0x59, 0x50, 0x89, 0x76, 0xf5,
+	0x56, 0xd9, 0x82, 0x0a, 0xc7, 0xd7, 0xa1, 0xcd, 0xd1, 0x8a, 0x3f, 0x98, 0x33, 0xdb, 0xf8, 0x51,
+	0x83, 0x7a, 0x7a, 0xad, 0x42, 0x1e, 0xb4, 0xeb, 0xf0, 0x70, 0xdd, 0xce, 0x6e, 0xfc, 0xa6, 0x81,
+	0x1e, 0x6d, 0x26, 0xe4, 0x38, 0x6f, 0xab, 0x51, 0x17, 0x3e, 0x87, 0xba, 0x9f, 0xda, 0xae, 0xae,
+	0xe5, 0x1e, 0x43, 0xcb, 0x42, 0x33, 0xb4, 0x47, 0x8f, 0xa1, 0x4c, 0xaa, 0xd6, 0x0b, 0xd8, 0xce,
+	0x41, 0x0a, 0x1e, 0x3f, 0x1f, 0x64, 0x1f, 0x3f, 0x3b, 0x4b, 0x0e, 0x36, 0xf5, 0xd8, 0x79, 0xef,
+	0x04, 0x9a, 0x8b, 0x84, 0x91, 0x3a, 0x54, 0xba, 0xbd, 0xe7, 0xc7, 0xc3, 0x53, 0x7a, 0xde, 0x5c,
+	0x23, 0x37, 0xa0, 0xfa, 0xb4, 0xfb, 0xac, 0xd7, 0x3d, 0x93, 0xa6, 0x26, 0x4d, 0xda, 0x7f, 0xda,
+	0xef, 0x1d, 0x77, 0xcf, 0xfa, 0xcd, 0x92, 0xc4, 0xf6, 0x8e, 0x87, 0xdd, 0x83, 0x93, 0x7e, 0xaf,
+	0x59, 0xee, 0xfc, 0x5a, 0x86, 0x4a, 0x52, 0x1b, 0x39, 0x80, 0xad, 0x78, 0x4c, 0xee, 0x2c, 0xfd,
+	0x5f, 0x68, 0xb5, 0x8a, 0xa6, 0xa2, 0x76, 0x61, 0xac, 0x91, 0x13, 0xa8, 0xa7, 0x3f, 0x78, 0xe4,
+	0x5e, 0x1e, 0x9d, 0xfe, 0x12, 0xbe, 0x21, 0xdb, 0x57, 0x50, 0x9d, 0xf5, 0xa4, 0x55, 0x7b, 0xda,
+	0x2d, 0x68, 0x48, 0xe9, 0x3c, 0x3d, 0xb8, 0x79, 0x84, 0x22, 0xd3, 0x8d, 0x6e, 0xe7, 0xe4, 0xd3,
+	0x97, 0x3f, 0xab, 0xad, 0x9d, 0xfc, 0x2a, 0x2a, 0xc0, 0x58, 0x23, 0x5f, 0xc2, 0x8d, 0x23, 0x14,
+	0x03, 0xf5, 0xc7, 0xbb, 0x32, 0x47, 0xa6, 0x43, 0xcd, 0xe0, 0xc6, 0x1a, 0xf9, 0x1a, 0xaa, 0x33,
+	0x49, 0x91, 0xfb, 0x57, 0x10, 0x5a, 0x6b, 0xc9, 0x12, 0xc6, 0xda, 0xcb, 0x4d, 0xe5, 0x79, 0xf4,
+	0xe7, 0x00, 0xa4, 0x22, 0x38, 0x03, 0x9e, 0x0f, 0x00, 0x00,
 }
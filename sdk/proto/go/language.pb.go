@@ -37,7 +37,7 @@ func (m *GetRequiredPluginsRequest) Reset()         { *m = GetRequiredPluginsReq
 func (m *GetRequiredPluginsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequiredPluginsRequest) ProtoMessage()    {}
 func (*GetRequiredPluginsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_language_aca56bed509f6786, []int{0}
+	return fileDescriptor_language_7f5161d6533561c5, []int{0}
 }
 func (m *GetRequiredPluginsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequiredPluginsRequest.Unmarshal(m, b)
@@ -89,7 +89,7 @@ func (m *GetRequiredPluginsResponse) Reset()         { *m = GetRequiredPluginsRe
 func (m *GetRequiredPluginsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRequiredPluginsResponse) ProtoMessage()    {}
 func (*GetRequiredPluginsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_language_aca56bed509f6786, []int{1}
+	return fileDescriptor_language_7f5161d6533561c5, []int{1}
 }
 func (m *GetRequiredPluginsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequiredPluginsResponse.Unmarshal(m, b)
@@ -128,6 +128,7 @@ type RunRequest struct {
 	Parallel             int32             `protobuf:"varint,8,opt,name=parallel" json:"parallel,omitempty"`
 	MonitorAddress       string            `protobuf:"bytes,9,opt,name=monitor_address,json=monitorAddress" json:"monitor_address,omitempty"`
 	QueryMode            bool              `protobuf:"varint,10,opt,name=queryMode" json:"queryMode,omitempty"`
+	ConfigSecretKeys     []string          `protobuf:"bytes,11,rep,name=configSecretKeys" json:"configSecretKeys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -137,7 +138,7 @@ func (m *RunRequest) Reset()         { *m = RunRequest{} }
 func (m *RunRequest) String() string { return proto.CompactTextString(m) }
 func (*RunRequest) ProtoMessage()    {}
 func (*RunRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_language_aca56bed509f6786, []int{2}
+	return fileDescriptor_language_7f5161d6533561c5, []int{2}
 }
 func (m *RunRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RunRequest.Unmarshal(m, b)
@@ -227,6 +228,13 @@ func (m *RunRequest) GetQueryMode() bool {
 	return false
 }
 
+func (m *RunRequest) GetConfigSecretKeys() []string {
+	if m != nil {
+		return m.ConfigSecretKeys
+	}
+	return nil
+}
+
 // RunResponse is the response back from the interpreter/source back to the monitor.
 type RunResponse struct {
 	// An unhandled error if any occurred.
@@ -244,7 +252,7 @@ func (m *RunResponse) Reset()         { *m = RunResponse{} }
 func (m *RunResponse) String() string { return proto.CompactTextString(m) }
 func (*RunResponse) ProtoMessage()    {}
 func (*RunResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_language_aca56bed509f6786, []int{3}
+	return fileDescriptor_language_7f5161d6533561c5, []int{3}
 }
 func (m *RunResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RunResponse.Unmarshal(m, b)
@@ -430,38 +438,39 @@ var _LanguageRuntime_serviceDesc = grpc.ServiceDesc{
 	Metadata: "language.proto",
 }
 
-func init() { proto.RegisterFile("language.proto", fileDescriptor_language_aca56bed509f6786) }
-
-var fileDescriptor_language_aca56bed509f6786 = []byte{
-	// 477 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x93, 0xdf, 0x6e, 0xd3, 0x30,
-	0x14, 0xc6, 0x97, 0x66, 0xfd, 0x77, 0x0a, 0x1b, 0xb2, 0xb6, 0xca, 0x64, 0x5c, 0x94, 0x08, 0x44,
-	0xaf, 0x32, 0x69, 0x88, 0x3f, 0xe3, 0x0a, 0x04, 0xd3, 0x84, 0x04, 0x12, 0x32, 0x0f, 0x80, 0xdc,
-	0xe4, 0x34, 0x0a, 0x4b, 0x6d, 0xcf, 0xb1, 0x41, 0x79, 0x68, 0x24, 0x1e, 0x01, 0xd9, 0x4e, 0xbb,
-	0xc2, 0x8a, 0x76, 0x77, 0xbe, 0x93, 0xef, 0x24, 0xbf, 0x7c, 0x3e, 0x86, 0x83, 0x9a, 0x8b, 0xd2,
-	0xf2, 0x12, 0x33, 0xa5, 0xa5, 0x91, 0x64, 0xac, 0x6c, 0x6d, 0x57, 0x95, 0x56, 0x79, 0x72, 0x4f,
-	0xd5, 0xb6, 0xac, 0x44, 0x78, 0x90, 0x9c, 0x94, 0x52, 0x96, 0x35, 0x9e, 0x7a, 0xb5, 0xb0, 0xcb,
-	0x53, 0x5c, 0x29, 0xd3, 0x86, 0x87, 0x29, 0x87, 0x87, 0x97, 0x68, 0x18, 0x5e, 0xdb, 0x4a, 0x63,
-	0xf1, 0xc5, 0xcf, 0x35, 0x4e, 0x62, 0x63, 0x08, 0x85, 0xa1, 0xd2, 0xf2, 0x3b, 0xe6, 0x86, 0x46,
-	0xb3, 0x68, 0x3e, 0x66, 0x6b, 0x49, 0x1e, 0x40, 0xac, 0x7e, 0x16, 0xb4, 0xe7, 0xbb, 0xae, 0xec,
-	0xbc, 0xa5, 0xe6, 0x2b, 0x1a, 0x6f, 0xbc, 0x4e, 0xa6, 0x5f, 0x21, 0xd9, 0xf5, 0x89, 0x46, 0x49,
-	0xd1, 0x20, 0x79, 0x01, 0xc3, 0x40, 0xdb, 0xd0, 0x68, 0x16, 0xcf, 0x27, 0x67, 0x27, 0xd9, 0xe6,
-	0x47, 0xb2, 0x60, 0xfe, 0x80, 0x0a, 0x45, 0x81, 0x22, 0x6f, 0xd9, 0xda, 0x9b, 0xfe, 0xea, 0x01,
-	0x30, 0x2b, 0xee, 0x26, 0x3d, 0x82, 0x7e, 0x63, 0x78, 0x7e, 0xd5, 0xb1, 0x06, 0xb1, 0xe6, 0x8f,
-	0x77, 0xf2, 0xef, 0xff, 0xc5, 0x4f, 0x08, 0xec, 0x73, 0x5d, 0x36, 0xb4, 0x3f, 0x8b, 0xe7, 0x63,
-	0xe6, 0x6b, 0x72, 0x0e, 0x83, 0x5c, 0x8a, 0x65, 0x55, 0xd2, 0x81, 0x87, 0x7e, 0xbc, 0x05, 0x7d,
-	0x83, 0x95, 0xbd, 0xf7, 0x9e, 0x0b, 0x61, 0x74, 0xcb, 0xba, 0x01, 0x32, 0x85, 0x41, 0xa1, 0x5b,
-	0x66, 0x05, 0x1d, 0xce, 0xa2, 0xf9, 0x88, 0x75, 0x8a, 0x24, 0x30, 0x52, 0x5c, 0xf3, 0xba, 0xc6,
-	0x9a, 0x8e, 0x66, 0xd1, 0xbc, 0xcf, 0x36, 0x9a, 0x3c, 0x83, 0xc3, 0x95, 0x14, 0x95, 0x91, 0xfa,
-	0x1b, 0x2f, 0x0a, 0x8d, 0x4d, 0x43, 0xc7, 0x1e, 0xf2, 0xa0, 0x6b, 0xbf, 0x0b, 0x5d, 0xf2, 0x08,
-	0xc6, 0xd7, 0x16, 0x75, 0xfb, 0x59, 0x16, 0x48, 0xc1, 0xbf, 0xff, 0xa6, 0x91, 0x9c, 0xc3, 0x64,
-	0x8b, 0xc8, 0x85, 0x70, 0x85, 0x6d, 0x17, 0x98, 0x2b, 0x5d, 0x58, 0x3f, 0x78, 0x6d, 0x71, 0x1d,
-	0x96, 0x17, 0x6f, 0x7a, 0xaf, 0xa3, 0xf4, 0x15, 0x4c, 0xfc, 0x7f, 0x75, 0xa7, 0x76, 0x04, 0x7d,
-	0xd4, 0x5a, 0xea, 0x6e, 0x38, 0x08, 0x97, 0xd4, 0x82, 0x57, 0xb5, 0x9f, 0x1e, 0x31, 0x5f, 0x9f,
-	0xfd, 0x8e, 0xe0, 0xf0, 0x53, 0xb7, 0xa9, 0xcc, 0x0a, 0x53, 0xad, 0x90, 0xe4, 0x40, 0x6e, 0x6f,
-	0x04, 0x79, 0xb2, 0x95, 0xe1, 0x7f, 0x77, 0x32, 0x79, 0x7a, 0x87, 0x2b, 0x00, 0xa6, 0x7b, 0xe4,
-	0x25, 0xc4, 0x2e, 0xd6, 0xe3, 0x9d, 0x27, 0x93, 0x4c, 0xff, 0x6d, 0x6f, 0xe6, 0xde, 0xc2, 0xfd,
-	0x4b, 0x34, 0xe1, 0x7d, 0x1f, 0xc5, 0x52, 0x92, 0x69, 0x16, 0x2e, 0x50, 0xb6, 0xbe, 0x40, 0xd9,
-	0x85, 0xbb, 0x40, 0xc9, 0xf1, 0xad, 0x45, 0x75, 0xf6, 0x74, 0x6f, 0x31, 0xf0, 0xc6, 0xe7, 0x7f,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x4e, 0xdb, 0x96, 0xaa, 0xa2, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("language.proto", fileDescriptor_language_7f5161d6533561c5) }
+
+var fileDescriptor_language_7f5161d6533561c5 = []byte{
+	// 493 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xdf, 0x6e, 0xd3, 0x30,
+	0x14, 0xc6, 0x97, 0x65, 0xfd, 0x77, 0x0a, 0xdb, 0x64, 0x6d, 0x95, 0xc9, 0xb8, 0x28, 0x11, 0x88,
+	0x8a, 0x8b, 0x4c, 0x1a, 0xe2, 0xcf, 0xb8, 0x02, 0xc1, 0x34, 0x21, 0x40, 0x42, 0xde, 0x03, 0x20,
+	0x37, 0x39, 0x8d, 0xc2, 0x52, 0x3b, 0x73, 0x6c, 0x50, 0x1e, 0x85, 0xb7, 0xe4, 0x11, 0x90, 0xed,
+	0xa4, 0x2b, 0xb4, 0x68, 0x77, 0xe7, 0x3b, 0xf9, 0x4e, 0xf2, 0xcb, 0xe7, 0x63, 0xd8, 0x2f, 0xb9,
+	0xc8, 0x0d, 0xcf, 0x31, 0xa9, 0x94, 0xd4, 0x92, 0x8c, 0x2a, 0x53, 0x9a, 0x65, 0xa1, 0xaa, 0x34,
+	0xba, 0x57, 0x95, 0x26, 0x2f, 0x84, 0x7f, 0x10, 0x9d, 0xe4, 0x52, 0xe6, 0x25, 0x9e, 0x3a, 0x35,
+	0x37, 0x8b, 0x53, 0x5c, 0x56, 0xba, 0xf1, 0x0f, 0x63, 0x0e, 0x0f, 0x2e, 0x51, 0x33, 0xbc, 0x31,
+	0x85, 0xc2, 0xec, 0xab, 0x9b, 0xab, 0xad, 0xc4, 0x5a, 0x13, 0x0a, 0x83, 0x4a, 0xc9, 0xef, 0x98,
+	0x6a, 0x1a, 0x4c, 0x83, 0xd9, 0x88, 0x75, 0x92, 0x1c, 0x42, 0x58, 0xfd, 0xcc, 0xe8, 0xae, 0xeb,
+	0xda, 0xb2, 0xf5, 0xe6, 0x8a, 0x2f, 0x69, 0xb8, 0xf2, 0x5a, 0x19, 0x5f, 0x41, 0xb4, 0xed, 0x13,
+	0x75, 0x25, 0x45, 0x8d, 0xe4, 0x05, 0x0c, 0x3c, 0x6d, 0x4d, 0x83, 0x69, 0x38, 0x1b, 0x9f, 0x9d,
+	0x24, 0xab, 0x1f, 0x49, 0xbc, 0xf9, 0x03, 0x56, 0x28, 0x32, 0x14, 0x69, 0xc3, 0x3a, 0x6f, 0xfc,
+	0x2b, 0x04, 0x60, 0x46, 0xdc, 0x4d, 0x7a, 0x04, 0xbd, 0x5a, 0xf3, 0xf4, 0xba, 0x65, 0xf5, 0xa2,
+	0xe3, 0x0f, 0xb7, 0xf2, 0xef, 0xfd, 0xc5, 0x4f, 0x08, 0xec, 0x71, 0x95, 0xd7, 0xb4, 0x37, 0x0d,
+	0x67, 0x23, 0xe6, 0x6a, 0x72, 0x0e, 0xfd, 0x54, 0x8a, 0x45, 0x91, 0xd3, 0xbe, 0x83, 0x7e, 0xb4,
+	0x06, 0x7d, 0x8b, 0x95, 0xbc, 0x77, 0x9e, 0x0b, 0xa1, 0x55, 0xc3, 0xda, 0x01, 0x32, 0x81, 0x7e,
+	0xa6, 0x1a, 0x66, 0x04, 0x1d, 0x4c, 0x83, 0xd9, 0x90, 0xb5, 0x8a, 0x44, 0x30, 0xac, 0xb8, 0xe2,
+	0x65, 0x89, 0x25, 0x1d, 0x4e, 0x83, 0x59, 0x8f, 0xad, 0x34, 0x79, 0x0a, 0x07, 0x4b, 0x29, 0x0a,
+	0x2d, 0xd5, 0x37, 0x9e, 0x65, 0x0a, 0xeb, 0x9a, 0x8e, 0x1c, 0xe4, 0x7e, 0xdb, 0x7e, 0xe7, 0xbb,
+	0xe4, 0x21, 0x8c, 0x6e, 0x0c, 0xaa, 0xe6, 0x8b, 0xcc, 0x90, 0x82, 0x7b, 0xff, 0x6d, 0x83, 0x3c,
+	0x83, 0x43, 0x0f, 0x71, 0x85, 0xa9, 0x42, 0xfd, 0x09, 0x9b, 0x9a, 0x8e, 0xdd, 0x5f, 0x6d, 0xf4,
+	0xa3, 0x73, 0x18, 0xaf, 0xd1, 0xdb, 0xc0, 0xae, 0xb1, 0x69, 0xc3, 0xb5, 0xa5, 0x0d, 0xf6, 0x07,
+	0x2f, 0x0d, 0x76, 0xc1, 0x3a, 0xf1, 0x66, 0xf7, 0x75, 0x10, 0xbf, 0x82, 0xb1, 0xcb, 0xa0, 0x3d,
+	0xe1, 0x23, 0xe8, 0xa1, 0x52, 0x52, 0xb5, 0xc3, 0x5e, 0xd8, 0x54, 0xe7, 0xbc, 0x28, 0xdd, 0xf4,
+	0x90, 0xb9, 0xfa, 0xec, 0x77, 0x00, 0x07, 0x9f, 0xdb, 0xad, 0x66, 0x46, 0xe8, 0x62, 0x89, 0x24,
+	0x05, 0xb2, 0xb9, 0x3d, 0xe4, 0xf1, 0x5a, 0xde, 0xff, 0xdd, 0xdf, 0xe8, 0xc9, 0x1d, 0x2e, 0x0f,
+	0x18, 0xef, 0x90, 0x97, 0x10, 0xda, 0x23, 0x38, 0xde, 0x7a, 0x8a, 0xd1, 0xe4, 0xdf, 0xf6, 0x6a,
+	0xee, 0x2d, 0xdc, 0xbf, 0x44, 0xed, 0xdf, 0xf7, 0x51, 0x2c, 0x24, 0x99, 0x24, 0xfe, 0xb2, 0x25,
+	0xdd, 0x65, 0x4b, 0x2e, 0xec, 0x65, 0x8b, 0x8e, 0x37, 0x96, 0xda, 0xda, 0xe3, 0x9d, 0x79, 0xdf,
+	0x19, 0x9f, 0xff, 0x19, 0x00, 0x1e, 0x7b, 0x8d, 0xa1, 0xce, 0x03, 0x00, 0x00,
 }
@@ -57,7 +57,7 @@ func (x PropertyDiff_Kind) String() string {
 	return proto.EnumName(PropertyDiff_Kind_name, int32(x))
 }
 func (PropertyDiff_Kind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{9, 0}
+	return fileDescriptor_provider_a64b40641226f728, []int{11, 0}
 }
 
 type DiffResponse_DiffChanges int32
@@ -83,7 +83,7 @@ func (x DiffResponse_DiffChanges) String() string {
 	return proto.EnumName(DiffResponse_DiffChanges_name, int32(x))
 }
 func (DiffResponse_DiffChanges) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{10, 0}
+	return fileDescriptor_provider_a64b40641226f728, []int{12, 0}
 }
 
 type ConfigureRequest struct {
@@ -99,7 +99,7 @@ func (m *ConfigureRequest) Reset()         { *m = ConfigureRequest{} }
 func (m *ConfigureRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureRequest) ProtoMessage()    {}
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{0}
+	return fileDescriptor_provider_a64b40641226f728, []int{0}
 }
 func (m *ConfigureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureRequest.Unmarshal(m, b)
@@ -151,7 +151,7 @@ func (m *ConfigureResponse) Reset()         { *m = ConfigureResponse{} }
 func (m *ConfigureResponse) String() string { return proto.CompactTextString(m) }
 func (*ConfigureResponse) ProtoMessage()    {}
 func (*ConfigureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{1}
+	return fileDescriptor_provider_a64b40641226f728, []int{1}
 }
 func (m *ConfigureResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureResponse.Unmarshal(m, b)
@@ -190,7 +190,7 @@ func (m *ConfigureErrorMissingKeys) Reset()         { *m = ConfigureErrorMissing
 func (m *ConfigureErrorMissingKeys) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{2}
+	return fileDescriptor_provider_a64b40641226f728, []int{2}
 }
 func (m *ConfigureErrorMissingKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys.Unmarshal(m, b)
@@ -229,7 +229,7 @@ func (m *ConfigureErrorMissingKeys_MissingKey) Reset()         { *m = ConfigureE
 func (m *ConfigureErrorMissingKeys_MissingKey) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys_MissingKey) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys_MissingKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{2, 0}
+	return fileDescriptor_provider_a64b40641226f728, []int{2, 0}
 }
 func (m *ConfigureErrorMissingKeys_MissingKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys_MissingKey.Unmarshal(m, b)
@@ -277,7 +277,7 @@ func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
 func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
 func (*InvokeRequest) ProtoMessage()    {}
 func (*InvokeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{3}
+	return fileDescriptor_provider_a64b40641226f728, []int{3}
 }
 func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
@@ -337,7 +337,7 @@ func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
 func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
 func (*InvokeResponse) ProtoMessage()    {}
 func (*InvokeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{4}
+	return fileDescriptor_provider_a64b40641226f728, []int{4}
 }
 func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
@@ -371,6 +371,122 @@ func (m *InvokeResponse) GetFailures() []*CheckFailure {
 	return nil
 }
 
+type CallRequest struct {
+	Tok                  string          `protobuf:"bytes,1,opt,name=tok" json:"tok,omitempty"`
+	Urn                  string          `protobuf:"bytes,2,opt,name=urn" json:"urn,omitempty"`
+	Id                   string          `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+	State                *_struct.Struct `protobuf:"bytes,4,opt,name=state" json:"state,omitempty"`
+	Args                 *_struct.Struct `protobuf:"bytes,5,opt,name=args" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CallRequest) Reset()         { *m = CallRequest{} }
+func (m *CallRequest) String() string { return proto.CompactTextString(m) }
+func (*CallRequest) ProtoMessage()    {}
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_a64b40641226f728, []int{5}
+}
+func (m *CallRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallRequest.Unmarshal(m, b)
+}
+func (m *CallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallRequest.Marshal(b, m, deterministic)
+}
+func (dst *CallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRequest.Merge(dst, src)
+}
+func (m *CallRequest) XXX_Size() int {
+	return xxx_messageInfo_CallRequest.Size(m)
+}
+func (m *CallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallRequest proto.InternalMessageInfo
+
+func (m *CallRequest) GetTok() string {
+	if m != nil {
+		return m.Tok
+	}
+	return ""
+}
+
+func (m *CallRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *CallRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CallRequest) GetState() *_struct.Struct {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *CallRequest) GetArgs() *_struct.Struct {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type CallResponse struct {
+	Return               *_struct.Struct `protobuf:"bytes,1,opt,name=return" json:"return,omitempty"`
+	Failures             []*CheckFailure `protobuf:"bytes,2,rep,name=failures" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CallResponse) Reset()         { *m = CallResponse{} }
+func (m *CallResponse) String() string { return proto.CompactTextString(m) }
+func (*CallResponse) ProtoMessage()    {}
+func (*CallResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_a64b40641226f728, []int{6}
+}
+func (m *CallResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallResponse.Unmarshal(m, b)
+}
+func (m *CallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallResponse.Marshal(b, m, deterministic)
+}
+func (dst *CallResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallResponse.Merge(dst, src)
+}
+func (m *CallResponse) XXX_Size() int {
+	return xxx_messageInfo_CallResponse.Size(m)
+}
+func (m *CallResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallResponse proto.InternalMessageInfo
+
+func (m *CallResponse) GetReturn() *_struct.Struct {
+	if m != nil {
+		return m.Return
+	}
+	return nil
+}
+
+func (m *CallResponse) GetFailures() []*CheckFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
 type CheckRequest struct {
 	Urn                  string          `protobuf:"bytes,1,opt,name=urn" json:"urn,omitempty"`
 	Olds                 *_struct.Struct `protobuf:"bytes,2,opt,name=olds" json:"olds,omitempty"`
@@ -384,7 +500,7 @@ func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
 func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest) ProtoMessage()    {}
 func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{5}
+	return fileDescriptor_provider_a64b40641226f728, []int{7}
 }
 func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckRequest.Unmarshal(m, b)
@@ -437,7 +553,7 @@ func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
 func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckResponse) ProtoMessage()    {}
 func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{6}
+	return fileDescriptor_provider_a64b40641226f728, []int{8}
 }
 func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
@@ -483,7 +599,7 @@ func (m *CheckFailure) Reset()         { *m = CheckFailure{} }
 func (m *CheckFailure) String() string { return proto.CompactTextString(m) }
 func (*CheckFailure) ProtoMessage()    {}
 func (*CheckFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{7}
+	return fileDescriptor_provider_a64b40641226f728, []int{9}
 }
 func (m *CheckFailure) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckFailure.Unmarshal(m, b)
@@ -532,7 +648,7 @@ func (m *DiffRequest) Reset()         { *m = DiffRequest{} }
 func (m *DiffRequest) String() string { return proto.CompactTextString(m) }
 func (*DiffRequest) ProtoMessage()    {}
 func (*DiffRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{8}
+	return fileDescriptor_provider_a64b40641226f728, []int{10}
 }
 func (m *DiffRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffRequest.Unmarshal(m, b)
@@ -599,7 +715,7 @@ func (m *PropertyDiff) Reset()         { *m = PropertyDiff{} }
 func (m *PropertyDiff) String() string { return proto.CompactTextString(m) }
 func (*PropertyDiff) ProtoMessage()    {}
 func (*PropertyDiff) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{9}
+	return fileDescriptor_provider_a64b40641226f728, []int{11}
 }
 func (m *PropertyDiff) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PropertyDiff.Unmarshal(m, b)
@@ -680,7 +796,7 @@ func (m *DiffResponse) Reset()         { *m = DiffResponse{} }
 func (m *DiffResponse) String() string { return proto.CompactTextString(m) }
 func (*DiffResponse) ProtoMessage()    {}
 func (*DiffResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{10}
+	return fileDescriptor_provider_a64b40641226f728, []int{12}
 }
 func (m *DiffResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffResponse.Unmarshal(m, b)
@@ -762,7 +878,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{11}
+	return fileDescriptor_provider_a64b40641226f728, []int{13}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -815,7 +931,7 @@ func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
 func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateResponse) ProtoMessage()    {}
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{12}
+	return fileDescriptor_provider_a64b40641226f728, []int{14}
 }
 func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
@@ -863,7 +979,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{13}
+	return fileDescriptor_provider_a64b40641226f728, []int{15}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
@@ -924,7 +1040,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{14}
+	return fileDescriptor_provider_a64b40641226f728, []int{16}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
@@ -981,7 +1097,7 @@ func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
 func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRequest) ProtoMessage()    {}
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{15}
+	return fileDescriptor_provider_a64b40641226f728, []int{17}
 }
 func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
@@ -1054,7 +1170,7 @@ func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
 func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResponse) ProtoMessage()    {}
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{16}
+	return fileDescriptor_provider_a64b40641226f728, []int{18}
 }
 func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
@@ -1095,7 +1211,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{17}
+	return fileDescriptor_provider_a64b40641226f728, []int{19}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -1159,7 +1275,7 @@ func (m *ErrorResourceInitFailed) Reset()         { *m = ErrorResourceInitFailed
 func (m *ErrorResourceInitFailed) String() string { return proto.CompactTextString(m) }
 func (*ErrorResourceInitFailed) ProtoMessage()    {}
 func (*ErrorResourceInitFailed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_31a5eda36dbe30f0, []int{18}
+	return fileDescriptor_provider_a64b40641226f728, []int{20}
 }
 func (m *ErrorResourceInitFailed) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ErrorResourceInitFailed.Unmarshal(m, b)
@@ -1215,6 +1331,8 @@ func init() {
 	proto.RegisterType((*ConfigureErrorMissingKeys_MissingKey)(nil), "pulumirpc.ConfigureErrorMissingKeys.MissingKey")
 	proto.RegisterType((*InvokeRequest)(nil), "pulumirpc.InvokeRequest")
 	proto.RegisterType((*InvokeResponse)(nil), "pulumirpc.InvokeResponse")
+	proto.RegisterType((*CallRequest)(nil), "pulumirpc.CallRequest")
+	proto.RegisterType((*CallResponse)(nil), "pulumirpc.CallResponse")
 	proto.RegisterType((*CheckRequest)(nil), "pulumirpc.CheckRequest")
 	proto.RegisterType((*CheckResponse)(nil), "pulumirpc.CheckResponse")
 	proto.RegisterType((*CheckFailure)(nil), "pulumirpc.CheckFailure")
@@ -1253,6 +1371,13 @@ type ResourceProviderClient interface {
 	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
 	// Invoke dynamically executes a built-in function in the provider.
 	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+	// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream of responses.
+	// Each response carries one element of the function's result.  If any arguments fail verification, the stream
+	// consists of a single response that carries the failures.
+	StreamInvoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (ResourceProvider_StreamInvokeClient, error)
+	// Call dynamically executes a method of a resource in the provider.  The resource's ID and current state are
+	// passed along with the method's arguments.
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
 	// Check validates that the given property bag is valid for a resource of the given type and returns the inputs
 	// that should be passed to successive calls to Diff, Create, or Update for this resource. As a rule, the provider
 	// inputs returned by a call to Check should preserve the original representation of the properties as present in
@@ -1321,6 +1446,47 @@ func (c *resourceProviderClient) Invoke(ctx context.Context, in *InvokeRequest,
 	return out, nil
 }
 
+func (c *resourceProviderClient) StreamInvoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (ResourceProvider_StreamInvokeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ResourceProvider_serviceDesc.Streams[0], c.cc, "/pulumirpc.ResourceProvider/StreamInvoke", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceProviderStreamInvokeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourceProvider_StreamInvokeClient interface {
+	Recv() (*InvokeResponse, error)
+	grpc.ClientStream
+}
+
+type resourceProviderStreamInvokeClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceProviderStreamInvokeClient) Recv() (*InvokeResponse, error) {
+	m := new(InvokeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourceProviderClient) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceProvider/Call", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceProviderClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
 	out := new(CheckResponse)
 	err := grpc.Invoke(ctx, "/pulumirpc.ResourceProvider/Check", in, out, c.cc, opts...)
@@ -1404,6 +1570,13 @@ type ResourceProviderServer interface {
 	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
 	// Invoke dynamically executes a built-in function in the provider.
 	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+	// StreamInvoke dynamically executes a built-in function in the provider, which returns a stream of responses.
+	// Each response carries one element of the function's result.  If any arguments fail verification, the stream
+	// consists of a single response that carries the failures.
+	StreamInvoke(*InvokeRequest, ResourceProvider_StreamInvokeServer) error
+	// Call dynamically executes a method of a resource in the provider.  The resource's ID and current state are
+	// passed along with the method's arguments.
+	Call(context.Context, *CallRequest) (*CallResponse, error)
 	// Check validates that the given property bag is valid for a resource of the given type and returns the inputs
 	// that should be passed to successive calls to Diff, Create, or Update for this resource. As a rule, the provider
 	// inputs returned by a call to Check should preserve the original representation of the properties as present in
@@ -1504,6 +1677,45 @@ func _ResourceProvider_Invoke_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceProvider_StreamInvoke_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvokeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceProviderServer).StreamInvoke(m, &resourceProviderStreamInvokeServer{stream})
+}
+
+type ResourceProvider_StreamInvokeServer interface {
+	Send(*InvokeResponse) error
+	grpc.ServerStream
+}
+
+type resourceProviderStreamInvokeServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceProviderStreamInvokeServer) Send(m *InvokeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ResourceProvider_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceProviderServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceProvider/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceProviderServer).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceProvider_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckRequest)
 	if err := dec(in); err != nil {
@@ -1668,6 +1880,10 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Invoke",
 			Handler:    _ResourceProvider_Invoke_Handler,
 		},
+		{
+			MethodName: "Call",
+			Handler:    _ResourceProvider_Call_Handler,
+		},
 		{
 			MethodName: "Check",
 			Handler:    _ResourceProvider_Check_Handler,
@@ -1701,88 +1917,98 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			Handler:    _ResourceProvider_GetPluginInfo_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInvoke",
+			Handler:       _ResourceProvider_StreamInvoke_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "provider.proto",
 }
 
-func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_31a5eda36dbe30f0) }
-
-var fileDescriptor_provider_31a5eda36dbe30f0 = []byte{
-	// 1207 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x57, 0xcd, 0x72, 0x1b, 0xc5,
-	0x13, 0xd7, 0x6a, 0x65, 0xd9, 0x6a, 0x7d, 0x44, 0x99, 0xff, 0x1f, 0x5b, 0x51, 0x7c, 0x50, 0x2d,
-	0x1c, 0x04, 0x14, 0x72, 0xca, 0x39, 0x40, 0x52, 0x49, 0x05, 0xdb, 0x92, 0xc1, 0xe5, 0xf8, 0x83,
-	0x75, 0xcc, 0xc7, 0x29, 0xac, 0xb5, 0x23, 0x79, 0x4a, 0xf2, 0xee, 0x32, 0x3b, 0x2b, 0xca, 0x9c,
-	0x39, 0xf0, 0x00, 0x5c, 0x78, 0x08, 0x8a, 0x2a, 0x9e, 0x80, 0x3b, 0xcf, 0xc0, 0x23, 0xf0, 0x0e,
-	0xd4, 0x7c, 0xec, 0x6a, 0xc6, 0x92, 0x1d, 0xd9, 0x95, 0x82, 0xdb, 0xf4, 0x74, 0xcf, 0x74, 0xf7,
-	0x6f, 0xba, 0x7f, 0x33, 0x03, 0xb5, 0x88, 0x86, 0x13, 0xe2, 0x63, 0xda, 0x89, 0x68, 0xc8, 0x42,
-	0x54, 0x8a, 0x92, 0x71, 0x72, 0x41, 0x68, 0xd4, 0x6f, 0x56, 0xa2, 0x71, 0x32, 0x24, 0x81, 0x54,
-	0x34, 0x1f, 0x0e, 0xc3, 0x70, 0x38, 0xc6, 0x1b, 0x42, 0x3a, 0x4b, 0x06, 0x1b, 0xf8, 0x22, 0x62,
-	0x97, 0x4a, 0xb9, 0x7e, 0x55, 0x19, 0x33, 0x9a, 0xf4, 0x99, 0xd4, 0x3a, 0x7f, 0x5b, 0x50, 0xdf,
-	0x09, 0x83, 0x01, 0x19, 0x26, 0x14, 0xbb, 0xf8, 0xbb, 0x04, 0xc7, 0x0c, 0x7d, 0x0e, 0xa5, 0x89,
-	0x47, 0x89, 0x77, 0x36, 0xc6, 0x71, 0xc3, 0x6a, 0xd9, 0xed, 0xf2, 0xe6, 0x07, 0x9d, 0xcc, 0x79,
-	0xe7, 0xaa, 0x7d, 0xe7, 0xcb, 0xd4, 0xb8, 0x17, 0x30, 0x7a, 0xe9, 0x4e, 0x17, 0xa3, 0x0f, 0xa1,
-	0xe0, 0xd1, 0x61, 0xdc, 0xc8, 0xb7, 0xac, 0x76, 0x79, 0x73, 0xad, 0x23, 0x63, 0xe9, 0xa4, 0xb1,
-	0x74, 0x4e, 0x44, 0x2c, 0xae, 0x30, 0x42, 0xef, 0x41, 0xd5, 0xeb, 0xf7, 0x71, 0xc4, 0x4e, 0x70,
-	0x9f, 0x62, 0x16, 0x37, 0xec, 0x96, 0xd5, 0x5e, 0x71, 0xcd, 0xc9, 0xe6, 0x33, 0xa8, 0x99, 0xfe,
-	0x50, 0x1d, 0xec, 0x11, 0xbe, 0x6c, 0x58, 0x2d, 0xab, 0x5d, 0x72, 0xf9, 0x10, 0xfd, 0x1f, 0x96,
-	0x26, 0xde, 0x38, 0xc1, 0xc2, 0x6f, 0xc9, 0x95, 0xc2, 0xd3, 0xfc, 0x27, 0x96, 0xf3, 0x04, 0xee,
-	0x6b, 0xe1, 0xc7, 0x51, 0x18, 0xc4, 0x78, 0xd6, 0xb1, 0x35, 0xc7, 0xb1, 0xf3, 0xbb, 0x05, 0x0f,
-	0xb2, 0xb5, 0x3d, 0x4a, 0x43, 0x7a, 0x40, 0xe2, 0x98, 0x04, 0xc3, 0x7d, 0x7c, 0x19, 0xa3, 0x2f,
-	0xa0, 0x7c, 0x31, 0x15, 0x15, 0x6a, 0x1b, 0xf3, 0x50, 0xbb, 0xba, 0xb4, 0x33, 0x1d, 0xbb, 0xfa,
-	0x1e, 0xcd, 0x6d, 0x80, 0xa9, 0x0a, 0x21, 0x28, 0x04, 0xde, 0x05, 0x56, 0x69, 0x8a, 0x31, 0x6a,
-	0x41, 0xd9, 0xc7, 0x71, 0x9f, 0x92, 0x88, 0x91, 0x30, 0x50, 0xd9, 0xea, 0x53, 0xce, 0x8f, 0x16,
-	0x54, 0xf7, 0x82, 0x49, 0x38, 0xca, 0x0e, 0xb7, 0x0e, 0x36, 0x0b, 0x47, 0x29, 0x5a, 0x2c, 0x1c,
-	0xdd, 0xee, 0x90, 0x9a, 0xb0, 0x92, 0x96, 0xa5, 0x38, 0x9f, 0x92, 0x9b, 0xc9, 0xa8, 0x01, 0xcb,
-	0x13, 0x4c, 0x63, 0x1e, 0x4a, 0x41, 0xa8, 0x52, 0xd1, 0x99, 0x40, 0x2d, 0x8d, 0x42, 0x61, 0xbe,
-	0x01, 0x45, 0x8a, 0x59, 0x42, 0x03, 0x11, 0xc9, 0x0d, 0x6e, 0x95, 0x19, 0x7a, 0x0c, 0x2b, 0x03,
-	0x8f, 0x8c, 0x13, 0x8a, 0x79, 0xa4, 0xb6, 0x58, 0xa2, 0xa1, 0x7b, 0x8e, 0xfb, 0xa3, 0x5d, 0xa9,
-	0x77, 0x33, 0x43, 0xe7, 0x07, 0xa8, 0x08, 0x8d, 0x96, 0x7c, 0xea, 0xb2, 0xe4, 0xf2, 0x21, 0x4f,
-	0x3e, 0x1c, 0xfb, 0x6f, 0x4e, 0x9e, 0x1b, 0x71, 0xe3, 0x00, 0x7f, 0x2f, 0x0b, 0xf3, 0x26, 0x63,
-	0x6e, 0xe4, 0x24, 0x50, 0x55, 0xbe, 0xa7, 0x29, 0x93, 0x20, 0x4a, 0x54, 0x7d, 0xdd, 0x94, 0xb2,
-	0x34, 0xbb, 0x5b, 0xca, 0xdb, 0x2a, 0x65, 0xa5, 0x51, 0x07, 0x16, 0x61, 0xca, 0xd2, 0x16, 0xc9,
-	0x64, 0xb4, 0xca, 0x0f, 0xc1, 0x8b, 0xb3, 0xd2, 0x51, 0x92, 0xf3, 0x9b, 0x05, 0xe5, 0x2e, 0x19,
-	0x0c, 0x52, 0xd8, 0x6a, 0x90, 0x27, 0xbe, 0x5a, 0x9d, 0x27, 0x7e, 0x0a, 0x63, 0x7e, 0x16, 0x46,
-	0xfb, 0x36, 0x30, 0x16, 0x16, 0x80, 0x91, 0x37, 0x27, 0x19, 0x06, 0x21, 0xc5, 0x3b, 0xe7, 0x5e,
-	0x30, 0xc4, 0x71, 0x63, 0xa9, 0x65, 0xb7, 0x4b, 0xae, 0x39, 0xe9, 0xfc, 0x61, 0x41, 0xe5, 0x58,
-	0xa5, 0xc5, 0x23, 0x47, 0x8f, 0xa0, 0x30, 0x22, 0x81, 0x0c, 0xba, 0xb6, 0xb9, 0xae, 0xe1, 0xa6,
-	0x9b, 0x75, 0xf6, 0x49, 0xe0, 0xbb, 0xc2, 0x12, 0xad, 0x43, 0x49, 0xe0, 0xce, 0xe7, 0x45, 0x6a,
-	0x2b, 0xee, 0x74, 0xc2, 0xf9, 0x16, 0x0a, 0xdc, 0x16, 0x2d, 0x83, 0xbd, 0xd5, 0xed, 0xd6, 0x73,
-	0xe8, 0x1e, 0x94, 0xb7, 0xba, 0xdd, 0xd7, 0x6e, 0xef, 0xf8, 0xe5, 0xd6, 0x4e, 0xaf, 0x6e, 0x21,
-	0x80, 0x62, 0xb7, 0xf7, 0xb2, 0xf7, 0xaa, 0x57, 0xcf, 0x23, 0x04, 0x35, 0x39, 0xce, 0xf4, 0x36,
-	0xd7, 0x9f, 0x1e, 0x77, 0xb7, 0x5e, 0xf5, 0xea, 0x05, 0xae, 0x97, 0xe3, 0x4c, 0xbf, 0xe4, 0xfc,
-	0x65, 0x43, 0x45, 0x82, 0xae, 0xea, 0xa5, 0x09, 0x2b, 0x14, 0x47, 0x63, 0xaf, 0xaf, 0x58, 0xb8,
-	0xe4, 0x66, 0x32, 0x6f, 0xb5, 0x98, 0x49, 0x82, 0xce, 0x0b, 0x55, 0x2a, 0xa2, 0x47, 0xf0, 0x3f,
-	0x1f, 0x8f, 0x31, 0xc3, 0xdb, 0x78, 0x10, 0x72, 0x92, 0x13, 0x2b, 0x14, 0x97, 0xce, 0x53, 0xa1,
-	0xe7, 0xb0, 0xdc, 0x57, 0xd8, 0x16, 0x04, 0x5a, 0xef, 0x6a, 0x68, 0xe9, 0x11, 0x09, 0x41, 0x21,
-	0xee, 0xa6, 0x6b, 0x38, 0xd9, 0xfa, 0x64, 0x30, 0x48, 0x0f, 0x46, 0x0a, 0xe8, 0x00, 0x2a, 0x3e,
-	0x66, 0x1e, 0x19, 0x63, 0x5f, 0x00, 0x5a, 0x14, 0xf5, 0xfb, 0xfe, 0xb5, 0x3b, 0x6b, 0xb6, 0xf2,
-	0x16, 0x31, 0x96, 0xa3, 0x36, 0xdc, 0x3b, 0xf7, 0x62, 0xdd, 0xaa, 0xb1, 0x2c, 0x32, 0xba, 0x3a,
-	0xdd, 0xfc, 0x1a, 0xee, 0xcf, 0x6c, 0x36, 0xe7, 0x8a, 0xf8, 0x48, 0xbf, 0x22, 0xcc, 0xc6, 0xd2,
-	0x0b, 0x44, 0xbf, 0x3b, 0x9e, 0xcb, 0xa6, 0x50, 0x00, 0xa0, 0x3a, 0x54, 0xba, 0x7b, 0xbb, 0xbb,
-	0xaf, 0x4f, 0x0f, 0xf7, 0x0f, 0x8f, 0xbe, 0x3a, 0xac, 0xe7, 0x50, 0x15, 0x4a, 0x62, 0xe6, 0xf0,
-	0xe8, 0x90, 0x17, 0x44, 0x2a, 0x9e, 0x1c, 0x1d, 0xf4, 0xea, 0x79, 0x87, 0x41, 0x75, 0x87, 0x62,
-	0x8f, 0xe1, 0xeb, 0xc9, 0xe8, 0x63, 0x00, 0xd5, 0x9b, 0x04, 0xbf, 0x91, 0x92, 0x34, 0x53, 0x5e,
-	0x0e, 0x8c, 0x5c, 0xe0, 0x30, 0x61, 0xe2, 0xa0, 0x2d, 0x37, 0x15, 0x9d, 0x6f, 0xa0, 0x96, 0x7a,
-	0x55, 0x65, 0x75, 0xb5, 0x99, 0xef, 0xea, 0xd4, 0xf9, 0xc5, 0x82, 0xb2, 0x8b, 0x3d, 0x7f, 0x71,
-	0x96, 0x30, 0x5d, 0xd9, 0x8b, 0xe7, 0x37, 0xa5, 0xce, 0xc2, 0x42, 0xd4, 0xe9, 0xfc, 0x64, 0x41,
-	0x45, 0xc6, 0xf6, 0x96, 0xb3, 0xd6, 0x42, 0xb1, 0x17, 0x0b, 0xe5, 0x4f, 0x0b, 0xaa, 0xa7, 0x91,
-	0xaf, 0x1d, 0xfc, 0x7f, 0x49, 0xa7, 0x5a, 0xa5, 0x2c, 0x19, 0x95, 0x32, 0x4b, 0xb4, 0xc5, 0x79,
-	0x44, 0xbb, 0x07, 0xb5, 0x34, 0x19, 0x85, 0xac, 0x89, 0xa4, 0xb5, 0x78, 0xfd, 0xf0, 0xb7, 0x49,
-	0x57, 0xf0, 0xd1, 0xbf, 0x50, 0x41, 0x5a, 0xde, 0x05, 0xb3, 0x43, 0x7e, 0xb5, 0x60, 0x4d, 0xbc,
-	0xc9, 0x5c, 0x1c, 0x87, 0x09, 0xed, 0xe3, 0xbd, 0x80, 0xb0, 0x5d, 0x41, 0x20, 0x6f, 0xaf, 0x6a,
-	0x1a, 0xb0, 0x2c, 0xef, 0x56, 0x1e, 0xb4, 0xe0, 0x6b, 0x25, 0xde, 0xba, 0xb4, 0x37, 0x7f, 0x2e,
-	0x42, 0x3d, 0x0d, 0xf5, 0x38, 0x7d, 0x7a, 0x6d, 0x43, 0x59, 0xdc, 0xfa, 0xf2, 0x95, 0x89, 0x66,
-	0xde, 0x09, 0x0a, 0xe1, 0x66, 0x63, 0x56, 0x21, 0x8f, 0xd1, 0xc9, 0xa1, 0x17, 0x00, 0x82, 0xdf,
-	0xe4, 0x16, 0xab, 0x33, 0x54, 0x2d, 0x77, 0x58, 0xbb, 0x86, 0xc2, 0x9d, 0x1c, 0xff, 0x37, 0x64,
-	0xaf, 0x5c, 0xf4, 0xf0, 0x86, 0x1f, 0x43, 0x73, 0x7d, 0xbe, 0x52, 0x0b, 0xa5, 0x28, 0xdf, 0x8b,
-	0x48, 0x0f, 0xd8, 0x78, 0xc8, 0x36, 0x1f, 0xcc, 0xd1, 0x64, 0x1b, 0x3c, 0x83, 0x25, 0x91, 0xde,
-	0xdd, 0x90, 0x78, 0x02, 0x05, 0x71, 0xeb, 0xdc, 0x01, 0x83, 0x17, 0x50, 0x94, 0x7c, 0x6b, 0x44,
-	0x6e, 0x10, 0xbf, 0x11, 0xb9, 0x49, 0xce, 0xd2, 0x37, 0x27, 0x2e, 0xc3, 0xb7, 0xc6, 0xb2, 0x86,
-	0x6f, 0x9d, 0xe1, 0xa4, 0x6f, 0xd9, 0x9b, 0x86, 0x6f, 0x83, 0x7b, 0x0c, 0xdf, 0x66, 0x23, 0x0b,
-	0xd4, 0x8a, 0xb2, 0x21, 0x8d, 0x0d, 0x8c, 0x1e, 0x6d, 0xae, 0xce, 0xd4, 0x67, 0x8f, 0xff, 0x36,
-	0x9d, 0x1c, 0x7a, 0x0a, 0xc5, 0x1d, 0x2f, 0xe8, 0xe3, 0x31, 0xba, 0xc6, 0xe6, 0x86, 0xb5, 0x9f,
-	0x42, 0xf5, 0x33, 0xcc, 0x8e, 0xc5, 0xaf, 0x76, 0x2f, 0x18, 0x84, 0xd7, 0x6e, 0xf1, 0x8e, 0x7e,
-	0x51, 0x67, 0xe6, 0x4e, 0xee, 0xac, 0x28, 0x0c, 0x1f, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0x37,
-	0xd1, 0x77, 0xc4, 0x36, 0x0f, 0x00, 0x00,
+func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_a64b40641226f728) }
+
+var fileDescriptor_provider_a64b40641226f728 = []byte{
+	// 1279 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x72, 0xdb, 0xb6,
+	0x13, 0x17, 0x45, 0x4a, 0xb2, 0x56, 0x1f, 0x61, 0xf0, 0xff, 0x37, 0x96, 0x19, 0x1f, 0x3c, 0x6c,
+	0x0f, 0x6a, 0x33, 0x91, 0x33, 0xce, 0xa1, 0x4d, 0x26, 0x99, 0xd4, 0x96, 0xe4, 0xd4, 0x93, 0xc4,
+	0x71, 0xe9, 0xa4, 0x1f, 0xa7, 0x94, 0x11, 0x21, 0x99, 0x23, 0x8a, 0x64, 0x41, 0x50, 0x1d, 0xf7,
+	0xdc, 0x43, 0x1f, 0xa1, 0x3d, 0xf4, 0x11, 0x3a, 0x9d, 0xe9, 0x13, 0xf4, 0xde, 0x67, 0xe8, 0x23,
+	0xf4, 0x1d, 0x3a, 0x00, 0x48, 0x0a, 0xb4, 0x3e, 0x22, 0xbb, 0x99, 0xf4, 0xc6, 0xc5, 0x2e, 0xb0,
+	0xbb, 0x3f, 0x2c, 0x7e, 0xbb, 0x12, 0x34, 0x43, 0x12, 0x4c, 0x5d, 0x07, 0x93, 0x4e, 0x48, 0x02,
+	0x1a, 0xa0, 0x6a, 0x18, 0x7b, 0xf1, 0xc4, 0x25, 0xe1, 0xc0, 0xa8, 0x87, 0x5e, 0x3c, 0x72, 0x7d,
+	0xa1, 0x30, 0x6e, 0x8e, 0x82, 0x60, 0xe4, 0xe1, 0x5d, 0x2e, 0xbd, 0x8e, 0x87, 0xbb, 0x78, 0x12,
+	0xd2, 0xf3, 0x44, 0xb9, 0x7d, 0x51, 0x19, 0x51, 0x12, 0x0f, 0xa8, 0xd0, 0x9a, 0x7f, 0x2b, 0xa0,
+	0x77, 0x03, 0x7f, 0xe8, 0x8e, 0x62, 0x82, 0x2d, 0xfc, 0x6d, 0x8c, 0x23, 0x8a, 0x3e, 0x83, 0xea,
+	0xd4, 0x26, 0xae, 0xfd, 0xda, 0xc3, 0x51, 0x4b, 0xd9, 0x51, 0xdb, 0xb5, 0xbd, 0x8f, 0x3a, 0x99,
+	0xf3, 0xce, 0x45, 0xfb, 0xce, 0x17, 0xa9, 0x71, 0xdf, 0xa7, 0xe4, 0xdc, 0x9a, 0x6d, 0x46, 0xb7,
+	0x40, 0xb3, 0xc9, 0x28, 0x6a, 0x15, 0x77, 0x94, 0x76, 0x6d, 0x6f, 0xb3, 0x23, 0x62, 0xe9, 0xa4,
+	0xb1, 0x74, 0x4e, 0x79, 0x2c, 0x16, 0x37, 0x42, 0x1f, 0x40, 0xc3, 0x1e, 0x0c, 0x70, 0x48, 0x4f,
+	0xf1, 0x80, 0x60, 0x1a, 0xb5, 0xd4, 0x1d, 0xa5, 0xbd, 0x61, 0xe5, 0x17, 0x8d, 0x07, 0xd0, 0xcc,
+	0xfb, 0x43, 0x3a, 0xa8, 0x63, 0x7c, 0xde, 0x52, 0x76, 0x94, 0x76, 0xd5, 0x62, 0x9f, 0xe8, 0xff,
+	0x50, 0x9a, 0xda, 0x5e, 0x8c, 0xb9, 0xdf, 0xaa, 0x25, 0x84, 0xfb, 0xc5, 0x4f, 0x14, 0xf3, 0x1e,
+	0x5c, 0x97, 0xc2, 0x8f, 0xc2, 0xc0, 0x8f, 0xf0, 0xbc, 0x63, 0x65, 0x81, 0x63, 0xf3, 0x77, 0x05,
+	0xb6, 0xb2, 0xbd, 0x7d, 0x42, 0x02, 0xf2, 0xcc, 0x8d, 0x22, 0xd7, 0x1f, 0x3d, 0xc1, 0xe7, 0x11,
+	0xfa, 0x1c, 0x6a, 0x93, 0x99, 0x98, 0xa0, 0xb6, 0xbb, 0x08, 0xb5, 0x8b, 0x5b, 0x3b, 0xb3, 0x6f,
+	0x4b, 0x3e, 0xc3, 0x38, 0x00, 0x98, 0xa9, 0x10, 0x02, 0xcd, 0xb7, 0x27, 0x38, 0x49, 0x93, 0x7f,
+	0xa3, 0x1d, 0xa8, 0x39, 0x38, 0x1a, 0x10, 0x37, 0xa4, 0x6e, 0xe0, 0x27, 0xd9, 0xca, 0x4b, 0xe6,
+	0x0f, 0x0a, 0x34, 0x8e, 0xfc, 0x69, 0x30, 0xce, 0x2e, 0x57, 0x07, 0x95, 0x06, 0xe3, 0x14, 0x2d,
+	0x1a, 0x8c, 0x2f, 0x77, 0x49, 0x06, 0x6c, 0xa4, 0x65, 0xc9, 0xef, 0xa7, 0x6a, 0x65, 0x32, 0x6a,
+	0x41, 0x65, 0x8a, 0x49, 0xc4, 0x42, 0xd1, 0xb8, 0x2a, 0x15, 0xcd, 0x29, 0x34, 0xd3, 0x28, 0x12,
+	0xcc, 0x77, 0xa1, 0x4c, 0x30, 0x8d, 0x89, 0xdf, 0x52, 0x56, 0xbb, 0x4d, 0xcc, 0xd0, 0x5d, 0xd8,
+	0x18, 0xda, 0xae, 0x17, 0x13, 0xcc, 0x22, 0x55, 0xf9, 0x16, 0x09, 0xdd, 0x33, 0x3c, 0x18, 0x1f,
+	0x0a, 0xbd, 0x95, 0x19, 0x9a, 0xbf, 0x28, 0x50, 0xeb, 0xda, 0x9e, 0xb7, 0x3c, 0x79, 0x1d, 0x54,
+	0x16, 0x84, 0x80, 0x8e, 0x7d, 0xa2, 0x26, 0x14, 0x5d, 0x27, 0xc9, 0xad, 0xe8, 0x3a, 0xe8, 0x36,
+	0x94, 0x22, 0x6a, 0x53, 0xcc, 0x73, 0x5a, 0x11, 0xa8, 0xb0, 0xca, 0xd0, 0x2c, 0xad, 0x81, 0xa6,
+	0x49, 0xa1, 0x2e, 0xc2, 0x7b, 0xa7, 0xa8, 0x7c, 0x0f, 0x75, 0xae, 0x91, 0x50, 0x49, 0x5d, 0x26,
+	0x18, 0xdc, 0x02, 0x2d, 0xf0, 0x9c, 0x37, 0x97, 0x04, 0x33, 0x62, 0xc6, 0x3e, 0xfe, 0x4e, 0x3c,
+	0xd7, 0x55, 0xc6, 0xcc, 0xc8, 0x8c, 0xa1, 0x91, 0xf8, 0x9e, 0xa5, 0xec, 0xfa, 0x61, 0x9c, 0xbc,
+	0xba, 0x55, 0x29, 0x0b, 0xb3, 0xab, 0xa5, 0x7c, 0x00, 0x75, 0x59, 0x93, 0x94, 0x71, 0x88, 0x09,
+	0x4d, 0x89, 0x23, 0x93, 0xd1, 0x0d, 0x76, 0x09, 0x76, 0x94, 0x3d, 0xa8, 0x44, 0x32, 0x7f, 0x53,
+	0xa0, 0xd6, 0x73, 0x87, 0xc3, 0x14, 0x36, 0x51, 0x28, 0x4a, 0x56, 0x28, 0xf3, 0xa5, 0x94, 0xc2,
+	0xa8, 0x5e, 0x06, 0x46, 0x6d, 0x0d, 0x18, 0x19, 0x65, 0xb9, 0x23, 0x3f, 0x20, 0xb8, 0x7b, 0x66,
+	0xfb, 0x23, 0xcc, 0xca, 0x4d, 0x6d, 0x57, 0xad, 0xfc, 0xa2, 0xf9, 0x87, 0x02, 0xf5, 0x93, 0x24,
+	0x2d, 0x16, 0x39, 0xba, 0x03, 0xda, 0xd8, 0xf5, 0x45, 0xd0, 0xcd, 0xbd, 0x6d, 0x09, 0x37, 0xd9,
+	0xac, 0xf3, 0xc4, 0xf5, 0x1d, 0x8b, 0x5b, 0xa2, 0x6d, 0xa8, 0x72, 0xdc, 0xd9, 0x3a, 0x4f, 0x6d,
+	0xc3, 0x9a, 0x2d, 0x98, 0xdf, 0x80, 0xc6, 0x6c, 0x51, 0x05, 0xd4, 0xfd, 0x5e, 0x4f, 0x2f, 0xa0,
+	0x6b, 0x50, 0xdb, 0xef, 0xf5, 0x5e, 0x59, 0xfd, 0x93, 0xa7, 0xfb, 0xdd, 0xbe, 0xae, 0x20, 0x80,
+	0x72, 0xaf, 0xff, 0xb4, 0xff, 0xa2, 0xaf, 0x17, 0x11, 0x82, 0xa6, 0xf8, 0xce, 0xf4, 0x2a, 0xd3,
+	0xbf, 0x3c, 0xe9, 0xed, 0xbf, 0xe8, 0xeb, 0x1a, 0xd3, 0x8b, 0xef, 0x4c, 0x5f, 0x32, 0xff, 0x52,
+	0xa1, 0x2e, 0x40, 0x4f, 0xea, 0xc5, 0x80, 0x0d, 0x82, 0x43, 0xcf, 0x1e, 0x24, 0xbd, 0xa9, 0x6a,
+	0x65, 0x32, 0x23, 0xa0, 0x88, 0x8a, 0xb6, 0x55, 0xe4, 0xaa, 0x54, 0x44, 0x77, 0xe0, 0x7f, 0x0e,
+	0xf6, 0x30, 0xc5, 0x07, 0x78, 0x18, 0x30, 0xea, 0xe7, 0x3b, 0x92, 0x0e, 0xb3, 0x48, 0x85, 0x1e,
+	0x42, 0x65, 0x90, 0x60, 0xab, 0x71, 0xb4, 0xde, 0x97, 0xd0, 0x92, 0x23, 0xe2, 0x42, 0x82, 0xb8,
+	0x95, 0xee, 0x61, 0x2d, 0xc8, 0x71, 0x87, 0xc3, 0xf4, 0x62, 0x84, 0x80, 0x9e, 0x41, 0xdd, 0xc1,
+	0xd4, 0x76, 0x3d, 0xec, 0x70, 0x40, 0xcb, 0xbc, 0x7e, 0x3f, 0x5c, 0x7a, 0xb2, 0x64, 0x2b, 0x7a,
+	0x6b, 0x6e, 0x3b, 0x6a, 0xc3, 0xb5, 0x33, 0x3b, 0x92, 0xad, 0x5a, 0x15, 0x9e, 0xd1, 0xc5, 0x65,
+	0xe3, 0x2b, 0xb8, 0x3e, 0x77, 0xd8, 0x82, 0xc6, 0x79, 0x5b, 0x6e, 0x9c, 0xf9, 0x87, 0x25, 0x17,
+	0x88, 0xdc, 0x51, 0x1f, 0x42, 0x4d, 0x02, 0x00, 0xe9, 0x50, 0xef, 0x1d, 0x1d, 0x1e, 0xbe, 0x7a,
+	0x79, 0xfc, 0xe4, 0xf8, 0xf9, 0x97, 0xc7, 0x7a, 0x01, 0x35, 0xa0, 0xca, 0x57, 0x8e, 0x9f, 0x1f,
+	0xb3, 0x82, 0x48, 0xc5, 0xd3, 0xe7, 0xcf, 0xfa, 0x7a, 0xd1, 0xa4, 0xd0, 0xe8, 0x12, 0x6c, 0x53,
+	0xbc, 0x9c, 0x8c, 0x3e, 0x06, 0x48, 0xde, 0xa6, 0x8b, 0xdf, 0x48, 0x49, 0x92, 0x29, 0x2b, 0x07,
+	0xea, 0x4e, 0x70, 0x10, 0x53, 0x7e, 0xd1, 0x8a, 0x95, 0x8a, 0xe6, 0xd7, 0xd0, 0x4c, 0xbd, 0x26,
+	0x65, 0x75, 0xf1, 0x31, 0x5f, 0xd5, 0xa9, 0xf9, 0xb3, 0x02, 0x35, 0x0b, 0xdb, 0xce, 0xfa, 0x2c,
+	0x91, 0x77, 0xa5, 0xae, 0x9f, 0xdf, 0x8c, 0x3a, 0xb5, 0xb5, 0xa8, 0xd3, 0xfc, 0x51, 0x81, 0xba,
+	0x88, 0xed, 0x2d, 0x67, 0x2d, 0x85, 0xa2, 0xae, 0x17, 0xca, 0x9f, 0x0a, 0x34, 0x5e, 0x86, 0x8e,
+	0x74, 0xf1, 0xff, 0x25, 0x9d, 0x4a, 0x95, 0x52, 0xca, 0x55, 0xca, 0x3c, 0xd1, 0x96, 0x17, 0x11,
+	0xed, 0x11, 0x34, 0xd3, 0x64, 0x12, 0x64, 0xf3, 0x48, 0x2a, 0xeb, 0xd7, 0x0f, 0x9b, 0xd8, 0x7a,
+	0x9c, 0x8f, 0xde, 0x41, 0x05, 0x49, 0x79, 0x6b, 0xf9, 0x17, 0xf2, 0xab, 0x02, 0x9b, 0x7c, 0x52,
+	0xb5, 0x70, 0x14, 0xc4, 0x64, 0x80, 0x8f, 0x7c, 0x97, 0x1e, 0x72, 0x02, 0x79, 0x7b, 0x55, 0xd3,
+	0x82, 0x8a, 0xe8, 0xad, 0x2c, 0x68, 0xce, 0xd7, 0x89, 0x78, 0xe9, 0xd2, 0xde, 0xfb, 0xa9, 0x02,
+	0x7a, 0x1a, 0xea, 0x49, 0x3a, 0x90, 0x1e, 0x40, 0x8d, 0x77, 0x7d, 0x31, 0x7b, 0xa3, 0xb9, 0x39,
+	0x21, 0x41, 0xd8, 0x68, 0xcd, 0x2b, 0xc4, 0x35, 0x9a, 0x05, 0xf4, 0x08, 0x80, 0xf3, 0x9b, 0x38,
+	0xe2, 0xc6, 0x1c, 0x55, 0x8b, 0x13, 0x36, 0x97, 0x50, 0xb8, 0x59, 0x60, 0xbf, 0xa6, 0xb2, 0xd9,
+	0x1f, 0xdd, 0x5c, 0xf1, 0x3b, 0xca, 0xd8, 0x5e, 0xac, 0x94, 0x42, 0x29, 0x8b, 0x29, 0x1a, 0xc9,
+	0x01, 0xe7, 0xc6, 0x7b, 0x63, 0x6b, 0x81, 0x26, 0x3b, 0xe0, 0x31, 0xd4, 0x4f, 0x29, 0xc1, 0xf6,
+	0xe4, 0x5f, 0x1d, 0x73, 0x47, 0x41, 0xf7, 0x40, 0x63, 0x73, 0x6b, 0x0e, 0x0e, 0x69, 0xce, 0x36,
+	0x36, 0xe7, 0xd6, 0xb3, 0x18, 0x1e, 0x40, 0x89, 0x43, 0x7c, 0xb5, 0xdb, 0xb8, 0x07, 0x1a, 0xef,
+	0x7c, 0x57, 0xb8, 0x87, 0x47, 0x50, 0x16, 0x9c, 0x9f, 0x4b, 0x3b, 0xd7, 0x7c, 0x8c, 0xad, 0x05,
+	0x1a, 0xd9, 0x37, 0x23, 0xcf, 0x9c, 0x6f, 0x89, 0xe9, 0x8d, 0xcd, 0xb9, 0x75, 0xd9, 0xb7, 0xe0,
+	0x87, 0x9c, 0xef, 0x1c, 0xff, 0x19, 0x5b, 0x0b, 0x34, 0x12, 0x6a, 0x65, 0x41, 0x0a, 0xb9, 0x03,
+	0x72, 0x3c, 0x61, 0xdc, 0x98, 0x7b, 0x23, 0x7d, 0xf6, 0x3f, 0x80, 0x59, 0x40, 0xf7, 0xa1, 0xdc,
+	0xb5, 0xfd, 0x01, 0xf6, 0xd0, 0x12, 0x9b, 0x15, 0x7b, 0x3f, 0x85, 0xc6, 0x63, 0x4c, 0x4f, 0xf8,
+	0xff, 0x0d, 0x47, 0xfe, 0x30, 0x58, 0x7a, 0xc4, 0x7b, 0xf2, 0xb0, 0x90, 0x99, 0x9b, 0x85, 0xd7,
+	0x65, 0x6e, 0x78, 0xf7, 0x9f, 0x01, 0x00, 0x13, 0x79, 0x7c, 0x88, 0xd0, 0x10, 0x00, 0x00,
 }
@@ -38,7 +38,7 @@ func (m *SupportsFeatureRequest) Reset()         { *m = SupportsFeatureRequest{}
 func (m *SupportsFeatureRequest) String() string { return proto.CompactTextString(m) }
 func (*SupportsFeatureRequest) ProtoMessage()    {}
 func (*SupportsFeatureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{0}
+	return fileDescriptor_resource_818c445c044d0f41, []int{0}
 }
 func (m *SupportsFeatureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportsFeatureRequest.Unmarshal(m, b)
@@ -76,7 +76,7 @@ func (m *SupportsFeatureResponse) Reset()         { *m = SupportsFeatureResponse
 func (m *SupportsFeatureResponse) String() string { return proto.CompactTextString(m) }
 func (*SupportsFeatureResponse) ProtoMessage()    {}
 func (*SupportsFeatureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{1}
+	return fileDescriptor_resource_818c445c044d0f41, []int{1}
 }
 func (m *SupportsFeatureResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportsFeatureResponse.Unmarshal(m, b)
@@ -125,7 +125,7 @@ func (m *ReadResourceRequest) Reset()         { *m = ReadResourceRequest{} }
 func (m *ReadResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceRequest) ProtoMessage()    {}
 func (*ReadResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{2}
+	return fileDescriptor_resource_818c445c044d0f41, []int{2}
 }
 func (m *ReadResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceRequest.Unmarshal(m, b)
@@ -235,7 +235,7 @@ func (m *ReadResourceResponse) Reset()         { *m = ReadResourceResponse{} }
 func (m *ReadResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceResponse) ProtoMessage()    {}
 func (*ReadResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{3}
+	return fileDescriptor_resource_818c445c044d0f41, []int{3}
 }
 func (m *ReadResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceResponse.Unmarshal(m, b)
@@ -298,7 +298,7 @@ func (m *RegisterResourceRequest) Reset()         { *m = RegisterResourceRequest
 func (m *RegisterResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest) ProtoMessage()    {}
 func (*RegisterResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{4}
+	return fileDescriptor_resource_818c445c044d0f41, []int{4}
 }
 func (m *RegisterResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest.Unmarshal(m, b)
@@ -460,7 +460,7 @@ func (m *RegisterResourceRequest_PropertyDependencies) String() string {
 }
 func (*RegisterResourceRequest_PropertyDependencies) ProtoMessage() {}
 func (*RegisterResourceRequest_PropertyDependencies) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{4, 0}
+	return fileDescriptor_resource_818c445c044d0f41, []int{4, 0}
 }
 func (m *RegisterResourceRequest_PropertyDependencies) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest_PropertyDependencies.Unmarshal(m, b)
@@ -503,7 +503,7 @@ func (m *RegisterResourceRequest_CustomTimeouts) Reset() {
 func (m *RegisterResourceRequest_CustomTimeouts) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest_CustomTimeouts) ProtoMessage()    {}
 func (*RegisterResourceRequest_CustomTimeouts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{4, 1}
+	return fileDescriptor_resource_818c445c044d0f41, []int{4, 1}
 }
 func (m *RegisterResourceRequest_CustomTimeouts) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest_CustomTimeouts.Unmarshal(m, b)
@@ -561,7 +561,7 @@ func (m *RegisterResourceResponse) Reset()         { *m = RegisterResourceRespon
 func (m *RegisterResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceResponse) ProtoMessage()    {}
 func (*RegisterResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{5}
+	return fileDescriptor_resource_818c445c044d0f41, []int{5}
 }
 func (m *RegisterResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceResponse.Unmarshal(m, b)
@@ -616,6 +616,166 @@ func (m *RegisterResourceResponse) GetStables() []string {
 	return nil
 }
 
+// ResourceCallRequest calls a method of a resource that the program has registered or read.  The call is serviced by
+// the resource's provider, which is passed the resource's ID and current state.
+type ResourceCallRequest struct {
+	Tok                  string                                               `protobuf:"bytes,1,opt,name=tok" json:"tok,omitempty"`
+	Urn                  string                                               `protobuf:"bytes,2,opt,name=urn" json:"urn,omitempty"`
+	Args                 *_struct.Struct                                      `protobuf:"bytes,3,opt,name=args" json:"args,omitempty"`
+	ArgDependencies      map[string]*ResourceCallRequest_ArgumentDependencies `protobuf:"bytes,4,rep,name=argDependencies" json:"argDependencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}                                             `json:"-"`
+	XXX_unrecognized     []byte                                               `json:"-"`
+	XXX_sizecache        int32                                                `json:"-"`
+}
+
+func (m *ResourceCallRequest) Reset()         { *m = ResourceCallRequest{} }
+func (m *ResourceCallRequest) String() string { return proto.CompactTextString(m) }
+func (*ResourceCallRequest) ProtoMessage()    {}
+func (*ResourceCallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_818c445c044d0f41, []int{6}
+}
+func (m *ResourceCallRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceCallRequest.Unmarshal(m, b)
+}
+func (m *ResourceCallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceCallRequest.Marshal(b, m, deterministic)
+}
+func (dst *ResourceCallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceCallRequest.Merge(dst, src)
+}
+func (m *ResourceCallRequest) XXX_Size() int {
+	return xxx_messageInfo_ResourceCallRequest.Size(m)
+}
+func (m *ResourceCallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceCallRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceCallRequest proto.InternalMessageInfo
+
+func (m *ResourceCallRequest) GetTok() string {
+	if m != nil {
+		return m.Tok
+	}
+	return ""
+}
+
+func (m *ResourceCallRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *ResourceCallRequest) GetArgs() *_struct.Struct {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *ResourceCallRequest) GetArgDependencies() map[string]*ResourceCallRequest_ArgumentDependencies {
+	if m != nil {
+		return m.ArgDependencies
+	}
+	return nil
+}
+
+// ArgumentDependencies describes the resources that a particular argument depends on.
+type ResourceCallRequest_ArgumentDependencies struct {
+	Urns                 []string `protobuf:"bytes,1,rep,name=urns" json:"urns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceCallRequest_ArgumentDependencies) Reset() {
+	*m = ResourceCallRequest_ArgumentDependencies{}
+}
+func (m *ResourceCallRequest_ArgumentDependencies) String() string { return proto.CompactTextString(m) }
+func (*ResourceCallRequest_ArgumentDependencies) ProtoMessage()    {}
+func (*ResourceCallRequest_ArgumentDependencies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_818c445c044d0f41, []int{6, 0}
+}
+func (m *ResourceCallRequest_ArgumentDependencies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceCallRequest_ArgumentDependencies.Unmarshal(m, b)
+}
+func (m *ResourceCallRequest_ArgumentDependencies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceCallRequest_ArgumentDependencies.Marshal(b, m, deterministic)
+}
+func (dst *ResourceCallRequest_ArgumentDependencies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceCallRequest_ArgumentDependencies.Merge(dst, src)
+}
+func (m *ResourceCallRequest_ArgumentDependencies) XXX_Size() int {
+	return xxx_messageInfo_ResourceCallRequest_ArgumentDependencies.Size(m)
+}
+func (m *ResourceCallRequest_ArgumentDependencies) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceCallRequest_ArgumentDependencies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceCallRequest_ArgumentDependencies proto.InternalMessageInfo
+
+func (m *ResourceCallRequest_ArgumentDependencies) GetUrns() []string {
+	if m != nil {
+		return m.Urns
+	}
+	return nil
+}
+
+// ResourceCallResponse contains the result of calling a method of a resource.
+type ResourceCallResponse struct {
+	Return               *_struct.Struct `protobuf:"bytes,1,opt,name=return" json:"return,omitempty"`
+	ReturnDependencies   []string        `protobuf:"bytes,2,rep,name=returnDependencies" json:"returnDependencies,omitempty"`
+	Failures             []*CheckFailure `protobuf:"bytes,3,rep,name=failures" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ResourceCallResponse) Reset()         { *m = ResourceCallResponse{} }
+func (m *ResourceCallResponse) String() string { return proto.CompactTextString(m) }
+func (*ResourceCallResponse) ProtoMessage()    {}
+func (*ResourceCallResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_resource_818c445c044d0f41, []int{7}
+}
+func (m *ResourceCallResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceCallResponse.Unmarshal(m, b)
+}
+func (m *ResourceCallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceCallResponse.Marshal(b, m, deterministic)
+}
+func (dst *ResourceCallResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceCallResponse.Merge(dst, src)
+}
+func (m *ResourceCallResponse) XXX_Size() int {
+	return xxx_messageInfo_ResourceCallResponse.Size(m)
+}
+func (m *ResourceCallResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceCallResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceCallResponse proto.InternalMessageInfo
+
+func (m *ResourceCallResponse) GetReturn() *_struct.Struct {
+	if m != nil {
+		return m.Return
+	}
+	return nil
+}
+
+func (m *ResourceCallResponse) GetReturnDependencies() []string {
+	if m != nil {
+		return m.ReturnDependencies
+	}
+	return nil
+}
+
+func (m *ResourceCallResponse) GetFailures() []*CheckFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
 // RegisterResourceOutputsRequest adds extra resource outputs created by the program after registration has occurred.
 type RegisterResourceOutputsRequest struct {
 	Urn                  string          `protobuf:"bytes,1,opt,name=urn" json:"urn,omitempty"`
@@ -629,7 +789,7 @@ func (m *RegisterResourceOutputsRequest) Reset()         { *m = RegisterResource
 func (m *RegisterResourceOutputsRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceOutputsRequest) ProtoMessage()    {}
 func (*RegisterResourceOutputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_0e7314448ddfd7ea, []int{6}
+	return fileDescriptor_resource_818c445c044d0f41, []int{8}
 }
 func (m *RegisterResourceOutputsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceOutputsRequest.Unmarshal(m, b)
@@ -673,6 +833,10 @@ func init() {
 	proto.RegisterType((*RegisterResourceRequest_PropertyDependencies)(nil), "pulumirpc.RegisterResourceRequest.PropertyDependencies")
 	proto.RegisterType((*RegisterResourceRequest_CustomTimeouts)(nil), "pulumirpc.RegisterResourceRequest.CustomTimeouts")
 	proto.RegisterType((*RegisterResourceResponse)(nil), "pulumirpc.RegisterResourceResponse")
+	proto.RegisterType((*ResourceCallRequest)(nil), "pulumirpc.ResourceCallRequest")
+	proto.RegisterMapType((map[string]*ResourceCallRequest_ArgumentDependencies)(nil), "pulumirpc.ResourceCallRequest.ArgDependenciesEntry")
+	proto.RegisterType((*ResourceCallRequest_ArgumentDependencies)(nil), "pulumirpc.ResourceCallRequest.ArgumentDependencies")
+	proto.RegisterType((*ResourceCallResponse)(nil), "pulumirpc.ResourceCallResponse")
 	proto.RegisterType((*RegisterResourceOutputsRequest)(nil), "pulumirpc.RegisterResourceOutputsRequest")
 }
 
@@ -689,6 +853,8 @@ const _ = grpc.SupportPackageIsVersion4
 type ResourceMonitorClient interface {
 	SupportsFeature(ctx context.Context, in *SupportsFeatureRequest, opts ...grpc.CallOption) (*SupportsFeatureResponse, error)
 	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+	StreamInvoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (ResourceMonitor_StreamInvokeClient, error)
+	Call(ctx context.Context, in *ResourceCallRequest, opts ...grpc.CallOption) (*ResourceCallResponse, error)
 	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error)
 	RegisterResource(ctx context.Context, in *RegisterResourceRequest, opts ...grpc.CallOption) (*RegisterResourceResponse, error)
 	RegisterResourceOutputs(ctx context.Context, in *RegisterResourceOutputsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
@@ -720,6 +886,47 @@ func (c *resourceMonitorClient) Invoke(ctx context.Context, in *InvokeRequest, o
 	return out, nil
 }
 
+func (c *resourceMonitorClient) StreamInvoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (ResourceMonitor_StreamInvokeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ResourceMonitor_serviceDesc.Streams[0], c.cc, "/pulumirpc.ResourceMonitor/StreamInvoke", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceMonitorStreamInvokeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourceMonitor_StreamInvokeClient interface {
+	Recv() (*InvokeResponse, error)
+	grpc.ClientStream
+}
+
+type resourceMonitorStreamInvokeClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceMonitorStreamInvokeClient) Recv() (*InvokeResponse, error) {
+	m := new(InvokeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourceMonitorClient) Call(ctx context.Context, in *ResourceCallRequest, opts ...grpc.CallOption) (*ResourceCallResponse, error) {
+	out := new(ResourceCallResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceMonitor/Call", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceMonitorClient) ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error) {
 	out := new(ReadResourceResponse)
 	err := grpc.Invoke(ctx, "/pulumirpc.ResourceMonitor/ReadResource", in, out, c.cc, opts...)
@@ -752,6 +959,8 @@ func (c *resourceMonitorClient) RegisterResourceOutputs(ctx context.Context, in
 type ResourceMonitorServer interface {
 	SupportsFeature(context.Context, *SupportsFeatureRequest) (*SupportsFeatureResponse, error)
 	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+	StreamInvoke(*InvokeRequest, ResourceMonitor_StreamInvokeServer) error
+	Call(context.Context, *ResourceCallRequest) (*ResourceCallResponse, error)
 	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error)
 	RegisterResource(context.Context, *RegisterResourceRequest) (*RegisterResourceResponse, error)
 	RegisterResourceOutputs(context.Context, *RegisterResourceOutputsRequest) (*empty.Empty, error)
@@ -797,6 +1006,45 @@ func _ResourceMonitor_Invoke_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceMonitor_StreamInvoke_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvokeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceMonitorServer).StreamInvoke(m, &resourceMonitorStreamInvokeServer{stream})
+}
+
+type ResourceMonitor_StreamInvokeServer interface {
+	Send(*InvokeResponse) error
+	grpc.ServerStream
+}
+
+type resourceMonitorStreamInvokeServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceMonitorStreamInvokeServer) Send(m *InvokeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ResourceMonitor_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResourceCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceMonitorServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceMonitor/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceMonitorServer).Call(ctx, req.(*ResourceCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceMonitor_ReadResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadResourceRequest)
 	if err := dec(in); err != nil {
@@ -863,6 +1111,10 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Invoke",
 			Handler:    _ResourceMonitor_Invoke_Handler,
 		},
+		{
+			MethodName: "Call",
+			Handler:    _ResourceMonitor_Call_Handler,
+		},
 		{
 			MethodName: "ReadResource",
 			Handler:    _ResourceMonitor_ReadResource_Handler,
@@ -876,65 +1128,82 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 			Handler:    _ResourceMonitor_RegisterResourceOutputs_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInvoke",
+			Handler:       _ResourceMonitor_StreamInvoke_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "resource.proto",
 }
 
-func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_0e7314448ddfd7ea) }
-
-var fileDescriptor_resource_0e7314448ddfd7ea = []byte{
-	// 845 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x6e, 0x1c, 0x35,
-	0x14, 0xee, 0xce, 0xb6, 0x93, 0xdd, 0x93, 0x74, 0x13, 0xdc, 0x68, 0xe3, 0x0e, 0x28, 0x84, 0x81,
-	0x8b, 0x85, 0x8b, 0x0d, 0x0d, 0x17, 0x2d, 0x08, 0x81, 0x44, 0x5b, 0xa4, 0x5e, 0x54, 0xc0, 0x84,
-	0x0b, 0x40, 0x02, 0xc9, 0x99, 0x39, 0xd9, 0x0e, 0x99, 0x1d, 0x1b, 0xdb, 0x13, 0x69, 0xef, 0x78,
-	0x0e, 0x6e, 0x78, 0x15, 0x9e, 0x89, 0x27, 0x40, 0xb6, 0xc7, 0xcb, 0xce, 0xcf, 0x26, 0x81, 0x3b,
-	0x9f, 0x1f, 0x9f, 0xb1, 0xbf, 0xef, 0xf3, 0x39, 0x03, 0x13, 0x89, 0x8a, 0x57, 0x32, 0xc5, 0xb9,
-	0x90, 0x5c, 0x73, 0x32, 0x16, 0x55, 0x51, 0x2d, 0x73, 0x29, 0xd2, 0xe8, 0xed, 0x05, 0xe7, 0x8b,
-	0x02, 0x4f, 0x6d, 0xe0, 0xa2, 0xba, 0x3c, 0xc5, 0xa5, 0xd0, 0x2b, 0x97, 0x17, 0xbd, 0xd3, 0x0e,
-	0x2a, 0x2d, 0xab, 0x54, 0xd7, 0xd1, 0x89, 0x90, 0xfc, 0x3a, 0xcf, 0x50, 0x3a, 0x3b, 0x9e, 0xc1,
-	0xf4, 0xbc, 0x12, 0x82, 0x4b, 0xad, 0xbe, 0x46, 0xa6, 0x2b, 0x89, 0x09, 0xfe, 0x56, 0xa1, 0xd2,
-	0x64, 0x02, 0x41, 0x9e, 0xd1, 0xc1, 0xc9, 0x60, 0x36, 0x4e, 0x82, 0x3c, 0x8b, 0x3f, 0x85, 0xa3,
-	0x4e, 0xa6, 0x12, 0xbc, 0x54, 0x48, 0x8e, 0x01, 0xde, 0x30, 0x55, 0x47, 0xed, 0x96, 0x51, 0xb2,
-	0xe1, 0x89, 0xff, 0x0e, 0xe0, 0x51, 0x82, 0x2c, 0x4b, 0xea, 0x1b, 0x6d, 0xf9, 0x04, 0x21, 0x70,
-	0x5f, 0xaf, 0x04, 0xd2, 0xc0, 0x7a, 0xec, 0xda, 0xf8, 0x4a, 0xb6, 0x44, 0x3a, 0x74, 0x3e, 0xb3,
-	0x26, 0x53, 0x08, 0x05, 0x93, 0x58, 0x6a, 0x7a, 0xdf, 0x7a, 0x6b, 0x8b, 0x3c, 0x05, 0x10, 0x92,
-	0x0b, 0x94, 0x3a, 0x47, 0x45, 0x1f, 0x9c, 0x0c, 0x66, 0xbb, 0x67, 0x47, 0x73, 0x87, 0xc7, 0xdc,
-	0xe3, 0x31, 0x3f, 0xb7, 0x78, 0x24, 0x1b, 0xa9, 0x24, 0x86, 0xbd, 0x0c, 0x05, 0x96, 0x19, 0x96,
-	0xa9, 0xd9, 0x1a, 0x9e, 0x0c, 0x67, 0xe3, 0xa4, 0xe1, 0x23, 0x11, 0x8c, 0x3c, 0x76, 0x74, 0xc7,
-	0x7e, 0x76, 0x6d, 0x13, 0x0a, 0x3b, 0xd7, 0x28, 0x55, 0xce, 0x4b, 0x3a, 0xb2, 0x21, 0x6f, 0x92,
-	0x0f, 0xe0, 0x21, 0x4b, 0x53, 0x14, 0xfa, 0x1c, 0x53, 0x89, 0x5a, 0xd1, 0xb1, 0x45, 0xa7, 0xe9,
-	0x24, 0xcf, 0xe0, 0x88, 0x65, 0x59, 0xae, 0x73, 0x5e, 0xb2, 0xc2, 0x39, 0xbf, 0xa9, 0xb4, 0xa8,
-	0xb4, 0xa2, 0x60, 0x8f, 0xb2, 0x2d, 0x6c, 0xbe, 0xcc, 0x8a, 0x9c, 0x29, 0x54, 0x74, 0xd7, 0x66,
-	0x7a, 0x33, 0x66, 0x70, 0xd8, 0xc4, 0xbc, 0x26, 0xeb, 0x00, 0x86, 0x95, 0x2c, 0x6b, 0xd4, 0xcd,
-	0xb2, 0x05, 0x5b, 0x70, 0x67, 0xd8, 0xe2, 0x3f, 0x46, 0x70, 0x94, 0xe0, 0x22, 0x57, 0x1a, 0x65,
-	0x9b, 0x5b, 0xcf, 0xe5, 0xa0, 0x87, 0xcb, 0xa0, 0x97, 0xcb, 0x61, 0x83, 0xcb, 0x29, 0x84, 0x69,
-	0xa5, 0x34, 0x5f, 0x5a, 0x8e, 0x47, 0x49, 0x6d, 0x91, 0x53, 0x08, 0xf9, 0xc5, 0xaf, 0x98, 0xea,
-	0xdb, 0xf8, 0xad, 0xd3, 0x0c, 0x42, 0x26, 0x64, 0x76, 0x84, 0xb6, 0x92, 0x37, 0x3b, 0xac, 0xef,
-	0xdc, 0xc2, 0xfa, 0xa8, 0xc5, 0xba, 0x80, 0xc3, 0x1a, 0x8c, 0xd5, 0x8b, 0xcd, 0x3a, 0xe3, 0x93,
-	0xe1, 0x6c, 0xf7, 0xec, 0xf3, 0xf9, 0xfa, 0xc1, 0xce, 0xb7, 0x80, 0x34, 0xff, 0xb6, 0x67, 0xfb,
-	0xcb, 0x52, 0xcb, 0x55, 0xd2, 0x5b, 0x99, 0x7c, 0x0c, 0x8f, 0x32, 0x2c, 0x50, 0xe3, 0x57, 0x78,
-	0xc9, 0xcd, 0x03, 0x14, 0x05, 0x4b, 0x91, 0x82, 0xbd, 0x57, 0x5f, 0x68, 0x53, 0x99, 0xbb, 0x1d,
-	0x65, 0xe6, 0x8b, 0x92, 0x4b, 0x7c, 0xfe, 0x86, 0x95, 0x0b, 0x54, 0x74, 0xcf, 0x5e, 0xbf, 0xe9,
-	0xec, 0xea, 0xf7, 0xe1, 0x7f, 0xd4, 0xef, 0xe4, 0xce, 0xfa, 0xdd, 0x6f, 0xe8, 0xd7, 0x20, 0x9f,
-	0x2f, 0x4d, 0xfb, 0x78, 0x95, 0xd1, 0x03, 0x87, 0xbc, 0xb7, 0xc9, 0x8f, 0x30, 0x71, 0x72, 0xf8,
-	0x3e, 0x5f, 0x22, 0x37, 0x9f, 0x79, 0xcb, 0x8a, 0xe1, 0xc9, 0x1d, 0x30, 0x7f, 0xde, 0xd8, 0x98,
-	0xb4, 0x0a, 0x91, 0x2f, 0x20, 0xea, 0xc1, 0xf1, 0x05, 0x5e, 0xe6, 0x25, 0x66, 0x94, 0xd8, 0xdb,
-	0xdf, 0x90, 0x11, 0x7d, 0x04, 0x87, 0x7d, 0xac, 0x1a, 0xed, 0x57, 0xb2, 0x54, 0x74, 0x60, 0x6f,
-	0x69, 0xd7, 0xd1, 0x0f, 0x30, 0x69, 0x9e, 0xc6, 0xaa, 0x5e, 0x22, 0xd3, 0xfe, 0xdd, 0xd4, 0x96,
-	0xf1, 0x57, 0x22, 0x33, 0x7e, 0xf7, 0x76, 0x6a, 0xcb, 0xf8, 0xdd, 0x59, 0xfc, 0xeb, 0x71, 0x56,
-	0xf4, 0xfb, 0x00, 0x1e, 0x6f, 0x15, 0x97, 0x69, 0x01, 0x57, 0xb8, 0xf2, 0x2d, 0xe0, 0x0a, 0x57,
-	0xe4, 0x35, 0x3c, 0xb8, 0x66, 0x45, 0x85, 0xf5, 0xeb, 0x7f, 0xfa, 0x3f, 0xb5, 0x9b, 0xb8, 0x2a,
-	0x9f, 0x05, 0xcf, 0x06, 0xf1, 0x9f, 0x03, 0xa0, 0xdd, 0xbd, 0x5b, 0x9b, 0x90, 0x9b, 0x05, 0xc1,
-	0x7a, 0x16, 0xfc, 0xfb, 0xce, 0x87, 0x77, 0x7b, 0xe7, 0x53, 0x08, 0x95, 0x66, 0x17, 0x05, 0xfa,
-	0x86, 0xe1, 0x2c, 0xa3, 0x30, 0xb7, 0x32, 0x13, 0xc1, 0x2a, 0xac, 0x36, 0x63, 0x84, 0xe3, 0xf6,
-	0x01, 0x6b, 0x59, 0xfa, 0x26, 0xd6, 0x3d, 0xe6, 0x13, 0xd8, 0xe1, 0xb5, 0xb2, 0x6f, 0x69, 0x94,
-	0x3e, 0xef, 0xec, 0xaf, 0x21, 0xec, 0xfb, 0xfa, 0xaf, 0x79, 0x99, 0x6b, 0x2e, 0xc9, 0x4f, 0xb0,
-	0xdf, 0x1a, 0xa6, 0xe4, 0xbd, 0x0d, 0xcc, 0xfb, 0x47, 0x72, 0x14, 0xdf, 0x94, 0xe2, 0x90, 0x8d,
-	0xef, 0x91, 0x2f, 0x21, 0x7c, 0x55, 0x5e, 0xf3, 0x2b, 0x24, 0x74, 0x23, 0xdf, 0xb9, 0x7c, 0xa5,
-	0xc7, 0x3d, 0x91, 0x75, 0x81, 0xef, 0x60, 0x6f, 0x73, 0x72, 0x90, 0xe3, 0x86, 0x1a, 0x3a, 0x63,
-	0x3c, 0x7a, 0x77, 0x6b, 0x7c, 0x5d, 0xf2, 0x67, 0x38, 0x68, 0x43, 0x4d, 0xe2, 0xdb, 0x45, 0x16,
-	0xbd, 0x7f, 0x63, 0xce, 0xba, 0xfc, 0x2f, 0xdd, 0x39, 0xe4, 0x1b, 0xcc, 0x87, 0x37, 0x54, 0x68,
-	0xb2, 0x1d, 0x4d, 0x3b, 0x54, 0xbe, 0x34, 0xff, 0x55, 0xf1, 0xbd, 0x8b, 0xd0, 0x7a, 0x3e, 0xf9,
-	0x27, 0x00, 0x00, 0xff, 0xff, 0xee, 0xb7, 0xd0, 0x4b, 0x94, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_818c445c044d0f41) }
+
+var fileDescriptor_resource_818c445c044d0f41 = []byte{
+	// 1015 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0xae, 0x64, 0xd7, 0xb1, 0x4f, 0x52, 0x27, 0x6c, 0x33, 0xb6, 0x2a, 0x98, 0x36, 0x08, 0x2e,
+	0x0c, 0xcc, 0x38, 0x6d, 0x72, 0xd1, 0xc2, 0x30, 0x30, 0x25, 0x6d, 0x99, 0x5c, 0x74, 0x00, 0x85,
+	0x0b, 0x60, 0xa6, 0xcc, 0x6c, 0xa4, 0x13, 0x47, 0x58, 0xd6, 0x8a, 0xd5, 0x2a, 0x8c, 0xef, 0x78,
+	0x0e, 0x6e, 0x78, 0x01, 0x1e, 0x87, 0xa7, 0xe0, 0x92, 0x27, 0x60, 0x76, 0x57, 0xeb, 0x58, 0x3f,
+	0xfe, 0x01, 0xee, 0xf6, 0xfc, 0xec, 0xd9, 0xb3, 0xdf, 0xf9, 0xce, 0x59, 0x09, 0xfa, 0x1c, 0x33,
+	0x96, 0xf3, 0x00, 0xc7, 0x29, 0x67, 0x82, 0x91, 0x5e, 0x9a, 0xc7, 0xf9, 0x2c, 0xe2, 0x69, 0xe0,
+	0xbe, 0x3d, 0x61, 0x6c, 0x12, 0xe3, 0xb1, 0x32, 0x5c, 0xe6, 0x57, 0xc7, 0x38, 0x4b, 0xc5, 0x5c,
+	0xfb, 0xb9, 0xef, 0x54, 0x8d, 0x99, 0xe0, 0x79, 0x20, 0x0a, 0x6b, 0x3f, 0xe5, 0xec, 0x26, 0x0a,
+	0x91, 0x6b, 0xd9, 0x1b, 0xc1, 0xe0, 0x22, 0x4f, 0x53, 0xc6, 0x45, 0xf6, 0x0a, 0xa9, 0xc8, 0x39,
+	0xfa, 0xf8, 0x73, 0x8e, 0x99, 0x20, 0x7d, 0xb0, 0xa3, 0xd0, 0xb1, 0x8e, 0xac, 0x51, 0xcf, 0xb7,
+	0xa3, 0xd0, 0xfb, 0x18, 0x86, 0x35, 0xcf, 0x2c, 0x65, 0x49, 0x86, 0xe4, 0x21, 0xc0, 0x35, 0xcd,
+	0x0a, 0xab, 0xda, 0xd2, 0xf5, 0x97, 0x34, 0xde, 0xdf, 0x36, 0xdc, 0xf7, 0x91, 0x86, 0x7e, 0x71,
+	0xa3, 0x15, 0x47, 0x10, 0x02, 0x6d, 0x31, 0x4f, 0xd1, 0xb1, 0x95, 0x46, 0xad, 0xa5, 0x2e, 0xa1,
+	0x33, 0x74, 0x5a, 0x5a, 0x27, 0xd7, 0x64, 0x00, 0x9d, 0x94, 0x72, 0x4c, 0x84, 0xd3, 0x56, 0xda,
+	0x42, 0x22, 0x4f, 0x01, 0x52, 0xce, 0x52, 0xe4, 0x22, 0xc2, 0xcc, 0xb9, 0x7b, 0x64, 0x8d, 0x76,
+	0x4f, 0x86, 0x63, 0x8d, 0xc7, 0xd8, 0xe0, 0x31, 0xbe, 0x50, 0x78, 0xf8, 0x4b, 0xae, 0xc4, 0x83,
+	0xbd, 0x10, 0x53, 0x4c, 0x42, 0x4c, 0x02, 0xb9, 0xb5, 0x73, 0xd4, 0x1a, 0xf5, 0xfc, 0x92, 0x8e,
+	0xb8, 0xd0, 0x35, 0xd8, 0x39, 0x3b, 0xea, 0xd8, 0x85, 0x4c, 0x1c, 0xd8, 0xb9, 0x41, 0x9e, 0x45,
+	0x2c, 0x71, 0xba, 0xca, 0x64, 0x44, 0xf2, 0x3e, 0xdc, 0xa3, 0x41, 0x80, 0xa9, 0xb8, 0xc0, 0x80,
+	0xa3, 0xc8, 0x9c, 0x9e, 0x42, 0xa7, 0xac, 0x24, 0xcf, 0x60, 0x48, 0xc3, 0x30, 0x12, 0x11, 0x4b,
+	0x68, 0xac, 0x95, 0x5f, 0xe5, 0x22, 0xcd, 0x45, 0xe6, 0x80, 0x4a, 0x65, 0x95, 0x59, 0x9e, 0x4c,
+	0xe3, 0x88, 0x66, 0x98, 0x39, 0xbb, 0xca, 0xd3, 0x88, 0x1e, 0x85, 0xc3, 0x32, 0xe6, 0x45, 0xb1,
+	0x0e, 0xa0, 0x95, 0xf3, 0xa4, 0x40, 0x5d, 0x2e, 0x2b, 0xb0, 0xd9, 0x5b, 0xc3, 0xe6, 0xfd, 0xd6,
+	0x85, 0xa1, 0x8f, 0x93, 0x28, 0x13, 0xc8, 0xab, 0xb5, 0x35, 0xb5, 0xb4, 0x1a, 0x6a, 0x69, 0x37,
+	0xd6, 0xb2, 0x55, 0xaa, 0xe5, 0x00, 0x3a, 0x41, 0x9e, 0x09, 0x36, 0x53, 0x35, 0xee, 0xfa, 0x85,
+	0x44, 0x8e, 0xa1, 0xc3, 0x2e, 0x7f, 0xc2, 0x40, 0x6c, 0xaa, 0x6f, 0xe1, 0x26, 0x11, 0x92, 0x26,
+	0xb9, 0xa3, 0xa3, 0x22, 0x19, 0xb1, 0x56, 0xf5, 0x9d, 0x0d, 0x55, 0xef, 0x56, 0xaa, 0x9e, 0xc2,
+	0x61, 0x01, 0xc6, 0xfc, 0xc5, 0x72, 0x9c, 0xde, 0x51, 0x6b, 0xb4, 0x7b, 0xf2, 0xe9, 0x78, 0xd1,
+	0xb0, 0xe3, 0x15, 0x20, 0x8d, 0xbf, 0x6e, 0xd8, 0xfe, 0x32, 0x11, 0x7c, 0xee, 0x37, 0x46, 0x26,
+	0x8f, 0xe1, 0x7e, 0x88, 0x31, 0x0a, 0xfc, 0x02, 0xaf, 0x18, 0x47, 0x1f, 0xd3, 0x98, 0x06, 0xe8,
+	0x80, 0xba, 0x57, 0x93, 0x69, 0x99, 0x99, 0xbb, 0x35, 0x66, 0x46, 0x93, 0x84, 0x71, 0x3c, 0xbb,
+	0xa6, 0xc9, 0x04, 0x33, 0x67, 0x4f, 0x5d, 0xbf, 0xac, 0xac, 0xf3, 0xf7, 0xde, 0xbf, 0xe4, 0x6f,
+	0x7f, 0x6b, 0xfe, 0xee, 0x97, 0xf8, 0x2b, 0x91, 0x8f, 0x66, 0x72, 0x7c, 0x9c, 0x87, 0xce, 0x81,
+	0x46, 0xde, 0xc8, 0xe4, 0x7b, 0xe8, 0x6b, 0x3a, 0x7c, 0x1b, 0xcd, 0x90, 0xc9, 0x63, 0xde, 0x52,
+	0x64, 0x78, 0xb2, 0x05, 0xe6, 0x67, 0xa5, 0x8d, 0x7e, 0x25, 0x10, 0xf9, 0x0c, 0xdc, 0x06, 0x1c,
+	0x5f, 0xe0, 0x55, 0x94, 0x60, 0xe8, 0x10, 0x75, 0xfb, 0x35, 0x1e, 0xee, 0x87, 0x70, 0xd8, 0x54,
+	0x55, 0xc9, 0xfd, 0x9c, 0x27, 0x99, 0x63, 0xa9, 0x5b, 0xaa, 0xb5, 0xfb, 0x1d, 0xf4, 0xcb, 0xd9,
+	0x28, 0xd6, 0x73, 0xa4, 0xc2, 0xf4, 0x4d, 0x21, 0x49, 0x7d, 0x9e, 0x86, 0x54, 0x98, 0xde, 0x29,
+	0x24, 0xa9, 0xd7, 0xb9, 0x98, 0xee, 0xd1, 0x92, 0xfb, 0xab, 0x05, 0x0f, 0x56, 0x92, 0x4b, 0x8e,
+	0x80, 0x29, 0xce, 0xcd, 0x08, 0x98, 0xe2, 0x9c, 0xbc, 0x86, 0xbb, 0x37, 0x34, 0xce, 0xb1, 0xe8,
+	0xfe, 0xa7, 0xff, 0x91, 0xbb, 0xbe, 0x8e, 0xf2, 0x89, 0xfd, 0xcc, 0xf2, 0x7e, 0xb7, 0xc0, 0xa9,
+	0xef, 0x5d, 0x39, 0x84, 0xf4, 0x5b, 0x60, 0x2f, 0xde, 0x82, 0xdb, 0x3e, 0x6f, 0x6d, 0xd7, 0xe7,
+	0x03, 0xe8, 0x64, 0x82, 0x5e, 0xc6, 0x68, 0x06, 0x86, 0x96, 0x24, 0xc3, 0xf4, 0x4a, 0xbe, 0x08,
+	0x8a, 0x61, 0x85, 0xe8, 0xfd, 0xa5, 0x9e, 0x25, 0x9d, 0xd9, 0x19, 0x8d, 0x63, 0x33, 0xba, 0x0e,
+	0xa0, 0x25, 0xd8, 0xd4, 0x24, 0x27, 0xd8, 0xd4, 0xa4, 0x6b, 0xdf, 0xa6, 0xfb, 0x11, 0xb4, 0x29,
+	0x9f, 0x64, 0x9b, 0x92, 0x53, 0x4e, 0xe4, 0x0d, 0xec, 0x53, 0x3e, 0x29, 0xcd, 0x88, 0xb6, 0x9a,
+	0x11, 0xa7, 0x25, 0x9c, 0x6b, 0x99, 0x8c, 0x9f, 0xf3, 0x49, 0xad, 0x7a, 0x7e, 0x35, 0x96, 0xa4,
+	0xdc, 0x73, 0x3e, 0xc9, 0x67, 0x98, 0x88, 0x8d, 0x94, 0xfb, 0x45, 0xf9, 0x6e, 0x43, 0x89, 0xf3,
+	0x32, 0x25, 0xb6, 0x48, 0xb5, 0x96, 0xc1, 0x32, 0x1d, 0xfe, 0xb0, 0xe0, 0xb0, 0xbc, 0xaf, 0xa0,
+	0xc2, 0x31, 0x74, 0x38, 0x0a, 0xc3, 0x86, 0x75, 0x85, 0xd6, 0x6e, 0x64, 0x0c, 0x44, 0xaf, 0x4a,
+	0x80, 0xda, 0xea, 0x92, 0x0d, 0x16, 0x72, 0x0a, 0xdd, 0x2b, 0x1a, 0xc5, 0x39, 0x47, 0x59, 0xae,
+	0x96, 0x3a, 0xe2, 0xf6, 0x2e, 0x67, 0xd7, 0x18, 0x4c, 0x5f, 0x69, 0xbb, 0xbf, 0x70, 0xf4, 0x10,
+	0x1e, 0x56, 0xc9, 0x5b, 0x8c, 0xac, 0x25, 0x96, 0x54, 0x28, 0xfc, 0x04, 0x76, 0x58, 0x31, 0xf5,
+	0x36, 0x3c, 0xa2, 0xc6, 0xef, 0xe4, 0xcf, 0x36, 0xec, 0x9b, 0xf8, 0xaf, 0x59, 0x12, 0x09, 0xc6,
+	0xc9, 0x0f, 0xb0, 0x5f, 0xf9, 0xd0, 0x22, 0xef, 0x2e, 0x25, 0xdc, 0xfc, 0xb9, 0xe6, 0x7a, 0xeb,
+	0x5c, 0x34, 0xd4, 0xde, 0x1d, 0xf2, 0x39, 0x74, 0xce, 0x93, 0x1b, 0x36, 0x45, 0xe2, 0x2c, 0xf9,
+	0x6b, 0x95, 0x89, 0xf4, 0xa0, 0xc1, 0xb2, 0x08, 0xf0, 0x25, 0xec, 0x5d, 0x08, 0x8e, 0x74, 0xf6,
+	0xbf, 0xc2, 0x3c, 0xb6, 0xc8, 0x39, 0xb4, 0x25, 0x0d, 0xc8, 0xc3, 0xf5, 0xbc, 0x72, 0x1f, 0xad,
+	0xb4, 0x2f, 0x72, 0xfa, 0x06, 0xf6, 0x96, 0xbf, 0x74, 0x2a, 0x21, 0x6b, 0x9f, 0x9d, 0xee, 0xa3,
+	0x95, 0xf6, 0x45, 0xc8, 0x37, 0x70, 0x50, 0x2d, 0x3f, 0xf1, 0x36, 0x0f, 0x45, 0xf7, 0xbd, 0xb5,
+	0x3e, 0x8b, 0xf0, 0x3f, 0xc2, 0x70, 0x05, 0xbb, 0xc8, 0x07, 0x6b, 0x22, 0x94, 0x19, 0xe8, 0x0e,
+	0x6a, 0xf4, 0x7a, 0x29, 0xff, 0x03, 0xbc, 0x3b, 0x97, 0x1d, 0xa5, 0x39, 0xfd, 0x67, 0x00, 0xc6,
+	0x5e, 0xae, 0xc8, 0x44, 0x0c, 0x00, 0x00,
 }
@@ -29,6 +29,11 @@ class AnalyzerStub(object):
         request_serializer=analyzer__pb2.AnalyzeStackRequest.SerializeToString,
         response_deserializer=analyzer__pb2.AnalyzeResponse.FromString,
         )
+    self.Remediate = channel.unary_unary(
+        '/pulumirpc.Analyzer/Remediate',
+        request_serializer=analyzer__pb2.AnalyzeRequest.SerializeToString,
+        response_deserializer=analyzer__pb2.RemediateResponse.FromString,
+        )
     self.GetAnalyzerInfo = channel.unary_unary(
         '/pulumirpc.Analyzer/GetAnalyzerInfo',
         request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
@@ -39,6 +44,11 @@ class AnalyzerStub(object):
         request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
         response_deserializer=plugin__pb2.PluginInfo.FromString,
         )
+    self.Configure = channel.unary_unary(
+        '/pulumirpc.Analyzer/Configure',
+        request_serializer=analyzer__pb2.ConfigureAnalyzerRequest.SerializeToString,
+        response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+        )
 
 
 class AnalyzerServicer(object):
@@ -65,6 +75,14 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Remediate(self, request, context):
+    """Remediate is given the opportunity to transform a single resource's properties, and returns the results of any
+    policies that did so.  Called with the "inputs" to the resource, before they are checked by its provider.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
   def GetAnalyzerInfo(self, request, context):
     """GetAnalyzerInfo returns metadata about the analyzer (e.g., list of policies contained).
     """
@@ -79,6 +97,14 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Configure(self, request, context):
+    """Configure configures the analyzer, passing configuration properties and enforcement levels for each policy.
+    Called once, before any analysis or remediation, with a configuration for every policy in the policy pack.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_AnalyzerServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -92,6 +118,11 @@ def add_AnalyzerServicer_to_server(servicer, server):
           request_deserializer=analyzer__pb2.AnalyzeStackRequest.FromString,
           response_serializer=analyzer__pb2.AnalyzeResponse.SerializeToString,
       ),
+      'Remediate': grpc.unary_unary_rpc_method_handler(
+          servicer.Remediate,
+          request_deserializer=analyzer__pb2.AnalyzeRequest.FromString,
+          response_serializer=analyzer__pb2.RemediateResponse.SerializeToString,
+      ),
       'GetAnalyzerInfo': grpc.unary_unary_rpc_method_handler(
           servicer.GetAnalyzerInfo,
           request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
@@ -102,6 +133,11 @@ def add_AnalyzerServicer_to_server(servicer, server):
           request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
           response_serializer=plugin__pb2.PluginInfo.SerializeToString,
       ),
+      'Configure': grpc.unary_unary_rpc_method_handler(
+          servicer.Configure,
+          request_deserializer=analyzer__pb2.ConfigureAnalyzerRequest.FromString,
+          response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'pulumirpc.Analyzer', rpc_method_handlers)